@@ -1,7 +1,6 @@
 package main
 
 import (
-	"fmt"
 	"os"
 
 	"github.com/imcclaskey/d3/internal/cli"
@@ -14,9 +13,8 @@ func main() {
 	// Initialize commands
 	app.InitCommands()
 
-	// Execute the CLI
+	// Execute the CLI; errors have already been printed
 	if err := app.Execute(); err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		os.Exit(cli.ExitCode(err))
 	}
 }
@@ -8,6 +8,7 @@ require (
 	github.com/golang/mock v1.6.0
 	github.com/mark3labs/mcp-go v0.25.0
 	github.com/spf13/cobra v1.9.1
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -2,12 +2,10 @@
 package cli
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 
 	"github.com/imcclaskey/d3/internal/cli/command"
-	"github.com/imcclaskey/d3/internal/version"
+	"github.com/imcclaskey/d3/internal/core/d3err"
 )
 
 // CLI represents the d3 command-line interface
@@ -23,6 +21,11 @@ func NewCLI() *CLI {
 		Long:  "Define, Design, Deliver (d3) Framework CLI",
 	}
 
+	// Flag parsing errors are reported as invalid arguments, with their own exit code
+	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return d3err.Wrap(d3err.InvalidArgument, err)
+	})
+
 	return &CLI{
 		rootCmd: rootCmd,
 	}
@@ -37,30 +40,72 @@ func (c *CLI) InitCommands() {
 
 	// Feature command and its subcommands
 	featureCmd := command.NewFeatureCommand()
-	featureCmd.AddCommand(command.NewFeatureCreateCommand()) // Add create as a subcommand of feature
-	featureCmd.AddCommand(command.NewFeatureEnterCommand())  // Add enter as a subcommand of feature
-	featureCmd.AddCommand(command.NewFeatureDeleteCommand()) // Add delete as a subcommand of feature
+	featureCmd.AddCommand(command.NewFeatureCreateCommand())         // Add create as a subcommand of feature
+	featureCmd.AddCommand(command.NewFeatureEnterCommand())          // Add enter as a subcommand of feature
+	featureCmd.AddCommand(command.NewFeatureDeleteCommand())         // Add delete as a subcommand of feature
+	featureCmd.AddCommand(command.NewFeatureRestoreCommand())        // Add restore as a subcommand of feature
+	featureCmd.AddCommand(command.NewFeatureCommitsCommand())        // Add commits as a subcommand of feature
+	featureCmd.AddCommand(command.NewFeatureExportCommand())         // Add export as a subcommand of feature
+	featureCmd.AddCommand(command.NewFeaturePackCommand())           // Add pack as a subcommand of feature
+	featureCmd.AddCommand(command.NewFeatureUnpackCommand())         // Add unpack as a subcommand of feature
+	featureCmd.AddCommand(command.NewFeatureImportIssueCommand())    // Add import-issue as a subcommand of feature
+	featureCmd.AddCommand(command.NewFeatureSnapshotsCommand())      // Add snapshots as a subcommand of feature
+	featureCmd.AddCommand(command.NewFeatureDiffCommand())           // Add diff as a subcommand of feature
+	featureCmd.AddCommand(command.NewFeatureRevertArtifactCommand()) // Add revert-artifact as a subcommand of feature
+	featureCmd.AddCommand(command.NewFeatureListCommand())           // Add list as a subcommand of feature
+	featureCmd.AddCommand(command.NewFeatureDependCommand())         // Add depend as a subcommand of feature
+	featureCmd.AddCommand(command.NewFeatureGraphCommand())          // Add graph as a subcommand of feature
 	// Future: featureCmd.AddCommand(command.NewFeatureExitCommand()) // Exit added as top-level below
 	c.rootCmd.AddCommand(featureCmd)
 
+	// Add top-level trash command for soft-deleted features
+	c.rootCmd.AddCommand(command.NewTrashCommand())
+
 	// Add top-level exit command
 	c.rootCmd.AddCommand(command.NewExitCommand())
 
 	// Add top-level phase command
 	c.rootCmd.AddCommand(command.NewPhaseCommand())
 
+	// Add top-level status command
+	c.rootCmd.AddCommand(command.NewStatusCommand())
+
+	// Add top-level report command
+	c.rootCmd.AddCommand(command.NewReportCommand())
+
+	// Add top-level trace command
+	c.rootCmd.AddCommand(command.NewTraceCommand())
+
+	// Add top-level check command
+	c.rootCmd.AddCommand(command.NewCheckCommand())
+
+	// Add top-level rules command
+	c.rootCmd.AddCommand(command.NewRulesCommand())
+
+	// Add top-level doctor command
+	c.rootCmd.AddCommand(command.NewDoctorCommand())
+
+	// Add top-level githooks command
+	c.rootCmd.AddCommand(command.NewGithooksCommand())
+
 	// Version command
-	c.rootCmd.AddCommand(&cobra.Command{
-		Use:   "version",
-		Short: "Print the version of d3",
-		Args:  cobra.NoArgs,
-		Run: func(cmd *cobra.Command, args []string) {
-			fmt.Printf("d3 version %s\n", version.Version)
-		},
-	})
+	c.rootCmd.AddCommand(command.NewVersionCommand())
+
+	// Global --output flag
+	command.AddOutputFlag(c.rootCmd)
 }
 
-// Execute executes the CLI
+// Execute executes the CLI. Errors are printed in the selected output format before being
+// returned, so callers only need to set the exit status with ExitCode.
 func (c *CLI) Execute() error {
-	return c.rootCmd.Execute()
+	cmd, err := c.rootCmd.ExecuteC()
+	if err != nil {
+		command.ReportError(cmd, err)
+	}
+	return err
+}
+
+// ExitCode returns the process exit status for an error returned by Execute.
+func ExitCode(err error) int {
+	return command.ExitCode(err)
 }
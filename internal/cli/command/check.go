@@ -0,0 +1,87 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imcclaskey/d3/internal/core/check"
+	"github.com/imcclaskey/d3/internal/core/d3err"
+	"github.com/imcclaskey/d3/internal/core/feature"
+	"github.com/imcclaskey/d3/internal/core/ports"
+	"github.com/imcclaskey/d3/internal/project"
+)
+
+// checkCmdRunner holds dependencies and options for the check command.
+type checkCmdRunner struct {
+	featureName string
+	projectSvc  project.ProjectService
+}
+
+// NewCheckCommand creates a new cobra command validating the structure of a feature's documents.
+func NewCheckCommand() *cobra.Command {
+	cmdRunner := &checkCmdRunner{}
+	cmd := &cobra.Command{
+		Use:   "check [name]",
+		Short: "Check that a feature's problem.md and plan.md follow the phase templates",
+		Long: `Validate the section structure of the documents a feature has reached: problem.md from the
+define phase on and plan.md from the design phase on. Each document must have the sections its
+template requires, with content where the template expects it, and none of the sections that
+belong to a later phase. Problems are printed as file:line diagnostics and make the command fail
+with the gate_failed code. Without a name, the active feature is checked.
+
+The schema of a phase can be overridden with .d3/rules/define.schema.yaml or
+.d3/rules/design.schema.yaml, whose required, non_empty and forbidden lists replace the defaults.`,
+		Args: cobra.MaximumNArgs(1),
+		// Problems are the report itself, not a misuse of the command
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 1 {
+				cmdRunner.featureName = args[0]
+			}
+
+			projectRoot, err := os.Getwd()
+			if err != nil {
+				return fmt.Errorf("could not determine workspace root: %w", err)
+			}
+			cfg, err := NewConfig(projectRoot)
+			if err != nil {
+				return err
+			}
+
+			cmdRunner.projectSvc, _ = wire(cfg, ports.RealFileSystem{})
+
+			return cmdRunner.run(context.Background())
+		},
+	}
+	return cmd
+}
+
+// run checks the feature, prints the diagnostics and fails when there are any.
+func (c *checkCmdRunner) run(ctx context.Context) error {
+	if c.projectSvc == nil {
+		return fmt.Errorf("project service not initialized in checkCmdRunner")
+	}
+
+	featureName := c.featureName
+	if featureName != "" {
+		var err error
+		if featureName, err = feature.NormalizeName(featureName); err != nil {
+			return err
+		}
+	}
+
+	checked, err := c.projectSvc.CheckFeature(ctx, featureName)
+	if err != nil {
+		return err
+	}
+
+	result := NewResult(check.Render(checked), checked, nil)
+	if !checked.OK() {
+		return emitFailure(result, d3err.New(d3err.GateFailed, "%d structural problem(s) found in '%s'", len(checked.Diagnostics), checked.Feature))
+	}
+	emit(result)
+	return nil
+}
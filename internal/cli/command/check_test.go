@@ -0,0 +1,79 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/imcclaskey/d3/internal/core/check"
+	"github.com/imcclaskey/d3/internal/core/d3err"
+	"github.com/imcclaskey/d3/internal/core/phase"
+	"github.com/imcclaskey/d3/internal/project"
+)
+
+func TestCheckCmdRunner_run(t *testing.T) {
+	tests := []struct {
+		name               string
+		cmd                checkCmdRunner
+		result             *check.Result
+		checkErr           error
+		wantFeature        string
+		wantCode           d3err.Code
+		wantOutputContains string
+	}{
+		{
+			name: "fails with diagnostics",
+			cmd:  checkCmdRunner{featureName: "Login"},
+			result: &check.Result{Feature: "login", Phases: []phase.Phase{phase.Define}, Diagnostics: []check.Diagnostic{
+				{File: ".d3/features/login/define/problem.md", Line: 6, Message: `section "Feature Goals" is empty`},
+			}},
+			wantFeature:        "login",
+			wantCode:           d3err.GateFailed,
+			wantOutputContains: `.d3/features/login/define/problem.md:6: section "Feature Goals" is empty`,
+		},
+		{
+			name:               "passes without diagnostics",
+			cmd:                checkCmdRunner{},
+			result:             &check.Result{Feature: "login", Phases: []phase.Phase{phase.Define}, Diagnostics: []check.Diagnostic{}},
+			wantOutputContains: "No structural problems found in problem.md of 'login'.",
+		},
+		{
+			name:     "no active feature",
+			cmd:      checkCmdRunner{},
+			checkErr: project.ErrNoActiveFeature,
+			wantCode: d3err.NoActiveFeature,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockProjectSvc := project.NewMockProjectService(ctrl)
+			mockProjectSvc.EXPECT().CheckFeature(gomock.Any(), tt.wantFeature).Return(tt.result, tt.checkErr)
+
+			cmdInstance := tt.cmd
+			cmdInstance.projectSvc = mockProjectSvc
+
+			rPipe, wPipe, restoreStdout := captureStdout(t)
+			err := cmdInstance.run(context.Background())
+			wPipe.Close()
+			restoreStdout()
+			stdoutBuf := new(bytes.Buffer)
+			stdoutBuf.ReadFrom(rPipe)
+			rPipe.Close()
+
+			if tt.wantCode == "" && err != nil {
+				t.Fatalf("checkCmdRunner.run() error = %v", err)
+			}
+			if tt.wantCode != "" && d3err.CodeOf(err) != tt.wantCode {
+				t.Fatalf("checkCmdRunner.run() error = %v, want code %s", err, tt.wantCode)
+			}
+			if !strings.Contains(stdoutBuf.String(), tt.wantOutputContains) {
+				t.Errorf("checkCmdRunner.run() output = %q, want to contain %q", stdoutBuf.String(), tt.wantOutputContains)
+			}
+		})
+	}
+}
@@ -8,17 +8,19 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/imcclaskey/d3/internal/core/feature"
-	"github.com/imcclaskey/d3/internal/core/phase"
+	"github.com/imcclaskey/d3/internal/core/git"
 	"github.com/imcclaskey/d3/internal/core/ports"
-	"github.com/imcclaskey/d3/internal/core/projectfiles"
-	"github.com/imcclaskey/d3/internal/core/rules"
 	"github.com/imcclaskey/d3/internal/project"
 )
 
 // FeatureCreateCommand holds dependencies for the feature create command.
 type FeatureCreateCommand struct {
-	featureName string
-	projectSvc  project.ProjectService
+	featureName   string
+	branch        bool
+	branchPattern string
+	projectSvc    project.ProjectService
+	featureSvc    feature.FeatureServicer
+	gitClient     git.Client
 }
 
 // NewFeatureCreateCommand creates a new cobra command for creating features.
@@ -28,7 +30,11 @@ func NewFeatureCreateCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "create <name>",
 		Short: "Create a new feature and set it as the current context",
-		Args:  cobra.ExactArgs(1),
+		Long: `Create a new feature and set it as the current context.
+
+With --branch, a git branch named from the D3_BRANCH_PATTERN pattern (default "feature/{name}")
+is created and checked out, and recorded in the feature directory.`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cmdRunner.featureName = args[0]
 
@@ -36,20 +42,22 @@ func NewFeatureCreateCommand() *cobra.Command {
 			if err != nil {
 				return fmt.Errorf("could not determine workspace root: %w", err)
 			}
-			cfg := NewConfig(projectRoot)
+			cfg, err := NewConfig(projectRoot)
+			if err != nil {
+				return err
+			}
 
 			fs := ports.RealFileSystem{}
-			featureSvc := feature.NewService(cfg.WorkspaceRoot, cfg.FeaturesDir, cfg.D3Dir, fs)
-			phaseSvc := phase.NewService(fs)
-			ruleGenerator := rules.NewRuleGenerator(cfg.WorkspaceRoot, fs)
-			rulesSvc := rules.NewService(cfg.WorkspaceRoot, cfg.CursorRulesDir, ruleGenerator, fs)
-			fileOp := projectfiles.NewDefaultFileOperator()
-
-			cmdRunner.projectSvc = project.New(cfg.WorkspaceRoot, fs, featureSvc, rulesSvc, phaseSvc, fileOp)
+			proj, services := wire(cfg, fs)
+			cmdRunner.projectSvc = proj
+			cmdRunner.featureSvc = services.Features
+			cmdRunner.gitClient = git.New(cfg.WorkspaceRoot)
+			cmdRunner.branchPattern = cfg.BranchPattern
 
 			return cmdRunner.run(context.Background())
 		},
 	}
+	cmd.Flags().BoolVar(&cmdRunner.branch, "branch", false, "Create and check out a git branch for the feature")
 	return cmd
 }
 
@@ -58,10 +66,67 @@ func (c *FeatureCreateCommand) run(ctx context.Context) error {
 	if c.projectSvc == nil {
 		return fmt.Errorf("project service not initialized in FeatureCreateCommand")
 	}
-	result, err := c.projectSvc.CreateFeature(ctx, c.featureName)
+	featureName, err := feature.NormalizeName(c.featureName)
 	if err != nil {
 		return err
 	}
-	fmt.Println(result.FormatCLI())
+
+	// Check the branch before creating anything, so that a bad name leaves no feature behind
+	var branch string
+	var branchExists bool
+	if c.branch {
+		if c.gitClient == nil || !c.gitClient.IsRepository(ctx) {
+			return fmt.Errorf("--branch requires a git repository")
+		}
+		branch = feature.BranchName(c.branchPattern, featureName)
+		if branchExists, err = c.gitClient.BranchExists(ctx, branch); err != nil {
+			return err
+		}
+	}
+	result, err := c.projectSvc.CreateFeature(ctx, featureName)
+	if err != nil {
+		return err
+	}
+	out := projectResult(result)
+
+	if c.branch {
+		message, err := c.checkoutFeatureBranch(ctx, featureName, branch, branchExists)
+		if err != nil {
+			// The feature exists now; report it along with the failure
+			out.Warnings = append(out.Warnings, fmt.Sprintf("feature '%s' was created and entered without a branch", featureName))
+			return emitFailure(out, fmt.Errorf("feature '%s' was created but its branch could not be checked out: %w", featureName, err))
+		}
+		data := out.Data.(FeatureData)
+		data.Branch = branch
+		out.Data = data
+		out.Message += "\n" + message
+	}
+	emit(out)
 	return nil
 }
+
+// checkoutFeatureBranch creates (or reuses, when exists is set) the feature's branch, checks it
+// out and records it. It returns a sentence describing what was done.
+func (c *FeatureCreateCommand) checkoutFeatureBranch(ctx context.Context, featureName, branch string, exists bool) (string, error) {
+	if c.featureSvc == nil {
+		return "", fmt.Errorf("feature service not initialized in FeatureCreateCommand")
+	}
+
+	var err error
+	if exists {
+		err = c.gitClient.SwitchBranch(ctx, branch)
+	} else {
+		err = c.gitClient.CreateBranch(ctx, branch)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if err := c.featureSvc.SetFeatureBranch(featureName, branch); err != nil {
+		return "", err
+	}
+	if exists {
+		return fmt.Sprintf("Switched to existing branch '%s'.", branch), nil
+	}
+	return fmt.Sprintf("Created and switched to branch '%s'.", branch), nil
+}
@@ -9,6 +9,8 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	featuremocks "github.com/imcclaskey/d3/internal/core/feature/mocks"
+	gitmocks "github.com/imcclaskey/d3/internal/core/git/mocks"
 	"github.com/imcclaskey/d3/internal/project"
 )
 
@@ -32,6 +34,22 @@ func TestFeatureCreateCommand_RunLogic(t *testing.T) {
 			wantErr:            false,
 			wantOutputContains: "Feature 'my-new-feature' created and set as the current context. Cursor rules have been updated.",
 		},
+		{
+			name:           "feature name is normalized before creation",
+			featureNameArg: "My New Feature",
+			setupMockProjectSvc: func(mockSvc *project.MockProjectService, featureName string) {
+				mockSvc.EXPECT().CreateFeature(gomock.Any(), "my-new-feature").Return(project.NewResult("Feature 'my-new-feature' created and set to define phase."), nil).Times(1)
+			},
+			wantErr:            false,
+			wantOutputContains: "Feature 'my-new-feature' created",
+		},
+		{
+			name:                "invalid feature name is rejected",
+			featureNameArg:      "../escape",
+			setupMockProjectSvc: func(mockSvc *project.MockProjectService, featureName string) {},
+			wantErr:             true,
+			wantOutputContains:  "invalid feature name",
+		},
 		{
 			name:           "project not initialized - projectSvc.CreateFeature returns error",
 			featureNameArg: "another-feature",
@@ -102,3 +120,106 @@ func TestFeatureCreateCommand_RunLogic(t *testing.T) {
 		})
 	}
 }
+
+func TestFeatureCreateCommand_RunWithBranch(t *testing.T) {
+	tests := []struct {
+		name               string
+		setupMocks         func(projectSvc *project.MockProjectService, featureSvc *featuremocks.MockFeatureServicer, gitClient *gitmocks.MockClient)
+		wantErr            bool
+		wantOutputContains string
+		wantStdoutContains string
+	}{
+		{
+			name: "creates new branch from pattern",
+			setupMocks: func(projectSvc *project.MockProjectService, featureSvc *featuremocks.MockFeatureServicer, gitClient *gitmocks.MockClient) {
+				gitClient.EXPECT().IsRepository(gomock.Any()).Return(true).Times(1)
+				projectSvc.EXPECT().CreateFeature(gomock.Any(), "login").Return(project.NewResult("Feature 'login' created."), nil).Times(1)
+				gitClient.EXPECT().BranchExists(gomock.Any(), "dev/login").Return(false, nil).Times(1)
+				gitClient.EXPECT().CreateBranch(gomock.Any(), "dev/login").Return(nil).Times(1)
+				featureSvc.EXPECT().SetFeatureBranch("login", "dev/login").Return(nil).Times(1)
+			},
+			wantOutputContains: "Created and switched to branch 'dev/login'.",
+		},
+		{
+			name: "reuses existing branch",
+			setupMocks: func(projectSvc *project.MockProjectService, featureSvc *featuremocks.MockFeatureServicer, gitClient *gitmocks.MockClient) {
+				gitClient.EXPECT().IsRepository(gomock.Any()).Return(true).Times(1)
+				projectSvc.EXPECT().CreateFeature(gomock.Any(), "login").Return(project.NewResult("Feature 'login' created."), nil).Times(1)
+				gitClient.EXPECT().BranchExists(gomock.Any(), "dev/login").Return(true, nil).Times(1)
+				gitClient.EXPECT().SwitchBranch(gomock.Any(), "dev/login").Return(nil).Times(1)
+				featureSvc.EXPECT().SetFeatureBranch("login", "dev/login").Return(nil).Times(1)
+			},
+			wantOutputContains: "Switched to existing branch 'dev/login'.",
+		},
+		{
+			name: "not a git repository",
+			setupMocks: func(projectSvc *project.MockProjectService, featureSvc *featuremocks.MockFeatureServicer, gitClient *gitmocks.MockClient) {
+				gitClient.EXPECT().IsRepository(gomock.Any()).Return(false).Times(1)
+			},
+			wantErr:            true,
+			wantOutputContains: "requires a git repository",
+		},
+		{
+			name: "invalid branch name creates no feature",
+			setupMocks: func(projectSvc *project.MockProjectService, featureSvc *featuremocks.MockFeatureServicer, gitClient *gitmocks.MockClient) {
+				gitClient.EXPECT().IsRepository(gomock.Any()).Return(true).Times(1)
+				gitClient.EXPECT().BranchExists(gomock.Any(), "dev/login").Return(false, fmt.Errorf("invalid branch name 'dev/login'")).Times(1)
+			},
+			wantErr:            true,
+			wantOutputContains: "invalid branch name",
+		},
+		{
+			name: "branch creation fails",
+			setupMocks: func(projectSvc *project.MockProjectService, featureSvc *featuremocks.MockFeatureServicer, gitClient *gitmocks.MockClient) {
+				gitClient.EXPECT().IsRepository(gomock.Any()).Return(true).Times(1)
+				gitClient.EXPECT().BranchExists(gomock.Any(), "dev/login").Return(false, nil).Times(1)
+				projectSvc.EXPECT().CreateFeature(gomock.Any(), "login").Return(project.NewResult("Feature 'login' created."), nil).Times(1)
+				gitClient.EXPECT().CreateBranch(gomock.Any(), "dev/login").Return(fmt.Errorf("uncommitted changes")).Times(1)
+			},
+			wantErr:            true,
+			wantOutputContains: "uncommitted changes",
+			wantStdoutContains: "Feature 'login' created.\nWarning: feature 'login' was created and entered without a branch",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockProjectSvc := project.NewMockProjectService(ctrl)
+			mockFeatureSvc := featuremocks.NewMockFeatureServicer(ctrl)
+			mockGit := gitmocks.NewMockClient(ctrl)
+			tt.setupMocks(mockProjectSvc, mockFeatureSvc, mockGit)
+
+			cmdInstance := &FeatureCreateCommand{
+				featureName:   "login",
+				branch:        true,
+				branchPattern: "dev/{name}",
+				projectSvc:    mockProjectSvc,
+				featureSvc:    mockFeatureSvc,
+				gitClient:     mockGit,
+			}
+
+			rPipe, wPipe, restoreStdout := captureStdout(t)
+			err := cmdInstance.run(context.Background())
+			wPipe.Close()
+			restoreStdout()
+			stdoutBuf := new(bytes.Buffer)
+			stdoutBuf.ReadFrom(rPipe)
+			rPipe.Close()
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FeatureCreateCommand.run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if !strings.Contains(err.Error(), tt.wantOutputContains) {
+					t.Errorf("FeatureCreateCommand.run() error = %q, want to contain %q", err.Error(), tt.wantOutputContains)
+				}
+			} else if !strings.Contains(stdoutBuf.String(), tt.wantOutputContains) {
+				t.Errorf("FeatureCreateCommand.run() output = %q, want to contain %q", stdoutBuf.String(), tt.wantOutputContains)
+			}
+			if !strings.Contains(stdoutBuf.String(), tt.wantStdoutContains) {
+				t.Errorf("FeatureCreateCommand.run() output = %q, want to contain %q", stdoutBuf.String(), tt.wantStdoutContains)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imcclaskey/d3/internal/core/doctor"
+	"github.com/imcclaskey/d3/internal/core/ports"
+)
+
+// doctorCmdRunner holds the dependencies and options for the doctor command.
+type doctorCmdRunner struct {
+	fix      bool
+	env      *doctor.Env
+	registry *doctor.Registry
+}
+
+// NewDoctorCommand creates a new cobra command that checks the installation for problems.
+func NewDoctorCommand() *cobra.Command {
+	cmdRunner := &doctorCmdRunner{}
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Check the d3 installation for problems and optionally repair them",
+		Long: `Check the project for problems that break d3 or the editor integration: an mcp.json that runs a
+d3 binary missing from PATH or points at another checkout, missing "# d3" sections in .gitignore and
+.cursorignore, an active feature that no longer exists, invalid .phase files and generated rules that
+do not match the active feature.
+
+With --fix, problems that can be repaired without losing data are fixed. The command exits with an
+error while error-level problems remain.`,
+		Args: cobra.NoArgs,
+		// Problems are the report itself, not a misuse of the command
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projectRoot, err := os.Getwd()
+			if err != nil {
+				return fmt.Errorf("could not determine workspace root: %w", err)
+			}
+			cfg, err := NewConfig(projectRoot)
+			if err != nil {
+				return err
+			}
+
+			fs := ports.RealFileSystem{}
+			_, services := wire(cfg, fs)
+			projectCfg := cfg.project()
+			cmdRunner.env = &doctor.Env{
+				ProjectRoot:          cfg.WorkspaceRoot,
+				D3Dir:                cfg.D3Dir,
+				FeaturesDir:          cfg.FeaturesDir,
+				CursorRulesDir:       cfg.CursorRulesDir,
+				MCPServerName:        projectCfg.MCPServerName,
+				GitignorePatterns:    projectCfg.GitignorePatterns,
+				CursorignorePatterns: projectCfg.CursorignorePatterns,
+				FS:                   fs,
+				Features:             services.Features,
+				Rules:                services.Rules,
+				Generator:            services.Generator,
+				Files:                services.Files,
+				LookPath:             exec.LookPath,
+			}
+			cmdRunner.registry = doctor.DefaultRegistry()
+
+			return cmdRunner.run(context.Background())
+		},
+	}
+	cmd.Flags().BoolVar(&cmdRunner.fix, "fix", false, "Repair the problems that can be fixed safely")
+	return cmd
+}
+
+// run runs every check, prints the report and fails when error-level problems remain.
+func (c *doctorCmdRunner) run(ctx context.Context) error {
+	if c.env == nil || c.registry == nil {
+		return fmt.Errorf("services not initialized in doctorCmdRunner")
+	}
+
+	report := c.registry.Run(ctx, c.env, c.fix)
+	result := NewResult(formatDoctorReport(report, c.fix), report, nil)
+
+	if unresolved := report.Unresolved(doctor.SeverityError); unresolved > 0 {
+		return emitFailure(result, fmt.Errorf("%d error-level problem(s) remain", unresolved))
+	}
+	emit(result)
+	return nil
+}
+
+// formatDoctorReport renders one line per problem followed by a summary.
+func formatDoctorReport(report *doctor.Report, fix bool) string {
+	var b strings.Builder
+	for _, p := range report.Problems {
+		status := ""
+		switch {
+		case p.Fixed:
+			status = " (fixed)"
+		case p.FixError != "":
+			status = fmt.Sprintf(" (fix failed: %s)", p.FixError)
+		case p.Fixable && !fix:
+			status = " (fixable with --fix)"
+		}
+		fmt.Fprintf(&b, "[%s] %s: %s%s\n", p.Severity, p.Check, p.Message, status)
+	}
+
+	if len(report.Problems) == 0 {
+		fmt.Fprintf(&b, "No problems found (%d checks run).", len(report.Checks))
+		return b.String()
+	}
+	fmt.Fprintf(&b, "%d problem(s) found by %d checks", len(report.Problems), len(report.Checks))
+	if fix {
+		fmt.Fprintf(&b, ", %d fixed", report.Fixed())
+	}
+	if len(report.Skipped) > 0 {
+		fmt.Fprintf(&b, "; skipped until the project is initialized: %s", strings.Join(report.Skipped, ", "))
+	}
+	b.WriteString(".")
+	return b.String()
+}
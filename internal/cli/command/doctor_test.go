@@ -0,0 +1,128 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/imcclaskey/d3/internal/core/doctor"
+	"github.com/imcclaskey/d3/internal/testutil"
+)
+
+func TestDoctorCmdRunner_run(t *testing.T) {
+	var repaired bool
+	checks := []doctor.Check{
+		{
+			Name: "repairable",
+			Run: func(ctx context.Context, env *doctor.Env) ([]doctor.Finding, error) {
+				if repaired {
+					return nil, nil
+				}
+				return []doctor.Finding{{Severity: doctor.SeverityError, Message: "mcp.json is stale", Fix: func(ctx context.Context) error {
+					repaired = true
+					return nil
+				}}}, nil
+			},
+		},
+		{
+			Name: "manual",
+			Run: func(ctx context.Context, env *doctor.Env) ([]doctor.Finding, error) {
+				return []doctor.Finding{{Severity: doctor.SeverityWarning, Message: "phase looks odd"}}, nil
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		fix        bool
+		wantErr    bool
+		wantOutput []string
+	}{
+		{
+			name:    "report only fails on errors",
+			wantErr: true,
+			wantOutput: []string{
+				"[error] repairable: mcp.json is stale (fixable with --fix)",
+				"[warning] manual: phase looks odd\n",
+				"2 problem(s) found by 2 checks.",
+			},
+		},
+		{
+			name: "fix resolves errors",
+			fix:  true,
+			wantOutput: []string{
+				"[error] repairable: mcp.json is stale (fixed)",
+				"2 problem(s) found by 2 checks, 1 fixed.",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withOutputFormat(t, OutputText, "doctor")
+			repaired = false
+			cmdInstance := &doctorCmdRunner{
+				fix:      tt.fix,
+				env:      &doctor.Env{ProjectRoot: "/p", D3Dir: "/p/.d3", FS: testutil.NewMemFS()},
+				registry: doctor.NewRegistry(checks...),
+			}
+
+			var err error
+			got := readStdout(t, func() { err = cmdInstance.run(context.Background()) })
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("doctorCmdRunner.run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(got, want) {
+					t.Errorf("doctorCmdRunner.run() output = %q, want to contain %q", got, want)
+				}
+			}
+		})
+	}
+
+	t.Run("no problems", func(t *testing.T) {
+		withOutputFormat(t, OutputText, "doctor")
+		cmdInstance := &doctorCmdRunner{env: &doctor.Env{FS: testutil.NewMemFS()}, registry: doctor.NewRegistry()}
+		got := readStdout(t, func() {
+			if err := cmdInstance.run(context.Background()); err != nil {
+				t.Errorf("doctorCmdRunner.run() error = %v", err)
+			}
+		})
+		if got != "No problems found (0 checks run).\n" {
+			t.Errorf("doctorCmdRunner.run() output = %q", got)
+		}
+	})
+
+	t.Run("json report", func(t *testing.T) {
+		withOutputFormat(t, OutputJSON, "doctor")
+		repaired = false
+		cmdInstance := &doctorCmdRunner{env: &doctor.Env{FS: testutil.NewMemFS()}, registry: doctor.NewRegistry(checks...)}
+
+		var err error
+		got := readStdout(t, func() {
+			err = cmdInstance.run(context.Background())
+			ReportError(nil, err)
+		})
+		if err == nil {
+			t.Fatal("doctorCmdRunner.run() error = nil, want unresolved problems")
+		}
+
+		var decoded struct {
+			OK   bool          `json:"ok"`
+			Data doctor.Report `json:"data"`
+		}
+		if jsonErr := json.Unmarshal([]byte(got), &decoded); jsonErr != nil {
+			t.Fatalf("doctorCmdRunner.run() printed invalid JSON: %v\n%s", jsonErr, got)
+		}
+		if decoded.OK || len(decoded.Data.Problems) != 2 || !decoded.Data.Problems[0].Fixable {
+			t.Errorf("doctorCmdRunner.run() envelope = %+v", decoded)
+		}
+	})
+
+	t.Run("missing services", func(t *testing.T) {
+		if err := (&doctorCmdRunner{}).run(context.Background()); err == nil {
+			t.Error("doctorCmdRunner.run() without services error = nil")
+		}
+	})
+}
@@ -7,11 +7,7 @@ import (
 
 	"github.com/spf13/cobra"
 
-	"github.com/imcclaskey/d3/internal/core/feature"
-	"github.com/imcclaskey/d3/internal/core/phase"
 	"github.com/imcclaskey/d3/internal/core/ports"
-	"github.com/imcclaskey/d3/internal/core/projectfiles"
-	"github.com/imcclaskey/d3/internal/core/rules"
 	"github.com/imcclaskey/d3/internal/project"
 )
 
@@ -32,16 +28,13 @@ func NewExitCommand() *cobra.Command {
 			if err != nil {
 				return fmt.Errorf("could not determine workspace root: %w", err)
 			}
-			cfg := NewConfig(projectRoot)
+			cfg, err := NewConfig(projectRoot)
+			if err != nil {
+				return err
+			}
 
 			fs := ports.RealFileSystem{}
-			featureSvc := feature.NewService(cfg.WorkspaceRoot, cfg.FeaturesDir, cfg.D3Dir, fs)
-			phaseSvc := phase.NewService(fs)
-			ruleGenerator := rules.NewRuleGenerator(cfg.WorkspaceRoot, fs)
-			rulesSvc := rules.NewService(cfg.WorkspaceRoot, cfg.CursorRulesDir, ruleGenerator, fs)
-			fileOp := projectfiles.NewDefaultFileOperator()
-
-			cmdRunner.projectSvc = project.New(cfg.WorkspaceRoot, fs, featureSvc, rulesSvc, phaseSvc, fileOp)
+			cmdRunner.projectSvc, _ = wire(cfg, fs)
 
 			return cmdRunner.run(context.Background())
 		},
@@ -60,7 +53,7 @@ func (c *ExitCommand) run(ctx context.Context) error {
 		return err
 	}
 
-	fmt.Println(result.FormatCLI())
+	emit(projectResult(result))
 
 	return nil
 }
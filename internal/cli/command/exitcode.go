@@ -0,0 +1,43 @@
+package command
+
+import "github.com/imcclaskey/d3/internal/core/d3err"
+
+// Exit codes returned by the d3 binary. Each d3err code has its own status so that scripts can
+// react to a failure without parsing the message.
+const (
+	ExitOK              = 0
+	ExitError           = 1
+	ExitInvalidArgument = 2
+	ExitNotInitialized  = 3
+	ExitNoActiveFeature = 4
+	ExitFeatureExists   = 5
+	ExitFeatureNotFound = 6
+	ExitInvalidName     = 7
+	ExitInvalidPhase    = 8
+	ExitGateFailed      = 9
+	ExitLocked          = 10
+)
+
+// exitCodes maps error codes to exit statuses. Codes not listed exit with ExitError.
+var exitCodes = map[d3err.Code]int{
+	d3err.InvalidArgument: ExitInvalidArgument,
+	d3err.NotInitialized:  ExitNotInitialized,
+	d3err.NoActiveFeature: ExitNoActiveFeature,
+	d3err.FeatureExists:   ExitFeatureExists,
+	d3err.FeatureNotFound: ExitFeatureNotFound,
+	d3err.InvalidName:     ExitInvalidName,
+	d3err.InvalidPhase:    ExitInvalidPhase,
+	d3err.GateFailed:      ExitGateFailed,
+	d3err.Locked:          ExitLocked,
+}
+
+// ExitCode returns the process exit status for the error a command returned.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	if code, ok := exitCodes[d3err.CodeOf(err)]; ok {
+		return code
+	}
+	return ExitError
+}
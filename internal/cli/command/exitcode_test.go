@@ -0,0 +1,40 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/imcclaskey/d3/internal/core/d3err"
+	"github.com/imcclaskey/d3/internal/core/feature"
+	"github.com/imcclaskey/d3/internal/project"
+)
+
+func TestExitCode(t *testing.T) {
+	_, invalidName := feature.NormalizeName("../etc")
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "success", err: nil, want: ExitOK},
+		{name: "untyped error", err: errors.New("boom"), want: ExitError},
+		{name: "flag error", err: d3err.Wrap(d3err.InvalidArgument, errors.New("unknown flag")), want: ExitInvalidArgument},
+		{name: "not initialized", err: fmt.Errorf("create: %w", project.ErrNotInitialized), want: ExitNotInitialized},
+		{name: "no active feature", err: project.ErrNoActiveFeature, want: ExitNoActiveFeature},
+		{name: "feature exists", err: d3err.New(d3err.FeatureExists, "feature x already exists"), want: ExitFeatureExists},
+		{name: "feature not found", err: d3err.New(d3err.FeatureNotFound, "feature x does not exist"), want: ExitFeatureNotFound},
+		{name: "invalid name", err: invalidName, want: ExitInvalidName},
+		{name: "invalid phase", err: d3err.New(d3err.InvalidPhase, "invalid phase: x"), want: ExitInvalidPhase},
+		{name: "locked", err: d3err.New(d3err.Locked, "busy"), want: ExitLocked},
+		{name: "gate failed", err: d3err.New(d3err.GateFailed, "blocked"), want: ExitGateFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.want {
+				t.Errorf("ExitCode(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imcclaskey/d3/internal/core/feature"
+	"github.com/imcclaskey/d3/internal/core/git"
+	"github.com/imcclaskey/d3/internal/core/githooks"
+)
+
+// featureCommitsCmdRunner holds the dependencies for the feature commits command.
+type featureCommitsCmdRunner struct {
+	featureName string
+	gitClient   git.Client
+}
+
+// NewFeatureCommitsCommand creates a new cobra command listing commits made for a feature.
+func NewFeatureCommitsCommand() *cobra.Command {
+	cmdRunner := &featureCommitsCmdRunner{}
+	return &cobra.Command{
+		Use:   "commits <name>",
+		Short: "List commits tagged with the feature's D3-Feature trailer",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmdRunner.featureName = args[0]
+
+			projectRoot, err := os.Getwd()
+			if err != nil {
+				return fmt.Errorf("could not determine workspace root: %w", err)
+			}
+			cmdRunner.gitClient = git.New(projectRoot)
+
+			return cmdRunner.run(context.Background())
+		},
+	}
+}
+
+// run prints the commits carrying the feature trailer, newest first.
+func (c *featureCommitsCmdRunner) run(ctx context.Context) error {
+	if c.gitClient == nil {
+		return fmt.Errorf("git client not initialized in featureCommitsCmdRunner")
+	}
+	featureName, err := feature.NormalizeName(c.featureName)
+	if err != nil {
+		return err
+	}
+	if !c.gitClient.IsRepository(ctx) {
+		return fmt.Errorf("listing feature commits requires a git repository")
+	}
+
+	commits, err := c.gitClient.CommitsWithTrailer(ctx, githooks.TrailerKey, featureName)
+	if err != nil {
+		return err
+	}
+
+	data := featureCommitsData{Feature: featureName, Commits: []featureCommit{}}
+	var message strings.Builder
+	if len(commits) == 0 {
+		fmt.Fprintf(&message, "No commits found for feature '%s'.", featureName)
+	}
+	for _, commit := range commits {
+		hash := commit.Hash
+		if len(hash) > 7 {
+			hash = hash[:7]
+		}
+		fmt.Fprintf(&message, "%s  %s  %s  %s\n", hash, commit.Date.Format("2006-01-02"), commit.Author, commit.Subject)
+		data.Commits = append(data.Commits, featureCommit{
+			Hash:    commit.Hash,
+			Date:    commit.Date,
+			Author:  commit.Author,
+			Subject: commit.Subject,
+		})
+	}
+	emit(NewResult(message.String(), data, nil))
+	return nil
+}
+
+// featureCommitsData is the JSON data of the feature commits command.
+type featureCommitsData struct {
+	Feature string          `json:"feature"`
+	Commits []featureCommit `json:"commits"`
+}
+
+// featureCommit is one commit in featureCommitsData, newest first.
+type featureCommit struct {
+	Hash    string    `json:"hash"`
+	Date    time.Time `json:"date"`
+	Author  string    `json:"author"`
+	Subject string    `json:"subject"`
+}
@@ -0,0 +1,85 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/imcclaskey/d3/internal/core/git"
+	gitmocks "github.com/imcclaskey/d3/internal/core/git/mocks"
+	"github.com/imcclaskey/d3/internal/core/githooks"
+)
+
+func TestFeatureCommitsCmdRunner_run(t *testing.T) {
+	tests := []struct {
+		name               string
+		featureNameArg     string
+		setupMocks         func(gitClient *gitmocks.MockClient)
+		wantErr            bool
+		wantOutputContains string
+	}{
+		{
+			name:           "lists commits",
+			featureNameArg: "Login",
+			setupMocks: func(gitClient *gitmocks.MockClient) {
+				gitClient.EXPECT().IsRepository(gomock.Any()).Return(true).Times(1)
+				gitClient.EXPECT().CommitsWithTrailer(gomock.Any(), githooks.TrailerKey, "login").Return([]git.Commit{
+					{Hash: "0123456789abcdef", Author: "Ann", Date: time.Date(2025, 5, 2, 0, 0, 0, 0, time.UTC), Subject: "Add login form"},
+				}, nil).Times(1)
+			},
+			wantOutputContains: "0123456  2025-05-02  Ann  Add login form",
+		},
+		{
+			name:           "no commits",
+			featureNameArg: "login",
+			setupMocks: func(gitClient *gitmocks.MockClient) {
+				gitClient.EXPECT().IsRepository(gomock.Any()).Return(true).Times(1)
+				gitClient.EXPECT().CommitsWithTrailer(gomock.Any(), githooks.TrailerKey, "login").Return([]git.Commit{}, nil).Times(1)
+			},
+			wantOutputContains: "No commits found for feature 'login'.",
+		},
+		{
+			name:           "not a repository",
+			featureNameArg: "login",
+			setupMocks: func(gitClient *gitmocks.MockClient) {
+				gitClient.EXPECT().IsRepository(gomock.Any()).Return(false).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name:           "invalid name",
+			featureNameArg: "../x",
+			setupMocks:     func(gitClient *gitmocks.MockClient) {},
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockGit := gitmocks.NewMockClient(ctrl)
+			tt.setupMocks(mockGit)
+
+			runner := &featureCommitsCmdRunner{featureName: tt.featureNameArg, gitClient: mockGit}
+
+			rPipe, wPipe, restoreStdout := captureStdout(t)
+			err := runner.run(context.Background())
+			wPipe.Close()
+			restoreStdout()
+			stdoutBuf := new(bytes.Buffer)
+			stdoutBuf.ReadFrom(rPipe)
+			rPipe.Close()
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("featureCommitsCmdRunner.run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !strings.Contains(stdoutBuf.String(), tt.wantOutputContains) {
+				t.Errorf("featureCommitsCmdRunner.run() output = %q, want to contain %q", stdoutBuf.String(), tt.wantOutputContains)
+			}
+		})
+	}
+}
@@ -9,27 +9,44 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/imcclaskey/d3/internal/core/d3err"
 	"github.com/imcclaskey/d3/internal/core/feature"
 	"github.com/imcclaskey/d3/internal/core/ports"
+	"github.com/imcclaskey/d3/internal/project"
 )
 
 // featureDeleteCmdRunner holds the dependencies and logic for the feature delete command.
 type featureDeleteCmdRunner struct {
 	featureName string
-	featureSvc  feature.FeatureServicer // Use the interface type
+	purge       bool
+	yes         bool
+	projectSvc  project.ProjectService
+}
+
+// featureDeleteData is the JSON data of the feature delete command.
+type featureDeleteData struct {
+	FeatureData
+	// Deleted is false when the confirmation prompt was declined.
+	Deleted bool `json:"deleted"`
+	// Purged is true when the feature was removed permanently rather than moved to the trash.
+	Purged bool `json:"purged"`
+	// ActiveFeatureCleared is true when the deleted feature was the active one.
+	ActiveFeatureCleared bool `json:"active_feature_cleared"`
 }
 
 // NewFeatureDeleteCommand creates a new cobra command for deleting features.
 func NewFeatureDeleteCommand() *cobra.Command {
-	// cmdRunner instance is created here but its fields (featureSvc, featureName)
+	// cmdRunner instance is created here but its fields (projectSvc, featureName)
 	// will be populated within RunE before calling its runLogic method.
 	cmdRunner := &featureDeleteCmdRunner{}
 
 	cmd := &cobra.Command{
 		Use:   "delete [feature-name]",
 		Short: "Delete a feature",
-		Long:  `Delete a feature by removing its directory and all associated content. This action is permanent and cannot be undone.`,
-		Args:  cobra.ExactArgs(1),
+		Long: `Delete a feature by moving its directory into the trash (.d3/.trash). Deleted features can be
+brought back with 'd3 feature restore' until the trash is emptied. Use --purge to remove the
+feature permanently instead.`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cmdRunner.featureName = args[0]
 
@@ -37,50 +54,76 @@ func NewFeatureDeleteCommand() *cobra.Command {
 			if err != nil {
 				return fmt.Errorf("could not determine workspace root: %w", err)
 			}
-			cfg := NewConfig(projectRoot)
-			fs := ports.RealFileSystem{}
-
-			// Initialize the real service for actual command execution
-			cmdRunner.featureSvc = feature.NewService(cfg.WorkspaceRoot, cfg.FeaturesDir, cfg.D3Dir, fs)
+			cfg, err := NewConfig(projectRoot)
+			if err != nil {
+				return err
+			}
+			// Delete through the project so that the pre_delete and post_delete hooks run
+			cmdRunner.projectSvc, _ = wire(cfg, ports.RealFileSystem{})
 
 			return cmdRunner.runLogic(context.Background())
 		},
 	}
+	cmd.Flags().BoolVar(&cmdRunner.purge, "purge", false, "Permanently delete the feature instead of moving it to the trash")
+	cmd.Flags().BoolVarP(&cmdRunner.yes, "yes", "y", false, "Skip the confirmation prompt (required with --output json)")
 	return cmd
 }
 
 // runLogic contains the core logic for deleting a feature.
 // This method is called by RunE and can be called directly in tests with a mock service.
 func (c *featureDeleteCmdRunner) runLogic(ctx context.Context) error {
-	// Confirmation prompt
-	// For actual CLI execution, os.Stdin will be used.
-	// For testing, os.Stdin can be mocked.
-	reader := bufio.NewReader(os.Stdin)
-	fmt.Printf("Are you sure you want to delete feature '%s'? This action cannot be undone. [y/N]: ", c.featureName)
-	input, _ := reader.ReadString('\n')
-	input = strings.TrimSpace(strings.ToLower(input))
+	featureName, err := feature.NormalizeName(c.featureName)
+	if err != nil {
+		return err
+	}
+	c.featureName = featureName
 
-	if input != "y" && input != "yes" {
-		fmt.Println("Feature deletion cancelled.")
+	if !c.yes && JSONOutput() {
+		return d3err.New(d3err.InvalidArgument, "deleting feature '%s' with --output json requires --yes", c.featureName)
+	}
+	if !c.yes && !c.confirm() {
+		emit(NewResult("Feature deletion cancelled.", featureDeleteData{FeatureData: FeatureData{Feature: c.featureName, FilesTouched: []string{}}}, nil))
 		return nil
 	}
 
-	if c.featureSvc == nil {
+	if c.projectSvc == nil {
 		// This should ideally not happen if RunE populates it correctly
-		return fmt.Errorf("feature service not initialized in featureDeleteCmdRunner")
+		return fmt.Errorf("project service not initialized in featureDeleteCmdRunner")
 	}
 
-	activeContextCleared, err := c.featureSvc.DeleteFeature(ctx, c.featureName)
+	var result *project.Result
+	if c.purge {
+		result, err = c.projectSvc.PurgeFeature(ctx, c.featureName)
+	} else {
+		result, err = c.projectSvc.DeleteFeature(ctx, c.featureName)
+	}
 	if err != nil {
 		// Error is simply returned to cobra, which will print it.
 		return fmt.Errorf("failed to delete feature '%s': %w", c.featureName, err)
 	}
 
 	// If we reach here, deletion was successful.
-	fmt.Printf("Feature '%s' deleted successfully.\n", c.featureName)
-	if activeContextCleared {
-		fmt.Println("The active feature context has been cleared.")
-	}
-
+	summary := projectResult(result)
+	emit(NewResult(summary.Message, featureDeleteData{
+		FeatureData:          summary.Data.(FeatureData),
+		Deleted:              true,
+		Purged:               c.purge,
+		ActiveFeatureCleared: result.ActiveFeatureCleared,
+	}, summary.Warnings))
 	return nil
 }
+
+// confirm asks before deleting.
+func (c *featureDeleteCmdRunner) confirm() bool {
+	// For actual CLI execution, os.Stdin will be used.
+	// For testing, os.Stdin can be mocked.
+	reader := bufio.NewReader(os.Stdin)
+	if c.purge {
+		fmt.Printf("Are you sure you want to permanently delete feature '%s'? This action cannot be undone. [y/N]: ", c.featureName)
+	} else {
+		fmt.Printf("Are you sure you want to delete feature '%s'? It will be moved to the trash. [y/N]: ", c.featureName)
+	}
+	input, _ := reader.ReadString('\n')
+	input = strings.TrimSpace(strings.ToLower(input))
+	return input == "y" || input == "yes"
+}
@@ -10,7 +10,9 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
-	featuremocks "github.com/imcclaskey/d3/internal/core/feature/mocks"
+	"github.com/imcclaskey/d3/internal/core/d3err"
+	"github.com/imcclaskey/d3/internal/core/phase"
+	"github.com/imcclaskey/d3/internal/project"
 )
 
 // helper function to simulate stdin for tests
@@ -51,39 +53,59 @@ func captureStdout(t *testing.T) (*os.File, *os.File, func()) {
 
 func TestFeatureDeleteCommand_runLogic(t *testing.T) {
 	ctx := context.Background()
+	deleted := func(featureName, message string, activeCleared bool) *project.Result {
+		result := project.NewResult(message).WithFeature(featureName, phase.None).WithFiles("/p/.d3/features/" + featureName)
+		result.ActiveFeatureCleared = activeCleared
+		return result
+	}
 	tests := []struct {
 		name                string
 		featureNameArg      string
+		purge               bool
+		yes                 bool
+		format              OutputFormat
 		userInput           string
-		setupMockFeatureSvc func(mockSvc *featuremocks.MockFeatureServicer, featureName string)
+		setupMockProjectSvc func(mockSvc *project.MockProjectService, featureName string)
 		wantErr             bool
+		wantExit            int
 		wantOutputContains  string
 	}{
 		{
 			name:           "successful deletion with y confirmation",
 			featureNameArg: "my-feature-to-delete",
 			userInput:      "y",
-			setupMockFeatureSvc: func(mockSvc *featuremocks.MockFeatureServicer, featureName string) {
-				mockSvc.EXPECT().DeleteFeature(gomock.Any(), featureName).Return(false, nil).Times(1)
+			setupMockProjectSvc: func(mockSvc *project.MockProjectService, featureName string) {
+				mockSvc.EXPECT().DeleteFeature(gomock.Any(), featureName).Return(deleted(featureName, "Feature 'my-feature-to-delete' moved to trash.", false), nil).Times(1)
 			},
 			wantErr:            false,
-			wantOutputContains: "Feature 'my-feature-to-delete' deleted successfully.",
+			wantOutputContains: "Feature 'my-feature-to-delete' moved to trash.",
 		},
 		{
 			name:           "successful deletion with yes confirmation",
 			featureNameArg: "another-feature",
 			userInput:      "yes",
-			setupMockFeatureSvc: func(mockSvc *featuremocks.MockFeatureServicer, featureName string) {
-				mockSvc.EXPECT().DeleteFeature(gomock.Any(), featureName).Return(false, nil).Times(1)
+			setupMockProjectSvc: func(mockSvc *project.MockProjectService, featureName string) {
+				mockSvc.EXPECT().DeleteFeature(gomock.Any(), featureName).Return(deleted(featureName, "Feature 'another-feature' moved to trash.", false), nil).Times(1)
+			},
+			wantErr:            false,
+			wantOutputContains: "Feature 'another-feature' moved to trash.",
+		},
+		{
+			name:           "purge permanently deletes",
+			featureNameArg: "purged-feature",
+			purge:          true,
+			userInput:      "y",
+			setupMockProjectSvc: func(mockSvc *project.MockProjectService, featureName string) {
+				mockSvc.EXPECT().PurgeFeature(gomock.Any(), featureName).Return(deleted(featureName, "Feature 'purged-feature' permanently deleted.", false), nil).Times(1)
 			},
 			wantErr:            false,
-			wantOutputContains: "Feature 'another-feature' deleted successfully.",
+			wantOutputContains: "Feature 'purged-feature' permanently deleted.",
 		},
 		{
 			name:           "deletion cancelled with n",
 			featureNameArg: "safe-feature",
 			userInput:      "n",
-			setupMockFeatureSvc: func(mockSvc *featuremocks.MockFeatureServicer, featureName string) {
+			setupMockProjectSvc: func(mockSvc *project.MockProjectService, featureName string) {
 				// DeleteFeature should not be called
 			},
 			wantErr:            false,
@@ -93,36 +115,78 @@ func TestFeatureDeleteCommand_runLogic(t *testing.T) {
 			name:           "deletion cancelled with empty input",
 			featureNameArg: "empty-input-feature",
 			userInput:      "",
-			setupMockFeatureSvc: func(mockSvc *featuremocks.MockFeatureServicer, featureName string) {
+			setupMockProjectSvc: func(mockSvc *project.MockProjectService, featureName string) {
 				// DeleteFeature should not be called
 			},
 			wantErr:            false,
 			wantOutputContains: "Feature deletion cancelled.",
 		},
 		{
-			name:           "feature service returns error on delete",
+			name:           "project service returns error on delete",
 			featureNameArg: "error-prone-feature",
 			userInput:      "y",
-			setupMockFeatureSvc: func(mockSvc *featuremocks.MockFeatureServicer, featureName string) {
-				mockSvc.EXPECT().DeleteFeature(gomock.Any(), featureName).Return(false, fmt.Errorf("internal service error")).Times(1)
+			setupMockProjectSvc: func(mockSvc *project.MockProjectService, featureName string) {
+				mockSvc.EXPECT().DeleteFeature(gomock.Any(), featureName).Return(nil, fmt.Errorf("internal service error")).Times(1)
 			},
 			wantErr:            true,                                                            // Error should be returned by runLogic
 			wantOutputContains: "Are you sure you want to delete feature 'error-prone-feature'", // Prompt still shown
 		},
+		{
+			name:           "failing pre_delete hook aborts the delete",
+			featureNameArg: "guarded",
+			yes:            true,
+			setupMockProjectSvc: func(mockSvc *project.MockProjectService, featureName string) {
+				mockSvc.EXPECT().DeleteFeature(gomock.Any(), featureName).Return(nil, d3err.New(d3err.GateFailed, "pre_delete hook failed")).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name:           "yes skips the confirmation",
+			featureNameArg: "unattended",
+			yes:            true,
+			setupMockProjectSvc: func(mockSvc *project.MockProjectService, featureName string) {
+				mockSvc.EXPECT().DeleteFeature(gomock.Any(), featureName).Return(deleted(featureName, "Feature 'unattended' moved to trash. Active feature context has been cleared.", true), nil).Times(1)
+			},
+			wantOutputContains: "Active feature context has been cleared.",
+		},
+		{
+			name:               "json output requires yes",
+			featureNameArg:     "scripted",
+			format:             OutputJSON,
+			userInput:          "y",
+			wantErr:            true,
+			wantExit:           ExitInvalidArgument,
+			wantOutputContains: "",
+		},
+		{
+			name:           "json output with yes",
+			featureNameArg: "scripted",
+			yes:            true,
+			format:         OutputJSON,
+			setupMockProjectSvc: func(mockSvc *project.MockProjectService, featureName string) {
+				mockSvc.EXPECT().DeleteFeature(gomock.Any(), featureName).Return(deleted(featureName, "Feature 'scripted' moved to trash.", true), nil).Times(1)
+			},
+			wantOutputContains: `"active_feature_cleared": true`,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
-			mockFeatureSvc := featuremocks.NewMockFeatureServicer(ctrl)
+			mockProjectSvc := project.NewMockProjectService(ctrl)
 
-			if tt.setupMockFeatureSvc != nil {
-				tt.setupMockFeatureSvc(mockFeatureSvc, tt.featureNameArg)
+			if tt.setupMockProjectSvc != nil {
+				tt.setupMockProjectSvc(mockProjectSvc, tt.featureNameArg)
 			}
 
+			if tt.format != "" {
+				withOutputFormat(t, tt.format, "feature delete")
+			}
 			cmdRunner := &featureDeleteCmdRunner{
 				featureName: tt.featureNameArg,
-				featureSvc:  mockFeatureSvc,
+				purge:       tt.purge,
+				yes:         tt.yes,
+				projectSvc:  mockProjectSvc,
 			}
 
 			// Mock stdin
@@ -144,6 +208,9 @@ func TestFeatureDeleteCommand_runLogic(t *testing.T) {
 			if (err != nil) != tt.wantErr {
 				t.Errorf("featureDeleteCmdRunner.runLogic() error = %v, wantErr %v\nOutput:\n%s", err, tt.wantErr, output)
 			}
+			if tt.wantExit != 0 && ExitCode(err) != tt.wantExit {
+				t.Errorf("ExitCode(featureDeleteCmdRunner.runLogic()) = %d, want %d", ExitCode(err), tt.wantExit)
+			}
 
 			if !strings.Contains(output, tt.wantOutputContains) {
 				t.Errorf("featureDeleteCmdRunner.runLogic() output = %q, want to contain %q", output, tt.wantOutputContains)
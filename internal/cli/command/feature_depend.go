@@ -0,0 +1,86 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imcclaskey/d3/internal/core/feature"
+	"github.com/imcclaskey/d3/internal/core/ports"
+	"github.com/imcclaskey/d3/internal/project"
+)
+
+// featureDependCmdRunner holds dependencies and options for the feature depend command.
+type featureDependCmdRunner struct {
+	featureName  string
+	dependencies []string
+	remove       bool
+	projectSvc   project.ProjectService
+}
+
+// NewFeatureDependCommand creates a new cobra command declaring the features a feature depends on.
+func NewFeatureDependCommand() *cobra.Command {
+	cmdRunner := &featureDependCmdRunner{}
+	cmd := &cobra.Command{
+		Use:   "depend <name> <dependency>...",
+		Short: "Declare that a feature depends on other features",
+		Long: `Record that a feature depends on other features, which must be complete (in deliver with every
+task of progress.yaml done) before it can enter deliver. Dependencies that would form a cycle are
+rejected. Use --remove to drop dependencies instead.`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmdRunner.featureName = args[0]
+			cmdRunner.dependencies = args[1:]
+
+			projectRoot, err := os.Getwd()
+			if err != nil {
+				return fmt.Errorf("could not determine workspace root: %w", err)
+			}
+			cfg, err := NewConfig(projectRoot)
+			if err != nil {
+				return err
+			}
+
+			cmdRunner.projectSvc, _ = wire(cfg, ports.RealFileSystem{})
+
+			return cmdRunner.run(context.Background())
+		},
+	}
+	cmd.Flags().BoolVar(&cmdRunner.remove, "remove", false, "Remove the given dependencies instead of adding them")
+	return cmd
+}
+
+// run adds or removes the dependencies through ProjectService.SetDependencies.
+func (c *featureDependCmdRunner) run(ctx context.Context) error {
+	if c.projectSvc == nil {
+		return fmt.Errorf("project service not initialized in featureDependCmdRunner")
+	}
+
+	featureName, err := feature.NormalizeName(c.featureName)
+	if err != nil {
+		return err
+	}
+	dependencies := make([]string, 0, len(c.dependencies))
+	for _, dep := range c.dependencies {
+		name, err := feature.NormalizeName(dep)
+		if err != nil {
+			return err
+		}
+		dependencies = append(dependencies, name)
+	}
+
+	var result *project.Result
+	if c.remove {
+		result, err = c.projectSvc.SetDependencies(ctx, featureName, nil, dependencies)
+	} else {
+		result, err = c.projectSvc.SetDependencies(ctx, featureName, dependencies, nil)
+	}
+	if err != nil {
+		return err
+	}
+
+	emit(projectResult(result))
+	return nil
+}
@@ -0,0 +1,89 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/imcclaskey/d3/internal/core/d3err"
+	"github.com/imcclaskey/d3/internal/project"
+)
+
+func TestFeatureDependCmdRunner_run(t *testing.T) {
+	tests := []struct {
+		name               string
+		featureName        string
+		dependencies       []string
+		remove             bool
+		setupMock          func(mockProjectSvc *project.MockProjectService)
+		wantErr            bool
+		wantOutputContains string
+	}{
+		{
+			name:         "adds dependencies",
+			featureName:  "UI",
+			dependencies: []string{"Api", "auth"},
+			setupMock: func(mockProjectSvc *project.MockProjectService) {
+				mockProjectSvc.EXPECT().SetDependencies(gomock.Any(), "ui", []string{"api", "auth"}, nil).
+					Return(project.NewResult("Feature 'ui' now depends on 'api' and 'auth'."), nil)
+			},
+			wantOutputContains: "now depends on 'api' and 'auth'",
+		},
+		{
+			name:         "removes dependencies",
+			featureName:  "ui",
+			dependencies: []string{"api"},
+			remove:       true,
+			setupMock: func(mockProjectSvc *project.MockProjectService) {
+				mockProjectSvc.EXPECT().SetDependencies(gomock.Any(), "ui", nil, []string{"api"}).
+					Return(project.NewResult("Feature 'ui' no longer depends on other features."), nil)
+			},
+			wantOutputContains: "no longer depends",
+		},
+		{
+			name:         "cycle",
+			featureName:  "api",
+			dependencies: []string{"ui"},
+			setupMock: func(mockProjectSvc *project.MockProjectService) {
+				mockProjectSvc.EXPECT().SetDependencies(gomock.Any(), "api", []string{"ui"}, nil).
+					Return(nil, d3err.New(d3err.InvalidArgument, "cannot add the dependency: it would form the cycle api -> ui -> api"))
+			},
+			wantErr: true,
+		},
+		{
+			name:         "invalid dependency name",
+			featureName:  "ui",
+			dependencies: []string{"../api"},
+			setupMock:    func(mockProjectSvc *project.MockProjectService) {},
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockProjectSvc := project.NewMockProjectService(ctrl)
+			tt.setupMock(mockProjectSvc)
+
+			cmdInstance := featureDependCmdRunner{featureName: tt.featureName, dependencies: tt.dependencies, remove: tt.remove, projectSvc: mockProjectSvc}
+
+			rPipe, wPipe, restoreStdout := captureStdout(t)
+			err := cmdInstance.run(context.Background())
+			wPipe.Close()
+			restoreStdout()
+			stdoutBuf := new(bytes.Buffer)
+			stdoutBuf.ReadFrom(rPipe)
+			rPipe.Close()
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("featureDependCmdRunner.run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !strings.Contains(stdoutBuf.String(), tt.wantOutputContains) {
+				t.Errorf("featureDependCmdRunner.run() output = %q, want to contain %q", stdoutBuf.String(), tt.wantOutputContains)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imcclaskey/d3/internal/core/feature"
+	"github.com/imcclaskey/d3/internal/core/ports"
+	"github.com/imcclaskey/d3/internal/project"
+)
+
+// featureDiffCmdRunner holds dependencies and options for the feature diff command.
+type featureDiffCmdRunner struct {
+	featureName string
+	from        string
+	to          string
+	projectSvc  project.ProjectService
+}
+
+// NewFeatureDiffCommand creates a new cobra command comparing a snapshot with the current artifacts.
+func NewFeatureDiffCommand() *cobra.Command {
+	cmdRunner := &featureDiffCmdRunner{}
+	cmd := &cobra.Command{
+		Use:   "diff <name> --from <snapshot> [--to <snapshot>]",
+		Short: "Show how a feature's artifacts changed since a snapshot",
+		Long: `Print a unified diff of the artifacts held by a snapshot against the feature's current files, or
+against another snapshot with --to. Snapshots are named by ID, as listed by 'd3 feature snapshots',
+or by any prefix matching a single ID.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmdRunner.featureName = args[0]
+
+			projectRoot, err := os.Getwd()
+			if err != nil {
+				return fmt.Errorf("could not determine workspace root: %w", err)
+			}
+			cfg, err := NewConfig(projectRoot)
+			if err != nil {
+				return err
+			}
+
+			cmdRunner.projectSvc, _ = wire(cfg, ports.RealFileSystem{})
+
+			return cmdRunner.run(context.Background())
+		},
+	}
+	cmd.Flags().StringVar(&cmdRunner.from, "from", "", "Snapshot to compare from (required)")
+	cmd.Flags().StringVar(&cmdRunner.to, "to", "", "Snapshot to compare to instead of the current files")
+	cmd.MarkFlagRequired("from")
+	return cmd
+}
+
+// run prints the diff, or a note when nothing changed.
+func (c *featureDiffCmdRunner) run(ctx context.Context) error {
+	if c.projectSvc == nil {
+		return fmt.Errorf("project service not initialized in featureDiffCmdRunner")
+	}
+
+	featureName, err := feature.NormalizeName(c.featureName)
+	if err != nil {
+		return err
+	}
+
+	diff, err := c.projectSvc.DiffArtifact(ctx, featureName, c.from, c.to)
+	if err != nil {
+		return err
+	}
+
+	message := diff
+	if diff == "" {
+		message = "No differences."
+	}
+	emit(NewResult(message, featureDiffData{Feature: featureName, From: c.from, To: c.to, Diff: diff}, nil))
+	return nil
+}
+
+// featureDiffData is the JSON data of the feature diff command.
+type featureDiffData struct {
+	Feature string `json:"feature"`
+	From    string `json:"from"`
+	// To is empty when the snapshot was compared with the current files.
+	To   string `json:"to,omitempty"`
+	Diff string `json:"diff"`
+}
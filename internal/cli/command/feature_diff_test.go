@@ -0,0 +1,71 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/imcclaskey/d3/internal/core/d3err"
+	"github.com/imcclaskey/d3/internal/project"
+)
+
+func TestFeatureDiffCmdRunner_run(t *testing.T) {
+	const diff = "--- snapshots/20261018T142233Z-design-to-deliver/design/plan.md\n+++ design/plan.md\n@@ -1 +1 @@\n-old\n+new\n"
+	tests := []struct {
+		name               string
+		cmd                featureDiffCmdRunner
+		diff               string
+		diffErr            error
+		wantTo             string
+		wantErr            bool
+		wantOutputContains string
+	}{
+		{
+			name:               "prints the diff",
+			cmd:                featureDiffCmdRunner{featureName: "Login", from: "20261018"},
+			diff:               diff,
+			wantOutputContains: diff,
+		},
+		{
+			name:               "between snapshots without changes",
+			cmd:                featureDiffCmdRunner{featureName: "login", from: "20261018", to: "20261019"},
+			wantTo:             "20261019",
+			wantOutputContains: "No differences.",
+		},
+		{
+			name:    "unknown snapshot",
+			cmd:     featureDiffCmdRunner{featureName: "login", from: "20261018"},
+			diffErr: d3err.New(d3err.InvalidArgument, "snapshot \"20261018\" not found"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockProjectSvc := project.NewMockProjectService(ctrl)
+			mockProjectSvc.EXPECT().DiffArtifact(gomock.Any(), "login", "20261018", tt.wantTo).Return(tt.diff, tt.diffErr)
+
+			cmdInstance := tt.cmd
+			cmdInstance.projectSvc = mockProjectSvc
+
+			rPipe, wPipe, restoreStdout := captureStdout(t)
+			err := cmdInstance.run(context.Background())
+			wPipe.Close()
+			restoreStdout()
+			stdoutBuf := new(bytes.Buffer)
+			stdoutBuf.ReadFrom(rPipe)
+			rPipe.Close()
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("featureDiffCmdRunner.run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !strings.Contains(stdoutBuf.String(), tt.wantOutputContains) {
+				t.Errorf("featureDiffCmdRunner.run() output = %q, want to contain %q", stdoutBuf.String(), tt.wantOutputContains)
+			}
+		})
+	}
+}
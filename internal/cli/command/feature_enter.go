@@ -1,17 +1,18 @@
 package command
 
 import (
+	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/imcclaskey/d3/internal/core/feature"
-	"github.com/imcclaskey/d3/internal/core/phase"
+	"github.com/imcclaskey/d3/internal/core/git"
 	"github.com/imcclaskey/d3/internal/core/ports"
-	"github.com/imcclaskey/d3/internal/core/projectfiles"
-	"github.com/imcclaskey/d3/internal/core/rules"
 	"github.com/imcclaskey/d3/internal/project"
 )
 
@@ -19,6 +20,8 @@ import (
 type FeatureEnterCommand struct {
 	featureName string
 	projectSvc  project.ProjectService
+	featureSvc  feature.FeatureServicer
+	gitClient   git.Client
 }
 
 // NewFeatureEnterCommand creates a new cobra command for entering features.
@@ -35,16 +38,16 @@ func NewFeatureEnterCommand() *cobra.Command {
 			if err != nil {
 				return fmt.Errorf("could not determine workspace root: %w", err)
 			}
-			cfg := NewConfig(projectRoot)
+			cfg, err := NewConfig(projectRoot)
+			if err != nil {
+				return err
+			}
 
 			fs := ports.RealFileSystem{}
-			featureSvc := feature.NewService(cfg.WorkspaceRoot, cfg.FeaturesDir, cfg.D3Dir, fs)
-			phaseSvc := phase.NewService(fs)
-			ruleGenerator := rules.NewRuleGenerator(cfg.WorkspaceRoot, fs)
-			rulesSvc := rules.NewService(cfg.WorkspaceRoot, cfg.CursorRulesDir, ruleGenerator, fs)
-			fileOp := projectfiles.NewDefaultFileOperator()
-
-			cmdRunner.projectSvc = project.New(cfg.WorkspaceRoot, fs, featureSvc, rulesSvc, phaseSvc, fileOp)
+			proj, services := wire(cfg, fs)
+			cmdRunner.projectSvc = proj
+			cmdRunner.featureSvc = services.Features
+			cmdRunner.gitClient = git.New(cfg.WorkspaceRoot)
 
 			return cmdRunner.run(context.Background())
 		},
@@ -58,12 +61,71 @@ func (c *FeatureEnterCommand) run(ctx context.Context) error {
 		return fmt.Errorf("project service not initialized in FeatureEnterCommand")
 	}
 
-	result, err := c.projectSvc.EnterFeature(ctx, c.featureName)
+	featureName, err := feature.NormalizeName(c.featureName)
+	if err != nil {
+		return err
+	}
+
+	result, err := c.projectSvc.EnterFeature(ctx, featureName)
 	if err != nil {
 		return err
 	}
 
-	fmt.Println(result.FormatCLI())
+	out := projectResult(result)
+
+	branch, err := c.branchToOffer(ctx, featureName)
+	if err != nil || branch == "" {
+		emit(out)
+		return err
+	}
+	if JSONOutput() {
+		// JSON output is for scripts, which cannot answer a prompt
+		out.Warnings = append(out.Warnings, fmt.Sprintf("feature '%s' is associated with branch '%s', which is not checked out", featureName, branch))
+		emit(out)
+		return nil
+	}
+	emit(out)
+	return c.offerBranchSwitch(ctx, featureName, branch)
+}
+
+// branchToOffer returns the feature's recorded branch when a different one is checked out.
+// Features without a recorded branch, or projects outside git, yield an empty string.
+func (c *FeatureEnterCommand) branchToOffer(ctx context.Context, featureName string) (string, error) {
+	if c.featureSvc == nil || c.gitClient == nil {
+		return "", nil
+	}
+	branch, err := c.featureSvc.GetFeatureBranch(featureName)
+	if err != nil || branch == "" {
+		return "", err
+	}
+	if !c.gitClient.IsRepository(ctx) {
+		return "", nil
+	}
+
+	current, err := c.gitClient.CurrentBranch(ctx)
+	if err != nil && !errors.Is(err, git.ErrNoBranch) {
+		return "", err
+	}
+	if current == branch {
+		return "", nil
+	}
+	return branch, nil
+}
+
+// offerBranchSwitch asks to check out the feature's recorded branch.
+func (c *FeatureEnterCommand) offerBranchSwitch(ctx context.Context, featureName, branch string) error {
+	reader := bufio.NewReader(os.Stdin)
+	fmt.Printf("Feature '%s' is associated with branch '%s'. Switch to it? [y/N]: ", featureName, branch)
+	input, _ := reader.ReadString('\n')
+	input = strings.TrimSpace(strings.ToLower(input))
+	if input != "y" && input != "yes" {
+		fmt.Printf("Staying on the current branch.\n")
+		return nil
+	}
 
+	if err := c.gitClient.SwitchBranch(ctx, branch); err != nil {
+		return err
+	}
+	fmt.Printf("Switched to branch '%s'.\n", branch)
 	return nil
 }
@@ -11,6 +11,8 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/spf13/cobra"
 
+	featuremocks "github.com/imcclaskey/d3/internal/core/feature/mocks"
+	gitmocks "github.com/imcclaskey/d3/internal/core/git/mocks"
 	"github.com/imcclaskey/d3/internal/project"
 )
 
@@ -115,3 +117,89 @@ func TestFeatureEnterCommand_RunLogic(t *testing.T) {
 		})
 	}
 }
+
+func TestFeatureEnterCommand_OfferBranchSwitch(t *testing.T) {
+	featureName := "login"
+
+	tests := []struct {
+		name               string
+		userInput          string
+		setupMocks         func(featureSvc *featuremocks.MockFeatureServicer, gitClient *gitmocks.MockClient)
+		wantOutputContains string
+	}{
+		{
+			name:      "switches when confirmed",
+			userInput: "y",
+			setupMocks: func(featureSvc *featuremocks.MockFeatureServicer, gitClient *gitmocks.MockClient) {
+				featureSvc.EXPECT().GetFeatureBranch(featureName).Return("feature/login", nil).Times(1)
+				gitClient.EXPECT().IsRepository(gomock.Any()).Return(true).Times(1)
+				gitClient.EXPECT().CurrentBranch(gomock.Any()).Return("main", nil).Times(1)
+				gitClient.EXPECT().SwitchBranch(gomock.Any(), "feature/login").Return(nil).Times(1)
+			},
+			wantOutputContains: "Switched to branch 'feature/login'.",
+		},
+		{
+			name:      "stays when declined",
+			userInput: "n",
+			setupMocks: func(featureSvc *featuremocks.MockFeatureServicer, gitClient *gitmocks.MockClient) {
+				featureSvc.EXPECT().GetFeatureBranch(featureName).Return("feature/login", nil).Times(1)
+				gitClient.EXPECT().IsRepository(gomock.Any()).Return(true).Times(1)
+				gitClient.EXPECT().CurrentBranch(gomock.Any()).Return("main", nil).Times(1)
+			},
+			wantOutputContains: "Staying on the current branch.",
+		},
+		{
+			name: "already on the feature branch",
+			setupMocks: func(featureSvc *featuremocks.MockFeatureServicer, gitClient *gitmocks.MockClient) {
+				featureSvc.EXPECT().GetFeatureBranch(featureName).Return("feature/login", nil).Times(1)
+				gitClient.EXPECT().IsRepository(gomock.Any()).Return(true).Times(1)
+				gitClient.EXPECT().CurrentBranch(gomock.Any()).Return("feature/login", nil).Times(1)
+			},
+			wantOutputContains: "Entered feature 'login'",
+		},
+		{
+			name: "no recorded branch",
+			setupMocks: func(featureSvc *featuremocks.MockFeatureServicer, gitClient *gitmocks.MockClient) {
+				featureSvc.EXPECT().GetFeatureBranch(featureName).Return("", nil).Times(1)
+			},
+			wantOutputContains: "Entered feature 'login'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockProjectSvc := project.NewMockProjectService(ctrl)
+			mockFeatureSvc := featuremocks.NewMockFeatureServicer(ctrl)
+			mockGit := gitmocks.NewMockClient(ctrl)
+			mockProjectSvc.EXPECT().EnterFeature(gomock.Any(), featureName).
+				Return(project.NewResult("Entered feature 'login' in phase 'define'."), nil).Times(1)
+			tt.setupMocks(mockFeatureSvc, mockGit)
+
+			restoreStdin := mockStdin(t, tt.userInput)
+			defer restoreStdin()
+			rPipe, wPipe, restoreStdout := captureStdout(t)
+
+			cmdInstance := &FeatureEnterCommand{
+				featureName: featureName,
+				projectSvc:  mockProjectSvc,
+				featureSvc:  mockFeatureSvc,
+				gitClient:   mockGit,
+			}
+			err := cmdInstance.run(context.Background())
+
+			wPipe.Close()
+			restoreStdout()
+			stdoutBuf := new(bytes.Buffer)
+			stdoutBuf.ReadFrom(rPipe)
+			rPipe.Close()
+
+			if err != nil {
+				t.Fatalf("FeatureEnterCommand.run() unexpected error: %v", err)
+			}
+			if !strings.Contains(stdoutBuf.String(), tt.wantOutputContains) {
+				t.Errorf("FeatureEnterCommand.run() output = %q, want to contain %q", stdoutBuf.String(), tt.wantOutputContains)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imcclaskey/d3/internal/core/export"
+	"github.com/imcclaskey/d3/internal/core/feature"
+	"github.com/imcclaskey/d3/internal/core/ports"
+	"github.com/imcclaskey/d3/internal/project"
+)
+
+// FeatureExportCommand holds dependencies and options for the feature export command.
+type FeatureExportCommand struct {
+	featureName string
+	format      string
+	preset      string
+	outputPath  string
+	projectSvc  project.ProjectService
+	fs          ports.FileSystem
+}
+
+// NewFeatureExportCommand creates a new cobra command for exporting a feature as a single report.
+func NewFeatureExportCommand() *cobra.Command {
+	cmdRunner := &FeatureExportCommand{}
+	cmd := &cobra.Command{
+		Use:   "export [name]",
+		Short: "Export a feature's problem, plan and tasks as one document",
+		Long: `Combine problem.md, plan.md and the progress.yaml task list into a single markdown, HTML or
+JSON document. Without a name, the active feature is exported. The 'pr' preset produces a condensed
+document sized for a pull-request description.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 1 {
+				cmdRunner.featureName = args[0]
+			}
+
+			projectRoot, err := os.Getwd()
+			if err != nil {
+				return fmt.Errorf("could not determine workspace root: %w", err)
+			}
+			cfg, err := NewConfig(projectRoot)
+			if err != nil {
+				return err
+			}
+
+			fs := ports.RealFileSystem{}
+			cmdRunner.projectSvc, _ = wire(cfg, fs)
+			cmdRunner.fs = fs
+
+			return cmdRunner.run(context.Background())
+		},
+	}
+	cmd.Flags().StringVarP(&cmdRunner.format, "format", "f", string(export.FormatMarkdown), "Output format: markdown, html or json")
+	cmd.Flags().StringVar(&cmdRunner.preset, "preset", string(export.PresetFull), "Content preset: full or pr")
+	cmd.Flags().StringVarP(&cmdRunner.outputPath, "out", "o", "", "Write the export to this file instead of stdout")
+	return cmd
+}
+
+// run renders the export and prints it or writes it to the output file.
+func (c *FeatureExportCommand) run(ctx context.Context) error {
+	if c.projectSvc == nil {
+		return fmt.Errorf("project service not initialized in FeatureExportCommand")
+	}
+
+	format, err := export.ParseFormat(c.format)
+	if err != nil {
+		return err
+	}
+	preset, err := export.ParsePreset(c.preset)
+	if err != nil {
+		return err
+	}
+
+	featureName := c.featureName
+	if featureName != "" {
+		if featureName, err = feature.NormalizeName(featureName); err != nil {
+			return err
+		}
+	}
+
+	document, err := c.projectSvc.ExportFeature(ctx, featureName, format, preset)
+	if err != nil {
+		return err
+	}
+
+	data := featureExportData{Feature: featureName, Format: string(format), Preset: string(preset)}
+	if c.outputPath == "" {
+		data.Content = document
+		emit(NewResult(document, data, nil))
+		return nil
+	}
+	if c.fs == nil {
+		return fmt.Errorf("file system not initialized in FeatureExportCommand")
+	}
+	if err := c.fs.WriteFile(c.outputPath, []byte(document), 0644); err != nil {
+		return fmt.Errorf("failed to write export to %s: %w", c.outputPath, err)
+	}
+	data.Path = c.outputPath
+	emit(NewResult(fmt.Sprintf("Exported to %s", c.outputPath), data, nil))
+	return nil
+}
+
+// featureExportData is the JSON data of the feature export command.
+type featureExportData struct {
+	// Feature is the exported feature, empty when the active feature was exported.
+	Feature string `json:"feature,omitempty"`
+	Format  string `json:"format"`
+	Preset  string `json:"preset"`
+	// Path is the file written with --out. Content holds the document otherwise.
+	Path    string `json:"path,omitempty"`
+	Content string `json:"content,omitempty"`
+}
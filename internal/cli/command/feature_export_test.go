@@ -0,0 +1,102 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/imcclaskey/d3/internal/core/d3err"
+	"github.com/imcclaskey/d3/internal/core/export"
+	portsmocks "github.com/imcclaskey/d3/internal/core/ports/mocks"
+	"github.com/imcclaskey/d3/internal/project"
+)
+
+func TestFeatureExportCommand_run(t *testing.T) {
+	tests := []struct {
+		name               string
+		cmd                FeatureExportCommand
+		setupMocks         func(projectSvc *project.MockProjectService, mockFS *portsmocks.MockFileSystem)
+		wantErr            bool
+		wantExit           int
+		wantOutputContains string
+	}{
+		{
+			name: "prints active feature export",
+			cmd:  FeatureExportCommand{format: "markdown", preset: "full"},
+			setupMocks: func(projectSvc *project.MockProjectService, mockFS *portsmocks.MockFileSystem) {
+				projectSvc.EXPECT().ExportFeature(gomock.Any(), "", export.FormatMarkdown, export.PresetFull).Return("# Feature: login\n", nil).Times(1)
+			},
+			wantOutputContains: "# Feature: login",
+		},
+		{
+			name: "normalizes name and writes to file",
+			cmd:  FeatureExportCommand{featureName: "Login", format: "html", preset: "pr", outputPath: "login.html"},
+			setupMocks: func(projectSvc *project.MockProjectService, mockFS *portsmocks.MockFileSystem) {
+				projectSvc.EXPECT().ExportFeature(gomock.Any(), "login", export.FormatHTML, export.PresetPR).Return("<html></html>", nil).Times(1)
+				mockFS.EXPECT().WriteFile("login.html", []byte("<html></html>"), os.FileMode(0644)).Return(nil).Times(1)
+			},
+			wantOutputContains: "Exported to login.html",
+		},
+		{
+			name:       "invalid preset",
+			cmd:        FeatureExportCommand{format: "markdown", preset: "tiny"},
+			setupMocks: func(projectSvc *project.MockProjectService, mockFS *portsmocks.MockFileSystem) {},
+			wantErr:    true,
+		},
+		{
+			name: "export fails",
+			cmd:  FeatureExportCommand{featureName: "login", format: "json", preset: "full"},
+			setupMocks: func(projectSvc *project.MockProjectService, mockFS *portsmocks.MockFileSystem) {
+				projectSvc.EXPECT().ExportFeature(gomock.Any(), "login", export.FormatJSON, export.PresetFull).Return("", fmt.Errorf("permission denied")).Times(1)
+			},
+			wantErr:  true,
+			wantExit: ExitError,
+		},
+		{
+			name: "missing feature",
+			cmd:  FeatureExportCommand{featureName: "login", format: "json", preset: "full"},
+			setupMocks: func(projectSvc *project.MockProjectService, mockFS *portsmocks.MockFileSystem) {
+				err := fmt.Errorf("failed to export feature 'login': %w", d3err.New(d3err.FeatureNotFound, "feature 'login' does not exist"))
+				projectSvc.EXPECT().ExportFeature(gomock.Any(), "login", export.FormatJSON, export.PresetFull).Return("", err).Times(1)
+			},
+			wantErr:  true,
+			wantExit: ExitFeatureNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockProjectSvc := project.NewMockProjectService(ctrl)
+			mockFS := portsmocks.NewMockFileSystem(ctrl)
+			tt.setupMocks(mockProjectSvc, mockFS)
+
+			cmdInstance := tt.cmd
+			cmdInstance.projectSvc = mockProjectSvc
+			cmdInstance.fs = mockFS
+
+			rPipe, wPipe, restoreStdout := captureStdout(t)
+			err := cmdInstance.run(context.Background())
+			wPipe.Close()
+			restoreStdout()
+			stdoutBuf := new(bytes.Buffer)
+			stdoutBuf.ReadFrom(rPipe)
+			rPipe.Close()
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FeatureExportCommand.run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantExit != 0 && ExitCode(err) != tt.wantExit {
+				t.Errorf("ExitCode(FeatureExportCommand.run()) = %d, want %d", ExitCode(err), tt.wantExit)
+			}
+			if !tt.wantErr && !strings.Contains(stdoutBuf.String(), tt.wantOutputContains) {
+				t.Errorf("FeatureExportCommand.run() output = %q, want to contain %q", stdoutBuf.String(), tt.wantOutputContains)
+			}
+		})
+	}
+}
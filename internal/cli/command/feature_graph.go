@@ -0,0 +1,83 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imcclaskey/d3/internal/core/d3err"
+	"github.com/imcclaskey/d3/internal/core/depgraph"
+	"github.com/imcclaskey/d3/internal/core/ports"
+	"github.com/imcclaskey/d3/internal/project"
+)
+
+// featureGraphCmdRunner holds dependencies and options for the feature graph command.
+type featureGraphCmdRunner struct {
+	format     string
+	projectSvc project.ProjectService
+}
+
+// NewFeatureGraphCommand creates a new cobra command rendering the feature dependency graph.
+func NewFeatureGraphCommand() *cobra.Command {
+	cmdRunner := &featureGraphCmdRunner{}
+	cmd := &cobra.Command{
+		Use:   "graph",
+		Short: "Show how features depend on one another",
+		Long: `Render the dependencies between features as an ASCII tree, with one root per feature nothing
+depends on, or in the Graphviz DOT language with --format dot, for example:
+
+  d3 feature graph --format dot | dot -Tsvg > features.svg`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projectRoot, err := os.Getwd()
+			if err != nil {
+				return fmt.Errorf("could not determine workspace root: %w", err)
+			}
+			cfg, err := NewConfig(projectRoot)
+			if err != nil {
+				return err
+			}
+
+			cmdRunner.projectSvc, _ = wire(cfg, ports.RealFileSystem{})
+
+			return cmdRunner.run(context.Background())
+		},
+	}
+	cmd.Flags().StringVarP(&cmdRunner.format, "format", "f", "tree", "Output format: tree or dot")
+	return cmd
+}
+
+// run renders the graph in the requested format.
+func (c *featureGraphCmdRunner) run(ctx context.Context) error {
+	if c.projectSvc == nil {
+		return fmt.Errorf("project service not initialized in featureGraphCmdRunner")
+	}
+	if c.format != "tree" && c.format != "dot" {
+		return d3err.New(d3err.InvalidArgument, "unknown graph format %q: expected tree or dot", c.format)
+	}
+
+	graph, err := c.projectSvc.FeatureGraph(ctx)
+	if err != nil {
+		return err
+	}
+
+	rendered := graph.DOT()
+	if c.format == "tree" {
+		rendered = graph.Tree()
+		if len(graph.Nodes) == 0 {
+			rendered = "No features."
+		}
+	}
+	emit(NewResult(rendered, featureGraphData{Format: c.format, Graph: rendered, Nodes: graph.Nodes}, cycleWarnings(graph)))
+	return nil
+}
+
+// featureGraphData is the JSON data of the feature graph command.
+type featureGraphData struct {
+	Format string `json:"format"`
+	// Graph is the rendered tree or DOT source.
+	Graph string          `json:"graph"`
+	Nodes []depgraph.Node `json:"nodes"`
+}
@@ -0,0 +1,60 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/imcclaskey/d3/internal/core/depgraph"
+	"github.com/imcclaskey/d3/internal/core/phase"
+	"github.com/imcclaskey/d3/internal/project"
+)
+
+func TestFeatureGraphCmdRunner_run(t *testing.T) {
+	graph := depgraph.New([]depgraph.Node{
+		{Name: "api", Phase: phase.Deliver, Complete: true},
+		{Name: "ui", Phase: phase.Design, DependsOn: []string{"api"}},
+	})
+
+	tests := []struct {
+		name               string
+		format             string
+		wantGraph          bool
+		wantErr            bool
+		wantOutputContains string
+	}{
+		{name: "tree", format: "tree", wantGraph: true, wantOutputContains: "ui [design]\n└── api [deliver, complete]\n"},
+		{name: "dot", format: "dot", wantGraph: true, wantOutputContains: "\"ui\" -> \"api\";\n"},
+		{name: "unknown format", format: "svg", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockProjectSvc := project.NewMockProjectService(ctrl)
+			if tt.wantGraph {
+				mockProjectSvc.EXPECT().FeatureGraph(gomock.Any()).Return(graph, nil)
+			}
+
+			cmdInstance := featureGraphCmdRunner{format: tt.format, projectSvc: mockProjectSvc}
+
+			rPipe, wPipe, restoreStdout := captureStdout(t)
+			err := cmdInstance.run(context.Background())
+			wPipe.Close()
+			restoreStdout()
+			stdoutBuf := new(bytes.Buffer)
+			stdoutBuf.ReadFrom(rPipe)
+			rPipe.Close()
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("featureGraphCmdRunner.run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !strings.Contains(stdoutBuf.String(), tt.wantOutputContains) {
+				t.Errorf("featureGraphCmdRunner.run() output = %q, want to contain %q", stdoutBuf.String(), tt.wantOutputContains)
+			}
+		})
+	}
+}
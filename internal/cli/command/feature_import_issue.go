@@ -0,0 +1,128 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imcclaskey/d3/internal/core/feature"
+	"github.com/imcclaskey/d3/internal/core/issue"
+	"github.com/imcclaskey/d3/internal/core/phase"
+	"github.com/imcclaskey/d3/internal/core/ports"
+	"github.com/imcclaskey/d3/internal/project"
+)
+
+// FeatureImportIssueCommand holds dependencies and options for the feature import-issue command.
+type FeatureImportIssueCommand struct {
+	issuePath   string
+	featureName string
+	format      string
+	projectSvc  project.ProjectService
+	featureSvc  feature.FeatureServicer
+	fs          ports.FileSystem
+	importers   *issue.Registry
+}
+
+// NewFeatureImportIssueCommand creates a new cobra command for creating a feature from an exported issue.
+func NewFeatureImportIssueCommand() *cobra.Command {
+	cmdRunner := &FeatureImportIssueCommand{}
+	cmd := &cobra.Command{
+		Use:   "import-issue <file>",
+		Short: "Create a feature from an exported issue",
+		Long: `Create a feature from an issue exported to a local file and pre-fill define/problem.md with its
+content. Supported formats are GitHub issue JSON (from the REST API or 'gh issue view --json') and
+markdown with YAML frontmatter. The feature name is derived from the issue title unless --name is given.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmdRunner.issuePath = args[0]
+
+			projectRoot, err := os.Getwd()
+			if err != nil {
+				return fmt.Errorf("could not determine workspace root: %w", err)
+			}
+			cfg, err := NewConfig(projectRoot)
+			if err != nil {
+				return err
+			}
+
+			fs := ports.RealFileSystem{}
+			proj, services := wire(cfg, fs)
+			cmdRunner.projectSvc = proj
+			cmdRunner.featureSvc = services.Features
+			cmdRunner.fs = fs
+			cmdRunner.importers = issue.DefaultRegistry()
+
+			return cmdRunner.run(context.Background())
+		},
+	}
+	cmd.Flags().StringVar(&cmdRunner.featureName, "name", "", "Feature name to use instead of one derived from the issue title")
+	cmd.Flags().StringVar(&cmdRunner.format, "format", "", "Issue format: "+strings.Join(issue.DefaultRegistry().Names(), ", ")+" (detected when omitted)")
+	return cmd
+}
+
+// run parses the issue, creates the feature and writes its problem statement.
+func (c *FeatureImportIssueCommand) run(ctx context.Context) error {
+	if c.projectSvc == nil || c.featureSvc == nil || c.fs == nil || c.importers == nil {
+		return fmt.Errorf("services not initialized in FeatureImportIssueCommand")
+	}
+
+	data, err := c.fs.ReadFile(c.issuePath)
+	if err != nil {
+		return fmt.Errorf("failed to read issue file %s: %w", c.issuePath, err)
+	}
+	imported, err := c.importers.Parse(filepath.Base(c.issuePath), data, c.format)
+	if err != nil {
+		return err
+	}
+
+	var featureName string
+	if c.featureName != "" {
+		featureName, err = feature.NormalizeName(c.featureName)
+	} else {
+		featureName, err = feature.SlugifyName(imported.Title)
+	}
+	if err != nil {
+		return err
+	}
+
+	result, err := c.projectSvc.CreateFeature(ctx, featureName)
+	if err != nil {
+		return err
+	}
+
+	problemDir := filepath.Join(c.featureSvc.GetFeaturePath(featureName), string(phase.Define))
+	if err := c.fs.MkdirAll(problemDir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", problemDir, err)
+	}
+	problemPath := filepath.Join(problemDir, phase.PhaseFileMap[phase.Define])
+	if err := c.fs.WriteFile(problemPath, []byte(issue.ProblemMarkdown(imported)), 0644); err != nil {
+		return fmt.Errorf("feature '%s' was created but writing %s failed: %w", featureName, problemPath, err)
+	}
+
+	r := projectResult(result.WithFiles(problemPath))
+	r.Message = fmt.Sprintf("%s\nImported issue %q into %s", r.Message, imported.Title, problemPath)
+	r.Data = featureImportIssueData{
+		FeatureData: r.Data.(FeatureData),
+		Title:       imported.Title,
+		Source:      imported.Source,
+		Number:      imported.Number,
+		URL:         imported.URL,
+	}
+	emit(r)
+	return nil
+}
+
+// featureImportIssueData is the JSON data of the feature import-issue command.
+type featureImportIssueData struct {
+	FeatureData
+	// Title is the imported issue's title.
+	Title string `json:"title"`
+	// Source names the importer that parsed the file.
+	Source string `json:"source"`
+	Number string `json:"number,omitempty"`
+	URL    string `json:"url,omitempty"`
+}
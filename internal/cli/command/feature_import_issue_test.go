@@ -0,0 +1,127 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	featuremocks "github.com/imcclaskey/d3/internal/core/feature/mocks"
+	"github.com/imcclaskey/d3/internal/core/issue"
+	"github.com/imcclaskey/d3/internal/project"
+	"github.com/imcclaskey/d3/internal/testutil"
+)
+
+func TestFeatureImportIssueCommand_run(t *testing.T) {
+	const githubIssue = `{"number": 42, "title": "Login fails for SSO users!", "body": "Blank page.", "labels": [{"name": "bug"}]}`
+	const problemPath = "/project/.d3/features/%s/define/problem.md"
+
+	tests := []struct {
+		name        string
+		cmd         FeatureImportIssueCommand
+		files       map[string]string
+		setupMocks  func(projectSvc *project.MockProjectService, featureSvc *featuremocks.MockFeatureServicer)
+		wantErr     string
+		wantFeature string
+		wantProblem []string
+	}{
+		{
+			name:  "github issue with derived name",
+			cmd:   FeatureImportIssueCommand{issuePath: "/tmp/issue.json"},
+			files: map[string]string{"/tmp/issue.json": githubIssue},
+			setupMocks: func(projectSvc *project.MockProjectService, featureSvc *featuremocks.MockFeatureServicer) {
+				projectSvc.EXPECT().CreateFeature(gomock.Any(), "login-fails-for-sso-users").Return(project.NewResult("created"), nil).Times(1)
+				featureSvc.EXPECT().GetFeaturePath("login-fails-for-sso-users").Return("/project/.d3/features/login-fails-for-sso-users").Times(1)
+			},
+			wantFeature: "login-fails-for-sso-users",
+			wantProblem: []string{"# Problem: Login fails for SSO users!", "## Problem Statement\n\nBlank page.", "- **Labels:** bug"},
+		},
+		{
+			name:  "markdown issue with explicit name",
+			cmd:   FeatureImportIssueCommand{issuePath: "/tmp/issue.md", featureName: "SSO Login"},
+			files: map[string]string{"/tmp/issue.md": "---\ntitle: SSO broken\n---\nDetails\n"},
+			setupMocks: func(projectSvc *project.MockProjectService, featureSvc *featuremocks.MockFeatureServicer) {
+				projectSvc.EXPECT().CreateFeature(gomock.Any(), "sso-login").Return(project.NewResult("created"), nil).Times(1)
+				featureSvc.EXPECT().GetFeaturePath("sso-login").Return("/project/.d3/features/sso-login").Times(1)
+			},
+			wantFeature: "sso-login",
+			wantProblem: []string{"# Problem: SSO broken", "Details", "- **Imported from:** markdown"},
+		},
+		{
+			name:       "missing file",
+			cmd:        FeatureImportIssueCommand{issuePath: "/tmp/missing.json"},
+			setupMocks: func(projectSvc *project.MockProjectService, featureSvc *featuremocks.MockFeatureServicer) {},
+			wantErr:    "failed to read issue file",
+		},
+		{
+			name:       "unknown format",
+			cmd:        FeatureImportIssueCommand{issuePath: "/tmp/issue.json", format: "jira"},
+			files:      map[string]string{"/tmp/issue.json": githubIssue},
+			setupMocks: func(projectSvc *project.MockProjectService, featureSvc *featuremocks.MockFeatureServicer) {},
+			wantErr:    "unknown issue format",
+		},
+		{
+			name:  "feature already exists",
+			cmd:   FeatureImportIssueCommand{issuePath: "/tmp/issue.json"},
+			files: map[string]string{"/tmp/issue.json": githubIssue},
+			setupMocks: func(projectSvc *project.MockProjectService, featureSvc *featuremocks.MockFeatureServicer) {
+				projectSvc.EXPECT().CreateFeature(gomock.Any(), "login-fails-for-sso-users").Return(nil, fmt.Errorf("feature login-fails-for-sso-users already exists")).Times(1)
+			},
+			wantErr: "already exists",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockProjectSvc := project.NewMockProjectService(ctrl)
+			mockFeatureSvc := featuremocks.NewMockFeatureServicer(ctrl)
+			tt.setupMocks(mockProjectSvc, mockFeatureSvc)
+
+			memFS := testutil.NewMemFS()
+			for path, content := range tt.files {
+				memFS.AddFile(path, content)
+			}
+
+			cmdInstance := tt.cmd
+			cmdInstance.projectSvc = mockProjectSvc
+			cmdInstance.featureSvc = mockFeatureSvc
+			cmdInstance.fs = memFS
+			cmdInstance.importers = issue.DefaultRegistry()
+
+			rPipe, wPipe, restoreStdout := captureStdout(t)
+			err := cmdInstance.run(context.Background())
+			wPipe.Close()
+			restoreStdout()
+			stdoutBuf := new(bytes.Buffer)
+			stdoutBuf.ReadFrom(rPipe)
+			rPipe.Close()
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("FeatureImportIssueCommand.run() error = %v, want to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FeatureImportIssueCommand.run() unexpected error: %v", err)
+			}
+
+			problem, err := memFS.ReadFile(fmt.Sprintf(problemPath, tt.wantFeature))
+			if err != nil {
+				t.Fatalf("problem.md not written: %v", err)
+			}
+			for _, want := range tt.wantProblem {
+				if !strings.Contains(string(problem), want) {
+					t.Errorf("problem.md missing %q:\n%s", want, problem)
+				}
+			}
+			if !strings.Contains(stdoutBuf.String(), "Imported issue") {
+				t.Errorf("FeatureImportIssueCommand.run() output = %q", stdoutBuf.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imcclaskey/d3/internal/core/depgraph"
+	"github.com/imcclaskey/d3/internal/core/ports"
+	"github.com/imcclaskey/d3/internal/project"
+)
+
+// featureListCmdRunner holds dependencies for the feature list command.
+type featureListCmdRunner struct {
+	projectSvc project.ProjectService
+}
+
+// NewFeatureListCommand creates a new cobra command listing every feature with its dependencies.
+func NewFeatureListCommand() *cobra.Command {
+	cmdRunner := &featureListCmdRunner{}
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List features with their phase and dependencies",
+		Long: `List every feature with its phase, the features it depends on, and the dependencies that still
+keep it out of deliver.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projectRoot, err := os.Getwd()
+			if err != nil {
+				return fmt.Errorf("could not determine workspace root: %w", err)
+			}
+			cfg, err := NewConfig(projectRoot)
+			if err != nil {
+				return err
+			}
+
+			cmdRunner.projectSvc, _ = wire(cfg, ports.RealFileSystem{})
+
+			return cmdRunner.run(context.Background())
+		},
+	}
+}
+
+// run prints one row per feature, ordered by name.
+func (c *featureListCmdRunner) run(ctx context.Context) error {
+	if c.projectSvc == nil {
+		return fmt.Errorf("project service not initialized in featureListCmdRunner")
+	}
+
+	graph, err := c.projectSvc.FeatureGraph(ctx)
+	if err != nil {
+		return err
+	}
+
+	data := featureListData{Features: []featureListEntry{}}
+	var message strings.Builder
+	if len(graph.Nodes) == 0 {
+		message.WriteString("No features.")
+	} else {
+		tw := tabwriter.NewWriter(&message, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "FEATURE\tPHASE\tDEPENDS ON\tBLOCKED BY")
+		for _, node := range graph.Nodes {
+			blocking := graph.Blocking(node.Name)
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", node.Name, graph.Label(node.Name), listOrDash(node.DependsOn), listOrDash(blocking))
+			data.Features = append(data.Features, featureListEntry{Node: node, BlockedBy: append([]string{}, blocking...)})
+		}
+		if err := tw.Flush(); err != nil {
+			return fmt.Errorf("failed to render feature list: %w", err)
+		}
+	}
+	emit(NewResult(message.String(), data, cycleWarnings(graph)))
+	return nil
+}
+
+// featureListData is the JSON data of the feature list command.
+type featureListData struct {
+	Features []featureListEntry `json:"features"`
+}
+
+// featureListEntry is one feature of the list.
+type featureListEntry struct {
+	depgraph.Node
+	// BlockedBy lists the dependencies that are not complete yet, or no longer exist.
+	BlockedBy []string `json:"blocked_by"`
+}
+
+// listOrDash joins names with commas, or renders "-" when there are none.
+func listOrDash(names []string) string {
+	if len(names) == 0 {
+		return "-"
+	}
+	return strings.Join(names, ", ")
+}
+
+// cycleWarnings warns about a dependency cycle, which only hand edits of .depends_on can create.
+func cycleWarnings(graph *depgraph.Graph) []string {
+	cycle := graph.Cycle()
+	if cycle == nil {
+		return nil
+	}
+	return []string{fmt.Sprintf("dependency cycle %s: none of these features can enter deliver", strings.Join(cycle, " -> "))}
+}
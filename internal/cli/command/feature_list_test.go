@@ -0,0 +1,83 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/imcclaskey/d3/internal/core/depgraph"
+	"github.com/imcclaskey/d3/internal/core/phase"
+	"github.com/imcclaskey/d3/internal/project"
+)
+
+func TestFeatureListCmdRunner_run(t *testing.T) {
+	tests := []struct {
+		name               string
+		graph              *depgraph.Graph
+		graphErr           error
+		wantErr            bool
+		wantOutputContains []string
+	}{
+		{
+			name: "lists features with dependencies",
+			graph: depgraph.New([]depgraph.Node{
+				{Name: "api", Phase: phase.Deliver, Complete: true},
+				{Name: "ui", Phase: phase.Design, DependsOn: []string{"api", "auth"}},
+			}),
+			wantOutputContains: []string{
+				"FEATURE  PHASE              DEPENDS ON  BLOCKED BY",
+				"api      deliver, complete  -           -",
+				"ui       design             api, auth   auth",
+			},
+		},
+		{
+			name: "warns about cycles",
+			graph: depgraph.New([]depgraph.Node{
+				{Name: "a", Phase: phase.Define, DependsOn: []string{"b"}},
+				{Name: "b", Phase: phase.Define, DependsOn: []string{"a"}},
+			}),
+			wantOutputContains: []string{"Warning: dependency cycle a -> b -> a"},
+		},
+		{
+			name:               "no features",
+			graph:              depgraph.New(nil),
+			wantOutputContains: []string{"No features."},
+		},
+		{
+			name:     "graph error",
+			graphErr: errors.New("not initialized"),
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockProjectSvc := project.NewMockProjectService(ctrl)
+			mockProjectSvc.EXPECT().FeatureGraph(gomock.Any()).Return(tt.graph, tt.graphErr)
+
+			cmdInstance := featureListCmdRunner{projectSvc: mockProjectSvc}
+
+			rPipe, wPipe, restoreStdout := captureStdout(t)
+			err := cmdInstance.run(context.Background())
+			wPipe.Close()
+			restoreStdout()
+			stdoutBuf := new(bytes.Buffer)
+			stdoutBuf.ReadFrom(rPipe)
+			rPipe.Close()
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("featureListCmdRunner.run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, want := range tt.wantOutputContains {
+				if !strings.Contains(stdoutBuf.String(), want) {
+					t.Errorf("featureListCmdRunner.run() output = %q, want to contain %q", stdoutBuf.String(), want)
+				}
+			}
+		})
+	}
+}
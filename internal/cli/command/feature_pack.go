@@ -0,0 +1,84 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imcclaskey/d3/internal/core/bundle"
+	"github.com/imcclaskey/d3/internal/core/feature"
+	"github.com/imcclaskey/d3/internal/core/ports"
+)
+
+// FeaturePackCommand holds dependencies and options for the feature pack command.
+type FeaturePackCommand struct {
+	featureName string
+	outputPath  string
+	featureSvc  feature.FeatureServicer
+	fs          ports.FileSystem
+}
+
+// NewFeaturePackCommand creates a new cobra command for bundling a feature into a portable archive.
+func NewFeaturePackCommand() *cobra.Command {
+	cmdRunner := &FeaturePackCommand{}
+	cmd := &cobra.Command{
+		Use:   "pack <name>",
+		Short: "Bundle a feature into a portable archive",
+		Long: `Write a feature directory to a tar.gz bundle together with a manifest recording the d3 version,
+the feature's phase and the source project, plus a checksum of every file. Import the bundle into
+another project with 'd3 feature unpack'.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmdRunner.featureName = args[0]
+
+			projectRoot, err := os.Getwd()
+			if err != nil {
+				return fmt.Errorf("could not determine workspace root: %w", err)
+			}
+			cfg, err := NewConfig(projectRoot)
+			if err != nil {
+				return err
+			}
+
+			fs := ports.RealFileSystem{}
+			cmdRunner.featureSvc = newFeatureService(cfg, fs)
+			cmdRunner.fs = fs
+
+			return cmdRunner.run(context.Background())
+		},
+	}
+	cmd.Flags().StringVarP(&cmdRunner.outputPath, "out", "o", "", "Bundle file to write (default <name>"+bundle.Extension+")")
+	return cmd
+}
+
+// run packs the feature and writes the bundle to disk.
+func (c *FeaturePackCommand) run(ctx context.Context) error {
+	if c.featureSvc == nil || c.fs == nil {
+		return fmt.Errorf("services not initialized in FeaturePackCommand")
+	}
+
+	featureName, err := feature.NormalizeName(c.featureName)
+	if err != nil {
+		return err
+	}
+
+	data, err := c.featureSvc.PackFeature(ctx, featureName)
+	if err != nil {
+		return err
+	}
+
+	outputPath := c.outputPath
+	if outputPath == "" {
+		outputPath = featureName + bundle.Extension
+	}
+	if err := c.fs.WriteFile(outputPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write bundle to %s: %w", outputPath, err)
+	}
+	emit(NewResult(fmt.Sprintf("Packed feature '%s' into %s", featureName, outputPath), FeatureData{
+		Feature:      featureName,
+		FilesTouched: []string{outputPath},
+	}, nil))
+	return nil
+}
@@ -0,0 +1,86 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	featuremocks "github.com/imcclaskey/d3/internal/core/feature/mocks"
+	portsmocks "github.com/imcclaskey/d3/internal/core/ports/mocks"
+)
+
+func TestFeaturePackCommand_run(t *testing.T) {
+	tests := []struct {
+		name               string
+		cmd                FeaturePackCommand
+		setupMocks         func(featureSvc *featuremocks.MockFeatureServicer, mockFS *portsmocks.MockFileSystem)
+		wantErr            bool
+		wantOutputContains string
+	}{
+		{
+			name: "writes default bundle file",
+			cmd:  FeaturePackCommand{featureName: "Login"},
+			setupMocks: func(featureSvc *featuremocks.MockFeatureServicer, mockFS *portsmocks.MockFileSystem) {
+				featureSvc.EXPECT().PackFeature(gomock.Any(), "login").Return([]byte("bundle"), nil).Times(1)
+				mockFS.EXPECT().WriteFile("login.d3.tar.gz", []byte("bundle"), os.FileMode(0644)).Return(nil).Times(1)
+			},
+			wantOutputContains: "Packed feature 'login' into login.d3.tar.gz",
+		},
+		{
+			name: "writes to output path",
+			cmd:  FeaturePackCommand{featureName: "login", outputPath: "/tmp/out.tgz"},
+			setupMocks: func(featureSvc *featuremocks.MockFeatureServicer, mockFS *portsmocks.MockFileSystem) {
+				featureSvc.EXPECT().PackFeature(gomock.Any(), "login").Return([]byte("bundle"), nil).Times(1)
+				mockFS.EXPECT().WriteFile("/tmp/out.tgz", []byte("bundle"), os.FileMode(0644)).Return(nil).Times(1)
+			},
+			wantOutputContains: "/tmp/out.tgz",
+		},
+		{
+			name: "pack fails",
+			cmd:  FeaturePackCommand{featureName: "missing"},
+			setupMocks: func(featureSvc *featuremocks.MockFeatureServicer, mockFS *portsmocks.MockFileSystem) {
+				featureSvc.EXPECT().PackFeature(gomock.Any(), "missing").Return(nil, fmt.Errorf("feature 'missing' does not exist")).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name:       "invalid name",
+			cmd:        FeaturePackCommand{featureName: "../etc"},
+			setupMocks: func(featureSvc *featuremocks.MockFeatureServicer, mockFS *portsmocks.MockFileSystem) {},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockFeatureSvc := featuremocks.NewMockFeatureServicer(ctrl)
+			mockFS := portsmocks.NewMockFileSystem(ctrl)
+			tt.setupMocks(mockFeatureSvc, mockFS)
+
+			cmdInstance := tt.cmd
+			cmdInstance.featureSvc = mockFeatureSvc
+			cmdInstance.fs = mockFS
+
+			rPipe, wPipe, restoreStdout := captureStdout(t)
+			err := cmdInstance.run(context.Background())
+			wPipe.Close()
+			restoreStdout()
+			stdoutBuf := new(bytes.Buffer)
+			stdoutBuf.ReadFrom(rPipe)
+			rPipe.Close()
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FeaturePackCommand.run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !strings.Contains(stdoutBuf.String(), tt.wantOutputContains) {
+				t.Errorf("FeaturePackCommand.run() output = %q, want to contain %q", stdoutBuf.String(), tt.wantOutputContains)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imcclaskey/d3/internal/core/feature"
+	"github.com/imcclaskey/d3/internal/core/ports"
+	"github.com/imcclaskey/d3/internal/project"
+)
+
+// FeatureRestoreCommand holds dependencies for the feature restore command.
+type FeatureRestoreCommand struct {
+	featureName string
+	projectSvc  project.ProjectService
+}
+
+// NewFeatureRestoreCommand creates a new cobra command for restoring deleted features from the trash.
+func NewFeatureRestoreCommand() *cobra.Command {
+	cmdRunner := &FeatureRestoreCommand{}
+	cmd := &cobra.Command{
+		Use:   "restore <name>",
+		Short: "Restore a deleted feature from the trash",
+		Long:  "Restore the most recently deleted copy of a feature from the trash (.d3/.trash).",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmdRunner.featureName = args[0]
+
+			projectRoot, err := os.Getwd()
+			if err != nil {
+				return fmt.Errorf("could not determine workspace root: %w", err)
+			}
+			cfg, err := NewConfig(projectRoot)
+			if err != nil {
+				return err
+			}
+
+			fs := ports.RealFileSystem{}
+			cmdRunner.projectSvc, _ = wire(cfg, fs)
+
+			return cmdRunner.run(context.Background())
+		},
+	}
+	return cmd
+}
+
+// run executes the logic to directly call ProjectService.RestoreFeature.
+func (c *FeatureRestoreCommand) run(ctx context.Context) error {
+	if c.projectSvc == nil {
+		return fmt.Errorf("project service not initialized in FeatureRestoreCommand")
+	}
+
+	featureName, err := feature.NormalizeName(c.featureName)
+	if err != nil {
+		return err
+	}
+
+	result, err := c.projectSvc.RestoreFeature(ctx, featureName)
+	if err != nil {
+		return err
+	}
+
+	emit(projectResult(result))
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/imcclaskey/d3/internal/core/d3err"
+	"github.com/imcclaskey/d3/internal/core/phase"
+	"github.com/imcclaskey/d3/internal/project"
+)
+
+func TestFeatureRestoreCommand_run(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name                string
+		featureNameArg      string
+		format              OutputFormat
+		setupMockProjectSvc func(mockSvc *project.MockProjectService)
+		wantErr             bool
+		wantExit            int
+		wantOutputContains  string
+	}{
+		{
+			name:           "restores the feature",
+			featureNameArg: "login",
+			setupMockProjectSvc: func(mockSvc *project.MockProjectService) {
+				result := project.NewResult("Feature 'login' restored from trash.").WithFeature("login", phase.Design).WithFiles("/p/.d3/features/login")
+				mockSvc.EXPECT().RestoreFeature(gomock.Any(), "login").Return(result, nil).Times(1)
+			},
+			wantOutputContains: "Feature 'login' restored from trash.",
+		},
+		{
+			name:           "name is normalized",
+			featureNameArg: "  Login ",
+			setupMockProjectSvc: func(mockSvc *project.MockProjectService) {
+				result := project.NewResult("Feature 'login' restored from trash.").WithFeature("login", phase.Design)
+				mockSvc.EXPECT().RestoreFeature(gomock.Any(), "login").Return(result, nil).Times(1)
+			},
+			wantOutputContains: "restored from trash",
+		},
+		{
+			name:           "invalid name",
+			featureNameArg: "../escape",
+			wantErr:        true,
+			wantExit:       ExitInvalidName,
+		},
+		{
+			name:           "not in the trash",
+			featureNameArg: "missing",
+			setupMockProjectSvc: func(mockSvc *project.MockProjectService) {
+				mockSvc.EXPECT().RestoreFeature(gomock.Any(), "missing").Return(nil, d3err.New(d3err.FeatureNotFound, "feature 'missing' is not in the trash")).Times(1)
+			},
+			wantErr:  true,
+			wantExit: ExitFeatureNotFound,
+		},
+		{
+			name:           "json output",
+			featureNameArg: "login",
+			format:         OutputJSON,
+			setupMockProjectSvc: func(mockSvc *project.MockProjectService) {
+				result := project.NewResult("Feature 'login' restored from trash.").WithFeature("login", phase.Design).WithFiles("/p/.d3/features/login")
+				mockSvc.EXPECT().RestoreFeature(gomock.Any(), "login").Return(result, nil).Times(1)
+			},
+			wantOutputContains: `"feature": "login"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockProjectSvc := project.NewMockProjectService(ctrl)
+			if tt.setupMockProjectSvc != nil {
+				tt.setupMockProjectSvc(mockProjectSvc)
+			}
+			if tt.format != "" {
+				withOutputFormat(t, tt.format, "feature restore")
+			}
+
+			cmdRunner := &FeatureRestoreCommand{featureName: tt.featureNameArg, projectSvc: mockProjectSvc}
+
+			rStdout, wStdout, cleanupStdout := captureStdout(t)
+			defer cleanupStdout()
+
+			err := cmdRunner.run(ctx)
+
+			wStdout.Close()
+			var buf bytes.Buffer
+			_, _ = io.Copy(&buf, rStdout)
+			rStdout.Close()
+			output := buf.String()
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FeatureRestoreCommand.run() error = %v, wantErr %v\nOutput:\n%s", err, tt.wantErr, output)
+			}
+			if tt.wantExit != 0 && ExitCode(err) != tt.wantExit {
+				t.Errorf("ExitCode(FeatureRestoreCommand.run()) = %d, want %d", ExitCode(err), tt.wantExit)
+			}
+			if !strings.Contains(output, tt.wantOutputContains) {
+				t.Errorf("FeatureRestoreCommand.run() output = %q, want to contain %q", output, tt.wantOutputContains)
+			}
+		})
+	}
+}
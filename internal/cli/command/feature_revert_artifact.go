@@ -0,0 +1,75 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imcclaskey/d3/internal/core/feature"
+	"github.com/imcclaskey/d3/internal/core/ports"
+	"github.com/imcclaskey/d3/internal/project"
+)
+
+// featureRevertArtifactCmdRunner holds dependencies and options for the feature revert-artifact command.
+type featureRevertArtifactCmdRunner struct {
+	featureName string
+	from        string
+	artifact    string
+	projectSvc  project.ProjectService
+}
+
+// NewFeatureRevertArtifactCommand creates a new cobra command restoring artifacts from a snapshot.
+func NewFeatureRevertArtifactCommand() *cobra.Command {
+	cmdRunner := &featureRevertArtifactCmdRunner{}
+	cmd := &cobra.Command{
+		Use:   "revert-artifact <name> --from <snapshot> [--artifact <define|design|deliver|file>]",
+		Short: "Restore a feature's artifacts from a snapshot",
+		Long: `Overwrite the artifacts held by a snapshot, such as plan.md, with their saved versions. The
+versions being replaced are saved as a new snapshot first, so the revert can itself be undone.
+Use --artifact to restore only one of them, named by phase, path or file name, such as design or
+plan.md. The feature's phase is not changed.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmdRunner.featureName = args[0]
+
+			projectRoot, err := os.Getwd()
+			if err != nil {
+				return fmt.Errorf("could not determine workspace root: %w", err)
+			}
+			cfg, err := NewConfig(projectRoot)
+			if err != nil {
+				return err
+			}
+
+			cmdRunner.projectSvc, _ = wire(cfg, ports.RealFileSystem{})
+
+			return cmdRunner.run(context.Background())
+		},
+	}
+	cmd.Flags().StringVar(&cmdRunner.from, "from", "", "Snapshot to restore (required)")
+	cmd.MarkFlagRequired("from")
+	cmd.Flags().StringVar(&cmdRunner.artifact, "artifact", "", "Only restore this artifact: a phase (define, design, deliver) or a file such as plan.md")
+	return cmd
+}
+
+// run restores the snapshot through ProjectService.RevertArtifact.
+func (c *featureRevertArtifactCmdRunner) run(ctx context.Context) error {
+	if c.projectSvc == nil {
+		return fmt.Errorf("project service not initialized in featureRevertArtifactCmdRunner")
+	}
+
+	featureName, err := feature.NormalizeName(c.featureName)
+	if err != nil {
+		return err
+	}
+
+	result, err := c.projectSvc.RevertArtifact(ctx, featureName, c.from, c.artifact)
+	if err != nil {
+		return err
+	}
+
+	emit(projectResult(result))
+	return nil
+}
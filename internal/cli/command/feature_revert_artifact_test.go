@@ -0,0 +1,81 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/imcclaskey/d3/internal/project"
+)
+
+func TestFeatureRevertArtifactCmdRunner_run(t *testing.T) {
+	tests := []struct {
+		name               string
+		featureName        string
+		artifact           string
+		setupMocks         func(mockProjectSvc *project.MockProjectService)
+		wantErr            bool
+		wantOutputContains string
+	}{
+		{
+			name:        "restores the snapshot",
+			featureName: "Login",
+			setupMocks: func(mockProjectSvc *project.MockProjectService) {
+				mockProjectSvc.EXPECT().RevertArtifact(gomock.Any(), "login", "20261018", "").Return(project.NewResult("Restored design/plan.md from snapshot 20261018T142233Z-design-to-deliver."), nil)
+			},
+			wantOutputContains: "Restored design/plan.md from snapshot 20261018T142233Z-design-to-deliver.",
+		},
+		{
+			name:        "restores one artifact",
+			featureName: "login",
+			artifact:    "plan.md",
+			setupMocks: func(mockProjectSvc *project.MockProjectService) {
+				mockProjectSvc.EXPECT().RevertArtifact(gomock.Any(), "login", "20261018", "plan.md").Return(project.NewResult("Restored design/plan.md from snapshot 20261018T142233Z-deliver-to-define."), nil)
+			},
+			wantOutputContains: "Restored design/plan.md",
+		},
+		{
+			name:        "revert fails",
+			featureName: "login",
+			setupMocks: func(mockProjectSvc *project.MockProjectService) {
+				mockProjectSvc.EXPECT().RevertArtifact(gomock.Any(), "login", "20261018", "").Return(nil, errors.New("write failed"))
+			},
+			wantErr: true,
+		},
+		{
+			name:        "invalid name",
+			featureName: "../x",
+			setupMocks:  func(mockProjectSvc *project.MockProjectService) {},
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockProjectSvc := project.NewMockProjectService(ctrl)
+			tt.setupMocks(mockProjectSvc)
+
+			cmdInstance := featureRevertArtifactCmdRunner{featureName: tt.featureName, from: "20261018", artifact: tt.artifact, projectSvc: mockProjectSvc}
+
+			rPipe, wPipe, restoreStdout := captureStdout(t)
+			err := cmdInstance.run(context.Background())
+			wPipe.Close()
+			restoreStdout()
+			stdoutBuf := new(bytes.Buffer)
+			stdoutBuf.ReadFrom(rPipe)
+			rPipe.Close()
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("featureRevertArtifactCmdRunner.run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !strings.Contains(stdoutBuf.String(), tt.wantOutputContains) {
+				t.Errorf("featureRevertArtifactCmdRunner.run() output = %q, want to contain %q", stdoutBuf.String(), tt.wantOutputContains)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imcclaskey/d3/internal/core/feature"
+	"github.com/imcclaskey/d3/internal/core/ports"
+	"github.com/imcclaskey/d3/internal/core/snapshot"
+	"github.com/imcclaskey/d3/internal/project"
+)
+
+// featureSnapshotsCmdRunner holds dependencies for the feature snapshots command.
+type featureSnapshotsCmdRunner struct {
+	featureName string
+	projectSvc  project.ProjectService
+}
+
+// NewFeatureSnapshotsCommand creates a new cobra command listing the snapshots of a feature's artifacts.
+func NewFeatureSnapshotsCommand() *cobra.Command {
+	cmdRunner := &featureSnapshotsCmdRunner{}
+	return &cobra.Command{
+		Use:   "snapshots [name]",
+		Short: "List the saved versions of a feature's problem.md, plan.md and progress.yaml",
+		Long: `List the snapshots of a feature's artifacts, oldest first. Every phase change saves the artifact
+of the phase being left, and every revert saves the version it replaces. Without a name, the
+active feature's snapshots are listed.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 1 {
+				cmdRunner.featureName = args[0]
+			}
+
+			projectRoot, err := os.Getwd()
+			if err != nil {
+				return fmt.Errorf("could not determine workspace root: %w", err)
+			}
+			cfg, err := NewConfig(projectRoot)
+			if err != nil {
+				return err
+			}
+
+			cmdRunner.projectSvc, _ = wire(cfg, ports.RealFileSystem{})
+
+			return cmdRunner.run(context.Background())
+		},
+	}
+}
+
+// run prints one line per snapshot: its ID, transition and files.
+func (c *featureSnapshotsCmdRunner) run(ctx context.Context) error {
+	if c.projectSvc == nil {
+		return fmt.Errorf("project service not initialized in featureSnapshotsCmdRunner")
+	}
+
+	featureName := c.featureName
+	if featureName != "" {
+		var err error
+		if featureName, err = feature.NormalizeName(featureName); err != nil {
+			return err
+		}
+	}
+
+	snapshots, err := c.projectSvc.FeatureSnapshots(ctx, featureName)
+	if err != nil {
+		return err
+	}
+
+	var message strings.Builder
+	if len(snapshots) == 0 {
+		message.WriteString("No snapshots saved yet.")
+	}
+	for _, snap := range snapshots {
+		fmt.Fprintf(&message, "%s  %s  %s\n", snap.ID, snap.Time.Local().Format("2006-01-02 15:04"), strings.Join(snap.Files, ", "))
+	}
+	emit(NewResult(message.String(), featureSnapshotsData{Snapshots: snapshots}, nil))
+	return nil
+}
+
+// featureSnapshotsData is the JSON data of the feature snapshots command.
+type featureSnapshotsData struct {
+	Snapshots []snapshot.Snapshot `json:"snapshots"`
+}
@@ -0,0 +1,71 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/imcclaskey/d3/internal/core/snapshot"
+	"github.com/imcclaskey/d3/internal/project"
+)
+
+func TestFeatureSnapshotsCmdRunner_run(t *testing.T) {
+	tests := []struct {
+		name               string
+		featureName        string
+		snapshots          []snapshot.Snapshot
+		snapshotsErr       error
+		wantFeature        string
+		wantErr            bool
+		wantOutputContains string
+	}{
+		{
+			name:        "lists snapshots",
+			featureName: "Login",
+			snapshots: []snapshot.Snapshot{
+				{ID: "20261018T142233Z-deliver-to-design", Time: time.Date(2026, 10, 18, 14, 22, 33, 0, time.UTC), Reason: "deliver-to-design", Files: []string{"deliver/progress.yaml"}},
+			},
+			wantFeature:        "login",
+			wantOutputContains: "20261018T142233Z-deliver-to-design  ",
+		},
+		{
+			name:               "no snapshots",
+			snapshots:          []snapshot.Snapshot{},
+			wantOutputContains: "No snapshots saved yet.",
+		},
+		{
+			name:         "no active feature",
+			snapshotsErr: project.ErrNoActiveFeature,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockProjectSvc := project.NewMockProjectService(ctrl)
+			mockProjectSvc.EXPECT().FeatureSnapshots(gomock.Any(), tt.wantFeature).Return(tt.snapshots, tt.snapshotsErr)
+
+			cmdInstance := featureSnapshotsCmdRunner{featureName: tt.featureName, projectSvc: mockProjectSvc}
+
+			rPipe, wPipe, restoreStdout := captureStdout(t)
+			err := cmdInstance.run(context.Background())
+			wPipe.Close()
+			restoreStdout()
+			stdoutBuf := new(bytes.Buffer)
+			stdoutBuf.ReadFrom(rPipe)
+			rPipe.Close()
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("featureSnapshotsCmdRunner.run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !strings.Contains(stdoutBuf.String(), tt.wantOutputContains) {
+				t.Errorf("featureSnapshotsCmdRunner.run() output = %q, want to contain %q", stdoutBuf.String(), tt.wantOutputContains)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imcclaskey/d3/internal/core/feature"
+	"github.com/imcclaskey/d3/internal/core/ports"
+)
+
+// FeatureUnpackCommand holds dependencies and options for the feature unpack command.
+type FeatureUnpackCommand struct {
+	bundlePath string
+	asName     string
+	featureSvc feature.FeatureServicer
+	fs         ports.FileSystem
+}
+
+// NewFeatureUnpackCommand creates a new cobra command for importing a feature bundle.
+func NewFeatureUnpackCommand() *cobra.Command {
+	cmdRunner := &FeatureUnpackCommand{}
+	cmd := &cobra.Command{
+		Use:   "unpack <file>",
+		Short: "Import a feature bundle into this project",
+		Long: `Import a bundle created by 'd3 feature pack'. The bundle's checksums and phase file are verified
+before anything is written. An existing feature is never overwritten; use --as to import under a
+different name.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmdRunner.bundlePath = args[0]
+
+			projectRoot, err := os.Getwd()
+			if err != nil {
+				return fmt.Errorf("could not determine workspace root: %w", err)
+			}
+			cfg, err := NewConfig(projectRoot)
+			if err != nil {
+				return err
+			}
+
+			fs := ports.RealFileSystem{}
+			cmdRunner.featureSvc = newFeatureService(cfg, fs)
+			cmdRunner.fs = fs
+
+			return cmdRunner.run(context.Background())
+		},
+	}
+	cmd.Flags().StringVar(&cmdRunner.asName, "as", "", "Import the feature under this name")
+	return cmd
+}
+
+// run reads the bundle file and imports it.
+func (c *FeatureUnpackCommand) run(ctx context.Context) error {
+	if c.featureSvc == nil || c.fs == nil {
+		return fmt.Errorf("services not initialized in FeatureUnpackCommand")
+	}
+
+	asName := c.asName
+	if asName != "" {
+		var err error
+		if asName, err = feature.NormalizeName(asName); err != nil {
+			return err
+		}
+	}
+
+	data, err := c.fs.ReadFile(c.bundlePath)
+	if err != nil {
+		return fmt.Errorf("failed to read bundle %s: %w", c.bundlePath, err)
+	}
+
+	info, err := c.featureSvc.UnpackFeature(ctx, data, asName)
+	if err != nil {
+		return fmt.Errorf("failed to unpack %s: %w", c.bundlePath, err)
+	}
+	featurePhase, err := c.featureSvc.GetFeaturePhase(ctx, info.Name)
+	if err != nil {
+		return err
+	}
+	emit(NewResult(fmt.Sprintf("Imported feature '%s'. Use 'd3 feature enter %s' to work on it.", info.Name, info.Name), FeatureData{
+		Feature:      info.Name,
+		Phase:        string(featurePhase),
+		FilesTouched: []string{info.Path},
+	}, nil))
+	return nil
+}
@@ -0,0 +1,92 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/imcclaskey/d3/internal/core/feature"
+	featuremocks "github.com/imcclaskey/d3/internal/core/feature/mocks"
+	"github.com/imcclaskey/d3/internal/core/phase"
+	portsmocks "github.com/imcclaskey/d3/internal/core/ports/mocks"
+)
+
+func TestFeatureUnpackCommand_run(t *testing.T) {
+	tests := []struct {
+		name               string
+		cmd                FeatureUnpackCommand
+		setupMocks         func(featureSvc *featuremocks.MockFeatureServicer, mockFS *portsmocks.MockFileSystem)
+		wantErr            bool
+		wantOutputContains string
+	}{
+		{
+			name: "imports bundle",
+			cmd:  FeatureUnpackCommand{bundlePath: "login.d3.tar.gz"},
+			setupMocks: func(featureSvc *featuremocks.MockFeatureServicer, mockFS *portsmocks.MockFileSystem) {
+				mockFS.EXPECT().ReadFile("login.d3.tar.gz").Return([]byte("bundle"), nil).Times(1)
+				featureSvc.EXPECT().UnpackFeature(gomock.Any(), []byte("bundle"), "").Return(&feature.FeatureInfo{Name: "login"}, nil).Times(1)
+				featureSvc.EXPECT().GetFeaturePhase(gomock.Any(), "login").Return(phase.Define, nil).Times(1)
+			},
+			wantOutputContains: "Imported feature 'login'",
+		},
+		{
+			name: "normalizes --as name",
+			cmd:  FeatureUnpackCommand{bundlePath: "login.d3.tar.gz", asName: "Login V2"},
+			setupMocks: func(featureSvc *featuremocks.MockFeatureServicer, mockFS *portsmocks.MockFileSystem) {
+				mockFS.EXPECT().ReadFile("login.d3.tar.gz").Return([]byte("bundle"), nil).Times(1)
+				featureSvc.EXPECT().UnpackFeature(gomock.Any(), []byte("bundle"), "login-v2").Return(&feature.FeatureInfo{Name: "login-v2"}, nil).Times(1)
+				featureSvc.EXPECT().GetFeaturePhase(gomock.Any(), "login-v2").Return(phase.Define, nil).Times(1)
+			},
+			wantOutputContains: "Imported feature 'login-v2'",
+		},
+		{
+			name: "missing bundle file",
+			cmd:  FeatureUnpackCommand{bundlePath: "nope.tgz"},
+			setupMocks: func(featureSvc *featuremocks.MockFeatureServicer, mockFS *portsmocks.MockFileSystem) {
+				mockFS.EXPECT().ReadFile("nope.tgz").Return(nil, fmt.Errorf("no such file")).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "name collision",
+			cmd:  FeatureUnpackCommand{bundlePath: "login.d3.tar.gz"},
+			setupMocks: func(featureSvc *featuremocks.MockFeatureServicer, mockFS *portsmocks.MockFileSystem) {
+				mockFS.EXPECT().ReadFile("login.d3.tar.gz").Return([]byte("bundle"), nil).Times(1)
+				featureSvc.EXPECT().UnpackFeature(gomock.Any(), []byte("bundle"), "").Return(nil, fmt.Errorf("feature 'login' already exists")).Times(1)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockFeatureSvc := featuremocks.NewMockFeatureServicer(ctrl)
+			mockFS := portsmocks.NewMockFileSystem(ctrl)
+			tt.setupMocks(mockFeatureSvc, mockFS)
+
+			cmdInstance := tt.cmd
+			cmdInstance.featureSvc = mockFeatureSvc
+			cmdInstance.fs = mockFS
+
+			rPipe, wPipe, restoreStdout := captureStdout(t)
+			err := cmdInstance.run(context.Background())
+			wPipe.Close()
+			restoreStdout()
+			stdoutBuf := new(bytes.Buffer)
+			stdoutBuf.ReadFrom(rPipe)
+			rPipe.Close()
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FeatureUnpackCommand.run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !strings.Contains(stdoutBuf.String(), tt.wantOutputContains) {
+				t.Errorf("FeatureUnpackCommand.run() output = %q, want to contain %q", stdoutBuf.String(), tt.wantOutputContains)
+			}
+		})
+	}
+}
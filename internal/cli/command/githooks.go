@@ -0,0 +1,259 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imcclaskey/d3/internal/core/d3err"
+	"github.com/imcclaskey/d3/internal/core/feature"
+	"github.com/imcclaskey/d3/internal/core/git"
+	"github.com/imcclaskey/d3/internal/core/githooks"
+	"github.com/imcclaskey/d3/internal/core/ports"
+)
+
+// githooksCmdRunner holds the dependencies and options shared by the githooks subcommands.
+type githooksCmdRunner struct {
+	preCommit string
+	force     bool
+	mode      string
+	// featuresDir is the features directory relative to the project root
+	featuresDir string
+	fs          ports.FileSystem
+	gitClient   git.Client
+	featureSvc  feature.FeatureServicer
+}
+
+// NewGithooksCommand creates a new cobra command for managing d3 git hooks.
+func NewGithooksCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "githooks",
+		Short: "Manage git hooks that tie commits to d3 features",
+		Long: `The prepare-commit-msg hook appends a 'D3-Feature: <name>' trailer to commits made while a
+feature is active. The optional pre-commit hook warns about, or blocks, commits of files outside .d3
+and the features directory while the active feature is still in the define or design phase.`,
+	}
+	cmd.AddCommand(newGithooksInstallCommand())
+	cmd.AddCommand(newGithooksUninstallCommand())
+	cmd.AddCommand(newGithooksRunCommand())
+	return cmd
+}
+
+// wire populates the runner with real services for the current working directory.
+func (c *githooksCmdRunner) wire() error {
+	projectRoot, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("could not determine workspace root: %w", err)
+	}
+	cfg, err := NewConfig(projectRoot)
+	if err != nil {
+		return err
+	}
+	c.fs = ports.RealFileSystem{}
+	if rel, err := filepath.Rel(cfg.WorkspaceRoot, cfg.FeaturesDir); err == nil {
+		c.featuresDir = rel
+	}
+	c.gitClient = git.New(cfg.WorkspaceRoot)
+	c.featureSvc = newFeatureService(cfg, c.fs)
+	return nil
+}
+
+func newGithooksInstallCommand() *cobra.Command {
+	cmdRunner := &githooksCmdRunner{}
+	cmd := &cobra.Command{
+		Use:   "install",
+		Short: "Install the d3 git hooks",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := cmdRunner.wire(); err != nil {
+				return err
+			}
+			return cmdRunner.runInstall(context.Background())
+		},
+	}
+	cmd.Flags().StringVar(&cmdRunner.preCommit, "pre-commit", string(githooks.PreCommitOff), "Pre-commit hook behaviour for code committed during define or design: off, warn or block")
+	cmd.Flags().BoolVar(&cmdRunner.force, "force", false, "Replace existing hooks that were not installed by d3")
+	return cmd
+}
+
+func newGithooksUninstallCommand() *cobra.Command {
+	cmdRunner := &githooksCmdRunner{}
+	return &cobra.Command{
+		Use:   "uninstall",
+		Short: "Remove the d3 git hooks",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := cmdRunner.wire(); err != nil {
+				return err
+			}
+			return cmdRunner.runUninstall(context.Background())
+		},
+	}
+}
+
+func newGithooksRunCommand() *cobra.Command {
+	cmdRunner := &githooksCmdRunner{}
+	cmd := &cobra.Command{
+		Use:    "run <hook> [args...]",
+		Short:  "Run a d3 git hook (invoked by the installed hook scripts)",
+		Hidden: true,
+		Args:   cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := cmdRunner.wire(); err != nil {
+				return err
+			}
+			cmd.SilenceUsage = true
+			return cmdRunner.runHook(context.Background(), args[0], args[1:])
+		},
+	}
+	cmd.Flags().StringVar(&cmdRunner.mode, "mode", string(githooks.PreCommitWarn), "Pre-commit behaviour: warn or block")
+	return cmd
+}
+
+// runInstall writes the hook scripts into the repository's hooks directory.
+func (c *githooksCmdRunner) runInstall(ctx context.Context) error {
+	if c.gitClient == nil || c.fs == nil {
+		return fmt.Errorf("dependencies not initialized in githooksCmdRunner")
+	}
+	mode, err := githooks.ParsePreCommitMode(c.preCommit)
+	if err != nil {
+		return err
+	}
+	if !c.gitClient.IsRepository(ctx) {
+		return fmt.Errorf("githooks require a git repository")
+	}
+	hooksDir, err := c.gitClient.HooksDir(ctx)
+	if err != nil {
+		return err
+	}
+
+	installed, err := githooks.Install(c.fs, hooksDir, githooks.InstallOptions{PreCommit: mode, Force: c.force})
+	if err != nil {
+		// Hooks written before the failure are still reported in text mode
+		if !JSONOutput() {
+			for _, path := range installed {
+				fmt.Printf("Installed %s\n", path)
+			}
+		}
+		return err
+	}
+	var message strings.Builder
+	for _, path := range installed {
+		fmt.Fprintf(&message, "Installed %s\n", path)
+	}
+	emit(NewResult(message.String(), githooksData{HooksDir: hooksDir, Installed: nonNil(installed), Removed: []string{}}, nil))
+	return nil
+}
+
+// runUninstall removes the d3-managed hook scripts.
+func (c *githooksCmdRunner) runUninstall(ctx context.Context) error {
+	if c.gitClient == nil || c.fs == nil {
+		return fmt.Errorf("dependencies not initialized in githooksCmdRunner")
+	}
+	hooksDir, err := c.gitClient.HooksDir(ctx)
+	if err != nil {
+		return err
+	}
+
+	removed, err := githooks.Uninstall(c.fs, hooksDir)
+	if err != nil {
+		if !JSONOutput() {
+			for _, path := range removed {
+				fmt.Printf("Removed %s\n", path)
+			}
+		}
+		return err
+	}
+	var message strings.Builder
+	for _, path := range removed {
+		fmt.Fprintf(&message, "Removed %s\n", path)
+	}
+	if len(removed) == 0 {
+		message.WriteString("No d3 git hooks installed.")
+	}
+	emit(NewResult(message.String(), githooksData{HooksDir: hooksDir, Installed: []string{}, Removed: nonNil(removed)}, nil))
+	return nil
+}
+
+// githooksData is the JSON data of the githooks install and uninstall commands.
+type githooksData struct {
+	HooksDir  string   `json:"hooks_dir"`
+	Installed []string `json:"installed"`
+	Removed   []string `json:"removed"`
+}
+
+// runHook dispatches a hook invocation from one of the installed scripts.
+func (c *githooksCmdRunner) runHook(ctx context.Context, hook string, args []string) error {
+	if c.gitClient == nil || c.featureSvc == nil {
+		return fmt.Errorf("dependencies not initialized in githooksCmdRunner")
+	}
+	switch hook {
+	case githooks.PrepareCommitMsg:
+		return c.runPrepareCommitMsg(ctx, args)
+	case githooks.PreCommit:
+		return c.runPreCommit(ctx)
+	default:
+		return fmt.Errorf("unknown hook '%s'", hook)
+	}
+}
+
+// runPrepareCommitMsg adds the feature trailer to the commit message.
+// Failures are reported but never abort the commit.
+func (c *githooksCmdRunner) runPrepareCommitMsg(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%s hook requires the commit message file", githooks.PrepareCommitMsg)
+	}
+	activeFeature, err := c.featureSvc.GetActiveFeature()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: d3 could not read the active feature: %v\n", err)
+		return nil
+	}
+	if activeFeature == "" {
+		return nil
+	}
+	if err := c.gitClient.AddTrailer(ctx, args[0], githooks.TrailerKey, activeFeature); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: d3 could not add the %s trailer: %v\n", githooks.TrailerKey, err)
+	}
+	return nil
+}
+
+// runPreCommit warns about, or blocks, code commits while the active feature is not yet in deliver.
+func (c *githooksCmdRunner) runPreCommit(ctx context.Context) error {
+	mode, err := githooks.ParsePreCommitMode(c.mode)
+	if err != nil {
+		return err
+	}
+	if mode == githooks.PreCommitOff {
+		return nil
+	}
+
+	activeFeature, err := c.featureSvc.GetActiveFeature()
+	if err != nil || activeFeature == "" {
+		return nil
+	}
+	currentPhase, err := c.featureSvc.GetFeaturePhase(ctx, activeFeature)
+	if err != nil || !githooks.GuardsPhase(currentPhase) {
+		return nil
+	}
+
+	staged, err := c.gitClient.StagedFiles(ctx)
+	if err != nil {
+		return err
+	}
+	code := githooks.CodeFiles(staged, c.featuresDir)
+	if len(code) == 0 {
+		return nil
+	}
+
+	msg := fmt.Sprintf("feature '%s' is still in the %s phase, but files outside d3's documents are staged:\n  %s",
+		activeFeature, currentPhase, strings.Join(code, "\n  "))
+	if mode == githooks.PreCommitBlock {
+		return d3err.New(d3err.GateFailed, "%s\nMove the feature to deliver with 'd3 phase move deliver', or commit with --no-verify", msg)
+	}
+	fmt.Fprintf(os.Stderr, "warning: %s\n", msg)
+	return nil
+}
@@ -0,0 +1,149 @@
+package command
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	featuremocks "github.com/imcclaskey/d3/internal/core/feature/mocks"
+	gitmocks "github.com/imcclaskey/d3/internal/core/git/mocks"
+	"github.com/imcclaskey/d3/internal/core/githooks"
+	"github.com/imcclaskey/d3/internal/core/phase"
+	portsmocks "github.com/imcclaskey/d3/internal/core/ports/mocks"
+)
+
+func TestGithooksCmdRunner_PrepareCommitMsg(t *testing.T) {
+	tests := []struct {
+		name       string
+		setupMocks func(featureSvc *featuremocks.MockFeatureServicer, gitClient *gitmocks.MockClient)
+	}{
+		{
+			name: "adds trailer for active feature",
+			setupMocks: func(featureSvc *featuremocks.MockFeatureServicer, gitClient *gitmocks.MockClient) {
+				featureSvc.EXPECT().GetActiveFeature().Return("login", nil).Times(1)
+				gitClient.EXPECT().AddTrailer(gomock.Any(), "/repo/.git/COMMIT_EDITMSG", githooks.TrailerKey, "login").Return(nil).Times(1)
+			},
+		},
+		{
+			name: "no active feature leaves message alone",
+			setupMocks: func(featureSvc *featuremocks.MockFeatureServicer, gitClient *gitmocks.MockClient) {
+				featureSvc.EXPECT().GetActiveFeature().Return("", nil).Times(1)
+			},
+		},
+		{
+			name: "trailer failure does not abort the commit",
+			setupMocks: func(featureSvc *featuremocks.MockFeatureServicer, gitClient *gitmocks.MockClient) {
+				featureSvc.EXPECT().GetActiveFeature().Return("login", nil).Times(1)
+				gitClient.EXPECT().AddTrailer(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(context.Canceled).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockFeatureSvc := featuremocks.NewMockFeatureServicer(ctrl)
+			mockGit := gitmocks.NewMockClient(ctrl)
+			tt.setupMocks(mockFeatureSvc, mockGit)
+
+			runner := &githooksCmdRunner{featureSvc: mockFeatureSvc, gitClient: mockGit}
+			if err := runner.runHook(context.Background(), githooks.PrepareCommitMsg, []string{"/repo/.git/COMMIT_EDITMSG", "message"}); err != nil {
+				t.Errorf("runHook(prepare-commit-msg) unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestGithooksCmdRunner_PreCommit(t *testing.T) {
+	tests := []struct {
+		name         string
+		mode         string
+		setupMocks   func(featureSvc *featuremocks.MockFeatureServicer, gitClient *gitmocks.MockClient)
+		wantErr      bool
+		wantContains string
+	}{
+		{
+			name: "blocks code during design",
+			mode: "block",
+			setupMocks: func(featureSvc *featuremocks.MockFeatureServicer, gitClient *gitmocks.MockClient) {
+				featureSvc.EXPECT().GetActiveFeature().Return("login", nil).Times(1)
+				featureSvc.EXPECT().GetFeaturePhase(gomock.Any(), "login").Return(phase.Design, nil).Times(1)
+				gitClient.EXPECT().StagedFiles(gomock.Any()).Return([]string{".d3/features/login/design/plan.md", "main.go"}, nil).Times(1)
+			},
+			wantErr:      true,
+			wantContains: "main.go",
+		},
+		{
+			name: "warns without blocking",
+			mode: "warn",
+			setupMocks: func(featureSvc *featuremocks.MockFeatureServicer, gitClient *gitmocks.MockClient) {
+				featureSvc.EXPECT().GetActiveFeature().Return("login", nil).Times(1)
+				featureSvc.EXPECT().GetFeaturePhase(gomock.Any(), "login").Return(phase.Define, nil).Times(1)
+				gitClient.EXPECT().StagedFiles(gomock.Any()).Return([]string{"main.go"}, nil).Times(1)
+			},
+		},
+		{
+			name: "only .d3 files staged",
+			mode: "block",
+			setupMocks: func(featureSvc *featuremocks.MockFeatureServicer, gitClient *gitmocks.MockClient) {
+				featureSvc.EXPECT().GetActiveFeature().Return("login", nil).Times(1)
+				featureSvc.EXPECT().GetFeaturePhase(gomock.Any(), "login").Return(phase.Define, nil).Times(1)
+				gitClient.EXPECT().StagedFiles(gomock.Any()).Return([]string{".d3/features/login/define/problem.md"}, nil).Times(1)
+			},
+		},
+		{
+			name: "deliver phase allows code",
+			mode: "block",
+			setupMocks: func(featureSvc *featuremocks.MockFeatureServicer, gitClient *gitmocks.MockClient) {
+				featureSvc.EXPECT().GetActiveFeature().Return("login", nil).Times(1)
+				featureSvc.EXPECT().GetFeaturePhase(gomock.Any(), "login").Return(phase.Deliver, nil).Times(1)
+			},
+		},
+		{
+			name: "no active feature",
+			mode: "block",
+			setupMocks: func(featureSvc *featuremocks.MockFeatureServicer, gitClient *gitmocks.MockClient) {
+				featureSvc.EXPECT().GetActiveFeature().Return("", nil).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockFeatureSvc := featuremocks.NewMockFeatureServicer(ctrl)
+			mockGit := gitmocks.NewMockClient(ctrl)
+			tt.setupMocks(mockFeatureSvc, mockGit)
+
+			runner := &githooksCmdRunner{mode: tt.mode, featureSvc: mockFeatureSvc, gitClient: mockGit}
+			err := runner.runHook(context.Background(), githooks.PreCommit, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("runHook(pre-commit) error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), tt.wantContains) {
+				t.Errorf("runHook(pre-commit) error = %q, want to contain %q", err.Error(), tt.wantContains)
+			}
+		})
+	}
+}
+
+func TestGithooksCmdRunner_UnknownHook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	runner := &githooksCmdRunner{featureSvc: featuremocks.NewMockFeatureServicer(ctrl), gitClient: gitmocks.NewMockClient(ctrl)}
+	if err := runner.runHook(context.Background(), "post-merge", nil); err == nil {
+		t.Errorf("runHook(post-merge) expected error")
+	}
+}
+
+func TestGithooksCmdRunner_InstallRequiresRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockGit := gitmocks.NewMockClient(ctrl)
+	mockGit.EXPECT().IsRepository(gomock.Any()).Return(false).Times(1)
+
+	runner := &githooksCmdRunner{preCommit: "off", gitClient: mockGit, fs: portsmocks.NewMockFileSystem(ctrl)}
+	if err := runner.runInstall(context.Background()); err == nil || !strings.Contains(err.Error(), "git repository") {
+		t.Errorf("runInstall() error = %v, want git repository error", err)
+	}
+}
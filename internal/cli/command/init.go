@@ -7,11 +7,7 @@ import (
 
 	"github.com/spf13/cobra"
 
-	"github.com/imcclaskey/d3/internal/core/feature"
-	"github.com/imcclaskey/d3/internal/core/phase"
 	"github.com/imcclaskey/d3/internal/core/ports"
-	"github.com/imcclaskey/d3/internal/core/projectfiles"
-	"github.com/imcclaskey/d3/internal/core/rules"
 	"github.com/imcclaskey/d3/internal/project"
 )
 
@@ -41,16 +37,13 @@ func NewInitCommand() *cobra.Command {
 			if err != nil {
 				return fmt.Errorf("could not determine project root: %w", err)
 			}
-			cfg := NewConfig(projectRoot)
+			cfg, err := NewConfig(projectRoot)
+			if err != nil {
+				return err
+			}
 
 			fs := ports.RealFileSystem{}
-			featureSvc := feature.NewService(cfg.WorkspaceRoot, cfg.FeaturesDir, cfg.D3Dir, fs)
-			ruleGenerator := rules.NewRuleGenerator(cfg.WorkspaceRoot, fs)
-			rulesSvc := rules.NewService(cfg.WorkspaceRoot, cfg.CursorRulesDir, ruleGenerator, fs)
-			phaseSvc := phase.NewService(fs)
-			fileOp := projectfiles.NewDefaultFileOperator()
-
-			cmdRunner.projectSvc = project.New(cfg.WorkspaceRoot, fs, featureSvc, rulesSvc, phaseSvc, fileOp)
+			cmdRunner.projectSvc, _ = wire(cfg, fs)
 
 			return cmdRunner.run(cmdRunner.clean, cmdRunner.refresh, cmdRunner.customRules)
 		},
@@ -73,6 +66,6 @@ func (c *InitCommand) run(clean bool, refresh bool, customRules bool) error {
 	if err != nil {
 		return err
 	}
-	fmt.Println(result.FormatCLI())
+	emit(projectResult(result))
 	return nil
 }
@@ -0,0 +1,236 @@
+package command
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imcclaskey/d3/internal/core/d3err"
+	"github.com/imcclaskey/d3/internal/project"
+)
+
+// OutputFormat selects how commands print their results.
+type OutputFormat string
+
+const (
+	// OutputText prints human-readable sentences. This is the default.
+	OutputText OutputFormat = "text"
+	// OutputJSON prints a single JSON envelope per command, including on failure.
+	OutputJSON OutputFormat = "json"
+)
+
+// JSONSchemaVersion identifies the layout of the JSON envelope. It is incremented only for
+// incompatible changes; new fields may be added without a version change.
+const JSONSchemaVersion = 1
+
+// output holds the format chosen with the global --output flag and the command being run.
+// Commands are executed one per process, so package state is sufficient.
+var output = struct {
+	format  OutputFormat
+	command string
+}{format: OutputText}
+
+// AddOutputFlag registers the global --output flag on the root command and validates it
+// before any subcommand runs. Errors are left to ReportError so that they can be printed in
+// the chosen format.
+func AddOutputFlag(root *cobra.Command) {
+	var value string
+	root.PersistentFlags().StringVar(&value, "output", string(OutputText), "Output format: text or json")
+	root.SilenceErrors = true
+	root.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		output.command = commandName(cmd)
+		format, err := ParseOutputFormat(value)
+		if err != nil {
+			return err
+		}
+		output.format = format
+		if format == OutputJSON {
+			// Usage text would only add noise to a machine-readable error
+			cmd.SilenceUsage = true
+		}
+		return nil
+	}
+}
+
+// ReportError prints the error a command returned, as a JSON envelope when --output json was
+// given and as "Error: ..." on stderr otherwise. The format is read from the flag as well, since
+// flag parsing errors occur before the command's pre-run hook.
+func ReportError(cmd *cobra.Command, err error) {
+	if cmd != nil && output.command == "" {
+		output.command = commandName(cmd)
+	}
+	if !JSONOutput() && cmd != nil {
+		if flag := cmd.Flags().Lookup("output"); flag != nil {
+			if format, parseErr := ParseOutputFormat(flag.Value.String()); parseErr == nil {
+				output.format = format
+			}
+		}
+	}
+	var reported reportedError
+	if errors.As(err, &reported) {
+		return
+	}
+	if JSONOutput() {
+		WriteJSONError(err)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+}
+
+// commandName returns the command path without the root command, e.g. "feature create".
+func commandName(cmd *cobra.Command) string {
+	return strings.TrimSpace(strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()))
+}
+
+// ParseOutputFormat validates an --output value.
+func ParseOutputFormat(value string) (OutputFormat, error) {
+	switch f := OutputFormat(strings.ToLower(strings.TrimSpace(value))); f {
+	case "", OutputText:
+		return OutputText, nil
+	case OutputJSON:
+		return f, nil
+	default:
+		return "", d3err.New(d3err.InvalidArgument, "invalid output format %q: expected text or json", value)
+	}
+}
+
+// JSONOutput reports whether results are being printed as JSON.
+func JSONOutput() bool {
+	return output.format == OutputJSON
+}
+
+// envelope is the JSON document printed for every command. See README for the schema.
+type envelope struct {
+	SchemaVersion int          `json:"schema_version"`
+	Command       string       `json:"command"`
+	OK            bool         `json:"ok"`
+	Message       string       `json:"message,omitempty"`
+	Data          interface{}  `json:"data,omitempty"`
+	Warnings      []string     `json:"warnings"`
+	Error         *errorDetail `json:"error,omitempty"`
+}
+
+// errorDetail describes a failed command.
+type errorDetail struct {
+	// Code is the d3err code, "unknown" for errors outside the taxonomy.
+	Code    d3err.Code `json:"code"`
+	Message string     `json:"message"`
+}
+
+// emit prints a command result. Text output is the message followed by any warnings;
+// JSON output is the envelope.
+func emit(r Result) {
+	if !JSONOutput() {
+		if r.Message != "" {
+			fmt.Print(r.Message)
+			if !strings.HasSuffix(r.Message, "\n") {
+				fmt.Println()
+			}
+		}
+		for _, warning := range r.Warnings {
+			fmt.Printf("Warning: %s\n", warning)
+		}
+		return
+	}
+
+	writeEnvelope(envelope{
+		SchemaVersion: JSONSchemaVersion,
+		Command:       output.command,
+		OK:            true,
+		Message:       r.Message,
+		Data:          r.Data,
+		Warnings:      nonNil(r.Warnings),
+	})
+}
+
+// reportedError marks an error whose envelope emitFailure has already printed, so that
+// ReportError does not print a second document.
+type reportedError struct {
+	error
+}
+
+// Unwrap returns the original error, so that its code still sets the exit status.
+func (e reportedError) Unwrap() error {
+	return e.error
+}
+
+// emitFailure prints the result of a command that ran to completion but must still fail, such
+// as doctor finding unresolved problems. Text output is the result, with err printed by
+// ReportError; JSON output is a single envelope carrying both the data and the error.
+func emitFailure(r Result, err error) error {
+	if !JSONOutput() {
+		emit(r)
+		return err
+	}
+
+	writeEnvelope(envelope{
+		SchemaVersion: JSONSchemaVersion,
+		Command:       output.command,
+		OK:            false,
+		Message:       r.Message,
+		Data:          r.Data,
+		Warnings:      nonNil(r.Warnings),
+		Error:         &errorDetail{Code: d3err.CodeOf(err), Message: err.Error()},
+	})
+	return reportedError{err}
+}
+
+// WriteJSONError prints a failed command's envelope. It is used by the CLI entry point so
+// that errors from flag parsing and validation are reported the same way as command errors.
+func WriteJSONError(err error) {
+	writeEnvelope(envelope{
+		SchemaVersion: JSONSchemaVersion,
+		Command:       output.command,
+		OK:            false,
+		Warnings:      []string{},
+		Error:         &errorDetail{Code: d3err.CodeOf(err), Message: err.Error()},
+	})
+}
+
+func writeEnvelope(e envelope) {
+	data, err := json.MarshalIndent(e, "", "  ")
+	if err != nil {
+		// Data types are plain structs, so this only happens on a programming error
+		data, _ = json.Marshal(envelope{SchemaVersion: JSONSchemaVersion, Command: e.Command, Warnings: []string{}, Error: &errorDetail{Code: d3err.Unknown, Message: err.Error()}})
+	}
+	fmt.Fprintln(os.Stdout, string(data))
+}
+
+// FeatureData is the JSON data of commands that act on a feature through the project service.
+type FeatureData struct {
+	// Feature is the feature acted on, if any.
+	Feature string `json:"feature,omitempty"`
+	// Phase is the feature's phase after the command.
+	Phase string `json:"phase,omitempty"`
+	// Branch is the git branch checked out for the feature by the command, if any.
+	Branch string `json:"branch,omitempty"`
+	// RulesChanged is true when the generated Cursor rules were rewritten.
+	RulesChanged bool `json:"rules_changed"`
+	// FilesTouched lists files and directories created, modified, moved or removed.
+	FilesTouched []string `json:"files_touched"`
+}
+
+// projectResult converts a project service result into a command result. Warnings are kept
+// apart from the message so that emit can list them, in text as well as in JSON.
+func projectResult(r *project.Result) Result {
+	summary := *r
+	summary.Warnings = nil
+	return NewResult(summary.FormatCLI(), FeatureData{
+		Feature:      r.Feature,
+		Phase:        string(r.Phase),
+		RulesChanged: r.RulesChanged,
+		FilesTouched: nonNil(r.FilesTouched),
+	}, r.Warnings)
+}
+
+// nonNil returns s, or an empty slice when s is nil, so that JSON lists are never null.
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
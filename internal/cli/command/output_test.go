@@ -0,0 +1,206 @@
+package command
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/imcclaskey/d3/internal/core/d3err"
+	"github.com/imcclaskey/d3/internal/project"
+	"github.com/spf13/cobra"
+)
+
+// withOutputFormat switches the package output format for the duration of a test.
+func withOutputFormat(t *testing.T, format OutputFormat, command string) {
+	t.Helper()
+	saved := output
+	output.format = format
+	output.command = command
+	t.Cleanup(func() { output = saved })
+}
+
+// readStdout runs fn and returns what it printed to stdout.
+func readStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	rPipe, wPipe, restoreStdout := captureStdout(t)
+	fn()
+	wPipe.Close()
+	restoreStdout()
+	buf := new(bytes.Buffer)
+	buf.ReadFrom(rPipe)
+	rPipe.Close()
+	return buf.String()
+}
+
+func TestParseOutputFormat(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    OutputFormat
+		wantErr bool
+	}{
+		{value: "", want: OutputText},
+		{value: "text", want: OutputText},
+		{value: " JSON ", want: OutputJSON},
+		{value: "yaml", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseOutputFormat(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseOutputFormat(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseOutputFormat(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEmit(t *testing.T) {
+	result := NewResult("Feature 'login' created.", FeatureData{Feature: "login", Phase: "define", FilesTouched: []string{"/p/login"}}, []string{"something odd"})
+
+	t.Run("text", func(t *testing.T) {
+		withOutputFormat(t, OutputText, "feature create")
+		got := readStdout(t, func() { emit(result) })
+		want := "Feature 'login' created.\nWarning: something odd\n"
+		if got != want {
+			t.Errorf("emit() text = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		withOutputFormat(t, OutputJSON, "feature create")
+		got := readStdout(t, func() { emit(result) })
+
+		var decoded map[string]interface{}
+		if err := json.Unmarshal([]byte(got), &decoded); err != nil {
+			t.Fatalf("emit() printed invalid JSON: %v\n%s", err, got)
+		}
+		if decoded["schema_version"] != float64(JSONSchemaVersion) || decoded["command"] != "feature create" || decoded["ok"] != true {
+			t.Errorf("emit() envelope = %v", decoded)
+		}
+		data, _ := decoded["data"].(map[string]interface{})
+		if data["feature"] != "login" || data["phase"] != "define" || data["rules_changed"] != false {
+			t.Errorf("emit() data = %v", data)
+		}
+		if files, _ := data["files_touched"].([]interface{}); len(files) != 1 || files[0] != "/p/login" {
+			t.Errorf("emit() files_touched = %v", data["files_touched"])
+		}
+		if warnings, _ := decoded["warnings"].([]interface{}); len(warnings) != 1 || warnings[0] != "something odd" {
+			t.Errorf("emit() warnings = %v", decoded["warnings"])
+		}
+		if _, ok := decoded["error"]; ok {
+			t.Errorf("emit() included an error: %v", decoded["error"])
+		}
+	})
+
+	t.Run("json lists are never null", func(t *testing.T) {
+		withOutputFormat(t, OutputJSON, "status")
+		got := readStdout(t, func() { emit(Result{Message: "No active feature."}) })
+		if !strings.Contains(got, `"warnings": []`) {
+			t.Errorf("emit() = %s, want empty warnings list", got)
+		}
+	})
+}
+
+func TestProjectResult(t *testing.T) {
+	r := project.NewResultWithRulesChanged("Feature 'login' created and set to define phase.").
+		WithFeature("login", "define").
+		WithWarnings("failed to ensure phase files for login: disk full")
+
+	withOutputFormat(t, OutputText, "create")
+	got := readStdout(t, func() { emit(projectResult(r)) })
+	want := "Feature 'login' created and set to define phase. Cursor rules have been updated.\nWarning: failed to ensure phase files for login: disk full\n"
+	if got != want {
+		t.Errorf("emit(projectResult()) = %q, want %q", got, want)
+	}
+	if len(r.Warnings) != 1 {
+		t.Errorf("projectResult() modified the project result warnings: %v", r.Warnings)
+	}
+}
+
+func TestEmitFailure(t *testing.T) {
+	result := NewResult("[error] mcp-config: broken", map[string]int{"problems": 1}, nil)
+	failure := d3err.New(d3err.GateFailed, "1 problem remains")
+
+	t.Run("text", func(t *testing.T) {
+		withOutputFormat(t, OutputText, "doctor")
+		var err error
+		got := readStdout(t, func() { err = emitFailure(result, failure) })
+		if got != "[error] mcp-config: broken\n" {
+			t.Errorf("emitFailure() text = %q", got)
+		}
+		if err != failure {
+			t.Errorf("emitFailure() error = %v, want the original error", err)
+		}
+	})
+
+	t.Run("json prints a single envelope", func(t *testing.T) {
+		withOutputFormat(t, OutputJSON, "doctor")
+		var err error
+		got := readStdout(t, func() {
+			err = emitFailure(result, failure)
+			ReportError(nil, err)
+		})
+
+		var decoded envelope
+		if jsonErr := json.Unmarshal([]byte(got), &decoded); jsonErr != nil {
+			t.Fatalf("emitFailure() printed more than one JSON document: %v\n%s", jsonErr, got)
+		}
+		if decoded.OK || decoded.Data == nil || decoded.Error == nil || decoded.Error.Code != d3err.GateFailed {
+			t.Errorf("emitFailure() envelope = %+v", decoded)
+		}
+		if ExitCode(err) != ExitGateFailed {
+			t.Errorf("ExitCode(emitFailure()) = %d, want %d", ExitCode(err), ExitGateFailed)
+		}
+	})
+}
+
+func TestReportError(t *testing.T) {
+	newRoot := func() (*cobra.Command, *cobra.Command) {
+		root := &cobra.Command{Use: "d3"}
+		child := &cobra.Command{Use: "status", RunE: func(cmd *cobra.Command, args []string) error { return nil }}
+		root.AddCommand(child)
+		AddOutputFlag(root)
+		return root, child
+	}
+
+	t.Run("json from flag", func(t *testing.T) {
+		withOutputFormat(t, OutputText, "")
+		_, child := newRoot()
+		if err := child.ParseFlags([]string{"--output", "json"}); err != nil {
+			t.Fatalf("ParseFlags() error = %v", err)
+		}
+		got := readStdout(t, func() { ReportError(child, fmt.Errorf("project not initialized")) })
+
+		var decoded envelope
+		if err := json.Unmarshal([]byte(got), &decoded); err != nil {
+			t.Fatalf("ReportError() printed invalid JSON: %v\n%s", err, got)
+		}
+		if decoded.OK || decoded.Command != "status" || decoded.Error == nil || decoded.Error.Message != "project not initialized" {
+			t.Errorf("ReportError() envelope = %+v", decoded)
+		}
+	})
+
+	t.Run("text goes to stderr", func(t *testing.T) {
+		withOutputFormat(t, OutputText, "")
+		_, child := newRoot()
+		got := readStdout(t, func() { ReportError(child, fmt.Errorf("boom")) })
+		if got != "" {
+			t.Errorf("ReportError() printed %q to stdout in text mode", got)
+		}
+	})
+
+	t.Run("pre-run validates format", func(t *testing.T) {
+		withOutputFormat(t, OutputText, "")
+		root, _ := newRoot()
+		root.SetArgs([]string{"status", "--output", "yaml"})
+		root.SetOut(new(bytes.Buffer))
+		root.SetErr(new(bytes.Buffer))
+		if _, err := root.ExecuteC(); err == nil || !strings.Contains(err.Error(), "invalid output format") {
+			t.Errorf("ExecuteC() error = %v, want invalid output format", err)
+		}
+	})
+}
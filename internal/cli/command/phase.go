@@ -9,11 +9,9 @@ import (
 
 	"github.com/spf13/cobra"
 
-	"github.com/imcclaskey/d3/internal/core/feature"
+	"github.com/imcclaskey/d3/internal/core/d3err"
 	"github.com/imcclaskey/d3/internal/core/phase"
 	"github.com/imcclaskey/d3/internal/core/ports"
-	"github.com/imcclaskey/d3/internal/core/projectfiles"
-	"github.com/imcclaskey/d3/internal/core/rules"
 	"github.com/imcclaskey/d3/internal/project"
 )
 
@@ -60,23 +58,20 @@ func NewPhaseMoveCommand() *cobra.Command {
 			case "deliver":
 				targetPhase = phase.Deliver
 			default:
-				return fmt.Errorf("invalid phase: %s (valid phases are: define, design, deliver)", targetPhaseStr)
+				return d3err.New(d3err.InvalidPhase, "invalid phase: %s (valid phases are: define, design, deliver)", targetPhaseStr)
 			}
 
 			projectRoot, err := os.Getwd()
 			if err != nil {
 				return fmt.Errorf("could not determine project root: %w", err)
 			}
-			cfg := NewConfig(projectRoot)
+			cfg, err := NewConfig(projectRoot)
+			if err != nil {
+				return err
+			}
 
 			fs := ports.RealFileSystem{}
-			featureSvc := feature.NewService(cfg.WorkspaceRoot, cfg.FeaturesDir, cfg.D3Dir, fs)
-			phaseSvc := phase.NewService(fs)
-			ruleGenerator := rules.NewRuleGenerator(cfg.WorkspaceRoot, fs)
-			rulesSvc := rules.NewService(cfg.WorkspaceRoot, cfg.CursorRulesDir, ruleGenerator, fs)
-			fileOp := projectfiles.NewDefaultFileOperator()
-
-			cmdRunner.projectSvc = project.New(cfg.WorkspaceRoot, fs, featureSvc, rulesSvc, phaseSvc, fileOp)
+			cmdRunner.projectSvc, _ = wire(cfg, fs)
 
 			return cmdRunner.run(targetPhase)
 		},
@@ -95,11 +90,11 @@ func (c *PhaseMoveCommand) run(targetPhase phase.Phase) error {
 	result, err := c.projectSvc.ChangePhase(ctx, targetPhase)
 	if err != nil {
 		if errors.Is(err, project.ErrNoActiveFeature) {
-			return fmt.Errorf("no active feature. Run 'd3 feature enter <feature-name>' first")
+			return d3err.New(d3err.NoActiveFeature, "no active feature. Run 'd3 feature enter <feature-name>' first")
 		}
 		return err
 	}
 
-	fmt.Println(result.FormatCLI())
+	emit(projectResult(result))
 	return nil
 }
@@ -0,0 +1,105 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imcclaskey/d3/internal/core/feature"
+	"github.com/imcclaskey/d3/internal/core/ports"
+	"github.com/imcclaskey/d3/internal/core/report"
+)
+
+// reportCmdRunner holds dependencies and options for the report command.
+type reportCmdRunner struct {
+	format            string
+	staleAfter        string
+	outputPath        string
+	defaultStaleAfter time.Duration
+	featureSvc        feature.FeatureServicer
+	fs                ports.FileSystem
+	now               func() time.Time
+}
+
+// NewReportCommand creates a new cobra command summarizing every feature in the project.
+func NewReportCommand() *cobra.Command {
+	cmdRunner := &reportCmdRunner{}
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Summarize every feature's phase, age and task completion",
+		Long: `Print a project-wide snapshot: how many features are in each phase, how long each has been in
+its current phase, and task completion for features in deliver. Features with no changes within the
+stale window (default 14d, or D3_STALE_AFTER) are flagged, as are features whose earlier-phase
+documents are still empty.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projectRoot, err := os.Getwd()
+			if err != nil {
+				return fmt.Errorf("could not determine workspace root: %w", err)
+			}
+			cfg, err := NewConfig(projectRoot)
+			if err != nil {
+				return err
+			}
+
+			fs := ports.RealFileSystem{}
+			cmdRunner.featureSvc = newFeatureService(cfg, fs)
+			cmdRunner.fs = fs
+			cmdRunner.defaultStaleAfter = cfg.StaleAfter
+			cmdRunner.now = time.Now
+
+			return cmdRunner.run(context.Background())
+		},
+	}
+	cmd.Flags().StringVarP(&cmdRunner.format, "format", "f", string(report.FormatTable), "Output format: table, markdown or json")
+	cmd.Flags().StringVar(&cmdRunner.staleAfter, "stale-after", "", "Flag features unmodified for longer than this (e.g. 14d, 72h)")
+	cmd.Flags().StringVarP(&cmdRunner.outputPath, "out", "o", "", "Write the report to this file instead of stdout")
+	return cmd
+}
+
+// run builds the report and prints it or writes it to the output file.
+func (c *reportCmdRunner) run(ctx context.Context) error {
+	if c.featureSvc == nil || c.fs == nil || c.now == nil {
+		return fmt.Errorf("services not initialized in reportCmdRunner")
+	}
+
+	format, err := report.ParseFormat(c.format)
+	if err != nil {
+		return err
+	}
+	staleAfter := c.defaultStaleAfter
+	if c.staleAfter != "" || staleAfter == 0 {
+		if staleAfter, err = report.ParseStaleAfter(c.staleAfter); err != nil {
+			return err
+		}
+	}
+
+	r, err := report.Build(ctx, c.fs, c.featureSvc, c.now(), staleAfter)
+	if err != nil {
+		return err
+	}
+	output, err := report.Render(r, format)
+	if err != nil {
+		return err
+	}
+
+	if c.outputPath == "" {
+		emit(NewResult(output, reportData{Report: r}, nil))
+		return nil
+	}
+	if err := c.fs.WriteFile(c.outputPath, []byte(output), 0644); err != nil {
+		return fmt.Errorf("failed to write report to %s: %w", c.outputPath, err)
+	}
+	emit(NewResult(fmt.Sprintf("Report written to %s", c.outputPath), reportData{Report: r, Path: c.outputPath}, nil))
+	return nil
+}
+
+// reportData is the JSON data of the report command: the report itself, whatever --format
+// was chosen, plus the file written with --out.
+type reportData struct {
+	*report.Report
+	Path string `json:"path,omitempty"`
+}
@@ -0,0 +1,89 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/imcclaskey/d3/internal/core/feature"
+	"github.com/imcclaskey/d3/internal/testutil"
+)
+
+func TestReportCmdRunner_run(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		cmd         reportCmdRunner
+		wantErr     bool
+		wantOutput  []string
+		wantFile    string
+		fileContent string
+	}{
+		{
+			name:       "table with default window",
+			cmd:        reportCmdRunner{format: "table", defaultStaleAfter: 14 * 24 * time.Hour},
+			wantOutput: []string{"1 features (define 1, design 0, deliver 0); 1 stale (no changes in 14d).", "login"},
+		},
+		{
+			name:       "stale-after flag overrides default",
+			cmd:        reportCmdRunner{format: "table", staleAfter: "30d", defaultStaleAfter: 14 * 24 * time.Hour},
+			wantOutput: []string{"0 stale (no changes in 30d)"},
+		},
+		{
+			name:        "markdown to file",
+			cmd:         reportCmdRunner{format: "markdown", outputPath: "/p/report.md", defaultStaleAfter: 14 * 24 * time.Hour},
+			wantOutput:  []string{"Report written to /p/report.md"},
+			wantFile:    "/p/report.md",
+			fileContent: "| login | define |",
+		},
+		{
+			name:    "invalid format",
+			cmd:     reportCmdRunner{format: "csv"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid stale window",
+			cmd:     reportCmdRunner{format: "table", staleAfter: "soon"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memFS := testutil.NewMemFS()
+			memFS.AddFile("/p/.d3/features/login/.phase", "define")
+			memFS.SetModTime("/p/.d3/features/login/.phase", now.Add(-20*24*time.Hour))
+
+			cmdInstance := tt.cmd
+			cmdInstance.featureSvc = feature.NewService("/p", "/p/.d3/features", "/p/.d3", memFS)
+			cmdInstance.fs = memFS
+			cmdInstance.now = func() time.Time { return now }
+
+			rPipe, wPipe, restoreStdout := captureStdout(t)
+			err := cmdInstance.run(context.Background())
+			wPipe.Close()
+			restoreStdout()
+			stdoutBuf := new(bytes.Buffer)
+			stdoutBuf.ReadFrom(rPipe)
+			rPipe.Close()
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("reportCmdRunner.run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(stdoutBuf.String(), want) {
+					t.Errorf("reportCmdRunner.run() output = %q, want to contain %q", stdoutBuf.String(), want)
+				}
+			}
+			if tt.wantFile != "" {
+				data, err := memFS.ReadFile(tt.wantFile)
+				if err != nil || !strings.Contains(string(data), tt.fileContent) {
+					t.Errorf("report file = %q, %v; want to contain %q", data, err, tt.fileContent)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imcclaskey/d3/internal/core/ports"
+	"github.com/imcclaskey/d3/internal/project"
+)
+
+// NewRulesCommand creates a new cobra command for managing the generated Cursor rules.
+func NewRulesCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rules",
+		Short: "Manage the generated Cursor rules",
+		Long:  `d3 generates Cursor rules in .cursor/rules/d3 from the active feature and its phase.`,
+	}
+	cmd.AddCommand(newRulesSyncCommand())
+	return cmd
+}
+
+// rulesSyncCmdRunner holds dependencies for the rules sync command.
+type rulesSyncCmdRunner struct {
+	projectSvc project.ProjectService
+}
+
+func newRulesSyncCommand() *cobra.Command {
+	cmdRunner := &rulesSyncCmdRunner{}
+	return &cobra.Command{
+		Use:   "sync",
+		Short: "Rewrite the generated rules that do not match the active feature and phase",
+		Long: `Reconcile .cursor/rules/d3 with the active feature and phase on disk, for example after
+.d3/.feature or a .phase file was edited by hand, a git checkout changed them or the rules directory
+was deleted. Only files that differ are rewritten. The MCP server does this automatically on startup
+and before each tool call.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projectRoot, err := os.Getwd()
+			if err != nil {
+				return fmt.Errorf("could not determine workspace root: %w", err)
+			}
+			cfg, err := NewConfig(projectRoot)
+			if err != nil {
+				return err
+			}
+
+			fs := ports.RealFileSystem{}
+			cmdRunner.projectSvc, _ = wire(cfg, fs)
+
+			return cmdRunner.run(context.Background())
+		},
+	}
+}
+
+// run reconciles the generated rules through the project service.
+func (c *rulesSyncCmdRunner) run(ctx context.Context) error {
+	if c.projectSvc == nil {
+		return fmt.Errorf("project service not initialized in rulesSyncCmdRunner")
+	}
+
+	result, err := c.projectSvc.SyncRules(ctx)
+	if err != nil {
+		return err
+	}
+
+	emit(projectResult(result))
+	return nil
+}
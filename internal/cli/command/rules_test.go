@@ -0,0 +1,63 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/imcclaskey/d3/internal/project"
+)
+
+func TestRulesSyncCmdRunner_run(t *testing.T) {
+	tests := []struct {
+		name                string
+		setupMockProjectSvc func(mockSvc *project.MockProjectService)
+		wantErr             bool
+		wantOutput          string
+	}{
+		{
+			name: "rules rewritten",
+			setupMockProjectSvc: func(mockSvc *project.MockProjectService) {
+				mockSvc.EXPECT().SyncRules(gomock.Any()).
+					Return(project.NewResultWithRulesChanged("Rewrote 2 generated rule file(s) to match the current state.").WithFeature("login", "design"), nil).Times(1)
+			},
+			wantOutput: "Rewrote 2 generated rule file(s) to match the current state. Cursor rules have been updated.\n",
+		},
+		{
+			name: "already in sync",
+			setupMockProjectSvc: func(mockSvc *project.MockProjectService) {
+				mockSvc.EXPECT().SyncRules(gomock.Any()).Return(project.NewResult("Cursor rules are up to date."), nil).Times(1)
+			},
+			wantOutput: "Cursor rules are up to date.\n",
+		},
+		{
+			name: "sync fails",
+			setupMockProjectSvc: func(mockSvc *project.MockProjectService) {
+				mockSvc.EXPECT().SyncRules(gomock.Any()).Return(nil, fmt.Errorf("project not initialized")).Times(1)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withOutputFormat(t, OutputText, "rules sync")
+			ctrl := gomock.NewController(t)
+			mockProjectSvc := project.NewMockProjectService(ctrl)
+			tt.setupMockProjectSvc(mockProjectSvc)
+
+			cmdInstance := &rulesSyncCmdRunner{projectSvc: mockProjectSvc}
+			var err error
+			got := readStdout(t, func() { err = cmdInstance.run(context.Background()) })
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("rulesSyncCmdRunner.run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !strings.Contains(got, tt.wantOutput) {
+				t.Errorf("rulesSyncCmdRunner.run() output = %q, want %q", got, tt.wantOutput)
+			}
+		})
+	}
+}
@@ -3,10 +3,16 @@ package command
 import (
 	"context"
 	"fmt"
+	"io"
+	"log/slog"
 	"os"
 
 	"github.com/spf13/cobra"
 
+	"github.com/imcclaskey/d3/internal/core/config"
+	"github.com/imcclaskey/d3/internal/core/d3err"
+	"github.com/imcclaskey/d3/internal/core/ports"
+	"github.com/imcclaskey/d3/internal/core/watch"
 	"github.com/imcclaskey/d3/internal/mcp"
 )
 
@@ -22,22 +28,67 @@ func NewServeCommand() *cobra.Command {
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			workdirFlag, _ := cmd.Flags().GetString("workdir") // Error can be ignored, defaults to ""
-			return runServe(workdirFlag)
+			logFile, _ := cmd.Flags().GetString("log-file")
+			logLevel, _ := cmd.Flags().GetString("log-level")
+			watchOpts, err := serveWatchOptions(cmd)
+			if err != nil {
+				return err
+			}
+			return runServe(workdirFlag, logFile, logLevel, watchOpts)
 		},
 	}
 
 	// Add a persistent flag for the working directory
 	cmd.PersistentFlags().StringP("workdir", "w", "", "Specify the working directory (project root)")
+	cmd.Flags().String("log-file", "", "Append server logs to this file instead of stderr")
+	cmd.Flags().String("log-level", "info", "Minimum level of server logs: debug, info, warn or error")
+	cmd.Flags().Bool("watch", false, "Regenerate rules and notify the client when project files are edited outside d3")
+	cmd.Flags().StringArray("watch-path", nil, "Additional glob, relative to the project root, to watch (repeatable)")
+	cmd.Flags().Duration("watch-interval", watch.DefaultInterval, "How often watched files are polled")
+	cmd.Flags().Duration("watch-debounce", watch.DefaultDebounce, "How long changes must settle before rules are regenerated")
 
 	return cmd
 }
 
-// runServe handles the serve command execution
-func runServe(workdirFlag string) error {
+// serveWatchOptions returns the watcher options selected by the --watch flags, or nil when
+// --watch is not set.
+func serveWatchOptions(cmd *cobra.Command) (*watch.Options, error) {
+	enabled, _ := cmd.Flags().GetBool("watch")
+	if !enabled {
+		return nil, nil
+	}
+	extra, _ := cmd.Flags().GetStringArray("watch-path")
+	interval, _ := cmd.Flags().GetDuration("watch-interval")
+	debounce, _ := cmd.Flags().GetDuration("watch-debounce")
+
+	if err := watch.ValidatePatterns(extra); err != nil {
+		return nil, err
+	}
+	if interval <= 0 {
+		return nil, d3err.New(d3err.InvalidArgument, "invalid watch interval %s: must be positive", interval)
+	}
+	// Zero would silently select the default in watch.Options, so both settings must be positive
+	if debounce <= 0 {
+		return nil, d3err.New(d3err.InvalidArgument, "invalid watch debounce %s: must be positive", debounce)
+	}
+	return &watch.Options{
+		Patterns: extra,
+		Interval: interval,
+		Debounce: debounce,
+	}, nil
+}
+
+// runServe handles the serve command execution. A nil watchOpts disables the file watcher.
+func runServe(workdirFlag, logFile, logLevel string, watchOpts *watch.Options) error {
 	command := &ServeCommand{}
 
+	logger, closeLog, err := newServeLogger(logFile, logLevel)
+	if err != nil {
+		return err
+	}
+	defer closeLog()
+
 	var workspaceRoot string
-	var err error
 
 	if workdirFlag != "" {
 		workspaceRoot = workdirFlag
@@ -57,7 +108,7 @@ func runServe(workdirFlag string) error {
 		}
 	}
 
-	result, err := command.Run(context.Background(), workspaceRoot)
+	result, err := command.Run(context.Background(), workspaceRoot, logger, watchOpts)
 
 	if err != nil {
 		return err
@@ -69,10 +120,17 @@ func runServe(workdirFlag string) error {
 }
 
 // Run implements a modified Command interface for serve
-func (s *ServeCommand) Run(ctx context.Context, workspaceRoot string) (Result, error) {
-	server := mcp.NewServer(workspaceRoot)
+func (s *ServeCommand) Run(ctx context.Context, workspaceRoot string, logger *slog.Logger, watchOpts *watch.Options) (Result, error) {
+	cfg, err := config.Load(ports.RealFileSystem{}, workspaceRoot)
+	if err != nil {
+		return Result{}, err
+	}
+	server := mcp.NewServer(cfg, logger.With("workspace", workspaceRoot))
+	if watchOpts != nil {
+		server.EnableWatch(*watchOpts)
+	}
 
-	err := mcp.ServeStdio(server)
+	err = mcp.ServeStdio(server)
 
 	if err != nil {
 		return Result{}, fmt.Errorf("failed to serve MCP: %w", err)
@@ -80,3 +138,25 @@ func (s *ServeCommand) Run(ctx context.Context, workspaceRoot string) (Result, e
 
 	return NewResult("MCP server started", nil, nil), nil
 }
+
+// newServeLogger returns the server's logger and a function that closes its destination.
+// Logs go to stderr unless logFile is set; stdout is reserved for the MCP protocol.
+func newServeLogger(logFile, logLevel string) (*slog.Logger, func(), error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(logLevel)); err != nil {
+		return nil, nil, d3err.New(d3err.InvalidArgument, "invalid log level %q: use debug, info, warn or error", logLevel)
+	}
+
+	var w io.Writer = os.Stderr
+	closeLog := func() {}
+	if logFile != "" {
+		f, err := os.OpenFile(logFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open log file: %w", err)
+		}
+		w = f
+		closeLog = func() { _ = f.Close() }
+	}
+
+	return slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{Level: level})), closeLog, nil
+}
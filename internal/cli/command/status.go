@@ -0,0 +1,109 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imcclaskey/d3/internal/core/feature"
+	"github.com/imcclaskey/d3/internal/core/git"
+	"github.com/imcclaskey/d3/internal/core/ports"
+)
+
+// statusCmdRunner holds the dependencies for the status command.
+type statusCmdRunner struct {
+	featureSvc feature.FeatureServicer
+	gitClient  git.Client
+}
+
+// NewStatusCommand creates a new cobra command showing the active feature and its git branch.
+func NewStatusCommand() *cobra.Command {
+	cmdRunner := &statusCmdRunner{}
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show the active feature, its phase and its git branch",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projectRoot, err := os.Getwd()
+			if err != nil {
+				return fmt.Errorf("could not determine workspace root: %w", err)
+			}
+			cfg, err := NewConfig(projectRoot)
+			if err != nil {
+				return err
+			}
+
+			cmdRunner.featureSvc = newFeatureService(cfg, ports.RealFileSystem{})
+			cmdRunner.gitClient = git.New(cfg.WorkspaceRoot)
+
+			return cmdRunner.run(context.Background())
+		},
+	}
+}
+
+// run prints the active feature and warns when the checked-out branch differs from the feature's branch.
+func (c *statusCmdRunner) run(ctx context.Context) error {
+	if c.featureSvc == nil {
+		return fmt.Errorf("feature service not initialized in statusCmdRunner")
+	}
+
+	activeFeature, err := c.featureSvc.GetActiveFeature()
+	if err != nil {
+		return err
+	}
+	if activeFeature == "" {
+		emit(NewResult("No active feature.", statusData{}, nil))
+		return nil
+	}
+
+	currentPhase, err := c.featureSvc.GetFeaturePhase(ctx, activeFeature)
+	if err != nil {
+		return err
+	}
+	data := statusData{Feature: activeFeature, Phase: string(currentPhase)}
+	message := fmt.Sprintf("Active feature: %s (phase: %s)\n", activeFeature, currentPhase)
+
+	branch, err := c.featureSvc.GetFeatureBranch(activeFeature)
+	if err != nil {
+		return err
+	}
+	if branch == "" {
+		emit(NewResult(message+"Branch: none recorded", data, nil))
+		return nil
+	}
+	data.Branch = branch
+	message += fmt.Sprintf("Branch: %s\n", branch)
+
+	if c.gitClient == nil || !c.gitClient.IsRepository(ctx) {
+		emit(NewResult(message, data, nil))
+		return nil
+	}
+	var warnings []string
+	current, err := c.gitClient.CurrentBranch(ctx)
+	switch {
+	case errors.Is(err, git.ErrNoBranch):
+		warnings = append(warnings, fmt.Sprintf("HEAD is detached, expected branch '%s' for feature '%s'.", branch, activeFeature))
+	case err != nil:
+		return err
+	default:
+		data.CurrentBranch = current
+		if current != branch {
+			warnings = append(warnings, fmt.Sprintf("checked-out branch '%s' does not match branch '%s' of active feature '%s'.", current, branch, activeFeature))
+		}
+	}
+	emit(NewResult(message, data, warnings))
+	return nil
+}
+
+// statusData is the JSON data of the status command. Fields are empty when there is no active feature.
+type statusData struct {
+	Feature string `json:"feature,omitempty"`
+	Phase   string `json:"phase,omitempty"`
+	// Branch is the git branch recorded for the feature.
+	Branch string `json:"branch,omitempty"`
+	// CurrentBranch is the branch checked out in the workspace, empty when HEAD is detached.
+	CurrentBranch string `json:"current_branch,omitempty"`
+}
@@ -0,0 +1,109 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	featuremocks "github.com/imcclaskey/d3/internal/core/feature/mocks"
+	"github.com/imcclaskey/d3/internal/core/git"
+	gitmocks "github.com/imcclaskey/d3/internal/core/git/mocks"
+	"github.com/imcclaskey/d3/internal/core/phase"
+)
+
+func TestStatusCmdRunner_run(t *testing.T) {
+	tests := []struct {
+		name               string
+		setupMocks         func(featureSvc *featuremocks.MockFeatureServicer, gitClient *gitmocks.MockClient)
+		wantOutputContains []string
+		wantNoWarning      bool
+	}{
+		{
+			name: "no active feature",
+			setupMocks: func(featureSvc *featuremocks.MockFeatureServicer, gitClient *gitmocks.MockClient) {
+				featureSvc.EXPECT().GetActiveFeature().Return("", nil).Times(1)
+			},
+			wantOutputContains: []string{"No active feature."},
+			wantNoWarning:      true,
+		},
+		{
+			name: "branch matches",
+			setupMocks: func(featureSvc *featuremocks.MockFeatureServicer, gitClient *gitmocks.MockClient) {
+				featureSvc.EXPECT().GetActiveFeature().Return("login", nil).Times(1)
+				featureSvc.EXPECT().GetFeaturePhase(gomock.Any(), "login").Return(phase.Design, nil).Times(1)
+				featureSvc.EXPECT().GetFeatureBranch("login").Return("feature/login", nil).Times(1)
+				gitClient.EXPECT().IsRepository(gomock.Any()).Return(true).Times(1)
+				gitClient.EXPECT().CurrentBranch(gomock.Any()).Return("feature/login", nil).Times(1)
+			},
+			wantOutputContains: []string{"Active feature: login (phase: design)", "Branch: feature/login"},
+			wantNoWarning:      true,
+		},
+		{
+			name: "branch mismatch warns",
+			setupMocks: func(featureSvc *featuremocks.MockFeatureServicer, gitClient *gitmocks.MockClient) {
+				featureSvc.EXPECT().GetActiveFeature().Return("login", nil).Times(1)
+				featureSvc.EXPECT().GetFeaturePhase(gomock.Any(), "login").Return(phase.Define, nil).Times(1)
+				featureSvc.EXPECT().GetFeatureBranch("login").Return("feature/login", nil).Times(1)
+				gitClient.EXPECT().IsRepository(gomock.Any()).Return(true).Times(1)
+				gitClient.EXPECT().CurrentBranch(gomock.Any()).Return("main", nil).Times(1)
+			},
+			wantOutputContains: []string{"Warning: checked-out branch 'main' does not match branch 'feature/login'"},
+		},
+		{
+			name: "detached HEAD warns",
+			setupMocks: func(featureSvc *featuremocks.MockFeatureServicer, gitClient *gitmocks.MockClient) {
+				featureSvc.EXPECT().GetActiveFeature().Return("login", nil).Times(1)
+				featureSvc.EXPECT().GetFeaturePhase(gomock.Any(), "login").Return(phase.Define, nil).Times(1)
+				featureSvc.EXPECT().GetFeatureBranch("login").Return("feature/login", nil).Times(1)
+				gitClient.EXPECT().IsRepository(gomock.Any()).Return(true).Times(1)
+				gitClient.EXPECT().CurrentBranch(gomock.Any()).Return("", git.ErrNoBranch).Times(1)
+			},
+			wantOutputContains: []string{"Warning: HEAD is detached"},
+		},
+		{
+			name: "no recorded branch",
+			setupMocks: func(featureSvc *featuremocks.MockFeatureServicer, gitClient *gitmocks.MockClient) {
+				featureSvc.EXPECT().GetActiveFeature().Return("login", nil).Times(1)
+				featureSvc.EXPECT().GetFeaturePhase(gomock.Any(), "login").Return(phase.Define, nil).Times(1)
+				featureSvc.EXPECT().GetFeatureBranch("login").Return("", nil).Times(1)
+			},
+			wantOutputContains: []string{"Branch: none recorded"},
+			wantNoWarning:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockFeatureSvc := featuremocks.NewMockFeatureServicer(ctrl)
+			mockGit := gitmocks.NewMockClient(ctrl)
+			tt.setupMocks(mockFeatureSvc, mockGit)
+
+			runner := &statusCmdRunner{featureSvc: mockFeatureSvc, gitClient: mockGit}
+
+			rPipe, wPipe, restoreStdout := captureStdout(t)
+			err := runner.run(context.Background())
+			wPipe.Close()
+			restoreStdout()
+			stdoutBuf := new(bytes.Buffer)
+			stdoutBuf.ReadFrom(rPipe)
+			rPipe.Close()
+			output := stdoutBuf.String()
+
+			if err != nil {
+				t.Fatalf("statusCmdRunner.run() unexpected error: %v", err)
+			}
+			for _, want := range tt.wantOutputContains {
+				if !strings.Contains(output, want) {
+					t.Errorf("statusCmdRunner.run() output = %q, want to contain %q", output, want)
+				}
+			}
+			if tt.wantNoWarning && strings.Contains(output, "Warning") {
+				t.Errorf("statusCmdRunner.run() output = %q, want no warning", output)
+			}
+		})
+	}
+}
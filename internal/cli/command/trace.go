@@ -0,0 +1,88 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imcclaskey/d3/internal/core/d3err"
+	"github.com/imcclaskey/d3/internal/core/feature"
+	"github.com/imcclaskey/d3/internal/core/ports"
+	"github.com/imcclaskey/d3/internal/core/trace"
+	"github.com/imcclaskey/d3/internal/project"
+)
+
+// traceCmdRunner holds dependencies and options for the trace command.
+type traceCmdRunner struct {
+	featureName string
+	check       bool
+	projectSvc  project.ProjectService
+}
+
+// NewTraceCommand creates a new cobra command reporting requirement traceability for a feature.
+func NewTraceCommand() *cobra.Command {
+	cmdRunner := &traceCmdRunner{}
+	cmd := &cobra.Command{
+		Use:   "trace [name]",
+		Short: "Report how a feature's requirements are covered by plan steps and tasks",
+		Long: `Link the requirement IDs declared in problem.md (list items starting with an ID such as R1 or
+AC-2) to the plan.md steps and progress.yaml tasks that reference them. The report lists
+requirements no step or task covers, plan steps with no tasks and references to IDs that are never
+declared. Without a name, the active feature is traced.
+
+With --check, the command fails with the gate_failed code while gaps remain, so that it can run
+as a pre_phase_change hook.`,
+		Args: cobra.MaximumNArgs(1),
+		// Gaps are the report itself, not a misuse of the command
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 1 {
+				cmdRunner.featureName = args[0]
+			}
+
+			projectRoot, err := os.Getwd()
+			if err != nil {
+				return fmt.Errorf("could not determine workspace root: %w", err)
+			}
+			cfg, err := NewConfig(projectRoot)
+			if err != nil {
+				return err
+			}
+
+			cmdRunner.projectSvc, _ = wire(cfg, ports.RealFileSystem{})
+
+			return cmdRunner.run(context.Background())
+		},
+	}
+	cmd.Flags().BoolVar(&cmdRunner.check, "check", false, "Fail while uncovered requirements, steps without tasks or dangling references remain")
+	return cmd
+}
+
+// run traces the feature, prints the report and, with --check, fails when it found gaps.
+func (c *traceCmdRunner) run(ctx context.Context) error {
+	if c.projectSvc == nil {
+		return fmt.Errorf("project service not initialized in traceCmdRunner")
+	}
+
+	featureName := c.featureName
+	if featureName != "" {
+		var err error
+		if featureName, err = feature.NormalizeName(featureName); err != nil {
+			return err
+		}
+	}
+
+	report, err := c.projectSvc.TraceFeature(ctx, featureName)
+	if err != nil {
+		return err
+	}
+
+	result := NewResult(trace.Render(report), report, nil)
+	if c.check && !report.OK() {
+		return emitFailure(result, d3err.New(d3err.GateFailed, "%d traceability gap(s) remain in '%s'", len(report.Issues()), report.Feature))
+	}
+	emit(result)
+	return nil
+}
@@ -0,0 +1,89 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/imcclaskey/d3/internal/core/d3err"
+	"github.com/imcclaskey/d3/internal/core/progress"
+	"github.com/imcclaskey/d3/internal/core/trace"
+	"github.com/imcclaskey/d3/internal/project"
+)
+
+func TestTraceCmdRunner_run(t *testing.T) {
+	gapped := trace.Analyze("login", "- R1: User can log in\n- R2: User can log out\n", "- S1 [code] Add the form (R1)\n", []progress.Task{{ID: "1", Refs: []string{"S1"}}})
+	complete := trace.Analyze("login", "- R1: User can log in\n", "- S1 [code] Add the form (R1)\n", []progress.Task{{ID: "1", Refs: []string{"S1"}}})
+
+	tests := []struct {
+		name               string
+		cmd                traceCmdRunner
+		report             *trace.Report
+		traceErr           error
+		wantFeature        string
+		wantCode           d3err.Code
+		wantOutputContains string
+	}{
+		{
+			name:               "reports gaps without failing",
+			cmd:                traceCmdRunner{},
+			report:             gapped,
+			wantOutputContains: "Uncovered requirements:\n  - R2: User can log out",
+		},
+		{
+			name:        "check fails on gaps",
+			cmd:         traceCmdRunner{featureName: "Login", check: true},
+			report:      gapped,
+			wantFeature: "login",
+			wantCode:    d3err.GateFailed,
+		},
+		{
+			name:               "check passes without gaps",
+			cmd:                traceCmdRunner{check: true},
+			report:             complete,
+			wantOutputContains: "No gaps found.",
+		},
+		{
+			name:     "no active feature",
+			cmd:      traceCmdRunner{},
+			traceErr: project.ErrNoActiveFeature,
+			wantCode: d3err.NoActiveFeature,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockProjectSvc := project.NewMockProjectService(ctrl)
+			mockProjectSvc.EXPECT().TraceFeature(gomock.Any(), tt.wantFeature).Return(tt.report, tt.traceErr)
+
+			cmdInstance := tt.cmd
+			cmdInstance.projectSvc = mockProjectSvc
+
+			rPipe, wPipe, restoreStdout := captureStdout(t)
+			err := cmdInstance.run(context.Background())
+			wPipe.Close()
+			restoreStdout()
+			stdoutBuf := new(bytes.Buffer)
+			stdoutBuf.ReadFrom(rPipe)
+			rPipe.Close()
+
+			if tt.wantCode == "" && err != nil {
+				t.Fatalf("traceCmdRunner.run() error = %v", err)
+			}
+			if tt.wantCode != "" && d3err.CodeOf(err) != tt.wantCode {
+				t.Fatalf("traceCmdRunner.run() error = %v, want code %s", err, tt.wantCode)
+			}
+			if tt.wantCode == d3err.GateFailed && !errors.Is(err, d3err.ErrGateFailed) {
+				t.Errorf("traceCmdRunner.run() error = %v, want it to match ErrGateFailed", err)
+			}
+			if !strings.Contains(stdoutBuf.String(), tt.wantOutputContains) {
+				t.Errorf("traceCmdRunner.run() output = %q, want to contain %q", stdoutBuf.String(), tt.wantOutputContains)
+			}
+		})
+	}
+}
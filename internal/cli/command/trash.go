@@ -0,0 +1,177 @@
+package command
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imcclaskey/d3/internal/core/d3err"
+	"github.com/imcclaskey/d3/internal/core/feature"
+	"github.com/imcclaskey/d3/internal/core/ports"
+)
+
+// NewTrashCommand creates a new cobra command for managing deleted features.
+func NewTrashCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "trash",
+		Short: "Manage deleted features held in the trash",
+		Long:  `Deleted features are moved to .d3/.trash and can be restored with 'd3 feature restore' until the trash is emptied.`,
+	}
+	cmd.AddCommand(newTrashListCommand())
+	cmd.AddCommand(newTrashEmptyCommand())
+	return cmd
+}
+
+// trashCmdRunner holds the dependencies and options shared by the trash subcommands.
+type trashCmdRunner struct {
+	olderThan  string
+	yes        bool
+	featureSvc feature.FeatureServicer
+}
+
+// newTrashFeatureService wires the feature service used by the trash subcommands.
+func newTrashFeatureService() (feature.FeatureServicer, error) {
+	projectRoot, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("could not determine workspace root: %w", err)
+	}
+	cfg, err := NewConfig(projectRoot)
+	if err != nil {
+		return nil, err
+	}
+	featureSvc := newFeatureService(cfg, ports.RealFileSystem{})
+	return featureSvc, nil
+}
+
+func newTrashListCommand() *cobra.Command {
+	cmdRunner := &trashCmdRunner{}
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List deleted features",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			featureSvc, err := newTrashFeatureService()
+			if err != nil {
+				return err
+			}
+			cmdRunner.featureSvc = featureSvc
+			return cmdRunner.runList(context.Background())
+		},
+	}
+}
+
+func newTrashEmptyCommand() *cobra.Command {
+	cmdRunner := &trashCmdRunner{}
+	cmd := &cobra.Command{
+		Use:   "empty",
+		Short: "Permanently remove deleted features from the trash",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			featureSvc, err := newTrashFeatureService()
+			if err != nil {
+				return err
+			}
+			cmdRunner.featureSvc = featureSvc
+			return cmdRunner.runEmpty(context.Background())
+		},
+	}
+	cmd.Flags().StringVar(&cmdRunner.olderThan, "older-than", "", "Only remove entries deleted longer ago than this (e.g. 30d, 12h). Removes everything when omitted")
+	cmd.Flags().BoolVarP(&cmdRunner.yes, "yes", "y", false, "Skip the confirmation prompt (required with --output json)")
+	return cmd
+}
+
+// runList prints every trash entry, newest first.
+func (c *trashCmdRunner) runList(ctx context.Context) error {
+	if c.featureSvc == nil {
+		return fmt.Errorf("feature service not initialized in trashCmdRunner")
+	}
+
+	entries, err := c.featureSvc.ListTrash(ctx)
+	if err != nil {
+		return err
+	}
+	data := trashListData{Entries: []trashEntry{}}
+	var message strings.Builder
+	if len(entries) == 0 {
+		message.WriteString("Trash is empty.")
+	}
+	for _, entry := range entries {
+		fmt.Fprintf(&message, "%s\tdeleted %s\n", entry.Name, entry.DeletedAt.Local().Format(time.RFC3339))
+		data.Entries = append(data.Entries, trashEntry{Name: entry.Name, DeletedAt: entry.DeletedAt.UTC(), Path: entry.Path})
+	}
+	emit(NewResult(message.String(), data, nil))
+	return nil
+}
+
+// trashListData is the JSON data of the trash list command.
+type trashListData struct {
+	Entries []trashEntry `json:"entries"`
+}
+
+// trashEntry is one deleted feature, newest first.
+type trashEntry struct {
+	Name      string    `json:"name"`
+	DeletedAt time.Time `json:"deleted_at"`
+	Path      string    `json:"path"`
+}
+
+// trashEmptyData is the JSON data of the trash empty command.
+type trashEmptyData struct {
+	// Removed lists the trash directories that were deleted.
+	Removed []string `json:"removed"`
+	// Emptied is false when the confirmation prompt was declined.
+	Emptied bool `json:"emptied"`
+}
+
+// runEmpty removes trash entries, optionally limited to those older than c.olderThan.
+func (c *trashCmdRunner) runEmpty(ctx context.Context) error {
+	if c.featureSvc == nil {
+		return fmt.Errorf("feature service not initialized in trashCmdRunner")
+	}
+
+	var olderThan time.Duration
+	if c.olderThan != "" {
+		d, err := feature.ParseTrashRetention(c.olderThan)
+		if err != nil {
+			return err
+		}
+		olderThan = d
+	}
+
+	if !c.yes && JSONOutput() {
+		return d3err.New(d3err.InvalidArgument, "emptying the trash with --output json requires --yes")
+	}
+	if !c.yes && !c.confirm() {
+		emit(NewResult("Emptying the trash cancelled.", trashEmptyData{Removed: []string{}}, nil))
+		return nil
+	}
+
+	removed, err := c.featureSvc.EmptyTrash(ctx, olderThan)
+	if err != nil {
+		return err
+	}
+	paths := []string{}
+	for _, entry := range removed {
+		paths = append(paths, entry.Path)
+	}
+	emit(NewResult(fmt.Sprintf("Removed %d feature(s) from the trash.", len(removed)), trashEmptyData{Removed: paths, Emptied: true}, nil))
+	return nil
+}
+
+// confirm asks before permanently removing trash entries.
+func (c *trashCmdRunner) confirm() bool {
+	reader := bufio.NewReader(os.Stdin)
+	if c.olderThan != "" {
+		fmt.Printf("Are you sure you want to permanently delete the features trashed more than %s ago? This action cannot be undone. [y/N]: ", c.olderThan)
+	} else {
+		fmt.Printf("Are you sure you want to permanently delete every feature in the trash? This action cannot be undone. [y/N]: ")
+	}
+	input, _ := reader.ReadString('\n')
+	input = strings.TrimSpace(strings.ToLower(input))
+	return input == "y" || input == "yes"
+}
@@ -0,0 +1,185 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/imcclaskey/d3/internal/core/feature"
+	featuremocks "github.com/imcclaskey/d3/internal/core/feature/mocks"
+)
+
+func TestTrashCmdRunner_runList(t *testing.T) {
+	deletedAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name               string
+		format             OutputFormat
+		entries            []feature.TrashEntry
+		wantOutputContains string
+	}{
+		{
+			name:               "empty trash",
+			wantOutputContains: "Trash is empty.",
+		},
+		{
+			name:               "lists entries",
+			entries:            []feature.TrashEntry{{Name: "login", Path: "/p/.d3/.trash/login-20261001T120000.000Z", DeletedAt: deletedAt}},
+			wantOutputContains: "login\tdeleted ",
+		},
+		{
+			name:               "json output",
+			format:             OutputJSON,
+			entries:            []feature.TrashEntry{{Name: "login", Path: "/p/.d3/.trash/login-20261001T120000.000Z", DeletedAt: deletedAt}},
+			wantOutputContains: `"deleted_at": "2026-10-01T12:00:00Z"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockFeatureSvc := featuremocks.NewMockFeatureServicer(ctrl)
+			mockFeatureSvc.EXPECT().ListTrash(gomock.Any()).Return(tt.entries, nil).Times(1)
+			if tt.format != "" {
+				withOutputFormat(t, tt.format, "trash list")
+			}
+
+			cmdRunner := &trashCmdRunner{featureSvc: mockFeatureSvc}
+
+			rStdout, wStdout, cleanupStdout := captureStdout(t)
+			defer cleanupStdout()
+
+			err := cmdRunner.runList(context.Background())
+
+			wStdout.Close()
+			var buf bytes.Buffer
+			_, _ = io.Copy(&buf, rStdout)
+			rStdout.Close()
+			output := buf.String()
+
+			if err != nil {
+				t.Fatalf("trashCmdRunner.runList() error = %v", err)
+			}
+			if !strings.Contains(output, tt.wantOutputContains) {
+				t.Errorf("trashCmdRunner.runList() output = %q, want to contain %q", output, tt.wantOutputContains)
+			}
+		})
+	}
+}
+
+func TestTrashCmdRunner_runEmpty(t *testing.T) {
+	removed := []feature.TrashEntry{{Name: "login", Path: "/p/.d3/.trash/login-20261001T120000.000Z"}}
+	tests := []struct {
+		name               string
+		olderThan          string
+		yes                bool
+		format             OutputFormat
+		userInput          string
+		setupMockSvc       func(mockSvc *featuremocks.MockFeatureServicer)
+		wantErr            bool
+		wantExit           int
+		wantOutputContains string
+	}{
+		{
+			name:      "empties everything after y confirmation",
+			userInput: "y",
+			setupMockSvc: func(mockSvc *featuremocks.MockFeatureServicer) {
+				mockSvc.EXPECT().EmptyTrash(gomock.Any(), time.Duration(0)).Return(removed, nil).Times(1)
+			},
+			wantOutputContains: "Removed 1 feature(s) from the trash.",
+		},
+		{
+			name:               "cancelled with n",
+			userInput:          "n",
+			wantOutputContains: "Emptying the trash cancelled.",
+		},
+		{
+			name:               "cancelled with empty input",
+			userInput:          "",
+			wantOutputContains: "permanently delete every feature in the trash",
+		},
+		{
+			name:      "older than limits the removal",
+			olderThan: "7d",
+			yes:       true,
+			setupMockSvc: func(mockSvc *featuremocks.MockFeatureServicer) {
+				mockSvc.EXPECT().EmptyTrash(gomock.Any(), 7*24*time.Hour).Return(nil, nil).Times(1)
+			},
+			wantOutputContains: "Removed 0 feature(s) from the trash.",
+		},
+		{
+			name:      "invalid older than",
+			olderThan: "a week",
+			yes:       true,
+			wantErr:   true,
+			wantExit:  ExitInvalidArgument,
+		},
+		{
+			name: "service error",
+			yes:  true,
+			setupMockSvc: func(mockSvc *featuremocks.MockFeatureServicer) {
+				mockSvc.EXPECT().EmptyTrash(gomock.Any(), time.Duration(0)).Return(nil, fmt.Errorf("permission denied")).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name:      "json output requires yes",
+			format:    OutputJSON,
+			userInput: "y",
+			wantErr:   true,
+			wantExit:  ExitInvalidArgument,
+		},
+		{
+			name:   "json output with yes",
+			yes:    true,
+			format: OutputJSON,
+			setupMockSvc: func(mockSvc *featuremocks.MockFeatureServicer) {
+				mockSvc.EXPECT().EmptyTrash(gomock.Any(), time.Duration(0)).Return(removed, nil).Times(1)
+			},
+			wantOutputContains: `"emptied": true`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockFeatureSvc := featuremocks.NewMockFeatureServicer(ctrl)
+			if tt.setupMockSvc != nil {
+				tt.setupMockSvc(mockFeatureSvc)
+			}
+			if tt.format != "" {
+				withOutputFormat(t, tt.format, "trash empty")
+			}
+
+			cmdRunner := &trashCmdRunner{olderThan: tt.olderThan, yes: tt.yes, featureSvc: mockFeatureSvc}
+
+			cleanupStdin := mockStdin(t, tt.userInput)
+			defer cleanupStdin()
+
+			rStdout, wStdout, cleanupStdout := captureStdout(t)
+			defer cleanupStdout()
+
+			err := cmdRunner.runEmpty(context.Background())
+
+			wStdout.Close()
+			var buf bytes.Buffer
+			_, _ = io.Copy(&buf, rStdout)
+			rStdout.Close()
+			output := buf.String()
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("trashCmdRunner.runEmpty() error = %v, wantErr %v\nOutput:\n%s", err, tt.wantErr, output)
+			}
+			if tt.wantExit != 0 && ExitCode(err) != tt.wantExit {
+				t.Errorf("ExitCode(trashCmdRunner.runEmpty()) = %d, want %d", ExitCode(err), tt.wantExit)
+			}
+			if !strings.Contains(output, tt.wantOutputContains) {
+				t.Errorf("trashCmdRunner.runEmpty() output = %q, want to contain %q", output, tt.wantOutputContains)
+			}
+		})
+	}
+}
@@ -3,7 +3,16 @@ package command
 
 import (
 	"context"
-	"path/filepath"
+	"io"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/imcclaskey/d3/internal/core/config"
+	"github.com/imcclaskey/d3/internal/core/feature"
+	"github.com/imcclaskey/d3/internal/core/ports"
+	"github.com/imcclaskey/d3/internal/core/report"
+	"github.com/imcclaskey/d3/internal/project"
 )
 
 // Config holds common configuration used by all commands
@@ -16,20 +25,64 @@ type Config struct {
 	FeaturesDir string
 	// CursorRulesDir is the path to the cursor rules directory
 	CursorRulesDir string
+	// TrashRetention is how long deleted features stay in the trash
+	TrashRetention time.Duration
+	// BranchPattern is the git branch name pattern for features, with a {name} placeholder
+	BranchPattern string
+	// StaleAfter is how long a feature may go unmodified before reports flag it as stale
+	StaleAfter time.Duration
+	// Logger receives warnings from services about problems that do not fail the command
+	Logger *slog.Logger
+	// Project is the resolved .d3/config.yaml the paths above were taken from
+	Project config.Config
 }
 
-// NewConfig creates a configuration with all needed dependencies
-func NewConfig(workspaceRoot string) Config {
-	d3Dir := filepath.Join(workspaceRoot, ".d3")
-	featuresDir := filepath.Join(d3Dir, "features")
-	cursorRulesDir := filepath.Join(workspaceRoot, ".cursor", "rules")
+// NewConfig creates a configuration with all needed dependencies. It fails when
+// .d3/config.yaml exists but is invalid.
+func NewConfig(workspaceRoot string) (Config, error) {
+	projectCfg, err := config.Load(ports.RealFileSystem{}, workspaceRoot)
+	if err != nil {
+		return Config{}, err
+	}
+
+	// An unparsable override falls back to the configured retention rather than failing every command
+	trashRetention := projectCfg.TrashRetention
+	if value := os.Getenv(feature.TrashRetentionEnv); value != "" {
+		if d, err := feature.ParseTrashRetention(value); err == nil {
+			trashRetention = d
+		}
+	}
+	staleAfter, err := report.ParseStaleAfter(os.Getenv(report.StaleAfterEnv))
+	if err != nil {
+		staleAfter = report.DefaultStaleAfter
+	}
 
 	return Config{
 		WorkspaceRoot:  workspaceRoot,
-		D3Dir:          d3Dir,
-		FeaturesDir:    featuresDir,
-		CursorRulesDir: cursorRulesDir,
-	}
+		D3Dir:          projectCfg.D3Dir,
+		FeaturesDir:    projectCfg.FeaturesDir,
+		CursorRulesDir: projectCfg.CursorRulesDir,
+		TrashRetention: trashRetention,
+		BranchPattern:  os.Getenv(feature.BranchPatternEnv),
+		StaleAfter:     staleAfter,
+		Logger:         newLogger(os.Stderr),
+		Project:        projectCfg,
+	}, nil
+}
+
+// newLogger returns the logger handed to services. It writes plain key=value lines without
+// timestamps, which read better than the default log format in a terminal. Only warnings and
+// errors are shown; routine progress is already reported by each command's result.
+func newLogger(w io.Writer) *slog.Logger {
+	return slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{
+		Level: slog.LevelWarn,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
 }
 
 // Command defines the interface for all d3 commands
@@ -57,3 +110,38 @@ func NewResult(message string, data interface{}, warnings []string) Result {
 		Warnings: warnings,
 	}
 }
+
+// wire builds the project and its services for the current session: paths, initial phase and
+// project files from the resolved configuration, trash retention from the config, and the
+// active feature store resolved from the environment and git worktree.
+func wire(cfg Config, fs ports.FileSystem) (*project.Project, *project.Services) {
+	return project.Wire(cfg.project(), fs, project.WireOptions{Logger: cfg.logger(), TrashRetention: &cfg.TrashRetention})
+}
+
+// newFeatureService creates a feature service wired as for a full project.
+func newFeatureService(cfg Config, fs ports.FileSystem) *feature.Service {
+	_, services := wire(cfg, fs)
+	return services.Features
+}
+
+// project returns the resolved project configuration, or the defaults for a Config built by
+// hand, with its paths taken from the Config's fields.
+func (c Config) project() config.Config {
+	projectCfg := c.Project
+	if projectCfg.ProjectRoot == "" {
+		projectCfg = config.Default(c.WorkspaceRoot)
+	}
+	projectCfg.ProjectRoot = c.WorkspaceRoot
+	projectCfg.D3Dir = c.D3Dir
+	projectCfg.FeaturesDir = c.FeaturesDir
+	projectCfg.CursorRulesDir = c.CursorRulesDir
+	return projectCfg
+}
+
+// logger returns the configured logger, or the default logger for a Config built by hand.
+func (c Config) logger() *slog.Logger {
+	if c.Logger == nil {
+		return slog.Default()
+	}
+	return c.Logger
+}
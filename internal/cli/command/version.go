@@ -0,0 +1,26 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/imcclaskey/d3/internal/version"
+)
+
+// versionData is the JSON data of the version command.
+type versionData struct {
+	Version string `json:"version"`
+}
+
+// NewVersionCommand creates a new cobra command that prints the d3 version.
+func NewVersionCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "version",
+		Short: "Print the version of d3",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			emit(NewResult(fmt.Sprintf("d3 version %s", version.Version), versionData{Version: version.Version}, nil))
+		},
+	}
+}
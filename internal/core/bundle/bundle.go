@@ -0,0 +1,241 @@
+// Package bundle reads and writes portable feature bundles: a gzipped tar archive holding a
+// feature directory and a manifest describing where it came from.
+package bundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// FormatVersion is the bundle layout version written to new manifests.
+const FormatVersion = 1
+
+// Extension is the conventional file extension for bundles.
+const Extension = ".d3.tar.gz"
+
+const (
+	manifestEntry = "manifest.json"
+	featurePrefix = "feature/"
+	// maxEntrySize guards against decompression bombs; phase documents are small.
+	maxEntrySize = 32 << 20
+)
+
+// File is a single file of the feature directory, addressed by slash-separated relative path.
+type File struct {
+	Path string
+	Data []byte
+}
+
+// FileEntry records a bundled file in the manifest.
+type FileEntry struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Manifest describes a bundle's contents and origin.
+type Manifest struct {
+	FormatVersion int         `json:"format_version"`
+	Name          string      `json:"name"`
+	Phase         string      `json:"phase"`
+	D3Version     string      `json:"d3_version"`
+	SourceProject string      `json:"source_project"`
+	CreatedAt     time.Time   `json:"created_at"`
+	Files         []FileEntry `json:"files"`
+	// Checksum is the SHA-256 over every file's path and hash, so it covers the whole feature.
+	Checksum string `json:"checksum"`
+}
+
+// Bundle is a decoded, verified bundle.
+type Bundle struct {
+	Manifest Manifest
+	Files    []File
+}
+
+// Write encodes manifest and files as a gzipped tar archive. The manifest's Files and
+// Checksum fields are computed from files; any values already set are replaced.
+func Write(manifest Manifest, files []File) ([]byte, error) {
+	files = append([]File(nil), files...)
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+
+	manifest.FormatVersion = FormatVersion
+	manifest.Files = make([]FileEntry, 0, len(files))
+	for _, f := range files {
+		if err := validatePath(f.Path); err != nil {
+			return nil, err
+		}
+		manifest.Files = append(manifest.Files, FileEntry{Path: f.Path, Size: int64(len(f.Data)), SHA256: hashHex(f.Data)})
+	}
+	manifest.Checksum = checksum(manifest.Files)
+
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode bundle manifest: %w", err)
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	modTime := manifest.CreatedAt.UTC()
+	if err := writeEntry(tw, manifestEntry, manifestData, modTime); err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		if err := writeEntry(tw, featurePrefix+f.Path, f.Data, modTime); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finish bundle archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress bundle: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// writeEntry adds a regular file to the archive.
+func writeEntry(tw *tar.Writer, name string, data []byte, modTime time.Time) error {
+	header := &tar.Header{
+		Name:     name,
+		Mode:     0644,
+		Size:     int64(len(data)),
+		ModTime:  modTime,
+		Typeflag: tar.TypeReg,
+		Format:   tar.FormatPAX,
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("failed to write bundle entry %s: %w", name, err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return fmt.Errorf("failed to write bundle entry %s: %w", name, err)
+	}
+	return nil
+}
+
+// Read decodes a bundle and verifies every file against the manifest.
+// Archives containing links, absolute paths or paths escaping the feature directory are rejected.
+func Read(data []byte) (*Bundle, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("not a d3 bundle: %w", err)
+	}
+	defer gz.Close()
+
+	var manifestData []byte
+	contents := map[string][]byte{}
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read bundle: %w", err)
+		}
+		if header.Typeflag == tar.TypeDir {
+			continue
+		}
+		if header.Typeflag != tar.TypeReg {
+			return nil, fmt.Errorf("bundle entry %s is not a regular file", header.Name)
+		}
+		if header.Size > maxEntrySize {
+			return nil, fmt.Errorf("bundle entry %s is too large (%d bytes)", header.Name, header.Size)
+		}
+		entryData, err := io.ReadAll(io.LimitReader(tr, maxEntrySize))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read bundle entry %s: %w", header.Name, err)
+		}
+
+		if header.Name == manifestEntry {
+			manifestData = entryData
+			continue
+		}
+		rel, ok := strings.CutPrefix(header.Name, featurePrefix)
+		if !ok {
+			return nil, fmt.Errorf("unexpected bundle entry %s", header.Name)
+		}
+		if err := validatePath(rel); err != nil {
+			return nil, err
+		}
+		if _, dup := contents[rel]; dup {
+			return nil, fmt.Errorf("bundle contains %s more than once", rel)
+		}
+		contents[rel] = entryData
+	}
+
+	if manifestData == nil {
+		return nil, fmt.Errorf("bundle is missing %s", manifestEntry)
+	}
+	var manifest Manifest
+	if err := json.Unmarshal(manifestData, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid bundle manifest: %w", err)
+	}
+	if manifest.FormatVersion < 1 || manifest.FormatVersion > FormatVersion {
+		return nil, fmt.Errorf("unsupported bundle format version %d (this d3 reads up to %d)", manifest.FormatVersion, FormatVersion)
+	}
+
+	if err := verify(manifest, contents); err != nil {
+		return nil, err
+	}
+
+	b := &Bundle{Manifest: manifest, Files: make([]File, 0, len(manifest.Files))}
+	for _, entry := range manifest.Files {
+		b.Files = append(b.Files, File{Path: entry.Path, Data: contents[entry.Path]})
+	}
+	return b, nil
+}
+
+// verify checks that the archive holds exactly the manifest's files with matching hashes.
+func verify(manifest Manifest, contents map[string][]byte) error {
+	if checksum(manifest.Files) != manifest.Checksum {
+		return fmt.Errorf("bundle checksum mismatch: manifest has been modified")
+	}
+	if len(manifest.Files) != len(contents) {
+		return fmt.Errorf("bundle checksum mismatch: manifest lists %d files, archive has %d", len(manifest.Files), len(contents))
+	}
+	for _, entry := range manifest.Files {
+		data, ok := contents[entry.Path]
+		if !ok {
+			return fmt.Errorf("bundle is missing %s", entry.Path)
+		}
+		if int64(len(data)) != entry.Size || hashHex(data) != entry.SHA256 {
+			return fmt.Errorf("bundle checksum mismatch for %s", entry.Path)
+		}
+	}
+	return nil
+}
+
+// validatePath rejects paths that would land outside the feature directory when extracted.
+func validatePath(p string) error {
+	if p == "" || strings.Contains(p, "\\") || path.IsAbs(p) || path.Clean(p) != p || p == ".." || strings.HasPrefix(p, "../") {
+		return fmt.Errorf("invalid path %q in bundle", p)
+	}
+	return nil
+}
+
+// checksum hashes the sorted path and file hash pairs.
+func checksum(entries []FileEntry) string {
+	lines := make([]string, 0, len(entries))
+	for _, e := range entries {
+		lines = append(lines, e.Path+"\x00"+e.SHA256+"\n")
+	}
+	sort.Strings(lines)
+	return hashHex([]byte(strings.Join(lines, "")))
+}
+
+func hashHex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
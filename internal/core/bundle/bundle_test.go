@@ -0,0 +1,173 @@
+package bundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testManifest = Manifest{
+	Name:          "login",
+	Phase:         "design",
+	D3Version:     "0.2.0",
+	SourceProject: "planning",
+	CreatedAt:     time.Date(2025, 5, 1, 12, 30, 0, 0, time.UTC),
+}
+
+func TestWriteRead_RoundTrip(t *testing.T) {
+	files := []File{
+		{Path: "design/plan.md", Data: []byte("# Plan\n")},
+		{Path: ".phase", Data: []byte("design")},
+		{Path: "define/problem.md", Data: []byte("# Problem\n")},
+	}
+
+	data, err := Write(testManifest, files)
+	if err != nil {
+		t.Fatalf("Write() unexpected error: %v", err)
+	}
+	again, err := Write(testManifest, files)
+	if err != nil {
+		t.Fatalf("Write() unexpected error: %v", err)
+	}
+	if !bytes.Equal(data, again) {
+		t.Errorf("Write() is not deterministic for identical input")
+	}
+
+	b, err := Read(data)
+	if err != nil {
+		t.Fatalf("Read() unexpected error: %v", err)
+	}
+	m := b.Manifest
+	if m.FormatVersion != FormatVersion || m.Name != "login" || m.Phase != "design" || m.SourceProject != "planning" || m.D3Version != "0.2.0" {
+		t.Errorf("Read() manifest = %+v", m)
+	}
+	if m.Checksum == "" || len(m.Files) != 3 {
+		t.Errorf("Read() manifest files = %+v, checksum %q", m.Files, m.Checksum)
+	}
+	wantOrder := []string{".phase", "define/problem.md", "design/plan.md"}
+	for i, f := range b.Files {
+		if f.Path != wantOrder[i] {
+			t.Errorf("Read() file %d = %s, want %s", i, f.Path, wantOrder[i])
+		}
+	}
+	if string(b.Files[2].Data) != "# Plan\n" {
+		t.Errorf("Read() plan content = %q", b.Files[2].Data)
+	}
+}
+
+func TestWrite_RejectsUnsafePaths(t *testing.T) {
+	for _, p := range []string{"../escape.md", "/etc/passwd", "a/../../b", "", `define\problem.md`} {
+		if _, err := Write(testManifest, []File{{Path: p, Data: []byte("x")}}); err == nil {
+			t.Errorf("Write() with path %q expected error, got nil", p)
+		}
+	}
+}
+
+// archive builds a raw bundle so tests can craft invalid contents.
+func archive(t *testing.T, entries map[string]string, extra ...*tar.Header) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range entries {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, h := range extra {
+		if err := tw.WriteHeader(h); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tw.Close()
+	gz.Close()
+	return buf.Bytes()
+}
+
+// validManifest returns the manifest JSON of a genuine bundle holding files.
+func validManifest(t *testing.T, files []File) string {
+	t.Helper()
+	data, err := Write(testManifest, files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gz, _ := gzip.NewReader(bytes.NewReader(data))
+	tr := tar.NewReader(gz)
+	if _, err := tr.Next(); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	buf.ReadFrom(tr)
+	return buf.String()
+}
+
+func TestRead_Errors(t *testing.T) {
+	files := []File{{Path: ".phase", Data: []byte("define")}}
+	manifest := validManifest(t, files)
+
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr string
+	}{
+		{name: "not gzip", data: []byte("plain text"), wantErr: "not a d3 bundle"},
+		{
+			name:    "missing manifest",
+			data:    archive(t, map[string]string{"feature/.phase": "define"}),
+			wantErr: "missing manifest.json",
+		},
+		{
+			name:    "tampered file",
+			data:    archive(t, map[string]string{manifestEntry: manifest, "feature/.phase": "deliver"}),
+			wantErr: "checksum mismatch for .phase",
+		},
+		{
+			name:    "extra file",
+			data:    archive(t, map[string]string{manifestEntry: manifest, "feature/.phase": "define", "feature/x.md": "x"}),
+			wantErr: "checksum mismatch",
+		},
+		{
+			name:    "tampered manifest",
+			data:    archive(t, map[string]string{manifestEntry: strings.Replace(manifest, `"sha256": "`, `"sha256": "0`, 1), "feature/.phase": "define"}),
+			wantErr: "manifest has been modified",
+		},
+		{
+			name:    "path traversal",
+			data:    archive(t, map[string]string{manifestEntry: manifest, "feature/../../evil": "x"}),
+			wantErr: "invalid path",
+		},
+		{
+			name:    "unexpected top-level entry",
+			data:    archive(t, map[string]string{manifestEntry: manifest, "other.txt": "x"}),
+			wantErr: "unexpected bundle entry",
+		},
+		{
+			name:    "symlink",
+			data:    archive(t, map[string]string{manifestEntry: manifest}, &tar.Header{Name: "feature/link", Typeflag: tar.TypeSymlink, Linkname: "/etc/passwd"}),
+			wantErr: "not a regular file",
+		},
+		{
+			name:    "future format",
+			data:    archive(t, map[string]string{manifestEntry: strings.Replace(manifest, `"format_version": 1`, `"format_version": 99`, 1), "feature/.phase": "define"}),
+			wantErr: "unsupported bundle format version 99",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Read(tt.data)
+			if err == nil {
+				t.Fatalf("Read() expected error containing %q, got nil", tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Read() error = %q, want to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+// Package check validates the markdown documents of the define and design phases against a
+// schema of required, required non-empty and forbidden sections. The default schemas follow the
+// section structure the phase templates prescribe; a project can override them with
+// .d3/rules/<phase>.schema.yaml.
+package check
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/imcclaskey/d3/internal/core/d3err"
+	"github.com/imcclaskey/d3/internal/core/outline"
+	"github.com/imcclaskey/d3/internal/core/phase"
+	"github.com/imcclaskey/d3/internal/core/ports"
+)
+
+// SchemaSuffix is appended to a phase name to form its schema override file in .d3/rules.
+const SchemaSuffix = ".schema.yaml"
+
+// Phases lists the phases whose documents are markdown and have a schema.
+var Phases = []phase.Phase{phase.Define, phase.Design}
+
+// Reached returns the phases with a schema that a feature in phase current has reached: define
+// always, design from the design phase on.
+func Reached(current phase.Phase) []phase.Phase {
+	var reached []phase.Phase
+	for _, p := range Phases {
+		if !current.Before(p) {
+			reached = append(reached, p)
+		}
+	}
+	return reached
+}
+
+// Crossed returns the phases among gates that a move from one phase to another leaves behind:
+// those from the current phase up to, but not including, the target. Moving backward crosses
+// nothing.
+func Crossed(gates []phase.Phase, from, to phase.Phase) []phase.Phase {
+	var crossed []phase.Phase
+	for _, p := range gates {
+		if !p.Before(from) && p.Before(to) {
+			crossed = append(crossed, p)
+		}
+	}
+	return crossed
+}
+
+// Schema describes the sections a phase document must and must not have. Section names are
+// compared with outline.Normalize, so numbering, emphasis and case do not matter.
+type Schema struct {
+	// Required sections must be present.
+	Required []string `yaml:"required"`
+	// NonEmpty sections must be present and have content.
+	NonEmpty []string `yaml:"non_empty"`
+	// Forbidden sections must not be present.
+	Forbidden []string `yaml:"forbidden"`
+}
+
+// DefaultSchemas are the schemas used without an override, following the define and design
+// templates.
+var DefaultSchemas = map[phase.Phase]Schema{
+	phase.Define: {
+		Required:  []string{"Problem Statement", "Feature Goals", "Core Requirements", "Scope Exclusions"},
+		NonEmpty:  []string{"Problem Statement", "Feature Goals", "Core Requirements"},
+		Forbidden: []string{"Technical Approach Overview", "Delivery Steps", "Implementation"},
+	},
+	phase.Design: {
+		Required: []string{"Technical Approach Overview", "Delivery Steps", "Technical Constraints & Requirements", "Considerations & Alternatives"},
+		NonEmpty: []string{"Technical Approach Overview", "Delivery Steps"},
+	},
+}
+
+// LoadSchema returns the schema for a phase: the default, with every list that
+// rulesDir/<phase>.schema.yaml sets replaced. A list set to [] removes the default entries.
+// Invalid override files are reported with the InvalidArgument code.
+func LoadSchema(fs ports.FileSystem, rulesDir string, p phase.Phase) (Schema, error) {
+	schema, ok := DefaultSchemas[p]
+	if !ok {
+		return Schema{}, d3err.New(d3err.InvalidPhase, "phase %q has no document schema; expected define or design", p)
+	}
+
+	path := filepath.Join(rulesDir, string(p)+SchemaSuffix)
+	data, err := fs.ReadFile(path)
+	if os.IsNotExist(err) {
+		return schema, nil
+	} else if err != nil {
+		return Schema{}, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var override Schema
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&override); err != nil && !errors.Is(err, io.EOF) {
+		return Schema{}, d3err.New(d3err.InvalidArgument, "invalid %s: %v", path, err)
+	}
+	if override.Required != nil {
+		schema.Required = override.Required
+	}
+	if override.NonEmpty != nil {
+		schema.NonEmpty = override.NonEmpty
+	}
+	if override.Forbidden != nil {
+		schema.Forbidden = override.Forbidden
+	}
+	return schema, nil
+}
+
+// Diagnostic is one problem found in a document.
+type Diagnostic struct {
+	File string `json:"file"`
+	// Line is the 1-based line of the offending heading, or 0 for a missing section.
+	Line    int    `json:"line,omitempty"`
+	Message string `json:"message"`
+}
+
+// String renders the diagnostic as "file:line: message", or "file: message" without a line.
+func (d Diagnostic) String() string {
+	if d.Line > 0 {
+		return fmt.Sprintf("%s:%d: %s", d.File, d.Line, d.Message)
+	}
+	return fmt.Sprintf("%s: %s", d.File, d.Message)
+}
+
+// Document validates markdown content against a schema. file names the document in diagnostics.
+func Document(schema Schema, file, content string) []Diagnostic {
+	o := outline.Parse(content)
+	diagnostics := []Diagnostic{}
+	seen := map[string]bool{}
+	for _, name := range append(append([]string{}, schema.Required...), schema.NonEmpty...) {
+		if seen[outline.Normalize(name)] {
+			continue
+		}
+		seen[outline.Normalize(name)] = true
+		if _, ok := o.Find(name); !ok {
+			diagnostics = append(diagnostics, Diagnostic{File: file, Message: fmt.Sprintf("missing required section %q", name)})
+		}
+	}
+	for _, name := range schema.NonEmpty {
+		if section, ok := o.Find(name); ok && section.Empty {
+			diagnostics = append(diagnostics, Diagnostic{File: file, Line: section.Line, Message: fmt.Sprintf("section %q is empty", section.Title)})
+		}
+	}
+	for _, name := range schema.Forbidden {
+		key := outline.Normalize(name)
+		for _, section := range o.Sections {
+			if section.Key() == key {
+				diagnostics = append(diagnostics, Diagnostic{File: file, Line: section.Line, Message: fmt.Sprintf("section %q is not allowed in this phase", section.Title)})
+			}
+		}
+	}
+	return diagnostics
+}
+
+// Result is the outcome of checking the documents of one feature.
+type Result struct {
+	Feature string `json:"feature"`
+	// Phases lists the phases whose documents were checked.
+	Phases      []phase.Phase `json:"phases"`
+	Diagnostics []Diagnostic  `json:"diagnostics"`
+}
+
+// OK reports whether no diagnostics were found.
+func (r *Result) OK() bool {
+	return len(r.Diagnostics) == 0
+}
+
+// Render lists the diagnostics one per line, followed by a summary.
+func Render(r *Result) string {
+	var b bytes.Buffer
+	for _, d := range r.Diagnostics {
+		fmt.Fprintln(&b, d.String())
+	}
+	names := make([]string, 0, len(r.Phases))
+	for _, p := range r.Phases {
+		names = append(names, phase.PhaseFileMap[p])
+	}
+	switch {
+	case len(names) == 0:
+		fmt.Fprintf(&b, "No documents of '%s' to check yet.\n", r.Feature)
+	case r.OK():
+		fmt.Fprintf(&b, "No structural problems found in %s of '%s'.\n", joinNames(names), r.Feature)
+	default:
+		fmt.Fprintf(&b, "%d problem(s) found in %s of '%s'.\n", len(r.Diagnostics), joinNames(names), r.Feature)
+	}
+	return b.String()
+}
+
+// joinNames joins file names as "a", "a and b".
+func joinNames(names []string) string {
+	if len(names) <= 1 {
+		return strings.Join(names, "")
+	}
+	return fmt.Sprintf("%s and %s", names[0], names[1])
+}
@@ -0,0 +1,136 @@
+package check
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/imcclaskey/d3/internal/core/d3err"
+	"github.com/imcclaskey/d3/internal/core/phase"
+	"github.com/imcclaskey/d3/internal/testutil"
+)
+
+const problem = `# Problem: login
+
+## Problem Statement
+Users cannot log in.
+
+## Feature Goals
+<!-- list measurable goals -->
+
+## Core Requirements
+- R1: User can log in
+
+## Delivery Steps
+- [code] Add the form
+`
+
+func TestDocument(t *testing.T) {
+	got := Document(DefaultSchemas[phase.Define], "problem.md", problem)
+	want := []Diagnostic{
+		{File: "problem.md", Message: `missing required section "Scope Exclusions"`},
+		{File: "problem.md", Line: 6, Message: `section "Feature Goals" is empty`},
+		{File: "problem.md", Line: 12, Message: `section "Delivery Steps" is not allowed in this phase`},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Document() =\n%+v\nwant\n%+v", got, want)
+	}
+
+	got = Document(DefaultSchemas[phase.Design], "plan.md", "")
+	if len(got) != 4 {
+		t.Errorf("Document() of an empty plan = %+v, want 4 missing sections", got)
+	}
+}
+
+func TestDiagnostic_String(t *testing.T) {
+	if got := (Diagnostic{File: "a.md", Line: 3, Message: "m"}).String(); got != "a.md:3: m" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := (Diagnostic{File: "a.md", Message: "m"}).String(); got != "a.md: m" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestLoadSchema(t *testing.T) {
+	tests := []struct {
+		name     string
+		override *string
+		want     Schema
+		wantErr  string
+	}{
+		{name: "default", want: DefaultSchemas[phase.Define]},
+		{
+			name:     "lists replace the defaults",
+			override: ptr("required: [Summary]\nforbidden: []\n"),
+			want:     Schema{Required: []string{"Summary"}, NonEmpty: DefaultSchemas[phase.Define].NonEmpty, Forbidden: []string{}},
+		},
+		{name: "empty file", override: ptr(""), want: DefaultSchemas[phase.Define]},
+		{name: "unknown key", override: ptr("optional: [x]\n"), wantErr: "field optional not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memFS := testutil.NewMemFS()
+			if tt.override != nil {
+				memFS.AddFile("/p/.d3/rules/define.schema.yaml", *tt.override)
+			}
+			got, err := LoadSchema(memFS, "/p/.d3/rules", phase.Define)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) || d3err.CodeOf(err) != d3err.InvalidArgument {
+					t.Fatalf("LoadSchema() error = %v, want an invalid argument containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadSchema() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LoadSchema() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := LoadSchema(testutil.NewMemFS(), "/p/.d3/rules", phase.Deliver); d3err.CodeOf(err) != d3err.InvalidPhase {
+		t.Errorf("LoadSchema(deliver) error = %v, want an invalid phase", err)
+	}
+}
+
+func TestReachedAndCrossed(t *testing.T) {
+	if got := Reached(phase.Define); !reflect.DeepEqual(got, []phase.Phase{phase.Define}) {
+		t.Errorf("Reached(define) = %v", got)
+	}
+	if got := Reached(phase.Deliver); !reflect.DeepEqual(got, Phases) {
+		t.Errorf("Reached(deliver) = %v", got)
+	}
+
+	gates := []phase.Phase{phase.Define, phase.Design}
+	tests := []struct {
+		from, to phase.Phase
+		want     []phase.Phase
+	}{
+		{phase.Define, phase.Design, []phase.Phase{phase.Define}},
+		{phase.Define, phase.Deliver, []phase.Phase{phase.Define, phase.Design}},
+		{phase.Design, phase.Deliver, []phase.Phase{phase.Design}},
+		{phase.Deliver, phase.Define, nil},
+	}
+	for _, tt := range tests {
+		if got := Crossed(gates, tt.from, tt.to); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Crossed(%s, %s) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestRender(t *testing.T) {
+	r := &Result{Feature: "login", Phases: Phases, Diagnostics: []Diagnostic{{File: "problem.md", Line: 6, Message: "m"}}}
+	if got, want := Render(r), "problem.md:6: m\n1 problem(s) found in problem.md and plan.md of 'login'.\n"; got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+	r = &Result{Feature: "login", Phases: []phase.Phase{phase.Define}, Diagnostics: []Diagnostic{}}
+	if got, want := Render(r), "No structural problems found in problem.md of 'login'.\n"; got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
+
+func ptr(s string) *string {
+	return &s
+}
@@ -0,0 +1,307 @@
+// Package config loads the optional project configuration file, .d3/config.yaml, and resolves
+// it into the absolute paths and settings every d3 service is built from.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/imcclaskey/d3/internal/core/d3err"
+	"github.com/imcclaskey/d3/internal/core/feature"
+	"github.com/imcclaskey/d3/internal/core/hooks"
+	"github.com/imcclaskey/d3/internal/core/phase"
+	"github.com/imcclaskey/d3/internal/core/ports"
+)
+
+// D3DirName is the project directory holding d3's state and this configuration. It cannot be
+// relocated, since the configuration file is found through it.
+const D3DirName = ".d3"
+
+// FileName is the configuration file inside the .d3 directory.
+const FileName = "config.yaml"
+
+// Defaults for the settings in File.
+const (
+	DefaultFeaturesDir   = ".d3/features"
+	DefaultRulesDir      = ".cursor/rules"
+	DefaultInitialPhase  = phase.Define
+	DefaultMCPServerName = "d3"
+)
+
+// File is the schema of .d3/config.yaml. Every field is optional; paths are relative to the
+// project root.
+type File struct {
+	// FeaturesDir holds one directory per feature, e.g. "docs/features".
+	FeaturesDir string `yaml:"features_dir"`
+	// RulesDir is the Cursor rules directory; generated rules are written to its d3 subdirectory.
+	RulesDir string `yaml:"rules_dir"`
+	// InitialPhase is the phase new features start in.
+	InitialPhase string `yaml:"initial_phase"`
+	// MCPServerName is the key of d3's entry in .cursor/mcp.json.
+	MCPServerName string `yaml:"mcp_server_name"`
+	// Ignore replaces the entries d3 maintains in the root ignore files.
+	Ignore IgnoreFile `yaml:"ignore"`
+	// Hooks maps an event, such as "pre_phase_change", to the commands run for it.
+	Hooks map[string][]HookFile `yaml:"hooks"`
+	// CheckGates lists the phases, define or design, whose document must pass "d3 check" before
+	// a feature moves past them.
+	CheckGates []string `yaml:"check_gates"`
+	// TraceGates lists the phases, define or design, after which "d3 trace" must report no gaps
+	// before a feature moves on.
+	TraceGates []string `yaml:"trace_gates"`
+	// TrashRetention is how long deleted features stay in the trash, such as "7d" or "72h";
+	// "0d" keeps them until the trash is emptied by hand. D3_TRASH_RETENTION overrides it.
+	TrashRetention string `yaml:"trash_retention"`
+}
+
+// HookFile is one command run for a hook event.
+type HookFile struct {
+	Command string `yaml:"command"`
+	// Timeout is a duration such as "30s"; empty selects hooks.DefaultTimeout.
+	Timeout string `yaml:"timeout"`
+}
+
+// IgnoreFile lists the entries of the "# d3" sections of the root ignore files. A nil list
+// keeps the defaults; an empty list keeps the section empty.
+type IgnoreFile struct {
+	Gitignore    []string `yaml:"gitignore"`
+	Cursorignore []string `yaml:"cursorignore"`
+}
+
+// Config is the resolved configuration. Paths are absolute.
+type Config struct {
+	ProjectRoot string
+	D3Dir       string
+	FeaturesDir string
+	// CursorRulesDir is the Cursor rules directory; generated rules go to its d3 subdirectory.
+	CursorRulesDir string
+	InitialPhase   phase.Phase
+	MCPServerName  string
+	// GitignorePatterns and CursorignorePatterns are the entries of the "# d3" ignore sections.
+	GitignorePatterns    []string
+	CursorignorePatterns []string
+	// Hooks lists the commands run for each event, in configuration order.
+	Hooks map[hooks.Event][]hooks.Hook
+	// CheckGates lists the phases whose document structure gates moving forward past them.
+	CheckGates []phase.Phase
+	// TraceGates lists the phases whose traceability gaps gate moving forward past them.
+	TraceGates []phase.Phase
+	// TrashRetention is how long deleted features stay in the trash; zero disables pruning.
+	TrashRetention time.Duration
+}
+
+// Default returns the configuration used when a project has no config.yaml.
+func Default(projectRoot string) Config {
+	cfg, _ := resolve(projectRoot, File{})
+	return cfg
+}
+
+// Path returns the location of the configuration file for a project.
+func Path(projectRoot string) string {
+	return filepath.Join(projectRoot, D3DirName, FileName)
+}
+
+// Load reads and validates .d3/config.yaml, applying defaults for everything it leaves out.
+// A missing file yields Default. Invalid files are reported with the InvalidArgument code.
+func Load(fs ports.FileSystem, projectRoot string) (Config, error) {
+	path := Path(projectRoot)
+	data, err := fs.ReadFile(path)
+	if os.IsNotExist(err) {
+		return Default(projectRoot), nil
+	} else if err != nil {
+		return Config{}, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	file, err := Parse(data)
+	if err != nil {
+		return Config{}, err
+	}
+	return resolve(projectRoot, file)
+}
+
+// Parse decodes configuration file content. Unknown keys are rejected so that a misspelt
+// setting is not silently ignored.
+func Parse(data []byte) (File, error) {
+	var file File
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+		return File{}, invalid("%v", err)
+	}
+	return file, nil
+}
+
+// resolve validates file and combines it with the defaults.
+func resolve(projectRoot string, file File) (Config, error) {
+	featuresDir, err := relativeDir("features_dir", file.FeaturesDir, DefaultFeaturesDir)
+	if err != nil {
+		return Config{}, err
+	}
+	rulesDir, err := relativeDir("rules_dir", file.RulesDir, DefaultRulesDir)
+	if err != nil {
+		return Config{}, err
+	}
+
+	initialPhase := DefaultInitialPhase
+	if file.InitialPhase != "" {
+		initialPhase = phase.Phase(file.InitialPhase)
+		if initialPhase != phase.Define && initialPhase != phase.Design && initialPhase != phase.Deliver {
+			return Config{}, invalid("initial_phase %q must be define, design or deliver", file.InitialPhase)
+		}
+	}
+
+	serverName := DefaultMCPServerName
+	if file.MCPServerName != "" {
+		serverName = file.MCPServerName
+		if strings.ContainsAny(serverName, " \t\r\n") {
+			return Config{}, invalid("mcp_server_name %q must not contain whitespace", serverName)
+		}
+	}
+
+	gitignore := file.Ignore.Gitignore
+	if gitignore == nil {
+		gitignore = defaultGitignorePatterns(featuresDir, rulesDir)
+	}
+	cursorignore := file.Ignore.Cursorignore
+	if cursorignore == nil {
+		cursorignore = []string{D3DirName + "/templates/"}
+	}
+	for _, patterns := range [][]string{gitignore, cursorignore} {
+		if err := validatePatterns(patterns); err != nil {
+			return Config{}, err
+		}
+	}
+
+	configured, err := resolveHooks(file.Hooks)
+	if err != nil {
+		return Config{}, err
+	}
+
+	checkGates, err := resolveGates("check_gates", file.CheckGates)
+	if err != nil {
+		return Config{}, err
+	}
+	traceGates, err := resolveGates("trace_gates", file.TraceGates)
+	if err != nil {
+		return Config{}, err
+	}
+
+	trashRetention, err := feature.ParseTrashRetention(file.TrashRetention)
+	if err != nil {
+		return Config{}, invalid("trash_retention %q must be a duration such as 7d or 72h", file.TrashRetention)
+	}
+
+	return Config{
+		ProjectRoot:          projectRoot,
+		D3Dir:                filepath.Join(projectRoot, D3DirName),
+		FeaturesDir:          filepath.Join(projectRoot, featuresDir),
+		CursorRulesDir:       filepath.Join(projectRoot, rulesDir),
+		InitialPhase:         initialPhase,
+		MCPServerName:        serverName,
+		GitignorePatterns:    gitignore,
+		CursorignorePatterns: cursorignore,
+		Hooks:                configured,
+		CheckGates:           checkGates,
+		TraceGates:           traceGates,
+		TrashRetention:       trashRetention,
+	}, nil
+}
+
+// resolveGates validates a list of gated phases: only define and design can be moved past.
+func resolveGates(key string, names []string) ([]phase.Phase, error) {
+	var gates []phase.Phase
+	for _, name := range names {
+		gate := phase.Phase(name)
+		if gate != phase.Define && gate != phase.Design {
+			return nil, invalid("%s entry %q must be define or design", key, name)
+		}
+		gates = append(gates, gate)
+	}
+	return gates, nil
+}
+
+// resolveHooks validates the hooks section: known events, non-empty commands and positive
+// timeouts.
+func resolveHooks(file map[string][]HookFile) (map[hooks.Event][]hooks.Hook, error) {
+	if len(file) == 0 {
+		return nil, nil
+	}
+	resolved := make(map[hooks.Event][]hooks.Hook, len(file))
+	for name, entries := range file {
+		event, err := hooks.ParseEvent(name)
+		if err != nil {
+			return nil, invalid("unknown hook event %q", name)
+		}
+		for _, entry := range entries {
+			if strings.TrimSpace(entry.Command) == "" {
+				return nil, invalid("%s hook must have a command", name)
+			}
+			hook := hooks.Hook{Command: entry.Command}
+			if entry.Timeout != "" {
+				hook.Timeout, err = time.ParseDuration(entry.Timeout)
+				if err != nil || hook.Timeout <= 0 {
+					return nil, invalid("%s hook timeout %q must be a positive duration such as 30s", name, entry.Timeout)
+				}
+			}
+			resolved[event] = append(resolved[event], hook)
+		}
+	}
+	return resolved, nil
+}
+
+// defaultGitignorePatterns keeps d3's local state out of commits: generated rules, active
+// feature markers, phase markers and the trash. Paths follow the configured directories.
+func defaultGitignorePatterns(featuresDir, rulesDir string) []string {
+	generated := filepath.ToSlash(rulesDir) + "/d3/"
+	phaseMarkers := filepath.ToSlash(featuresDir) + "/*/.phase"
+	return []string{
+		generated,                // d3 rules directory
+		generated + "*.gen.mdc",  // generated rule files
+		D3DirName + "/.feature",  // active feature marker
+		D3DirName + "/sessions/", // per-session active feature markers
+		phaseMarkers,             // phase markers
+		D3DirName + "/.trash/",   // soft-deleted features
+		D3DirName + "/.lock",     // lock held while a d3 process changes the project
+	}
+}
+
+// relativeDir validates a directory setting: it must stay inside the project root, and may not
+// be the root or the .d3 directory itself.
+func relativeDir(key, value, fallback string) (string, error) {
+	if value == "" {
+		return fallback, nil
+	}
+	cleaned := filepath.Clean(filepath.FromSlash(value))
+	switch {
+	case filepath.IsAbs(cleaned):
+		return "", invalid("%s %q must be relative to the project root", key, value)
+	case cleaned == "." || cleaned == D3DirName:
+		return "", invalid("%s %q must name a directory below the project root other than %s", key, value, D3DirName)
+	case cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)):
+		return "", invalid("%s %q must not leave the project root", key, value)
+	}
+	return cleaned, nil
+}
+
+// validatePatterns rejects ignore entries that would break the ignore file's line format.
+func validatePatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		if strings.TrimSpace(pattern) == "" || strings.ContainsAny(pattern, "\r\n") {
+			return invalid("ignore pattern %q must be a single non-empty line", pattern)
+		}
+	}
+	return nil
+}
+
+// invalid returns an InvalidArgument error naming the configuration file.
+func invalid(format string, args ...interface{}) error {
+	return d3err.New(d3err.InvalidArgument, "invalid %s/%s: %s", D3DirName, FileName, fmt.Sprintf(format, args...))
+}
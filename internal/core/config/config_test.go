@@ -0,0 +1,181 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/imcclaskey/d3/internal/core/d3err"
+	"github.com/imcclaskey/d3/internal/core/hooks"
+	"github.com/imcclaskey/d3/internal/core/phase"
+	"github.com/imcclaskey/d3/internal/testutil"
+)
+
+func TestDefault(t *testing.T) {
+	cfg := Default("/p")
+	want := Config{
+		ProjectRoot:    "/p",
+		D3Dir:          "/p/.d3",
+		FeaturesDir:    "/p/.d3/features",
+		CursorRulesDir: "/p/.cursor/rules",
+		InitialPhase:   phase.Define,
+		MCPServerName:  "d3",
+		GitignorePatterns: []string{
+			".cursor/rules/d3/",
+			".cursor/rules/d3/*.gen.mdc",
+			".d3/.feature",
+			".d3/sessions/",
+			".d3/features/*/.phase",
+			".d3/.trash/",
+			".d3/.lock",
+		},
+		CursorignorePatterns: []string{".d3/templates/"},
+		TrashRetention:       30 * 24 * time.Hour,
+	}
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("Default() = %+v, want %+v", cfg, want)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		content *string
+		check   func(t *testing.T, cfg Config)
+		wantErr string
+	}{
+		{
+			name: "missing file uses defaults",
+			check: func(t *testing.T, cfg Config) {
+				if !reflect.DeepEqual(cfg, Default("/p")) {
+					t.Errorf("Load() = %+v, want defaults", cfg)
+				}
+			},
+		},
+		{
+			name:    "empty file uses defaults",
+			content: ptr(""),
+			check: func(t *testing.T, cfg Config) {
+				if !reflect.DeepEqual(cfg, Default("/p")) {
+					t.Errorf("Load() = %+v, want defaults", cfg)
+				}
+			},
+		},
+		{
+			name:    "relocated directories",
+			content: ptr("features_dir: docs/features\nrules_dir: .cursor/rules/team\ninitial_phase: design\nmcp_server_name: d3-docs\n"),
+			check: func(t *testing.T, cfg Config) {
+				if cfg.FeaturesDir != "/p/docs/features" || cfg.CursorRulesDir != "/p/.cursor/rules/team" {
+					t.Errorf("Load() dirs = %s, %s", cfg.FeaturesDir, cfg.CursorRulesDir)
+				}
+				if cfg.InitialPhase != phase.Design || cfg.MCPServerName != "d3-docs" {
+					t.Errorf("Load() initial phase = %s, server name = %s", cfg.InitialPhase, cfg.MCPServerName)
+				}
+				// The default ignore entries follow the relocated directories
+				for _, want := range []string{".cursor/rules/team/d3/", "docs/features/*/.phase"} {
+					if !contains(cfg.GitignorePatterns, want) {
+						t.Errorf("Load() gitignore = %v, want it to contain %q", cfg.GitignorePatterns, want)
+					}
+				}
+			},
+		},
+		{
+			name:    "ignore patterns replace the defaults",
+			content: ptr("ignore:\n  gitignore: [\".d3/.feature\"]\n  cursorignore: []\n"),
+			check: func(t *testing.T, cfg Config) {
+				if !reflect.DeepEqual(cfg.GitignorePatterns, []string{".d3/.feature"}) {
+					t.Errorf("Load() gitignore = %v", cfg.GitignorePatterns)
+				}
+				if cfg.CursorignorePatterns == nil || len(cfg.CursorignorePatterns) != 0 {
+					t.Errorf("Load() cursorignore = %#v, want empty", cfg.CursorignorePatterns)
+				}
+			},
+		},
+		{
+			name:    "hooks",
+			content: ptr("hooks:\n  pre_phase_change:\n    - command: make lint\n      timeout: 30s\n    - command: ./check.sh\n"),
+			check: func(t *testing.T, cfg Config) {
+				want := map[hooks.Event][]hooks.Hook{
+					hooks.PrePhaseChange: {{Command: "make lint", Timeout: 30 * time.Second}, {Command: "./check.sh"}},
+				}
+				if !reflect.DeepEqual(cfg.Hooks, want) {
+					t.Errorf("Load() hooks = %+v, want %+v", cfg.Hooks, want)
+				}
+			},
+		},
+		{
+			name:    "check and trace gates",
+			content: ptr("check_gates: [define, design]\ntrace_gates: [design]\n"),
+			check: func(t *testing.T, cfg Config) {
+				if !reflect.DeepEqual(cfg.CheckGates, []phase.Phase{phase.Define, phase.Design}) {
+					t.Errorf("Load() check gates = %v", cfg.CheckGates)
+				}
+				if !reflect.DeepEqual(cfg.TraceGates, []phase.Phase{phase.Design}) {
+					t.Errorf("Load() trace gates = %v", cfg.TraceGates)
+				}
+			},
+		},
+		{
+			name:    "trash retention",
+			content: ptr("trash_retention: 7d\n"),
+			check: func(t *testing.T, cfg Config) {
+				if cfg.TrashRetention != 7*24*time.Hour {
+					t.Errorf("Load() trash retention = %v, want 168h", cfg.TrashRetention)
+				}
+			},
+		},
+		{name: "unknown key", content: ptr("feature_dir: docs\n"), wantErr: "field feature_dir not found"},
+		{name: "malformed yaml", content: ptr("features_dir: [\n"), wantErr: "invalid .d3/config.yaml"},
+		{name: "absolute features dir", content: ptr("features_dir: /srv/features\n"), wantErr: "must be relative"},
+		{name: "features dir outside root", content: ptr("features_dir: ../features\n"), wantErr: "must not leave the project root"},
+		{name: "features dir is root", content: ptr("features_dir: .\n"), wantErr: "must name a directory"},
+		{name: "rules dir is .d3", content: ptr("rules_dir: .d3/\n"), wantErr: "must name a directory"},
+		{name: "unknown phase", content: ptr("initial_phase: deploy\n"), wantErr: "initial_phase \"deploy\""},
+		{name: "server name with space", content: ptr("mcp_server_name: \"d3 docs\"\n"), wantErr: "must not contain whitespace"},
+		{name: "blank ignore pattern", content: ptr("ignore:\n  gitignore: [\" \"]\n"), wantErr: "single non-empty line"},
+		{name: "unknown hook event", content: ptr("hooks:\n  before_create:\n    - command: x\n"), wantErr: "unknown hook event \"before_create\""},
+		{name: "hook without command", content: ptr("hooks:\n  post_exit:\n    - timeout: 5s\n"), wantErr: "post_exit hook must have a command"},
+		{name: "deliver check gate", content: ptr("check_gates: [deliver]\n"), wantErr: "check_gates entry \"deliver\" must be define or design"},
+		{name: "deliver trace gate", content: ptr("trace_gates: [deliver]\n"), wantErr: "trace_gates entry \"deliver\" must be define or design"},
+		{name: "invalid trash retention", content: ptr("trash_retention: a month\n"), wantErr: "trash_retention \"a month\""},
+		{name: "invalid hook timeout", content: ptr("hooks:\n  pre_create:\n    - command: x\n      timeout: soon\n"), wantErr: "must be a positive duration"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memFS := testutil.NewMemFS()
+			if tt.content != nil {
+				memFS.AddFile("/p/.d3/config.yaml", *tt.content)
+			}
+
+			cfg, err := Load(memFS, "/p")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Load() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				if d3err.CodeOf(err) != d3err.InvalidArgument {
+					t.Errorf("Load() error code = %s, want %s", d3err.CodeOf(err), d3err.InvalidArgument)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			tt.check(t, cfg)
+		})
+	}
+}
+
+func ptr(s string) *string {
+	return &s
+}
+
+func contains(values []string, want string) bool {
+	for _, v := range values {
+		if v == want {
+			return true
+		}
+	}
+	return false
+}
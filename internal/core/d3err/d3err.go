@@ -0,0 +1,114 @@
+// Package d3err defines the error taxonomy shared by the core packages, the CLI and the MCP
+// server. Each kind of failure has a stable Code that callers match with errors.Is or read
+// with CodeOf, however deeply the error has been wrapped.
+package d3err
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Code identifies a kind of failure. Codes are part of the CLI's JSON output and of MCP tool
+// error results, so existing values must not change.
+type Code string
+
+const (
+	// Unknown is reported for errors outside the taxonomy.
+	Unknown Code = "unknown"
+	// InvalidArgument means a command or tool was called with missing or malformed input.
+	InvalidArgument Code = "invalid_argument"
+	// NotInitialized means the project has no .d3 directory.
+	NotInitialized Code = "not_initialized"
+	// NoActiveFeature means the operation needs an active feature and none is set.
+	NoActiveFeature Code = "no_active_feature"
+	// FeatureExists means a feature with the requested name is already present.
+	FeatureExists Code = "feature_exists"
+	// FeatureNotFound means the named feature, or its copy in the trash, does not exist.
+	FeatureNotFound Code = "feature_not_found"
+	// InvalidName means a feature name was rejected by the naming policy.
+	InvalidName Code = "invalid_name"
+	// InvalidPhase means a phase value is not one of define, design or deliver.
+	InvalidPhase Code = "invalid_phase"
+	// GateFailed means a check that guards the workflow, such as the pre-commit hook, refused
+	// to let the operation through.
+	GateFailed Code = "gate_failed"
+	// Locked means another d3 process holds the project lock.
+	Locked Code = "locked"
+)
+
+// Sentinels for matching with errors.Is. Any error carrying the same code matches, whatever
+// its message.
+var (
+	ErrInvalidArgument = &Error{Code: InvalidArgument, Message: "invalid argument"}
+	ErrNotInitialized  = &Error{Code: NotInitialized, Message: "project not initialized"}
+	ErrNoActiveFeature = &Error{Code: NoActiveFeature, Message: "no active feature"}
+	ErrFeatureExists   = &Error{Code: FeatureExists, Message: "feature already exists"}
+	ErrFeatureNotFound = &Error{Code: FeatureNotFound, Message: "feature not found"}
+	ErrInvalidName     = &Error{Code: InvalidName, Message: "invalid feature name"}
+	ErrInvalidPhase    = &Error{Code: InvalidPhase, Message: "invalid phase"}
+	ErrGateFailed      = &Error{Code: GateFailed, Message: "gate failed"}
+	ErrLocked          = &Error{Code: Locked, Message: "locked"}
+)
+
+// Coder is implemented by errors that belong to the taxonomy. Types defined elsewhere, such as
+// feature.InvalidNameError, implement it to take part in CodeOf.
+type Coder interface {
+	ErrorCode() Code
+}
+
+// Error is a failure with a code and a human-readable message.
+type Error struct {
+	Code    Code
+	Message string
+	// Err is the underlying cause, if any.
+	Err error
+}
+
+// New returns an error with the given code and formatted message.
+func New(code Code, format string, args ...interface{}) error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// Wrap attaches a code to err, keeping its message and leaving it reachable with errors.Unwrap.
+// A nil err yields nil.
+func Wrap(code Code, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Code: code, Message: err.Error(), Err: err}
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the underlying cause.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// ErrorCode implements Coder.
+func (e *Error) ErrorCode() Code {
+	return e.Code
+}
+
+// Is reports whether target carries the same code, so that errors.Is(err, ErrFeatureExists)
+// matches every "feature exists" error.
+func (e *Error) Is(target error) bool {
+	var c Coder
+	return errors.As(target, &c) && c.ErrorCode() == e.Code
+}
+
+// CodeOf returns the code of the first error in err's chain that has one, or Unknown.
+// A nil err yields the empty code.
+func CodeOf(err error) Code {
+	if err == nil {
+		return ""
+	}
+	var c Coder
+	if errors.As(err, &c) {
+		return c.ErrorCode()
+	}
+	return Unknown
+}
@@ -0,0 +1,72 @@
+package d3err
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// namedError is an error type defined outside the package that takes part in the taxonomy.
+type namedError struct{}
+
+func (namedError) Error() string   { return "bad name" }
+func (namedError) ErrorCode() Code { return InvalidName }
+func (namedError) Is(e error) bool { return CodeOf(e) == InvalidName }
+
+func TestCodeOf(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want Code
+	}{
+		{name: "nil", err: nil, want: ""},
+		{name: "plain error", err: errors.New("boom"), want: Unknown},
+		{name: "coded error", err: New(FeatureExists, "feature %s already exists", "login"), want: FeatureExists},
+		{name: "wrapped coded error", err: fmt.Errorf("create: %w", New(FeatureNotFound, "missing")), want: FeatureNotFound},
+		{name: "wrap keeps cause", err: Wrap(InvalidArgument, errors.New("unknown flag")), want: InvalidArgument},
+		{name: "outer code wins", err: Wrap(GateFailed, New(InvalidPhase, "bad phase")), want: GateFailed},
+		{name: "external coder", err: fmt.Errorf("ctx: %w", namedError{}), want: InvalidName},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CodeOf(tt.err); got != tt.want {
+				t.Errorf("CodeOf() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorIs(t *testing.T) {
+	err := fmt.Errorf("failed to restore: %w", New(FeatureExists, "feature %s already exists", "login"))
+
+	if !errors.Is(err, ErrFeatureExists) {
+		t.Error("errors.Is(err, ErrFeatureExists) = false, want true")
+	}
+	if errors.Is(err, ErrFeatureNotFound) {
+		t.Error("errors.Is(err, ErrFeatureNotFound) = true, want false")
+	}
+	if errors.Is(errors.New("feature login already exists"), ErrFeatureExists) {
+		t.Error("plain error matched ErrFeatureExists")
+	}
+	if !errors.Is(fmt.Errorf("ctx: %w", namedError{}), ErrInvalidName) {
+		t.Error("external coder did not match ErrInvalidName")
+	}
+	if got := err.Error(); got != "failed to restore: feature login already exists" {
+		t.Errorf("Error() = %q", got)
+	}
+}
+
+func TestWrap(t *testing.T) {
+	cause := errors.New("unknown flag: --bad")
+	err := Wrap(InvalidArgument, cause)
+
+	if !errors.Is(err, cause) {
+		t.Error("Wrap() lost the cause")
+	}
+	if err.Error() != cause.Error() {
+		t.Errorf("Wrap() message = %q, want %q", err.Error(), cause.Error())
+	}
+	if Wrap(InvalidArgument, nil) != nil {
+		t.Error("Wrap(nil) != nil")
+	}
+}
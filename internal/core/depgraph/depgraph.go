@@ -0,0 +1,236 @@
+// Package depgraph models the dependencies between features. A feature that depends on another
+// may not enter deliver until the other is complete, that is in deliver with every task of its
+// progress.yaml done. The graph must stay acyclic, and can be rendered as DOT or an ASCII tree.
+package depgraph
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/imcclaskey/d3/internal/core/phase"
+)
+
+// Node is one feature of the graph.
+type Node struct {
+	Name  string      `json:"name"`
+	Phase phase.Phase `json:"phase"`
+	// Complete reports whether the feature is in deliver with every task done.
+	Complete  bool     `json:"complete"`
+	DependsOn []string `json:"depends_on"`
+}
+
+// Graph holds the features of a project and their dependencies.
+type Graph struct {
+	// Nodes are ordered by name.
+	Nodes []Node `json:"nodes"`
+	index map[string]int
+}
+
+// New builds a graph from nodes, ordering them by name.
+func New(nodes []Node) *Graph {
+	g := &Graph{Nodes: append([]Node{}, nodes...), index: map[string]int{}}
+	sort.Slice(g.Nodes, func(i, j int) bool {
+		return g.Nodes[i].Name < g.Nodes[j].Name
+	})
+	for i := range g.Nodes {
+		if g.Nodes[i].DependsOn == nil {
+			g.Nodes[i].DependsOn = []string{}
+		}
+		g.index[g.Nodes[i].Name] = i
+	}
+	return g
+}
+
+// Node returns the feature called name, if the graph has it.
+func (g *Graph) Node(name string) (Node, bool) {
+	i, ok := g.index[name]
+	if !ok {
+		return Node{}, false
+	}
+	return g.Nodes[i], true
+}
+
+// WithDependencies returns a copy of the graph in which the feature called name depends on
+// dependencies, to check a change before it is recorded.
+func (g *Graph) WithDependencies(name string, dependencies []string) *Graph {
+	nodes := append([]Node{}, g.Nodes...)
+	i, ok := g.index[name]
+	if !ok {
+		return New(append(nodes, Node{Name: name, DependsOn: dependencies}))
+	}
+	nodes[i].DependsOn = dependencies
+	return New(nodes)
+}
+
+// Cycle returns the first dependency cycle found, as the path from a feature back to itself,
+// such as [ui api ui], or nil if the graph is acyclic.
+func (g *Graph) Cycle() []string {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := map[string]int{}
+	var path []string
+	var visit func(name string) []string
+	visit = func(name string) []string {
+		switch state[name] {
+		case visiting:
+			for i, n := range path {
+				if n == name {
+					return append(append([]string{}, path[i:]...), name)
+				}
+			}
+		case done:
+			return nil
+		}
+		node, ok := g.Node(name)
+		if !ok {
+			return nil
+		}
+		state[name] = visiting
+		path = append(path, name)
+		for _, dep := range node.DependsOn {
+			if cycle := visit(dep); cycle != nil {
+				return cycle
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = done
+		return nil
+	}
+	for _, node := range g.Nodes {
+		if state[node.Name] != unvisited {
+			continue
+		}
+		if cycle := visit(node.Name); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
+
+// Blocking returns the direct dependencies of the feature called name that are not complete,
+// including those that no longer exist.
+func (g *Graph) Blocking(name string) []string {
+	node, ok := g.Node(name)
+	if !ok {
+		return nil
+	}
+	var blocking []string
+	for _, dep := range node.DependsOn {
+		if d, ok := g.Node(dep); !ok || !d.Complete {
+			blocking = append(blocking, dep)
+		}
+	}
+	return blocking
+}
+
+// Dependents returns the features that depend directly on the feature called name.
+func (g *Graph) Dependents(name string) []string {
+	var dependents []string
+	for _, node := range g.Nodes {
+		for _, dep := range node.DependsOn {
+			if dep == name {
+				dependents = append(dependents, node.Name)
+				break
+			}
+		}
+	}
+	return dependents
+}
+
+// Label describes a feature's state in a few words, such as "design" or "deliver, complete".
+// Dependencies that no longer exist are labelled "missing".
+func (g *Graph) Label(name string) string {
+	node, ok := g.Node(name)
+	switch {
+	case !ok:
+		return "missing"
+	case node.Phase == phase.None:
+		return "no phase"
+	case node.Complete:
+		return string(node.Phase) + ", complete"
+	default:
+		return string(node.Phase)
+	}
+}
+
+// DOT renders the graph in the Graphviz DOT language, with an edge from each feature to every
+// feature it depends on. Complete features are filled and missing ones dashed.
+func (g *Graph) DOT() string {
+	var b strings.Builder
+	b.WriteString("digraph features {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box];\n")
+	var missing []string
+	seen := map[string]bool{}
+	for _, node := range g.Nodes {
+		style := ""
+		if node.Complete {
+			style = ", style=filled, fillcolor=palegreen"
+		}
+		fmt.Fprintf(&b, "  %q [label=%q%s];\n", node.Name, node.Name+"\n"+g.Label(node.Name), style)
+		for _, dep := range node.DependsOn {
+			if _, ok := g.Node(dep); !ok && !seen[dep] {
+				seen[dep] = true
+				missing = append(missing, dep)
+			}
+		}
+	}
+	sort.Strings(missing)
+	for _, name := range missing {
+		fmt.Fprintf(&b, "  %q [label=%q, style=dashed];\n", name, name+"\nmissing")
+	}
+	for _, node := range g.Nodes {
+		for _, dep := range node.DependsOn {
+			fmt.Fprintf(&b, "  %q -> %q;\n", node.Name, dep)
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// Tree renders the graph as an ASCII tree with one root per feature that nothing depends on,
+// each followed by what it depends on. A feature reached again through a cycle is marked and
+// not expanded. Features that are only reachable through a cycle become roots of their own.
+func (g *Graph) Tree() string {
+	var b strings.Builder
+	printed := map[string]bool{}
+	var walk func(name, prefix string, ancestors map[string]bool)
+	walk = func(name, prefix string, ancestors map[string]bool) {
+		node, _ := g.Node(name)
+		for i, dep := range node.DependsOn {
+			branch, indent := "├── ", "│   "
+			if i == len(node.DependsOn)-1 {
+				branch, indent = "└── ", "    "
+			}
+			if ancestors[dep] {
+				fmt.Fprintf(&b, "%s%s%s (cycle)\n", prefix, branch, dep)
+				continue
+			}
+			fmt.Fprintf(&b, "%s%s%s [%s]\n", prefix, branch, dep, g.Label(dep))
+			printed[dep] = true
+			ancestors[dep] = true
+			walk(dep, prefix+indent, ancestors)
+			delete(ancestors, dep)
+		}
+	}
+	root := func(name string) {
+		fmt.Fprintf(&b, "%s [%s]\n", name, g.Label(name))
+		printed[name] = true
+		walk(name, "", map[string]bool{name: true})
+	}
+	for _, node := range g.Nodes {
+		if len(g.Dependents(node.Name)) == 0 {
+			root(node.Name)
+		}
+	}
+	for _, node := range g.Nodes {
+		if !printed[node.Name] {
+			root(node.Name)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,105 @@
+package depgraph
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/imcclaskey/d3/internal/core/phase"
+)
+
+func sampleGraph() *Graph {
+	return New([]Node{
+		{Name: "ui", Phase: phase.Design, DependsOn: []string{"api", "billing"}},
+		{Name: "api", Phase: phase.Deliver, Complete: true, DependsOn: []string{"auth"}},
+		{Name: "auth", Phase: phase.Deliver, Complete: true},
+		{Name: "docs", Phase: phase.Define},
+	})
+}
+
+func TestGraph_Queries(t *testing.T) {
+	g := sampleGraph()
+
+	var names []string
+	for _, node := range g.Nodes {
+		names = append(names, node.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"api", "auth", "docs", "ui"}) {
+		t.Errorf("New() nodes = %v, want them ordered by name", names)
+	}
+	if got := g.Blocking("ui"); !reflect.DeepEqual(got, []string{"billing"}) {
+		t.Errorf("Blocking(ui) = %v, want the missing dependency", got)
+	}
+	if got := g.Blocking("api"); got != nil {
+		t.Errorf("Blocking(api) = %v, want nothing", got)
+	}
+	if got := g.Dependents("api"); !reflect.DeepEqual(got, []string{"ui"}) {
+		t.Errorf("Dependents(api) = %v", got)
+	}
+
+	labels := map[string]string{"api": "deliver, complete", "docs": "define", "billing": "missing"}
+	for name, want := range labels {
+		if got := g.Label(name); got != want {
+			t.Errorf("Label(%s) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestGraph_Cycle(t *testing.T) {
+	g := sampleGraph()
+	if cycle := g.Cycle(); cycle != nil {
+		t.Errorf("Cycle() = %v, want none", cycle)
+	}
+	if cycle := g.WithDependencies("auth", []string{"ui"}).Cycle(); !reflect.DeepEqual(cycle, []string{"api", "auth", "ui", "api"}) {
+		t.Errorf("Cycle() = %v, want api -> auth -> ui -> api", cycle)
+	}
+	if cycle := g.WithDependencies("docs", []string{"docs"}).Cycle(); !reflect.DeepEqual(cycle, []string{"docs", "docs"}) {
+		t.Errorf("Cycle() = %v, want docs -> docs", cycle)
+	}
+	// The graph itself is left unchanged
+	if cycle := g.Cycle(); cycle != nil {
+		t.Errorf("Cycle() after WithDependencies = %v, want none", cycle)
+	}
+}
+
+func TestGraph_DOT(t *testing.T) {
+	want := `digraph features {
+  rankdir=LR;
+  node [shape=box];
+  "api" [label="api\ndeliver, complete", style=filled, fillcolor=palegreen];
+  "auth" [label="auth\ndeliver, complete", style=filled, fillcolor=palegreen];
+  "docs" [label="docs\ndefine"];
+  "ui" [label="ui\ndesign"];
+  "billing" [label="billing\nmissing", style=dashed];
+  "api" -> "auth";
+  "ui" -> "api";
+  "ui" -> "billing";
+}
+`
+	if got := sampleGraph().DOT(); got != want {
+		t.Errorf("DOT() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestGraph_Tree(t *testing.T) {
+	want := `docs [define]
+ui [design]
+├── api [deliver, complete]
+│   └── auth [deliver, complete]
+└── billing [missing]
+`
+	if got := sampleGraph().Tree(); got != want {
+		t.Errorf("Tree() =\n%s\nwant\n%s", got, want)
+	}
+
+	cyclic := New([]Node{
+		{Name: "a", Phase: phase.Define, DependsOn: []string{"b"}},
+		{Name: "b", Phase: phase.Define, DependsOn: []string{"a"}},
+	})
+	want = `a [define]
+└── b [define]
+    └── a (cycle)
+`
+	if got := cyclic.Tree(); got != want {
+		t.Errorf("Tree() of a cycle =\n%s\nwant\n%s", got, want)
+	}
+}
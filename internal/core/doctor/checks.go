@@ -0,0 +1,313 @@
+package doctor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/imcclaskey/d3/internal/core/phase"
+	"github.com/imcclaskey/d3/internal/core/projectfiles"
+	"github.com/imcclaskey/d3/internal/core/rules"
+)
+
+// phaseFileName is the per-feature file holding the feature's current phase.
+const phaseFileName = ".phase"
+
+// ProjectCheck reports a project without a .d3 directory. Every other built-in check needs one.
+var ProjectCheck = Check{
+	Name:        "project",
+	Description: "The project has been initialized with d3 init",
+	Run: func(ctx context.Context, env *Env) ([]Finding, error) {
+		if isInitialized(env) {
+			return nil, nil
+		}
+		return []Finding{{
+			Severity: SeverityError,
+			Message:  "project is not initialized: there is no .d3 directory. Run 'd3 init'",
+		}}, nil
+	},
+}
+
+// MCPConfigCheck verifies that .cursor/mcp.json starts this checkout's d3 server.
+var MCPConfigCheck = Check{
+	Name:         "mcp-config",
+	Description:  ".cursor/mcp.json runs a d3 binary on PATH with this checkout as --workdir",
+	RequiresInit: true,
+	Run:          checkMCPConfig,
+}
+
+func checkMCPConfig(ctx context.Context, env *Env) ([]Finding, error) {
+	mcpPath := filepath.Join(env.ProjectRoot, ".cursor", "mcp.json")
+	repair := func(ctx context.Context) error {
+		// EnsureMCPJSON expects the .cursor directory to exist
+		if err := env.FS.MkdirAll(filepath.Dir(mcpPath), 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", filepath.Dir(mcpPath), err)
+		}
+		return env.Files.EnsureMCPJSON(env.FS, env.ProjectRoot)
+	}
+
+	data, err := env.FS.ReadFile(mcpPath)
+	if os.IsNotExist(err) {
+		return []Finding{{Severity: SeverityError, Message: ".cursor/mcp.json is missing, so Cursor cannot start the d3 server", Fix: repair}}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", mcpPath, err)
+	}
+
+	var config projectfiles.MCPRootConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		// Rewriting the file would drop any other servers it configures
+		return []Finding{{Severity: SeverityError, Message: fmt.Sprintf(".cursor/mcp.json is not valid JSON (%v). Fix it by hand", err)}}, nil
+	}
+	entry, ok := config.MCPServers[env.MCPServerName]
+	if !ok {
+		return []Finding{{Severity: SeverityError, Message: fmt.Sprintf(".cursor/mcp.json has no %q server entry", env.MCPServerName), Fix: repair}}, nil
+	}
+
+	// Repairing rewrites the command as well, so only do it for entries using the default one
+	var repairEntry func(ctx context.Context) error
+	if entry.Command == projectfiles.D3Command {
+		repairEntry = repair
+	}
+
+	var findings []Finding
+	if entry.Command == "" {
+		findings = append(findings, Finding{Severity: SeverityError, Message: "the d3 server entry in .cursor/mcp.json has no command", Fix: repair})
+	} else if _, err := env.LookPath(entry.Command); err != nil {
+		findings = append(findings, Finding{
+			Severity: SeverityError,
+			Message:  fmt.Sprintf(".cursor/mcp.json runs %q, which was not found on PATH. Install d3 or set the full path to the binary", entry.Command),
+		})
+	}
+
+	workdir, ok := serveWorkdir(entry.Args)
+	switch {
+	case !ok:
+		findings = append(findings, Finding{
+			Severity: SeverityWarning,
+			Message:  "the d3 server entry in .cursor/mcp.json does not pass --workdir, so the server depends on the editor's working directory",
+			Fix:      repairEntry,
+		})
+	case filepath.Clean(workdir) != filepath.Clean(env.ProjectRoot):
+		findings = append(findings, Finding{
+			Severity: SeverityError,
+			Message:  fmt.Sprintf("the d3 server entry in .cursor/mcp.json uses --workdir %s, but this checkout is at %s", workdir, env.ProjectRoot),
+			Fix:      repairEntry,
+		})
+	}
+	return findings, nil
+}
+
+// serveWorkdir extracts the --workdir value from the d3 server arguments. d3 init writes them as
+// a single "serve --workdir <path>" argument, but separate arguments are accepted as well.
+func serveWorkdir(args projectfiles.MCPServerArgs) (string, bool) {
+	joined := strings.Join(args, " ")
+	for _, flag := range []string{"--workdir=", "--workdir ", "-w "} {
+		if idx := strings.Index(joined, flag); idx >= 0 {
+			workdir := strings.TrimSpace(joined[idx+len(flag):])
+			return workdir, workdir != ""
+		}
+	}
+	return "", false
+}
+
+// GitignoreCheck verifies the "# d3" section of the root .gitignore.
+var GitignoreCheck = Check{
+	Name:         "gitignore",
+	Description:  "The root .gitignore has d3's section, keeping local state out of commits",
+	RequiresInit: true,
+	Run: func(ctx context.Context, env *Env) ([]Finding, error) {
+		return checkIgnoreFile(env, ".gitignore", env.GitignorePatterns, SeverityWarning, func(ctx context.Context) error {
+			return env.Files.EnsureRootGitignoreEntries(env.FS, env.ProjectRoot)
+		})
+	},
+}
+
+// CursorignoreCheck verifies the "# d3" section of the root .cursorignore.
+var CursorignoreCheck = Check{
+	Name:         "cursorignore",
+	Description:  "The root .cursorignore has d3's section, keeping templates out of the AI's context",
+	RequiresInit: true,
+	Run: func(ctx context.Context, env *Env) ([]Finding, error) {
+		return checkIgnoreFile(env, ".cursorignore", env.CursorignorePatterns, SeverityInfo, func(ctx context.Context) error {
+			return env.Files.EnsureRootCursorignoreEntries(env.FS, env.ProjectRoot)
+		})
+	},
+}
+
+// checkIgnoreFile reports a missing ignore file, a missing "# d3" section or missing entries.
+func checkIgnoreFile(env *Env, name string, patterns []string, severity Severity, repair func(ctx context.Context) error) ([]Finding, error) {
+	path := filepath.Join(env.ProjectRoot, name)
+	data, err := env.FS.ReadFile(path)
+	if os.IsNotExist(err) {
+		return []Finding{{Severity: severity, Message: fmt.Sprintf("%s is missing", name), Fix: repair}}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	hasSection, missing := projectfiles.MissingIgnoreEntries(data, patterns, projectfiles.D3IgnoreSectionMarker)
+	switch {
+	case !hasSection:
+		return []Finding{{Severity: severity, Message: fmt.Sprintf("%s has no %q section", name, projectfiles.D3IgnoreSectionMarker), Fix: repair}}, nil
+	case len(missing) > 0:
+		return []Finding{{Severity: severity, Message: fmt.Sprintf("the %q section of %s is missing %s", projectfiles.D3IgnoreSectionMarker, name, strings.Join(missing, ", ")), Fix: repair}}, nil
+	}
+	return nil, nil
+}
+
+// FeaturePhasesCheck verifies that every feature's .phase file holds a valid phase.
+var FeaturePhasesCheck = Check{
+	Name:         "feature-phases",
+	Description:  "Every feature's .phase file names define, design or deliver",
+	RequiresInit: true,
+	Run:          checkFeaturePhases,
+}
+
+func checkFeaturePhases(ctx context.Context, env *Env) ([]Finding, error) {
+	features, err := env.Features.ListFeatures(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []Finding
+	for _, f := range features {
+		name := f.Name
+		raw, err := env.FS.ReadFile(filepath.Join(f.Path, phaseFileName))
+		if os.IsNotExist(err) {
+			findings = append(findings, Finding{
+				Severity: SeverityWarning,
+				Message:  fmt.Sprintf("feature %s has no %s file; it will be treated as being in define", name, phaseFileName),
+				Fix:      setPhase(env, name, phase.Define),
+			})
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read %s for feature %s: %w", phaseFileName, name, err)
+		}
+
+		value := strings.TrimSpace(string(raw))
+		if phase.Phase(value).Valid() {
+			continue
+		}
+		if normalized := phase.Phase(strings.ToLower(value)); normalized.Valid() {
+			findings = append(findings, Finding{
+				Severity: SeverityWarning,
+				Message:  fmt.Sprintf("feature %s has phase %q in %s; d3 expects %q", name, value, phaseFileName, normalized),
+				Fix:      setPhase(env, name, normalized),
+			})
+			continue
+		}
+		findings = append(findings, Finding{
+			Severity: SeverityError,
+			Message:  fmt.Sprintf("feature %s has an invalid phase %q in %s. Set it to define, design or deliver", name, value, phaseFileName),
+		})
+	}
+	return findings, nil
+}
+
+// setPhase returns a fix that writes a feature's phase.
+func setPhase(env *Env, featureName string, p phase.Phase) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		return env.Features.SetFeaturePhase(ctx, featureName, p)
+	}
+}
+
+// ActiveFeatureCheck verifies that the active feature marker names an existing feature.
+var ActiveFeatureCheck = Check{
+	Name:         "active-feature",
+	Description:  "The active feature exists",
+	RequiresInit: true,
+	Run:          checkActiveFeature,
+}
+
+func checkActiveFeature(ctx context.Context, env *Env) ([]Finding, error) {
+	clear := func(ctx context.Context) error {
+		if err := env.Features.ClearActiveFeature(); err != nil {
+			return err
+		}
+		return env.Rules.ClearGeneratedRules()
+	}
+
+	active, err := env.Features.GetActiveFeature()
+	if err != nil {
+		return []Finding{{Severity: SeverityError, Message: fmt.Sprintf("the active feature could not be read: %v", err), Fix: clear}}, nil
+	}
+	if active != "" && !env.Features.FeatureExists(active) {
+		return []Finding{{
+			Severity: SeverityError,
+			Message:  fmt.Sprintf("the active feature %q does not exist; it may have been deleted or renamed", active),
+			Fix:      clear,
+		}}, nil
+	}
+	return nil, nil
+}
+
+// GeneratedRulesCheck verifies that the generated Cursor rules match the active feature and phase.
+var GeneratedRulesCheck = Check{
+	Name:         "generated-rules",
+	Description:  "The generated Cursor rules match the active feature and its phase",
+	RequiresInit: true,
+	Run:          checkGeneratedRules,
+}
+
+func checkGeneratedRules(ctx context.Context, env *Env) ([]Finding, error) {
+	active, err := env.Features.GetActiveFeature()
+	if err != nil || (active != "" && !env.Features.FeatureExists(active)) {
+		// Reported by the active feature check
+		return nil, nil
+	}
+	rulesDir := filepath.Join(env.CursorRulesDir, rules.GeneratedDirName)
+
+	if active == "" {
+		generated, err := env.FS.Glob(filepath.Join(rulesDir, rules.GeneratedRulePattern))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list generated rules: %w", err)
+		}
+		if len(generated) == 0 {
+			return nil, nil
+		}
+		return []Finding{{
+			Severity: SeverityWarning,
+			Message:  "generated rules are present but no feature is active, so the AI follows stale instructions",
+			Fix: func(ctx context.Context) error {
+				return env.Rules.ClearGeneratedRules()
+			},
+		}}, nil
+	}
+
+	raw, err := env.FS.ReadFile(filepath.Join(env.FeaturesDir, active, phaseFileName))
+	current := phase.Phase(strings.TrimSpace(string(raw)))
+	if err != nil || !current.Valid() {
+		// Reported by the feature phases check
+		return nil, nil
+	}
+
+	coreContent, err := env.Generator.GenerateCoreContent(active, string(current))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate core rule: %w", err)
+	}
+	phaseContent, err := env.Generator.GeneratePhaseContent(active, string(current))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate phase rule: %w", err)
+	}
+
+	var stale []string
+	for name, want := range map[string]string{rules.CoreRuleFileName: coreContent, rules.PhaseRuleFileName: phaseContent} {
+		got, err := env.FS.ReadFile(filepath.Join(rulesDir, name))
+		if err != nil || !bytes.Equal(got, []byte(want)) {
+			stale = append(stale, name)
+		}
+	}
+	if len(stale) == 0 {
+		return nil, nil
+	}
+	return []Finding{{
+		Severity: SeverityWarning,
+		Message:  fmt.Sprintf("generated rules are missing or out of date for feature %s in the %s phase", active, current),
+		Fix: func(ctx context.Context) error {
+			return env.Rules.RefreshRules(active, string(current))
+		},
+	}}, nil
+}
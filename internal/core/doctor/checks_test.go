@@ -0,0 +1,305 @@
+package doctor
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/imcclaskey/d3/internal/core/config"
+	"github.com/imcclaskey/d3/internal/core/feature"
+	"github.com/imcclaskey/d3/internal/core/projectfiles"
+	"github.com/imcclaskey/d3/internal/core/rules"
+	"github.com/imcclaskey/d3/internal/testutil"
+)
+
+const validMCPJSON = `{"mcpServers":{"d3":{"command":"d3","args":["serve --workdir /p"]}}}`
+
+// newTestEnv returns an Env for an initialized project at /p backed by real services on memFS.
+// Only "d3" resolves on PATH.
+func newTestEnv(memFS *testutil.MemFS) *Env {
+	memFS.MkdirAll("/p/.d3/features", 0755)
+	generator := rules.NewRuleGenerator(config.Default("/p"), memFS)
+	cfg := config.Default("/p")
+	return &Env{
+		ProjectRoot:          cfg.ProjectRoot,
+		D3Dir:                cfg.D3Dir,
+		FeaturesDir:          cfg.FeaturesDir,
+		CursorRulesDir:       cfg.CursorRulesDir,
+		MCPServerName:        cfg.MCPServerName,
+		GitignorePatterns:    cfg.GitignorePatterns,
+		CursorignorePatterns: cfg.CursorignorePatterns,
+		FS:                   memFS,
+		Features:             feature.NewService("/p", "/p/.d3/features", "/p/.d3", memFS),
+		Rules:                rules.NewService(config.Default("/p"), generator, memFS),
+		Generator:            generator,
+		Files:                projectfiles.NewFileOperator(cfg),
+		LookPath: func(file string) (string, error) {
+			if file == "d3" {
+				return "/usr/local/bin/d3", nil
+			}
+			return "", errors.New("executable file not found in $PATH")
+		},
+	}
+}
+
+func TestChecks(t *testing.T) {
+	tests := []struct {
+		name  string
+		check Check
+		setup func(memFS *testutil.MemFS)
+		// wantMessages are fragments of the expected findings, in order
+		wantMessages []string
+		wantFixable  []bool
+		// verify inspects the file system after every fix has been applied
+		verify func(t *testing.T, memFS *testutil.MemFS)
+	}{
+		{
+			name:         "mcp config valid",
+			check:        MCPConfigCheck,
+			setup:        func(memFS *testutil.MemFS) { memFS.AddFile("/p/.cursor/mcp.json", validMCPJSON) },
+			wantMessages: nil,
+		},
+		{
+			name:         "mcp config missing",
+			check:        MCPConfigCheck,
+			wantMessages: []string{".cursor/mcp.json is missing"},
+			wantFixable:  []bool{true},
+			verify: func(t *testing.T, memFS *testutil.MemFS) {
+				data, err := memFS.ReadFile("/p/.cursor/mcp.json")
+				if err != nil || !strings.Contains(string(data), "serve --workdir /p") {
+					t.Errorf("mcp.json after fix = %q, %v", data, err)
+				}
+			},
+		},
+		{
+			name:         "mcp config invalid JSON is not fixable",
+			check:        MCPConfigCheck,
+			setup:        func(memFS *testutil.MemFS) { memFS.AddFile("/p/.cursor/mcp.json", "{") },
+			wantMessages: []string{"not valid JSON"},
+			wantFixable:  []bool{false},
+		},
+		{
+			name:  "mcp config stale workdir",
+			check: MCPConfigCheck,
+			setup: func(memFS *testutil.MemFS) {
+				memFS.AddFile("/p/.cursor/mcp.json", `{"mcpServers":{"d3":{"command":"d3","args":["serve --workdir /old"]},"other":{"command":"x"}}}`)
+			},
+			wantMessages: []string{"uses --workdir /old, but this checkout is at /p"},
+			wantFixable:  []bool{true},
+			verify: func(t *testing.T, memFS *testutil.MemFS) {
+				data, _ := memFS.ReadFile("/p/.cursor/mcp.json")
+				if !strings.Contains(string(data), "serve --workdir /p") || !strings.Contains(string(data), `"other"`) {
+					t.Errorf("mcp.json after fix = %s, want new workdir and other servers kept", data)
+				}
+			},
+		},
+		{
+			name:  "mcp config custom command not on PATH",
+			check: MCPConfigCheck,
+			setup: func(memFS *testutil.MemFS) {
+				memFS.AddFile("/p/.cursor/mcp.json", `{"mcpServers":{"d3":{"command":"/opt/d3","args":["serve","--workdir","/old"]}}}`)
+			},
+			wantMessages: []string{`runs "/opt/d3", which was not found on PATH`, "uses --workdir /old"},
+			// Fixing the workdir would replace the custom command
+			wantFixable: []bool{false, false},
+		},
+		{
+			name:  "mcp config without workdir",
+			check: MCPConfigCheck,
+			setup: func(memFS *testutil.MemFS) {
+				memFS.AddFile("/p/.cursor/mcp.json", `{"mcpServers":{"d3":{"command":"d3","args":["serve"]}}}`)
+			},
+			wantMessages: []string{"does not pass --workdir"},
+			wantFixable:  []bool{true},
+		},
+		{
+			name:  "gitignore complete",
+			check: GitignoreCheck,
+			setup: func(memFS *testutil.MemFS) {
+				memFS.AddFile("/p/.gitignore", "bin/\n\n# d3\n"+strings.Join(config.Default("/p").GitignorePatterns, "\n")+"\n")
+			},
+			wantMessages: nil,
+		},
+		{
+			name:  "gitignore missing entries",
+			check: GitignoreCheck,
+			setup: func(memFS *testutil.MemFS) {
+				memFS.AddFile("/p/.gitignore", "# d3\n.cursor/rules/d3/\n")
+			},
+			wantMessages: []string{"is missing .cursor/rules/d3/*.gen.mdc"},
+			wantFixable:  []bool{true},
+			verify: func(t *testing.T, memFS *testutil.MemFS) {
+				data, _ := memFS.ReadFile("/p/.gitignore")
+				if _, missing := projectfiles.MissingIgnoreEntries(data, config.Default("/p").GitignorePatterns, projectfiles.D3IgnoreSectionMarker); len(missing) > 0 {
+					t.Errorf(".gitignore after fix still misses %v", missing)
+				}
+			},
+		},
+		{
+			name:         "cursorignore without section",
+			check:        CursorignoreCheck,
+			setup:        func(memFS *testutil.MemFS) { memFS.AddFile("/p/.cursorignore", "secrets/\n") },
+			wantMessages: []string{`has no "# d3" section`},
+			wantFixable:  []bool{true},
+		},
+		{
+			name:  "feature phases",
+			check: FeaturePhasesCheck,
+			setup: func(memFS *testutil.MemFS) {
+				memFS.AddFile("/p/.d3/features/ok/.phase", "deliver\n")
+				memFS.AddFile("/p/.d3/features/shouting/.phase", "DESIGN")
+				memFS.AddFile("/p/.d3/features/bogus/.phase", "testing")
+				memFS.MkdirAll("/p/.d3/features/nophase", 0755)
+			},
+			wantMessages: []string{`feature bogus has an invalid phase "testing"`, "feature nophase has no .phase file", `feature shouting has phase "DESIGN"`},
+			wantFixable:  []bool{false, true, true},
+			verify: func(t *testing.T, memFS *testutil.MemFS) {
+				for path, want := range map[string]string{"/p/.d3/features/shouting/.phase": "design", "/p/.d3/features/nophase/.phase": "define"} {
+					if data, _ := memFS.ReadFile(path); strings.TrimSpace(string(data)) != want {
+						t.Errorf("%s after fix = %q, want %q", path, data, want)
+					}
+				}
+			},
+		},
+		{
+			name:  "active feature exists",
+			check: ActiveFeatureCheck,
+			setup: func(memFS *testutil.MemFS) {
+				memFS.AddFile("/p/.d3/features/login/.phase", "define")
+				memFS.AddFile("/p/.d3/.feature", "login")
+			},
+			wantMessages: nil,
+		},
+		{
+			name:  "active feature deleted",
+			check: ActiveFeatureCheck,
+			setup: func(memFS *testutil.MemFS) {
+				memFS.AddFile("/p/.d3/.feature", "gone")
+				memFS.AddFile("/p/.cursor/rules/d3/core.gen.mdc", "stale")
+			},
+			wantMessages: []string{`the active feature "gone" does not exist`},
+			wantFixable:  []bool{true},
+			verify: func(t *testing.T, memFS *testutil.MemFS) {
+				for _, path := range memFS.Files() {
+					if path == "/p/.d3/.feature" || strings.HasSuffix(path, ".gen.mdc") {
+						t.Errorf("%s still exists after fix", path)
+					}
+				}
+			},
+		},
+		{
+			name:         "generated rules without active feature",
+			check:        GeneratedRulesCheck,
+			setup:        func(memFS *testutil.MemFS) { memFS.AddFile("/p/.cursor/rules/d3/phase.gen.mdc", "stale") },
+			wantMessages: []string{"no feature is active"},
+			wantFixable:  []bool{true},
+			verify: func(t *testing.T, memFS *testutil.MemFS) {
+				if exists, _ := memFS.Exists("/p/.cursor/rules/d3/phase.gen.mdc"); exists {
+					t.Error("phase.gen.mdc still exists after fix")
+				}
+			},
+		},
+		{
+			name:  "generated rules out of date",
+			check: GeneratedRulesCheck,
+			setup: func(memFS *testutil.MemFS) {
+				memFS.AddFile("/p/.d3/features/login/.phase", "design")
+				memFS.AddFile("/p/.d3/.feature", "login")
+				memFS.AddFile("/p/.cursor/rules/d3/core.gen.mdc", "old")
+			},
+			wantMessages: []string{"out of date for feature login in the design phase"},
+			wantFixable:  []bool{true},
+			verify: func(t *testing.T, memFS *testutil.MemFS) {
+				data, _ := memFS.ReadFile("/p/.cursor/rules/d3/phase.gen.mdc")
+				if !strings.Contains(string(data), "login") {
+					t.Errorf("phase.gen.mdc after fix = %q, want rules for login", data)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memFS := testutil.NewMemFS()
+			env := newTestEnv(memFS)
+			if tt.setup != nil {
+				tt.setup(memFS)
+			}
+			ctx := context.Background()
+
+			findings, err := tt.check.Run(ctx, env)
+			if err != nil {
+				t.Fatalf("%s.Run() error = %v", tt.check.Name, err)
+			}
+			if len(findings) != len(tt.wantMessages) {
+				t.Fatalf("%s.Run() = %+v, want %d findings", tt.check.Name, findings, len(tt.wantMessages))
+			}
+			for i, finding := range findings {
+				if !strings.Contains(finding.Message, tt.wantMessages[i]) {
+					t.Errorf("finding %d message = %q, want to contain %q", i, finding.Message, tt.wantMessages[i])
+				}
+				if (finding.Fix != nil) != tt.wantFixable[i] {
+					t.Errorf("finding %d fixable = %v, want %v", i, finding.Fix != nil, tt.wantFixable[i])
+				}
+				if finding.Fix != nil {
+					if err := finding.Fix(ctx); err != nil {
+						t.Errorf("finding %d fix error = %v", i, err)
+					}
+				}
+			}
+			if tt.verify != nil {
+				tt.verify(t, memFS)
+			}
+
+			// Whatever could be fixed must no longer be reported
+			after, err := tt.check.Run(ctx, env)
+			if err != nil {
+				t.Fatalf("%s.Run() after fix error = %v", tt.check.Name, err)
+			}
+			for _, finding := range after {
+				if finding.Fix != nil {
+					t.Errorf("%s.Run() after fix still reports %q", tt.check.Name, finding.Message)
+				}
+			}
+		})
+	}
+}
+
+func TestProjectCheck(t *testing.T) {
+	memFS := testutil.NewMemFS()
+	env := &Env{ProjectRoot: "/p", D3Dir: "/p/.d3", FS: memFS}
+
+	findings, _ := ProjectCheck.Run(context.Background(), env)
+	if len(findings) != 1 || findings[0].Severity != SeverityError {
+		t.Errorf("ProjectCheck on an uninitialized project = %+v, want one error", findings)
+	}
+
+	memFS.MkdirAll("/p/.d3", 0755)
+	if findings, _ := ProjectCheck.Run(context.Background(), env); len(findings) != 0 {
+		t.Errorf("ProjectCheck on an initialized project = %+v, want none", findings)
+	}
+}
+
+func TestServeWorkdir(t *testing.T) {
+	tests := []struct {
+		args   projectfiles.MCPServerArgs
+		want   string
+		wantOK bool
+	}{
+		{args: projectfiles.MCPServerArgs{"serve --workdir /p"}, want: "/p", wantOK: true},
+		{args: projectfiles.MCPServerArgs{"serve", "--workdir", "/p"}, want: "/p", wantOK: true},
+		{args: projectfiles.MCPServerArgs{"serve", "--workdir=/p"}, want: "/p", wantOK: true},
+		{args: projectfiles.MCPServerArgs{"serve", "-w", "/p"}, want: "/p", wantOK: true},
+		{args: projectfiles.MCPServerArgs{"serve"}},
+		{args: projectfiles.MCPServerArgs{"serve --workdir "}},
+	}
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			got, ok := serveWorkdir(tt.args)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("serveWorkdir(%q) = %q, %v, want %q, %v", tt.args, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,235 @@
+// Package doctor checks a d3 installation for problems, such as a stale mcp.json or an active
+// feature that no longer exists, and repairs the ones it safely can.
+package doctor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/imcclaskey/d3/internal/core/feature"
+	"github.com/imcclaskey/d3/internal/core/phase"
+	"github.com/imcclaskey/d3/internal/core/ports"
+	"github.com/imcclaskey/d3/internal/core/rules"
+)
+
+// Severity ranks how much a problem matters.
+type Severity string
+
+const (
+	// SeverityInfo marks something worth knowing that does not affect d3.
+	SeverityInfo Severity = "info"
+	// SeverityWarning marks a problem d3 can work around, such as a missing ignore entry.
+	SeverityWarning Severity = "warning"
+	// SeverityError marks a problem that breaks d3 or the editor integration.
+	SeverityError Severity = "error"
+)
+
+// FeatureSource is the subset of feature operations the checks inspect and repair with.
+type FeatureSource interface {
+	ListFeatures(ctx context.Context) ([]feature.FeatureInfo, error)
+	FeatureExists(featureName string) bool
+	SetFeaturePhase(ctx context.Context, featureName string, p phase.Phase) error
+	GetActiveFeature() (string, error)
+	ClearActiveFeature() error
+}
+
+// RulesRepairer is the subset of rules operations used to repair generated rules.
+type RulesRepairer interface {
+	RefreshRules(feature string, phase string) error
+	ClearGeneratedRules() error
+}
+
+// FileRepairer is the subset of project file operations used to repair editor and ignore files.
+type FileRepairer interface {
+	EnsureMCPJSON(fs ports.FileSystem, projectRoot string) error
+	EnsureRootGitignoreEntries(fs ports.FileSystem, projectRootAbs string) error
+	EnsureRootCursorignoreEntries(fs ports.FileSystem, projectRootAbs string) error
+}
+
+// Env holds the paths and services checks use.
+type Env struct {
+	ProjectRoot    string
+	D3Dir          string
+	FeaturesDir    string
+	CursorRulesDir string
+	// MCPServerName, GitignorePatterns and CursorignorePatterns are the configured values the
+	// editor and ignore files are checked against.
+	MCPServerName        string
+	GitignorePatterns    []string
+	CursorignorePatterns []string
+
+	FS        ports.FileSystem
+	Features  FeatureSource
+	Rules     RulesRepairer
+	Generator rules.Generator
+	Files     FileRepairer
+	// LookPath resolves a command the way the editor would; exec.LookPath in production.
+	LookPath func(file string) (string, error)
+}
+
+// Finding is one problem reported by a check.
+type Finding struct {
+	Severity Severity
+	Message  string
+	// Fix repairs the problem. It is nil when the problem cannot be repaired safely.
+	Fix func(ctx context.Context) error
+}
+
+// Check inspects one aspect of an installation.
+type Check struct {
+	// Name identifies the check in reports, e.g. "mcp-config".
+	Name string
+	// Description says what the check looks at.
+	Description string
+	// RequiresInit skips the check when the project has no .d3 directory.
+	RequiresInit bool
+	// Run returns the problems found. An error means the check itself could not complete.
+	Run func(ctx context.Context, env *Env) ([]Finding, error)
+}
+
+// Problem is a finding as it appears in a report.
+type Problem struct {
+	Check    string   `json:"check"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+	Fixable  bool     `json:"fixable"`
+	Fixed    bool     `json:"fixed"`
+	// FixError is set when a fix was attempted and failed.
+	FixError string `json:"fix_error,omitempty"`
+}
+
+// Report lists the checks that ran and the problems they found.
+type Report struct {
+	Checks   []string  `json:"checks"`
+	Skipped  []string  `json:"skipped"`
+	Problems []Problem `json:"problems"`
+}
+
+// Unresolved counts problems of at least the given severity that were not fixed.
+func (r *Report) Unresolved(min Severity) int {
+	count := 0
+	for _, p := range r.Problems {
+		if !p.Fixed && rank(p.Severity) >= rank(min) {
+			count++
+		}
+	}
+	return count
+}
+
+// Fixed counts the problems that were repaired.
+func (r *Report) Fixed() int {
+	count := 0
+	for _, p := range r.Problems {
+		if p.Fixed {
+			count++
+		}
+	}
+	return count
+}
+
+// rank orders severities for comparison.
+func rank(s Severity) int {
+	switch s {
+	case SeverityError:
+		return 2
+	case SeverityWarning:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Registry holds checks in the order they run.
+type Registry struct {
+	checks []Check
+}
+
+// NewRegistry creates a registry with the given checks.
+func NewRegistry(checks ...Check) *Registry {
+	r := &Registry{}
+	for _, check := range checks {
+		r.Register(check)
+	}
+	return r
+}
+
+// DefaultRegistry returns a registry with every built-in check. Checks that repair state run
+// before the checks that depend on it, e.g. the active feature is fixed before the rules are
+// compared against it.
+func DefaultRegistry() *Registry {
+	return NewRegistry(
+		ProjectCheck,
+		MCPConfigCheck,
+		GitignoreCheck,
+		CursorignoreCheck,
+		FeaturePhasesCheck,
+		ActiveFeatureCheck,
+		GeneratedRulesCheck,
+	)
+}
+
+// Register appends a check. It panics if a check with the same name is already registered,
+// since that is a programming error.
+func (r *Registry) Register(check Check) {
+	for _, existing := range r.checks {
+		if existing.Name == check.Name {
+			panic(fmt.Sprintf("doctor: check %q registered twice", check.Name))
+		}
+	}
+	r.checks = append(r.checks, check)
+}
+
+// Checks returns the registered checks in run order.
+func (r *Registry) Checks() []Check {
+	return append([]Check(nil), r.checks...)
+}
+
+// Run runs every check in order. With fix set, each fixable problem is repaired before the
+// next check runs, so later checks see the repaired state.
+func (r *Registry) Run(ctx context.Context, env *Env, fix bool) *Report {
+	report := &Report{Checks: []string{}, Skipped: []string{}, Problems: []Problem{}}
+	initialized := isInitialized(env)
+
+	for _, check := range r.checks {
+		if check.RequiresInit && !initialized {
+			report.Skipped = append(report.Skipped, check.Name)
+			continue
+		}
+		report.Checks = append(report.Checks, check.Name)
+
+		findings, err := check.Run(ctx, env)
+		if err != nil {
+			report.Problems = append(report.Problems, Problem{
+				Check:    check.Name,
+				Severity: SeverityError,
+				Message:  fmt.Sprintf("check could not complete: %v", err),
+			})
+			continue
+		}
+
+		for _, finding := range findings {
+			problem := Problem{
+				Check:    check.Name,
+				Severity: finding.Severity,
+				Message:  finding.Message,
+				Fixable:  finding.Fix != nil,
+			}
+			if fix && finding.Fix != nil {
+				if err := finding.Fix(ctx); err != nil {
+					problem.FixError = err.Error()
+				} else {
+					problem.Fixed = true
+				}
+			}
+			report.Problems = append(report.Problems, problem)
+		}
+	}
+	return report
+}
+
+// isInitialized reports whether the project has a .d3 directory, the same test the project
+// service uses.
+func isInitialized(env *Env) bool {
+	_, err := env.FS.Stat(env.D3Dir)
+	return err == nil
+}
@@ -0,0 +1,160 @@
+package doctor
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/imcclaskey/d3/internal/testutil"
+)
+
+func TestRegistry_Run(t *testing.T) {
+	var repaired bool
+	fixable := Check{
+		Name:         "fixable",
+		RequiresInit: true,
+		Run: func(ctx context.Context, env *Env) ([]Finding, error) {
+			if repaired {
+				return nil, nil
+			}
+			return []Finding{{Severity: SeverityError, Message: "broken", Fix: func(ctx context.Context) error {
+				repaired = true
+				return nil
+			}}}, nil
+		},
+	}
+	// dependent sees the state left by fixable, since fixes run before the next check
+	dependent := Check{
+		Name:         "dependent",
+		RequiresInit: true,
+		Run: func(ctx context.Context, env *Env) ([]Finding, error) {
+			if repaired {
+				return nil, nil
+			}
+			return []Finding{{Severity: SeverityWarning, Message: "follows from broken"}}, nil
+		},
+	}
+	failingFix := Check{
+		Name: "failing-fix",
+		Run: func(ctx context.Context, env *Env) ([]Finding, error) {
+			return []Finding{{Severity: SeverityWarning, Message: "stuck", Fix: func(ctx context.Context) error {
+				return errors.New("permission denied")
+			}}}, nil
+		},
+	}
+	failingCheck := Check{
+		Name: "failing-check",
+		Run: func(ctx context.Context, env *Env) ([]Finding, error) {
+			return nil, errors.New("disk on fire")
+		},
+	}
+
+	tests := []struct {
+		name         string
+		initialized  bool
+		fix          bool
+		wantChecks   []string
+		wantSkipped  []string
+		wantProblems []Problem
+	}{
+		{
+			name:        "report only",
+			initialized: true,
+			wantChecks:  []string{"fixable", "dependent", "failing-fix", "failing-check"},
+			wantSkipped: []string{},
+			wantProblems: []Problem{
+				{Check: "fixable", Severity: SeverityError, Message: "broken", Fixable: true},
+				{Check: "dependent", Severity: SeverityWarning, Message: "follows from broken"},
+				{Check: "failing-fix", Severity: SeverityWarning, Message: "stuck", Fixable: true},
+				{Check: "failing-check", Severity: SeverityError, Message: "check could not complete: disk on fire"},
+			},
+		},
+		{
+			name:        "fix",
+			initialized: true,
+			fix:         true,
+			wantChecks:  []string{"fixable", "dependent", "failing-fix", "failing-check"},
+			wantSkipped: []string{},
+			wantProblems: []Problem{
+				{Check: "fixable", Severity: SeverityError, Message: "broken", Fixable: true, Fixed: true},
+				{Check: "failing-fix", Severity: SeverityWarning, Message: "stuck", Fixable: true, FixError: "permission denied"},
+				{Check: "failing-check", Severity: SeverityError, Message: "check could not complete: disk on fire"},
+			},
+		},
+		{
+			name:        "not initialized skips checks requiring init",
+			wantChecks:  []string{"failing-fix", "failing-check"},
+			wantSkipped: []string{"fixable", "dependent"},
+			wantProblems: []Problem{
+				{Check: "failing-fix", Severity: SeverityWarning, Message: "stuck", Fixable: true},
+				{Check: "failing-check", Severity: SeverityError, Message: "check could not complete: disk on fire"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repaired = false
+			memFS := testutil.NewMemFS()
+			if tt.initialized {
+				memFS.MkdirAll("/p/.d3", 0755)
+			}
+			env := &Env{ProjectRoot: "/p", D3Dir: "/p/.d3", FS: memFS}
+
+			report := NewRegistry(fixable, dependent, failingFix, failingCheck).Run(context.Background(), env, tt.fix)
+
+			if !reflect.DeepEqual(report.Checks, tt.wantChecks) {
+				t.Errorf("Run() checks = %v, want %v", report.Checks, tt.wantChecks)
+			}
+			if !reflect.DeepEqual(report.Skipped, tt.wantSkipped) {
+				t.Errorf("Run() skipped = %v, want %v", report.Skipped, tt.wantSkipped)
+			}
+			if !reflect.DeepEqual(report.Problems, tt.wantProblems) {
+				t.Errorf("Run() problems = %+v, want %+v", report.Problems, tt.wantProblems)
+			}
+		})
+	}
+}
+
+func TestRegistry_RegisterDuplicate(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Register() with a duplicate name did not panic")
+		}
+	}()
+	NewRegistry(Check{Name: "same"}, Check{Name: "same"})
+}
+
+func TestDefaultRegistry(t *testing.T) {
+	var names []string
+	for _, check := range DefaultRegistry().Checks() {
+		names = append(names, check.Name)
+	}
+	want := []string{"project", "mcp-config", "gitignore", "cursorignore", "feature-phases", "active-feature", "generated-rules"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("DefaultRegistry() checks = %v, want %v", names, want)
+	}
+}
+
+func TestReport_Counts(t *testing.T) {
+	report := &Report{Problems: []Problem{
+		{Severity: SeverityError},
+		{Severity: SeverityError, Fixed: true},
+		{Severity: SeverityWarning},
+		{Severity: SeverityInfo},
+	}}
+
+	if got := report.Unresolved(SeverityError); got != 1 {
+		t.Errorf("Unresolved(error) = %d, want 1", got)
+	}
+	if got := report.Unresolved(SeverityWarning); got != 2 {
+		t.Errorf("Unresolved(warning) = %d, want 2", got)
+	}
+	if got := report.Unresolved(SeverityInfo); got != 3 {
+		t.Errorf("Unresolved(info) = %d, want 3", got)
+	}
+	if got := report.Fixed(); got != 1 {
+		t.Errorf("Fixed() = %d, want 1", got)
+	}
+}
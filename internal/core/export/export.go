@@ -0,0 +1,190 @@
+// Package export renders a feature's documents as a single report.
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/imcclaskey/d3/internal/core/d3err"
+	"github.com/imcclaskey/d3/internal/core/phase"
+	"github.com/imcclaskey/d3/internal/core/ports"
+	"github.com/imcclaskey/d3/internal/core/progress"
+)
+
+// Format selects the output encoding of an export.
+type Format string
+
+const (
+	FormatMarkdown Format = "markdown"
+	FormatHTML     Format = "html"
+	FormatJSON     Format = "json"
+)
+
+// Preset selects how much of the feature an export includes.
+type Preset string
+
+const (
+	// PresetFull includes every document in full.
+	PresetFull Preset = "full"
+	// PresetPR trims the documents to fit a pull-request description.
+	PresetPR Preset = "pr"
+)
+
+// Limits applied by PresetPR. GitHub rejects PR bodies over 65536 characters.
+const (
+	prProblemLimit = 1200
+	prPlanLimit    = 2500
+	prBodyLimit    = 60000
+)
+
+// ParseFormat validates a format name, accepting "md" as shorthand for markdown.
+func ParseFormat(value string) (Format, error) {
+	switch f := Format(strings.ToLower(strings.TrimSpace(value))); f {
+	case "", "md", FormatMarkdown:
+		return FormatMarkdown, nil
+	case FormatHTML, FormatJSON:
+		return f, nil
+	default:
+		return "", d3err.New(d3err.InvalidArgument, "invalid export format %q: expected markdown, html or json", value)
+	}
+}
+
+// ParsePreset validates a preset name.
+func ParsePreset(value string) (Preset, error) {
+	switch p := Preset(strings.ToLower(strings.TrimSpace(value))); p {
+	case "", PresetFull:
+		return PresetFull, nil
+	case PresetPR:
+		return p, nil
+	default:
+		return "", d3err.New(d3err.InvalidArgument, "invalid export preset %q: expected full or pr", value)
+	}
+}
+
+// FeatureSource is the subset of feature operations the exporter needs.
+type FeatureSource interface {
+	FeatureExists(featureName string) bool
+	GetFeaturePath(featureName string) string
+	GetFeaturePhase(ctx context.Context, featureName string) (phase.Phase, error)
+}
+
+// Document is the content of one phase file.
+type Document struct {
+	Content   string     `json:"content"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// Report is everything an export renders for one feature.
+type Report struct {
+	Feature        string          `json:"feature"`
+	Phase          phase.Phase     `json:"phase"`
+	ExportedAt     time.Time       `json:"exported_at"`
+	Problem        Document        `json:"problem"`
+	Plan           Document        `json:"plan"`
+	Tasks          []progress.Task `json:"tasks"`
+	TasksUpdatedAt *time.Time      `json:"tasks_updated_at,omitempty"`
+	Warnings       []string        `json:"warnings,omitempty"`
+}
+
+// CompletedTasks returns the number of tasks marked done.
+func (r *Report) CompletedTasks() int {
+	done := 0
+	for _, task := range r.Tasks {
+		if task.Done() {
+			done++
+		}
+	}
+	return done
+}
+
+// Build collects a feature's phase documents and task list into a Report.
+// Missing documents are left empty; an unreadable progress.yaml is reported as a warning.
+func Build(ctx context.Context, fs ports.FileSystem, features FeatureSource, featureName string, now time.Time) (*Report, error) {
+	if !features.FeatureExists(featureName) {
+		return nil, d3err.New(d3err.FeatureNotFound, "feature '%s' does not exist", featureName)
+	}
+	currentPhase, err := features.GetFeaturePhase(ctx, featureName)
+	if err != nil {
+		return nil, err
+	}
+	featurePath := features.GetFeaturePath(featureName)
+
+	report := &Report{
+		Feature:    featureName,
+		Phase:      currentPhase,
+		ExportedAt: now.UTC(),
+		Tasks:      []progress.Task{},
+	}
+
+	if report.Problem, err = readDocument(fs, featurePath, phase.Define); err != nil {
+		return nil, err
+	}
+	if report.Plan, err = readDocument(fs, featurePath, phase.Design); err != nil {
+		return nil, err
+	}
+
+	progressDoc, err := readDocument(fs, featurePath, phase.Deliver)
+	if err != nil {
+		return nil, err
+	}
+	report.TasksUpdatedAt = progressDoc.UpdatedAt
+	if tasks, err := progress.Parse([]byte(progressDoc.Content)); err != nil {
+		report.Warnings = append(report.Warnings, err.Error())
+	} else {
+		report.Tasks = tasks
+	}
+
+	return report, nil
+}
+
+// readDocument reads the standard file for phase p, returning an empty document if it is missing.
+func readDocument(fs ports.FileSystem, featurePath string, p phase.Phase) (Document, error) {
+	path := filepath.Join(featurePath, string(p), phase.PhaseFileMap[p])
+	data, err := fs.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Document{}, nil
+		}
+		return Document{}, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	doc := Document{Content: strings.TrimSpace(string(data))}
+	if info, err := fs.Stat(path); err == nil {
+		updatedAt := info.ModTime().UTC()
+		doc.UpdatedAt = &updatedAt
+	}
+	return doc, nil
+}
+
+// Render encodes a report in the given format, applying the preset's size limits.
+func Render(report *Report, format Format, preset Preset) (string, error) {
+	if preset == PresetPR {
+		trimmed := *report
+		trimmed.Problem.Content = truncateMarkdown(report.Problem.Content, prProblemLimit)
+		trimmed.Plan.Content = truncateMarkdown(report.Plan.Content, prPlanLimit)
+		report = &trimmed
+	}
+
+	switch format {
+	case FormatMarkdown:
+		if preset == PresetPR {
+			return truncateMarkdown(renderPRMarkdown(report), prBodyLimit), nil
+		}
+		return renderMarkdown(report), nil
+	case FormatHTML:
+		return renderHTML(report)
+	case FormatJSON:
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("failed to encode report: %w", err)
+		}
+		return string(data) + "\n", nil
+	default:
+		return "", fmt.Errorf("unsupported export format %q", format)
+	}
+}
@@ -0,0 +1,220 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/imcclaskey/d3/internal/core/d3err"
+	"github.com/imcclaskey/d3/internal/core/phase"
+	portsmocks "github.com/imcclaskey/d3/internal/core/ports/mocks"
+	"github.com/imcclaskey/d3/internal/core/progress"
+	"github.com/imcclaskey/d3/internal/testutil"
+)
+
+var testNow = time.Date(2025, 5, 1, 12, 30, 0, 0, time.UTC)
+
+// fakeFeatures is a minimal FeatureSource for a single existing feature.
+type fakeFeatures struct {
+	name  string
+	path  string
+	phase phase.Phase
+}
+
+func (f fakeFeatures) FeatureExists(featureName string) bool    { return featureName == f.name }
+func (f fakeFeatures) GetFeaturePath(featureName string) string { return f.path }
+func (f fakeFeatures) GetFeaturePhase(ctx context.Context, featureName string) (phase.Phase, error) {
+	return f.phase, nil
+}
+
+func TestBuild(t *testing.T) {
+	featurePath := filepath.Join("/project", ".d3", "features", "login")
+	problemPath := filepath.Join(featurePath, "define", "problem.md")
+	planPath := filepath.Join(featurePath, "design", "plan.md")
+	progressPath := filepath.Join(featurePath, "deliver", "progress.yaml")
+	features := fakeFeatures{name: "login", path: featurePath, phase: phase.Deliver}
+
+	t.Run("collects documents and tasks", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockFS := portsmocks.NewMockFileSystem(ctrl)
+		modTime := testNow.Add(-time.Hour)
+		mockFS.EXPECT().ReadFile(problemPath).Return([]byte("# Problem\n\nSlow logins.\n"), nil)
+		mockFS.EXPECT().Stat(problemPath).Return(testutil.MockFileInfo{FModTime: modTime}, nil)
+		mockFS.EXPECT().ReadFile(planPath).Return(nil, os.ErrNotExist)
+		mockFS.EXPECT().ReadFile(progressPath).Return([]byte("- id: 1\n  description: Cache sessions\n  status: complete\n"), nil)
+		mockFS.EXPECT().Stat(progressPath).Return(testutil.MockFileInfo{FModTime: modTime}, nil)
+
+		report, err := Build(context.Background(), mockFS, features, "login", testNow)
+		if err != nil {
+			t.Fatalf("Build() unexpected error: %v", err)
+		}
+		if report.Phase != phase.Deliver || report.Problem.Content != "# Problem\n\nSlow logins." || report.Plan.Content != "" {
+			t.Errorf("Build() report = %+v", report)
+		}
+		if report.Problem.UpdatedAt == nil || !report.Problem.UpdatedAt.Equal(modTime) {
+			t.Errorf("Build() problem updated at = %v, want %v", report.Problem.UpdatedAt, modTime)
+		}
+		if len(report.Tasks) != 1 || report.CompletedTasks() != 1 {
+			t.Errorf("Build() tasks = %+v", report.Tasks)
+		}
+	})
+
+	t.Run("unparsable progress becomes a warning", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockFS := portsmocks.NewMockFileSystem(ctrl)
+		mockFS.EXPECT().ReadFile(problemPath).Return(nil, os.ErrNotExist)
+		mockFS.EXPECT().ReadFile(planPath).Return(nil, os.ErrNotExist)
+		mockFS.EXPECT().ReadFile(progressPath).Return([]byte("tasks: ["), nil)
+		mockFS.EXPECT().Stat(progressPath).Return(testutil.MockFileInfo{FModTime: testNow}, nil)
+
+		report, err := Build(context.Background(), mockFS, features, "login", testNow)
+		if err != nil {
+			t.Fatalf("Build() unexpected error: %v", err)
+		}
+		if len(report.Warnings) != 1 || len(report.Tasks) != 0 {
+			t.Errorf("Build() warnings = %v, tasks = %v", report.Warnings, report.Tasks)
+		}
+	})
+
+	t.Run("read error fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockFS := portsmocks.NewMockFileSystem(ctrl)
+		mockFS.EXPECT().ReadFile(problemPath).Return(nil, fmt.Errorf("permission denied"))
+
+		if _, err := Build(context.Background(), mockFS, features, "login", testNow); err == nil {
+			t.Errorf("Build() expected error")
+		}
+	})
+
+	t.Run("missing feature", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		if _, err := Build(context.Background(), portsmocks.NewMockFileSystem(ctrl), features, "other", testNow); !errors.Is(err, d3err.ErrFeatureNotFound) {
+			t.Errorf("Build() error = %v, want a feature not found error", err)
+		}
+	})
+}
+
+func testReport() *Report {
+	updated := testNow.Add(-time.Hour)
+	return &Report{
+		Feature:    "login",
+		Phase:      phase.Deliver,
+		ExportedAt: testNow,
+		Problem:    Document{Content: "# Problem\n\nSlow logins.", UpdatedAt: &updated},
+		Plan:       Document{Content: "## Steps\n\n```sh\n# comment\n```\n1. Cache <sessions>"},
+		Tasks: []progress.Task{
+			{ID: "1", Description: "Cache sessions", Type: "code", Status: "complete"},
+			{ID: "2", Description: "Measure | compare", Type: "verify", Status: "pending"},
+		},
+	}
+}
+
+func TestRender_Markdown(t *testing.T) {
+	got, err := Render(testReport(), FormatMarkdown, PresetFull)
+	if err != nil {
+		t.Fatalf("Render() unexpected error: %v", err)
+	}
+	for _, want := range []string{
+		"# Feature: login",
+		"- **Phase:** deliver",
+		"- **Progress:** 1 of 2 tasks complete",
+		"## Problem\n\n_Last updated 2025-05-01T11:30:00Z_\n\n### Problem",
+		"#### Steps",
+		"# comment", // Headings inside code fences are untouched
+		"| 2 | Measure \\| compare | verify | pending |",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Render(markdown) missing %q in:\n%s", want, got)
+		}
+	}
+}
+
+func TestRender_PRPreset(t *testing.T) {
+	report := testReport()
+	report.Problem.Content = strings.Repeat("Long paragraph text. ", 40) + "\n\n" + strings.Repeat("More detail. ", 200)
+
+	got, err := Render(report, FormatMarkdown, PresetPR)
+	if err != nil {
+		t.Fatalf("Render() unexpected error: %v", err)
+	}
+	for _, want := range []string{"## Summary", "## Approach", "## Tasks (1/2 complete)", "- [x] Cache sessions", "- [ ] Measure | compare", "_(truncated)_"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Render(pr) missing %q in:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "More detail.") {
+		t.Errorf("Render(pr) did not truncate the problem statement")
+	}
+	if report.Problem.Content == "" || !strings.Contains(report.Problem.Content, "More detail.") {
+		t.Errorf("Render(pr) modified the caller's report")
+	}
+}
+
+func TestRender_JSON(t *testing.T) {
+	got, err := Render(testReport(), FormatJSON, PresetFull)
+	if err != nil {
+		t.Fatalf("Render() unexpected error: %v", err)
+	}
+	var decoded Report
+	if err := json.Unmarshal([]byte(got), &decoded); err != nil {
+		t.Fatalf("Render(json) produced invalid JSON: %v", err)
+	}
+	if decoded.Feature != "login" || len(decoded.Tasks) != 2 || decoded.Tasks[0].Status != "complete" {
+		t.Errorf("Render(json) decoded = %+v", decoded)
+	}
+}
+
+func TestRender_HTML(t *testing.T) {
+	got, err := Render(testReport(), FormatHTML, PresetFull)
+	if err != nil {
+		t.Fatalf("Render() unexpected error: %v", err)
+	}
+	for _, want := range []string{
+		"<!DOCTYPE html>",
+		"<h3>Problem</h3>",
+		"<pre><code># comment\n</code></pre>",
+		"<li>Cache &lt;sessions&gt;</li>",
+		`<td class="done">complete</td>`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Render(html) missing %q in:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "<script") || strings.Contains(got, "http://") || strings.Contains(got, "https://") {
+		t.Errorf("Render(html) is not self-contained")
+	}
+}
+
+func TestParseFormatAndPreset(t *testing.T) {
+	if f, err := ParseFormat("md"); err != nil || f != FormatMarkdown {
+		t.Errorf("ParseFormat(md) = %v, %v", f, err)
+	}
+	if _, err := ParseFormat("pdf"); !errors.Is(err, d3err.ErrInvalidArgument) {
+		t.Errorf("ParseFormat(pdf) error = %v, want ErrInvalidArgument", err)
+	}
+	if p, err := ParsePreset(""); err != nil || p != PresetFull {
+		t.Errorf("ParsePreset(\"\") = %v, %v", p, err)
+	}
+	if _, err := ParsePreset("tiny"); !errors.Is(err, d3err.ErrInvalidArgument) {
+		t.Errorf("ParsePreset(tiny) error = %v, want ErrInvalidArgument", err)
+	}
+}
+
+func TestTruncateMarkdown(t *testing.T) {
+	md := "intro\n\n```go\n" + strings.Repeat("code line\n", 50) + "```\n"
+	got := truncateMarkdown(md, 120)
+	if len(got) > 124 {
+		t.Errorf("truncateMarkdown() length = %d, want about 120", len(got))
+	}
+	if strings.Count(got, "```")%2 != 0 {
+		t.Errorf("truncateMarkdown() left a code fence open:\n%s", got)
+	}
+}
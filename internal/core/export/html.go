@@ -0,0 +1,160 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"html/template"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// htmlPage is a self-contained page: styles are inline and nothing is loaded from the network.
+var htmlPage = template.Must(template.New("export").Funcs(template.FuncMap{
+	"markdown": markdownToHTML,
+	"date":     func(t *time.Time) string { return t.Format(time.RFC3339) },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Feature: {{.Feature}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; max-width: 52rem; margin: 2rem auto; padding: 0 1rem; line-height: 1.5; color: #1f2328; }
+h1, h2 { border-bottom: 1px solid #d0d7de; padding-bottom: .3rem; }
+.meta { color: #59636e; }
+.updated { color: #59636e; font-style: italic; }
+.warning { background: #fff8c5; border-left: 4px solid #d4a72c; padding: .5rem 1rem; }
+pre { background: #f6f8fa; padding: 1rem; overflow-x: auto; }
+code { background: #f6f8fa; padding: .1rem .3rem; }
+table { border-collapse: collapse; width: 100%; }
+th, td { border: 1px solid #d0d7de; padding: .4rem .6rem; text-align: left; }
+.done { color: #1a7f37; }
+</style>
+</head>
+<body>
+<h1>Feature: {{.Feature}}</h1>
+<p class="meta">Phase: {{if .Phase}}{{.Phase}}{{else}}none{{end}} &middot; Exported {{.ExportedAt.Format "2006-01-02T15:04:05Z07:00"}}{{if .Tasks}} &middot; {{.CompletedTasks}} of {{len .Tasks}} tasks complete{{end}}</p>
+<h2>Problem</h2>
+{{with .Problem.UpdatedAt}}<p class="updated">Last updated {{date .}}</p>{{end}}
+{{if .Problem.Content}}{{markdown .Problem.Content}}{{else}}<p><em>Not written yet.</em></p>{{end}}
+<h2>Plan</h2>
+{{with .Plan.UpdatedAt}}<p class="updated">Last updated {{date .}}</p>{{end}}
+{{if .Plan.Content}}{{markdown .Plan.Content}}{{else}}<p><em>Not written yet.</em></p>{{end}}
+<h2>Tasks</h2>
+{{with .TasksUpdatedAt}}<p class="updated">Last updated {{date .}}</p>{{end}}
+{{range .Warnings}}<p class="warning">{{.}}</p>
+{{end}}{{if .Tasks}}<table>
+<thead><tr><th>ID</th><th>Task</th><th>Type</th><th>Status</th></tr></thead>
+<tbody>
+{{range .Tasks}}<tr><td>{{.ID}}</td><td>{{.Description}}</td><td>{{.Type}}</td><td{{if .Done}} class="done"{{end}}>{{.Status}}</td></tr>
+{{end}}</tbody>
+</table>{{else}}<p><em>No tasks recorded.</em></p>{{end}}
+</body>
+</html>
+`))
+
+// renderHTML renders the report as a standalone HTML page.
+func renderHTML(r *Report) (string, error) {
+	var buf bytes.Buffer
+	if err := htmlPage.Execute(&buf, r); err != nil {
+		return "", fmt.Errorf("failed to render HTML export: %w", err)
+	}
+	return buf.String(), nil
+}
+
+var (
+	orderedItemPattern = regexp.MustCompile(`^\d+[.)]\s+`)
+	inlineCodePattern  = regexp.MustCompile("`([^`]+)`")
+	boldPattern        = regexp.MustCompile(`\*\*([^*]+)\*\*`)
+)
+
+// markdownToHTML converts the markdown subset used in d3 documents: headings, paragraphs,
+// bullet and numbered lists, fenced code blocks, inline code and bold. Everything else is
+// escaped and shown as text, so the output is always safe to embed.
+func markdownToHTML(md string) template.HTML {
+	var b strings.Builder
+	var paragraph []string
+	listTag := ""
+	inFence := false
+
+	flushParagraph := func() {
+		if len(paragraph) > 0 {
+			b.WriteString("<p>" + inlineHTML(strings.Join(paragraph, " ")) + "</p>\n")
+			paragraph = nil
+		}
+	}
+	closeList := func() {
+		if listTag != "" {
+			b.WriteString("</" + listTag + ">\n")
+			listTag = ""
+		}
+	}
+	openList := func(tag string) {
+		if listTag != tag {
+			closeList()
+			b.WriteString("<" + tag + ">\n")
+			listTag = tag
+		}
+	}
+
+	for _, line := range strings.Split(demoteHeadings(md, 2), "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			if inFence {
+				b.WriteString("</code></pre>\n")
+			} else {
+				flushParagraph()
+				closeList()
+				b.WriteString("<pre><code>")
+			}
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			b.WriteString(html.EscapeString(line) + "\n")
+			continue
+		}
+
+		switch {
+		case trimmed == "":
+			flushParagraph()
+			closeList()
+		case strings.HasPrefix(trimmed, "#"):
+			level := len(trimmed) - len(strings.TrimLeft(trimmed, "#"))
+			if level <= 6 && len(trimmed) > level && trimmed[level] == ' ' {
+				flushParagraph()
+				closeList()
+				fmt.Fprintf(&b, "<h%d>%s</h%d>\n", level, inlineHTML(strings.TrimSpace(trimmed[level:])), level)
+			} else {
+				paragraph = append(paragraph, trimmed)
+			}
+		case strings.HasPrefix(trimmed, "- ") || strings.HasPrefix(trimmed, "* ") || strings.HasPrefix(trimmed, "+ "):
+			flushParagraph()
+			openList("ul")
+			b.WriteString("<li>" + inlineHTML(strings.TrimSpace(trimmed[2:])) + "</li>\n")
+		case orderedItemPattern.MatchString(trimmed):
+			flushParagraph()
+			openList("ol")
+			b.WriteString("<li>" + inlineHTML(orderedItemPattern.ReplaceAllString(trimmed, "")) + "</li>\n")
+		default:
+			closeList()
+			paragraph = append(paragraph, trimmed)
+		}
+	}
+
+	if inFence {
+		b.WriteString("</code></pre>\n")
+	}
+	flushParagraph()
+	closeList()
+	return template.HTML(b.String())
+}
+
+// inlineHTML escapes text and applies inline code and bold markup.
+func inlineHTML(text string) string {
+	escaped := html.EscapeString(text)
+	escaped = inlineCodePattern.ReplaceAllString(escaped, "<code>$1</code>")
+	return boldPattern.ReplaceAllString(escaped, "<strong>$1</strong>")
+}
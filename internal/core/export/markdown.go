@@ -0,0 +1,155 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/imcclaskey/d3/internal/core/progress"
+)
+
+// renderMarkdown lays out the full report as a markdown document.
+func renderMarkdown(r *Report) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Feature: %s\n\n", r.Feature)
+	fmt.Fprintf(&b, "- **Phase:** %s\n", phaseLabel(r))
+	fmt.Fprintf(&b, "- **Exported:** %s\n", r.ExportedAt.Format(time.RFC3339))
+	if len(r.Tasks) > 0 {
+		fmt.Fprintf(&b, "- **Progress:** %d of %d tasks complete\n", r.CompletedTasks(), len(r.Tasks))
+	}
+
+	writeSection(&b, "Problem", r.Problem)
+	writeSection(&b, "Plan", r.Plan)
+
+	b.WriteString("\n## Tasks\n\n")
+	if r.TasksUpdatedAt != nil {
+		fmt.Fprintf(&b, "_Last updated %s_\n\n", r.TasksUpdatedAt.Format(time.RFC3339))
+	}
+	for _, warning := range r.Warnings {
+		fmt.Fprintf(&b, "> **Warning:** %s\n\n", warning)
+	}
+	if len(r.Tasks) == 0 {
+		b.WriteString("_No tasks recorded._\n")
+	} else {
+		b.WriteString(taskTable(r.Tasks))
+	}
+	return b.String()
+}
+
+// renderPRMarkdown lays out a condensed report suited to a pull-request description.
+func renderPRMarkdown(r *Report) string {
+	var b strings.Builder
+	b.WriteString("## Summary\n\n")
+	if r.Problem.Content == "" {
+		b.WriteString("_No problem statement recorded._\n")
+	} else {
+		b.WriteString(demoteHeadings(r.Problem.Content, 2) + "\n")
+	}
+
+	if r.Plan.Content != "" {
+		b.WriteString("\n## Approach\n\n")
+		b.WriteString(demoteHeadings(r.Plan.Content, 2) + "\n")
+	}
+
+	if len(r.Tasks) > 0 {
+		fmt.Fprintf(&b, "\n## Tasks (%d/%d complete)\n\n", r.CompletedTasks(), len(r.Tasks))
+		for _, task := range r.Tasks {
+			check := " "
+			if task.Done() {
+				check = "x"
+			}
+			fmt.Fprintf(&b, "- [%s] %s\n", check, oneLine(task.Description))
+		}
+	}
+
+	fmt.Fprintf(&b, "\n_Generated by d3 from feature `%s` (phase: %s)._\n", r.Feature, phaseLabel(r))
+	return b.String()
+}
+
+// writeSection appends a document under a level-two heading, demoting its own headings below it.
+func writeSection(b *strings.Builder, title string, doc Document) {
+	fmt.Fprintf(b, "\n## %s\n\n", title)
+	if doc.UpdatedAt != nil {
+		fmt.Fprintf(b, "_Last updated %s_\n\n", doc.UpdatedAt.Format(time.RFC3339))
+	}
+	if doc.Content == "" {
+		b.WriteString("_Not written yet._\n")
+		return
+	}
+	b.WriteString(demoteHeadings(doc.Content, 2) + "\n")
+}
+
+// taskTable renders tasks as a markdown table.
+func taskTable(tasks []progress.Task) string {
+	var b strings.Builder
+	b.WriteString("| ID | Task | Type | Status |\n")
+	b.WriteString("|----|------|------|--------|\n")
+	for _, task := range tasks {
+		fmt.Fprintf(&b, "| %s | %s | %s | %s |\n",
+			tableCell(task.ID), tableCell(task.Description), tableCell(task.Type), tableCell(task.Status))
+	}
+	return b.String()
+}
+
+// phaseLabel returns the report phase, or "none" when no phase is set.
+func phaseLabel(r *Report) string {
+	if r.Phase == "" {
+		return "none"
+	}
+	return string(r.Phase)
+}
+
+// tableCell makes text safe to place inside a markdown table cell.
+func tableCell(s string) string {
+	return strings.ReplaceAll(oneLine(s), "|", `\|`)
+}
+
+// oneLine collapses newlines so text fits on a single line.
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// demoteHeadings pushes every ATX heading outside code fences down by levels, capped at level six.
+func demoteHeadings(md string, levels int) string {
+	lines := strings.Split(md, "\n")
+	inFence := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence || !strings.HasPrefix(line, "#") {
+			continue
+		}
+		level := len(line) - len(strings.TrimLeft(line, "#"))
+		if level > 6 || (len(line) > level && line[level] != ' ') {
+			continue // Not a heading, e.g. "#hashtag"
+		}
+		newLevel := level + levels
+		if newLevel > 6 {
+			newLevel = 6
+		}
+		lines[i] = strings.Repeat("#", newLevel) + line[level:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// truncateMarkdown shortens md to at most limit bytes, cutting at a paragraph break where possible
+// and closing any code fence left open by the cut.
+func truncateMarkdown(md string, limit int) string {
+	if len(md) <= limit {
+		return md
+	}
+	const marker = "\n\n_(truncated)_"
+	cut := strings.ToValidUTF8(md[:limit-len(marker)], "")
+	if idx := strings.LastIndex(cut, "\n\n"); idx > len(cut)/2 {
+		cut = cut[:idx]
+	} else if idx := strings.LastIndex(cut, "\n"); idx > 0 {
+		cut = cut[:idx]
+	}
+	if strings.Count(cut, "```")%2 == 1 {
+		cut += "\n```"
+	}
+	return strings.TrimRight(cut, "\n") + marker
+}
@@ -0,0 +1,159 @@
+package feature
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/imcclaskey/d3/internal/core/ports"
+)
+
+// SessionEnv names the environment variable that selects an explicit d3 session.
+// Each session tracks its own active feature.
+const SessionEnv = "D3_SESSION"
+
+const sessionsDirName = "sessions" // Directory under .d3 holding per-session active feature files
+
+// sessionKeyPattern matches characters that are replaced when deriving a session file name.
+var sessionKeyPattern = regexp.MustCompile(`[^a-z0-9._-]+`)
+
+// ActiveFeatureStore persists which feature is currently active.
+// Implementations decide whose context the active feature belongs to.
+type ActiveFeatureStore interface {
+	// Get returns the active feature name, or an empty string if none is active.
+	Get() (string, error)
+	// Set records featureName as the active feature.
+	Set(featureName string) error
+	// Clear removes the active feature.
+	Clear() error
+	// Location describes where the active feature is stored, for diagnostics.
+	Location() string
+}
+
+// FileActiveStore keeps the active feature name in a single plain-text file.
+type FileActiveStore struct {
+	path string
+	fs   ports.FileSystem
+}
+
+// NewFileActiveStore creates a store backed by the file at path.
+func NewFileActiveStore(path string, fs ports.FileSystem) *FileActiveStore {
+	return &FileActiveStore{path: path, fs: fs}
+}
+
+// Get reads the active feature name from the store file.
+// Returns an empty string and nil error if the file is empty or does not exist.
+func (s *FileActiveStore) Get() (string, error) {
+	data, err := s.fs.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil // File not existing means no active feature
+		}
+		return "", fmt.Errorf("failed to read active feature file %s: %w", s.path, err)
+	}
+	// Return the content, trimming whitespace
+	return strings.TrimSpace(string(data)), nil
+}
+
+// Set saves the active feature name to the store file.
+func (s *FileActiveStore) Set(featureName string) error {
+	// Ensure the base directory exists
+	if err := s.fs.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("failed to create active feature file directory: %w", err)
+	}
+
+	// Write the feature name as plain text
+	if err := s.fs.WriteFile(s.path, []byte(featureName), 0644); err != nil {
+		return fmt.Errorf("failed to write active feature file %s: %w", s.path, err)
+	}
+	return nil
+}
+
+// Clear removes the store file, effectively clearing the active feature.
+func (s *FileActiveStore) Clear() error {
+	err := s.fs.Remove(s.path)
+	// Ignore "not exist" error, as it means the state is already cleared
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove active feature file %s: %w", s.path, err)
+	}
+	return nil
+}
+
+// Location returns the path of the store file.
+func (s *FileActiveStore) Location() string {
+	return s.path
+}
+
+// ResolveActiveFeatureStore picks where the active feature is kept for the current session.
+// An explicit D3_SESSION wins; otherwise a linked git worktree gets its own store. The shared
+// .d3/.feature file is used when neither applies. Worktrees are detected from the local .git
+// file alone, so no git binary is required.
+func ResolveActiveFeatureStore(projectRoot, d3Dir string, fs ports.FileSystem, getenv func(string) string) ActiveFeatureStore {
+	sessionsDir := filepath.Join(d3Dir, sessionsDirName)
+
+	if session := SessionName(getenv); session != "" {
+		return NewFileActiveStore(filepath.Join(sessionsDir, session), fs)
+	}
+
+	if worktree := findWorktreeName(projectRoot, fs); worktree != "" {
+		return NewFileActiveStore(filepath.Join(sessionsDir, "worktree-"+worktree), fs)
+	}
+
+	return NewFileActiveStore(filepath.Join(d3Dir, activeFeatureFileName), fs)
+}
+
+// SessionName returns the session selected by D3_SESSION as it names the session's store, or an
+// empty string when none is set. Linked worktrees are not sessions in this sense: each has its own
+// checkout and so its own generated rules.
+func SessionName(getenv func(string) string) string {
+	return sessionKey(getenv(SessionEnv))
+}
+
+// sessionKey turns a raw session identifier into a safe file name.
+func sessionKey(raw string) string {
+	key := sessionKeyPattern.ReplaceAllString(strings.ToLower(strings.TrimSpace(raw)), "-")
+	return strings.Trim(key, ".-")
+}
+
+// findWorktreeName walks up from dir looking for .git. When .git is a file pointing into
+// <repo>/.git/worktrees/<name>, the directory is a linked worktree and <name> is returned.
+// The main worktree, where .git is a directory, yields an empty string.
+func findWorktreeName(dir string, fs ports.FileSystem) string {
+	for {
+		gitPath := filepath.Join(dir, ".git")
+		if info, err := fs.Stat(gitPath); err == nil {
+			if info.IsDir() {
+				return ""
+			}
+			data, err := fs.ReadFile(gitPath)
+			if err != nil {
+				return ""
+			}
+			return worktreeNameFromGitFile(string(data))
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// worktreeNameFromGitFile extracts the worktree name from the contents of a .git file.
+func worktreeNameFromGitFile(content string) string {
+	for _, line := range strings.Split(content, "\n") {
+		gitDir, ok := strings.CutPrefix(strings.TrimSpace(line), "gitdir:")
+		if !ok {
+			continue
+		}
+		gitDir = filepath.Clean(strings.TrimSpace(gitDir))
+		if filepath.Base(filepath.Dir(gitDir)) != "worktrees" {
+			return "" // Submodules and other layouts share the fallback store
+		}
+		return sessionKey(filepath.Base(gitDir))
+	}
+	return ""
+}
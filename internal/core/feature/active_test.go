@@ -0,0 +1,133 @@
+package feature
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	portsmocks "github.com/imcclaskey/d3/internal/core/ports/mocks"
+	"github.com/imcclaskey/d3/internal/testutil"
+)
+
+func TestResolveActiveFeatureStore(t *testing.T) {
+	projectRoot := filepath.FromSlash("/work/repo")
+	d3Dir := filepath.Join(projectRoot, ".d3")
+	gitPath := filepath.Join(projectRoot, ".git")
+
+	tests := []struct {
+		name       string
+		session    string
+		setupMocks func(mockFS *portsmocks.MockFileSystem)
+		want       string
+	}{
+		{
+			name:       "explicit session wins",
+			session:    "Alice Laptop",
+			setupMocks: func(mockFS *portsmocks.MockFileSystem) {},
+			want:       filepath.Join(d3Dir, "sessions", "alice-laptop"),
+		},
+		{
+			name: "linked worktree",
+			setupMocks: func(mockFS *portsmocks.MockFileSystem) {
+				mockFS.EXPECT().Stat(gitPath).Return(testutil.MockFileInfo{FIsDir: false}, nil).Times(1)
+				mockFS.EXPECT().ReadFile(gitPath).Return([]byte("gitdir: /work/main/.git/worktrees/login-fix\n"), nil).Times(1)
+			},
+			want: filepath.Join(d3Dir, "sessions", "worktree-login-fix"),
+		},
+		{
+			name: "main worktree uses shared file",
+			setupMocks: func(mockFS *portsmocks.MockFileSystem) {
+				mockFS.EXPECT().Stat(gitPath).Return(testutil.MockFileInfo{FIsDir: true}, nil).Times(1)
+			},
+			want: filepath.Join(d3Dir, ".feature"),
+		},
+		{
+			name: "submodule uses shared file",
+			setupMocks: func(mockFS *portsmocks.MockFileSystem) {
+				mockFS.EXPECT().Stat(gitPath).Return(testutil.MockFileInfo{FIsDir: false}, nil).Times(1)
+				mockFS.EXPECT().ReadFile(gitPath).Return([]byte("gitdir: ../.git/modules/repo"), nil).Times(1)
+			},
+			want: filepath.Join(d3Dir, ".feature"),
+		},
+		{
+			name: "worktree found in parent directory",
+			setupMocks: func(mockFS *portsmocks.MockFileSystem) {
+				parentGit := filepath.Join(filepath.Dir(projectRoot), ".git")
+				mockFS.EXPECT().Stat(gitPath).Return(nil, os.ErrNotExist).Times(1)
+				mockFS.EXPECT().Stat(parentGit).Return(testutil.MockFileInfo{FIsDir: false}, nil).Times(1)
+				mockFS.EXPECT().ReadFile(parentGit).Return([]byte("gitdir: /src/.git/worktrees/wt2"), nil).Times(1)
+			},
+			want: filepath.Join(d3Dir, "sessions", "worktree-wt2"),
+		},
+		{
+			name: "no git repository",
+			setupMocks: func(mockFS *portsmocks.MockFileSystem) {
+				mockFS.EXPECT().Stat(gomock.Any()).Return(nil, os.ErrNotExist).AnyTimes()
+			},
+			want: filepath.Join(d3Dir, ".feature"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockFS := portsmocks.NewMockFileSystem(ctrl)
+			tt.setupMocks(mockFS)
+			getenv := func(key string) string {
+				if key == SessionEnv {
+					return tt.session
+				}
+				return ""
+			}
+
+			store := ResolveActiveFeatureStore(projectRoot, d3Dir, mockFS, getenv)
+			if got := store.Location(); got != tt.want {
+				t.Errorf("ResolveActiveFeatureStore() location = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSessionName(t *testing.T) {
+	getenv := func(value string) func(string) string {
+		return func(key string) string {
+			if key == SessionEnv {
+				return value
+			}
+			return ""
+		}
+	}
+	if got := SessionName(getenv(" Alice Laptop ")); got != "alice-laptop" {
+		t.Errorf("SessionName() = %q, want %q", got, "alice-laptop")
+	}
+	if got := SessionName(getenv("")); got != "" {
+		t.Errorf("SessionName() without D3_SESSION = %q, want empty", got)
+	}
+}
+
+func TestService_ActiveFeatureStore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	s, mockFS := newTestService(t, ctrl)
+	sessionPath := filepath.Join(s.d3Dir, "sessions", "bob")
+	s.SetActiveFeatureStore(NewFileActiveStore(sessionPath, mockFS))
+
+	mockFS.EXPECT().MkdirAll(filepath.Dir(sessionPath), os.FileMode(0755)).Return(nil).Times(1)
+	mockFS.EXPECT().WriteFile(sessionPath, []byte("login"), os.FileMode(0644)).Return(nil).Times(1)
+	mockFS.EXPECT().ReadFile(sessionPath).Return([]byte("login\n"), nil).Times(1)
+	mockFS.EXPECT().Remove(sessionPath).Return(fmt.Errorf("permission denied")).Times(1)
+
+	if err := s.SetActiveFeature("login"); err != nil {
+		t.Fatalf("SetActiveFeature() unexpected error: %v", err)
+	}
+	if got, err := s.GetActiveFeature(); err != nil || got != "login" {
+		t.Errorf("GetActiveFeature() = %q, %v; want \"login\", nil", got, err)
+	}
+	if err := s.ClearActiveFeature(); err == nil {
+		t.Errorf("ClearActiveFeature() expected error from store, got nil")
+	}
+	if got := s.ActiveFeatureLocation(); got != sessionPath {
+		t.Errorf("ActiveFeatureLocation() = %s, want %s", got, sessionPath)
+	}
+}
@@ -0,0 +1,64 @@
+package feature
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/imcclaskey/d3/internal/core/d3err"
+)
+
+const branchFileName = ".branch" // File in a feature directory recording its git branch
+
+// DefaultBranchPattern is the branch name used for new features when no pattern is configured.
+// The {name} placeholder is replaced with the feature name.
+const DefaultBranchPattern = "feature/{name}"
+
+// BranchPatternEnv names the environment variable that overrides DefaultBranchPattern.
+const BranchPatternEnv = "D3_BRANCH_PATTERN"
+
+// BranchName builds the git branch name for a feature from pattern.
+// An empty pattern uses DefaultBranchPattern; a pattern without {name} gets the name appended.
+func BranchName(pattern, featureName string) string {
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" {
+		pattern = DefaultBranchPattern
+	}
+	if !strings.Contains(pattern, "{name}") {
+		return pattern + featureName
+	}
+	return strings.ReplaceAll(pattern, "{name}", featureName)
+}
+
+// GetFeatureBranch returns the git branch recorded for a feature.
+// Returns an empty string and nil error if no branch is associated.
+func (s *Service) GetFeatureBranch(featureName string) (string, error) {
+	if err := ValidateName(featureName); err != nil {
+		return "", err
+	}
+	branchFilePath := filepath.Join(s.featuresDir, featureName, branchFileName)
+	data, err := s.fs.ReadFile(branchFilePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to read %s for feature %s: %w", branchFileName, featureName, err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// SetFeatureBranch records the git branch associated with a feature.
+func (s *Service) SetFeatureBranch(featureName, branch string) error {
+	if err := ValidateName(featureName); err != nil {
+		return err
+	}
+	if !s.FeatureExists(featureName) {
+		return d3err.New(d3err.FeatureNotFound, "feature %s does not exist, cannot set branch", featureName)
+	}
+	branchFilePath := filepath.Join(s.featuresDir, featureName, branchFileName)
+	if err := s.fs.WriteFile(branchFilePath, []byte(branch+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to write %s for feature %s: %w", branchFileName, featureName, err)
+	}
+	return nil
+}
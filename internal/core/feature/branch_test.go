@@ -0,0 +1,80 @@
+package feature
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/imcclaskey/d3/internal/testutil"
+)
+
+func TestBranchName(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    string
+	}{
+		{pattern: "", want: "feature/login"},
+		{pattern: "{name}", want: "login"},
+		{pattern: "alice/{name}-wip", want: "alice/login-wip"},
+		{pattern: "topic/", want: "topic/login"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			if got := BranchName(tt.pattern, "login"); got != tt.want {
+				t.Errorf("BranchName(%q, login) = %q, want %q", tt.pattern, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_GetFeatureBranch(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		readErr error
+		want    string
+		wantErr bool
+	}{
+		{name: "recorded branch", data: []byte("feature/login\n"), want: "feature/login"},
+		{name: "no branch file", readErr: os.ErrNotExist, want: ""},
+		{name: "read error", readErr: fmt.Errorf("disk error"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			s, mockFS := newTestService(t, ctrl)
+			branchPath := filepath.Join(s.featuresDir, "login", ".branch")
+			mockFS.EXPECT().ReadFile(branchPath).Return(tt.data, tt.readErr).Times(1)
+
+			got, err := s.GetFeatureBranch("login")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetFeatureBranch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("GetFeatureBranch() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_SetFeatureBranch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	s, mockFS := newTestService(t, ctrl)
+	featurePath := filepath.Join(s.featuresDir, "login")
+
+	mockFS.EXPECT().Stat(featurePath).Return(testutil.MockFileInfo{FIsDir: true}, nil).Times(1)
+	mockFS.EXPECT().WriteFile(filepath.Join(featurePath, ".branch"), []byte("feature/login\n"), os.FileMode(0644)).Return(nil).Times(1)
+
+	if err := s.SetFeatureBranch("login", "feature/login"); err != nil {
+		t.Fatalf("SetFeatureBranch() unexpected error: %v", err)
+	}
+
+	mockFS.EXPECT().Stat(filepath.Join(s.featuresDir, "missing")).Return(nil, os.ErrNotExist).Times(1)
+	if err := s.SetFeatureBranch("missing", "feature/missing"); err == nil {
+		t.Errorf("SetFeatureBranch() on missing feature expected error")
+	}
+}
@@ -0,0 +1,172 @@
+package feature
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/imcclaskey/d3/internal/core/bundle"
+	"github.com/imcclaskey/d3/internal/core/d3err"
+	"github.com/imcclaskey/d3/internal/core/phase"
+	"github.com/imcclaskey/d3/internal/version"
+)
+
+// unbundledFiles are feature files tied to the source repository rather than the feature itself.
+var unbundledFiles = map[string]bool{
+	branchFileName:  true,
+	dependsFileName: true,
+}
+
+// PackFeature bundles a feature directory as a gzipped tar archive with a manifest.
+func (s *Service) PackFeature(ctx context.Context, featureName string) ([]byte, error) {
+	if err := ValidateName(featureName); err != nil {
+		return nil, err
+	}
+	if !s.FeatureExists(featureName) {
+		return nil, d3err.New(d3err.FeatureNotFound, "feature '%s' does not exist", featureName)
+	}
+	currentPhase, err := s.GetFeaturePhase(ctx, featureName)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := s.collectFiles(filepath.Join(s.featuresDir, featureName), "")
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := bundle.Manifest{
+		Name:          featureName,
+		Phase:         string(currentPhase),
+		D3Version:     version.Version,
+		SourceProject: filepath.Base(s.projectRoot),
+		CreatedAt:     s.now().UTC(),
+	}
+	data, err := bundle.Write(manifest, files)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack feature '%s': %w", featureName, err)
+	}
+	return data, nil
+}
+
+// collectFiles reads every file below dir, returning paths relative to the feature root.
+// Symlinks and other non-regular entries are skipped, so a bundle never carries content from
+// outside the feature directory.
+func (s *Service) collectFiles(dir, rel string) ([]bundle.File, error) {
+	entries, err := s.fs.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+	var files []bundle.File
+	for _, entry := range entries {
+		entryRel := path.Join(rel, entry.Name())
+		entryPath := filepath.Join(dir, entry.Name())
+		if entry.IsDir() {
+			nested, err := s.collectFiles(entryPath, entryRel)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, nested...)
+			continue
+		}
+		if rel == "" && unbundledFiles[entry.Name()] {
+			continue
+		}
+		if !entry.Type().IsRegular() {
+			s.logger.Warn("skipped non-regular file while packing feature", "path", entryPath)
+			continue
+		}
+		data, err := s.fs.ReadFile(entryPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", entryPath, err)
+		}
+		files = append(files, bundle.File{Path: entryRel, Data: data})
+	}
+	return files, nil
+}
+
+// UnpackFeature imports a bundle produced by PackFeature into this project.
+// The feature keeps its bundled name unless asName is given. Existing features are never overwritten.
+func (s *Service) UnpackFeature(ctx context.Context, data []byte, asName string) (*FeatureInfo, error) {
+	if exists, _ := s.fs.Exists(s.d3Dir); !exists {
+		return nil, d3err.New(d3err.NotInitialized, "project not initialized: %s not found, run 'd3 init' first", s.d3Dir)
+	}
+
+	b, err := bundle.Read(data)
+	if err != nil {
+		return nil, err
+	}
+
+	featureName := b.Manifest.Name
+	if asName != "" {
+		featureName = asName
+	}
+	if err := ValidateName(featureName); err != nil {
+		return nil, err
+	}
+	featurePath := filepath.Join(s.featuresDir, featureName)
+	if _, err := s.fs.Stat(featurePath); err == nil {
+		return nil, d3err.New(d3err.FeatureExists, "feature '%s' already exists; use --as <name> to import it under a different name", featureName)
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to check if feature %s exists: %w", featureName, err)
+	}
+
+	hasPhaseFile := false
+	for _, f := range b.Files {
+		if f.Path != phaseFileName {
+			continue
+		}
+		hasPhaseFile = true
+		if err := validateBundledPhase(f.Data, b.Manifest.Phase); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.fs.MkdirAll(featurePath, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create feature directory: %w", err)
+	}
+	if err := s.writeBundleFiles(featurePath, b.Files, hasPhaseFile); err != nil {
+		// Leave no half-imported feature behind
+		_ = s.fs.RemoveAll(featurePath)
+		return nil, err
+	}
+
+	return &FeatureInfo{Name: featureName, Path: featurePath}, nil
+}
+
+// writeBundleFiles extracts bundle files into featurePath, adding a default .phase if none was bundled.
+func (s *Service) writeBundleFiles(featurePath string, files []bundle.File, hasPhaseFile bool) error {
+	for _, f := range files {
+		target := filepath.Join(featurePath, filepath.FromSlash(f.Path))
+		if err := s.fs.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", f.Path, err)
+		}
+		if err := s.fs.WriteFile(target, f.Data, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", f.Path, err)
+		}
+	}
+	if !hasPhaseFile {
+		phaseFilePath := filepath.Join(featurePath, phaseFileName)
+		if err := s.fs.WriteFile(phaseFilePath, []byte(s.initialPhase), 0644); err != nil {
+			return fmt.Errorf("failed to write default %s: %w", phaseFileName, err)
+		}
+	}
+	return nil
+}
+
+// validateBundledPhase checks that a bundled .phase holds a known phase that agrees with the manifest.
+func validateBundledPhase(data []byte, manifestPhase string) error {
+	p := phase.Phase(strings.TrimSpace(string(data)))
+	switch p {
+	case phase.Define, phase.Design, phase.Deliver:
+	default:
+		return d3err.New(d3err.InvalidPhase, "bundle has invalid phase value \"%s\" in %s", p, phaseFileName)
+	}
+	if manifestPhase != "" && manifestPhase != string(p) {
+		return d3err.New(d3err.InvalidPhase, "bundle %s says %s but its manifest says %s", phaseFileName, p, manifestPhase)
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+package feature
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/imcclaskey/d3/internal/core/bundle"
+	"github.com/imcclaskey/d3/internal/core/ports"
+	"github.com/imcclaskey/d3/internal/testutil"
+)
+
+// newMemService returns a service over an in-memory project at root with an initialized .d3 directory.
+func newMemService(t *testing.T, root string) (*Service, *testutil.MemFS) {
+	t.Helper()
+	memFS := testutil.NewMemFS()
+	d3Dir := filepath.Join(root, ".d3")
+	if err := memFS.MkdirAll(filepath.Join(d3Dir, "features"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	s := NewService(root, filepath.Join(d3Dir, "features"), d3Dir, memFS)
+	s.now = func() time.Time { return testNow }
+	return s, memFS
+}
+
+func TestService_PackUnpackFeature(t *testing.T) {
+	ctx := context.Background()
+	src, srcFS := newMemService(t, "/planning")
+	srcFS.AddFile("/planning/.d3/features/login/.phase", "design")
+	srcFS.AddFile("/planning/.d3/features/login/.branch", "feature/login\n")
+	srcFS.AddFile("/planning/.d3/features/login/.depends_on", "auth\n")
+	srcFS.AddFile("/planning/.d3/features/login/define/problem.md", "# Problem\n")
+	srcFS.AddFile("/planning/.d3/features/login/design/plan.md", "# Plan\n")
+
+	data, err := src.PackFeature(ctx, "login")
+	if err != nil {
+		t.Fatalf("PackFeature() unexpected error: %v", err)
+	}
+	b, err := bundle.Read(data)
+	if err != nil {
+		t.Fatalf("bundle.Read() unexpected error: %v", err)
+	}
+	if b.Manifest.Phase != "design" || b.Manifest.SourceProject != "planning" || !b.Manifest.CreatedAt.Equal(testNow) {
+		t.Errorf("PackFeature() manifest = %+v", b.Manifest)
+	}
+	for _, f := range b.Files {
+		if unbundledFiles[f.Path] {
+			t.Errorf("PackFeature() bundled %s, which belongs to the source repository", f.Path)
+		}
+	}
+
+	dst, dstFS := newMemService(t, "/service")
+	info, err := dst.UnpackFeature(ctx, data, "")
+	if err != nil {
+		t.Fatalf("UnpackFeature() unexpected error: %v", err)
+	}
+	if info.Name != "login" || info.Path != "/service/.d3/features/login" {
+		t.Errorf("UnpackFeature() = %+v", info)
+	}
+	want := []string{
+		"/service/.d3/features/login/.phase",
+		"/service/.d3/features/login/define/problem.md",
+		"/service/.d3/features/login/design/plan.md",
+	}
+	if got := dstFS.Files(); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("UnpackFeature() wrote %v, want %v", got, want)
+	}
+	if p, err := dst.GetFeaturePhase(ctx, "login"); err != nil || p != "design" {
+		t.Errorf("GetFeaturePhase() after unpack = %q, %v; want design", p, err)
+	}
+
+	// A second import collides unless renamed
+	if _, err := dst.UnpackFeature(ctx, data, ""); err == nil || !strings.Contains(err.Error(), "--as") {
+		t.Errorf("UnpackFeature() collision error = %v, want hint about --as", err)
+	}
+	if info, err := dst.UnpackFeature(ctx, data, "login-v2"); err != nil || info.Name != "login-v2" {
+		t.Errorf("UnpackFeature() with asName = %+v, %v", info, err)
+	}
+}
+
+func TestService_PackFeature_NotFound(t *testing.T) {
+	s, _ := newMemService(t, "/project")
+	if _, err := s.PackFeature(context.Background(), "missing"); err == nil {
+		t.Error("PackFeature() expected error for missing feature, got nil")
+	}
+}
+
+func TestService_PackFeature_SkipsSymlinks(t *testing.T) {
+	root := t.TempDir()
+	d3Dir := filepath.Join(root, ".d3")
+	featureDir := filepath.Join(d3Dir, "features", "login")
+	secret := filepath.Join(root, "secret.txt")
+	for path, content := range map[string]string{
+		filepath.Join(featureDir, ".phase"):               "define",
+		filepath.Join(featureDir, "define", "problem.md"): "# Problem\n",
+		secret: "do not bundle\n",
+	} {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Symlink(secret, filepath.Join(featureDir, "define", "notes.md")); err != nil {
+		t.Skipf("symlinks unavailable: %v", err)
+	}
+	if err := os.Symlink(root, filepath.Join(featureDir, "project")); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewService(root, filepath.Join(d3Dir, "features"), d3Dir, ports.RealFileSystem{})
+	data, err := s.PackFeature(context.Background(), "login")
+	if err != nil {
+		t.Fatalf("PackFeature() unexpected error: %v", err)
+	}
+	b, err := bundle.Read(data)
+	if err != nil {
+		t.Fatalf("bundle.Read() unexpected error: %v", err)
+	}
+	var paths []string
+	for _, f := range b.Files {
+		paths = append(paths, f.Path)
+	}
+	if got, want := strings.Join(paths, ","), ".phase,define/problem.md"; got != want {
+		t.Errorf("PackFeature() bundled %s, want %s", got, want)
+	}
+}
+
+func TestService_UnpackFeature_Errors(t *testing.T) {
+	ctx := context.Background()
+	pack := func(phaseFile, manifestPhase string) []byte {
+		files := []bundle.File{{Path: "define/problem.md", Data: []byte("# Problem\n")}}
+		if phaseFile != "" {
+			files = append(files, bundle.File{Path: phaseFileName, Data: []byte(phaseFile)})
+		}
+		data, err := bundle.Write(bundle.Manifest{Name: "login", Phase: manifestPhase, CreatedAt: testNow}, files)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		asName  string
+		noInit  bool
+		wantErr string
+	}{
+		{name: "not initialized", data: pack("define", "define"), noInit: true, wantErr: "not initialized"},
+		{name: "invalid phase", data: pack("review", ""), wantErr: "invalid phase value"},
+		{name: "phase disagrees with manifest", data: pack("deliver", "define"), wantErr: "manifest says define"},
+		{name: "invalid target name", data: pack("define", "define"), asName: "../x", wantErr: "invalid feature name"},
+		{name: "corrupt bundle", data: []byte("garbage"), wantErr: "not a d3 bundle"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, memFS := newMemService(t, "/project")
+			if tt.noInit {
+				_ = memFS.RemoveAll("/project/.d3")
+			}
+			_, err := s.UnpackFeature(ctx, tt.data, tt.asName)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("UnpackFeature() error = %v, want to contain %q", err, tt.wantErr)
+			}
+			if files := memFS.Files(); len(files) != 0 {
+				t.Errorf("UnpackFeature() left files behind: %v", files)
+			}
+		})
+	}
+
+	t.Run("missing phase file defaults to define", func(t *testing.T) {
+		s, _ := newMemService(t, "/project")
+		if _, err := s.UnpackFeature(ctx, pack("", ""), ""); err != nil {
+			t.Fatalf("UnpackFeature() unexpected error: %v", err)
+		}
+		if p, err := s.GetFeaturePhase(ctx, "login"); err != nil || p != "define" {
+			t.Errorf("GetFeaturePhase() = %q, %v; want define", p, err)
+		}
+	})
+}
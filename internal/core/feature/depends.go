@@ -0,0 +1,58 @@
+package feature
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/imcclaskey/d3/internal/core/d3err"
+)
+
+const dependsFileName = ".depends_on" // File in a feature directory listing the features it depends on
+
+// GetFeatureDependencies returns the names of the features a feature depends on, in the order
+// they were declared. Returns nil and a nil error if the feature declares none.
+func (s *Service) GetFeatureDependencies(featureName string) ([]string, error) {
+	if err := ValidateName(featureName); err != nil {
+		return nil, err
+	}
+	dependsFilePath := filepath.Join(s.featuresDir, featureName, dependsFileName)
+	data, err := s.fs.ReadFile(dependsFilePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s for feature %s: %w", dependsFileName, featureName, err)
+	}
+	var dependencies []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if name := strings.TrimSpace(line); name != "" && !strings.HasPrefix(name, "#") {
+			dependencies = append(dependencies, name)
+		}
+	}
+	return dependencies, nil
+}
+
+// SetFeatureDependencies records the features a feature depends on, one name per line. An empty
+// list removes the record.
+func (s *Service) SetFeatureDependencies(featureName string, dependencies []string) error {
+	if err := ValidateName(featureName); err != nil {
+		return err
+	}
+	if !s.FeatureExists(featureName) {
+		return d3err.New(d3err.FeatureNotFound, "feature %s does not exist, cannot set dependencies", featureName)
+	}
+	dependsFilePath := filepath.Join(s.featuresDir, featureName, dependsFileName)
+	if len(dependencies) == 0 {
+		if err := s.fs.Remove(dependsFilePath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s for feature %s: %w", dependsFileName, featureName, err)
+		}
+		return nil
+	}
+	data := []byte(strings.Join(dependencies, "\n") + "\n")
+	if err := s.fs.WriteFile(dependsFilePath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s for feature %s: %w", dependsFileName, featureName, err)
+	}
+	return nil
+}
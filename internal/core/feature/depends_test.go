@@ -0,0 +1,66 @@
+package feature
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/imcclaskey/d3/internal/testutil"
+)
+
+func TestService_GetFeatureDependencies(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		readErr error
+		want    []string
+		wantErr bool
+	}{
+		{name: "recorded dependencies", data: []byte("api\n# shipped first\n\n  auth \n"), want: []string{"api", "auth"}},
+		{name: "no dependencies file", readErr: os.ErrNotExist, want: nil},
+		{name: "read error", readErr: fmt.Errorf("disk error"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			s, mockFS := newTestService(t, ctrl)
+			dependsPath := filepath.Join(s.featuresDir, "ui", ".depends_on")
+			mockFS.EXPECT().ReadFile(dependsPath).Return(tt.data, tt.readErr).Times(1)
+
+			got, err := s.GetFeatureDependencies("ui")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetFeatureDependencies() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetFeatureDependencies() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_SetFeatureDependencies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	s, mockFS := newTestService(t, ctrl)
+	featurePath := filepath.Join(s.featuresDir, "ui")
+	dependsPath := filepath.Join(featurePath, ".depends_on")
+
+	mockFS.EXPECT().Stat(featurePath).Return(testutil.MockFileInfo{FIsDir: true}, nil).Times(2)
+	mockFS.EXPECT().WriteFile(dependsPath, []byte("api\nauth\n"), os.FileMode(0644)).Return(nil).Times(1)
+	if err := s.SetFeatureDependencies("ui", []string{"api", "auth"}); err != nil {
+		t.Fatalf("SetFeatureDependencies() unexpected error: %v", err)
+	}
+
+	mockFS.EXPECT().Remove(dependsPath).Return(os.ErrNotExist).Times(1)
+	if err := s.SetFeatureDependencies("ui", nil); err != nil {
+		t.Errorf("SetFeatureDependencies() without dependencies unexpected error: %v", err)
+	}
+
+	mockFS.EXPECT().Stat(filepath.Join(s.featuresDir, "missing")).Return(nil, os.ErrNotExist).Times(1)
+	if err := s.SetFeatureDependencies("missing", []string{"api"}); err == nil {
+		t.Errorf("SetFeatureDependencies() on missing feature expected error")
+	}
+}
@@ -4,10 +4,13 @@ package feature
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
+	"github.com/imcclaskey/d3/internal/core/d3err"
 	"github.com/imcclaskey/d3/internal/core/phase"
 	"github.com/imcclaskey/d3/internal/core/ports"
 )
@@ -24,9 +27,19 @@ type FeatureServicer interface {
 	GetFeaturePath(featureName string) string
 	ListFeatures(ctx context.Context) ([]FeatureInfo, error)
 	DeleteFeature(ctx context.Context, featureName string) (activeContextCleared bool, err error)
+	PurgeFeature(ctx context.Context, featureName string) (activeContextCleared bool, err error)
+	RestoreFeature(ctx context.Context, featureName string) (*FeatureInfo, error)
+	ListTrash(ctx context.Context) ([]TrashEntry, error)
+	EmptyTrash(ctx context.Context, olderThan time.Duration) ([]TrashEntry, error)
 	GetActiveFeature() (string, error)
 	SetActiveFeature(featureName string) error
 	ClearActiveFeature() error
+	GetFeatureBranch(featureName string) (string, error)
+	SetFeatureBranch(featureName, branch string) error
+	GetFeatureDependencies(featureName string) ([]string, error)
+	SetFeatureDependencies(featureName string, dependencies []string) error
+	PackFeature(ctx context.Context, featureName string) ([]byte, error)
+	UnpackFeature(ctx context.Context, data []byte, asName string) (*FeatureInfo, error)
 }
 
 const phaseFileName = ".phase"           // New constant for the phase file
@@ -38,17 +51,30 @@ type Service struct {
 	featuresDir           string
 	d3Dir                 string
 	activeFeatureFilePath string
+	activeStore           ActiveFeatureStore
+	trashDir              string
+	trashRetention        time.Duration
+	initialPhase          phase.Phase
 	fs                    ports.FileSystem
+	logger                *slog.Logger
+	now                   func() time.Time
 }
 
 // NewService creates a new feature service
 func NewService(projectRoot, featuresDir, d3Dir string, fs ports.FileSystem) *Service {
+	activeFeatureFilePath := filepath.Join(d3Dir, activeFeatureFileName)
 	return &Service{
 		projectRoot:           projectRoot,
 		featuresDir:           featuresDir,
 		d3Dir:                 d3Dir,
-		activeFeatureFilePath: filepath.Join(d3Dir, activeFeatureFileName), // Use new constant
+		activeFeatureFilePath: activeFeatureFilePath,
+		activeStore:           NewFileActiveStore(activeFeatureFilePath, fs),
+		trashDir:              filepath.Join(d3Dir, trashDirName),
+		trashRetention:        DefaultTrashRetention,
+		initialPhase:          phase.Define,
 		fs:                    fs,
+		logger:                slog.Default(),
+		now:                   time.Now,
 	}
 }
 
@@ -60,11 +86,14 @@ type FeatureInfo struct {
 
 // CreateFeature creates a new feature directory and its initial .phase file
 func (s *Service) CreateFeature(ctx context.Context, featureName string) (*FeatureInfo, error) {
+	if err := ValidateName(featureName); err != nil {
+		return nil, err
+	}
 	featurePath := filepath.Join(s.featuresDir, featureName)
 
 	// Check if feature already exists
 	if _, err := s.fs.Stat(featurePath); err == nil {
-		return nil, fmt.Errorf("feature %s already exists", featureName)
+		return nil, d3err.New(d3err.FeatureExists, "feature %s already exists", featureName)
 	} else if !os.IsNotExist(err) {
 		// If it's an error other than NotExist, return it
 		return nil, fmt.Errorf("failed to check if feature %s exists: %w", featureName, err)
@@ -76,15 +105,15 @@ func (s *Service) CreateFeature(ctx context.Context, featureName string) (*Featu
 	}
 
 	// Create initial .phase for the feature
-	initialPhase := phase.Define // Default to Define phase
 	phaseFilePath := filepath.Join(featurePath, phaseFileName)
-	data := []byte(string(initialPhase))
+	data := []byte(string(s.initialPhase))
 
 	if err := s.fs.WriteFile(phaseFilePath, data, 0644); err != nil {
 		// Attempt to clean up created directory if phase file write fails
 		_ = s.fs.RemoveAll(featurePath)
 		return nil, fmt.Errorf("failed to write initial %s for feature %s: %w", phaseFileName, featureName, err)
 	}
+	s.logger.Debug("created feature directory", "feature", featureName, "path", featurePath)
 
 	return &FeatureInfo{
 		Name: featureName,
@@ -93,10 +122,13 @@ func (s *Service) CreateFeature(ctx context.Context, featureName string) (*Featu
 }
 
 // GetFeaturePhase reads the phase from a feature's .phase file.
-// If .phase doesn't exist, it creates it with a default phase (Define) and returns that.
+// If .phase doesn't exist, it creates it with the initial phase (define by default) and returns that.
 func (s *Service) GetFeaturePhase(ctx context.Context, featureName string) (phase.Phase, error) {
+	if err := ValidateName(featureName); err != nil {
+		return phase.None, err
+	}
 	if !s.FeatureExists(featureName) {
-		return phase.None, fmt.Errorf("feature %s does not exist", featureName)
+		return phase.None, d3err.New(d3err.FeatureNotFound, "feature %s does not exist", featureName)
 	}
 	featurePath := filepath.Join(s.featuresDir, featureName)
 	phaseFilePath := filepath.Join(featurePath, phaseFileName)
@@ -104,9 +136,8 @@ func (s *Service) GetFeaturePhase(ctx context.Context, featureName string) (phas
 	data, err := s.fs.ReadFile(phaseFilePath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			// .phase does not exist, create it with default Define phase
-			initialPhase := phase.Define
-			writeData := []byte(string(initialPhase))
+			// .phase does not exist, create it with the initial phase
+			writeData := []byte(string(s.initialPhase))
 
 			// Ensure feature directory exists (should be redundant if FeatureExists passed, but good for safety)
 			if errMkdir := s.fs.MkdirAll(featurePath, 0755); errMkdir != nil {
@@ -115,7 +146,7 @@ func (s *Service) GetFeaturePhase(ctx context.Context, featureName string) (phas
 			if writeErr := s.fs.WriteFile(phaseFilePath, writeData, 0644); writeErr != nil {
 				return phase.None, fmt.Errorf("failed to write default %s for %s: %w", phaseFileName, featureName, writeErr)
 			}
-			return initialPhase, nil // Return default phase after creation
+			return s.initialPhase, nil // Return default phase after creation
 		}
 		// Other error reading file
 		return phase.None, fmt.Errorf("failed to read %s for feature %s: %w", phaseFileName, featureName, err)
@@ -123,7 +154,7 @@ func (s *Service) GetFeaturePhase(ctx context.Context, featureName string) (phas
 
 	phaseString := strings.TrimSpace(string(data))
 	if phaseString == "" {
-		return phase.None, fmt.Errorf("phase file for feature %s is empty", featureName)
+		return phase.None, d3err.New(d3err.InvalidPhase, "phase file for feature %s is empty", featureName)
 	}
 
 	p := phase.Phase(phaseString)
@@ -131,7 +162,7 @@ func (s *Service) GetFeaturePhase(ctx context.Context, featureName string) (phas
 	case phase.Define, phase.Design, phase.Deliver, phase.None:
 		// Valid phase
 	default:
-		return phase.None, fmt.Errorf("invalid phase value \"%s\" found in %s for feature %s", phaseString, phaseFileName, featureName)
+		return phase.None, d3err.New(d3err.InvalidPhase, "invalid phase value \"%s\" found in %s for feature %s", phaseString, phaseFileName, featureName)
 	}
 
 	return p, nil
@@ -143,11 +174,15 @@ func (s *Service) SetFeaturePhase(ctx context.Context, featureName string, p pha
 	case phase.Define, phase.Design, phase.Deliver:
 		// Valid phase for setting
 	default:
-		return fmt.Errorf("invalid phase provided to set: %s", p)
+		return d3err.New(d3err.InvalidPhase, "invalid phase provided to set: %s", p)
+	}
+
+	if err := ValidateName(featureName); err != nil {
+		return err
 	}
 
 	if !s.FeatureExists(featureName) {
-		return fmt.Errorf("feature %s does not exist, cannot set phase", featureName)
+		return d3err.New(d3err.FeatureNotFound, "feature %s does not exist, cannot set phase", featureName)
 	}
 
 	featurePath := filepath.Join(s.featuresDir, featureName)
@@ -163,11 +198,15 @@ func (s *Service) SetFeaturePhase(ctx context.Context, featureName string, p pha
 	if err := s.fs.WriteFile(phaseFilePath, data, 0644); err != nil {
 		return fmt.Errorf("failed to write %s for feature %s: %w", phaseFileName, featureName, err)
 	}
+	s.logger.Debug("wrote feature phase", "feature", featureName, "phase", string(p))
 	return nil
 }
 
-// FeatureExists checks if a feature exists
+// FeatureExists checks if a feature exists. Invalid names never exist.
 func (s *Service) FeatureExists(featureName string) bool {
+	if ValidateName(featureName) != nil {
+		return false
+	}
 	featurePath := filepath.Join(s.featuresDir, featureName)
 	_, err := s.fs.Stat(featurePath)
 	return err == nil
@@ -196,7 +235,7 @@ func (s *Service) ListFeatures(ctx context.Context) ([]FeatureInfo, error) {
 	// Build result list
 	var features []FeatureInfo
 	for _, entry := range entries {
-		if entry.IsDir() {
+		if entry.IsDir() && ValidateName(entry.Name()) == nil {
 			features = append(features, FeatureInfo{
 				Name: entry.Name(),
 				Path: filepath.Join(s.featuresDir, entry.Name()),
@@ -207,80 +246,96 @@ func (s *Service) ListFeatures(ctx context.Context) ([]FeatureInfo, error) {
 	return features, nil
 }
 
-// GetActiveFeature reads the active feature name from the active feature file.
-// Returns an empty string and nil error if the file is empty or does not exist.
+// GetActiveFeature returns the active feature name for the current session.
+// Returns an empty string and nil error if no feature is active.
 func (s *Service) GetActiveFeature() (string, error) {
-	data, err := s.fs.ReadFile(s.activeFeatureFilePath) // activeFeatureFilePath is now using .feature
-	if err != nil {
-		if os.IsNotExist(err) {
-			return "", nil // File not existing means no active feature
-		}
-		return "", fmt.Errorf("failed to read active feature file %s: %w", s.activeFeatureFilePath, err)
-	}
-	// Return the content, trimming whitespace
-	return strings.TrimSpace(string(data)), nil
+	return s.activeStore.Get()
 }
 
-// SetActiveFeature saves the active feature name to the active feature file.
+// SetActiveFeature records the active feature name for the current session.
 func (s *Service) SetActiveFeature(featureName string) error {
-	// Ensure the base directory exists
-	if err := s.fs.MkdirAll(filepath.Dir(s.activeFeatureFilePath), 0755); err != nil { // activeFeatureFilePath is now using .feature
-		return fmt.Errorf("failed to create active feature file directory: %w", err)
-	}
-
-	// Write the feature name as plain text
-	data := []byte(featureName)
-	if err := s.fs.WriteFile(s.activeFeatureFilePath, data, 0644); err != nil { // activeFeatureFilePath is now using .feature
-		return fmt.Errorf("failed to write active feature file %s: %w", s.activeFeatureFilePath, err)
-	}
-	return nil
+	return s.activeStore.Set(featureName)
 }
 
-// ClearActiveFeature removes the active feature file, effectively clearing the active feature.
+// ClearActiveFeature clears the active feature for the current session.
 func (s *Service) ClearActiveFeature() error {
-	err := s.fs.Remove(s.activeFeatureFilePath) // activeFeatureFilePath is now using .feature
-	// Ignore "not exist" error, as it means the state is already cleared
-	if err != nil && !os.IsNotExist(err) {
-		return fmt.Errorf("failed to remove active feature file %s: %w", s.activeFeatureFilePath, err)
-	}
-	return nil
+	return s.activeStore.Clear()
+}
+
+// SetActiveFeatureStore replaces where the active feature is kept, for example with a
+// per-session store returned by ResolveActiveFeatureStore.
+func (s *Service) SetActiveFeatureStore(store ActiveFeatureStore) {
+	s.activeStore = store
 }
 
-// DeleteFeature removes a feature directory and its contents.
-// The .phase file within the feature directory will be removed as part of RemoveAll.
+// SetInitialPhase sets the phase written to the .phase file of new features, and of features
+// whose .phase file is missing. The default is define.
+func (s *Service) SetInitialPhase(p phase.Phase) {
+	s.initialPhase = p
+}
+
+// SetLogger replaces the logger that receives warnings from operations that still succeed.
+func (s *Service) SetLogger(logger *slog.Logger) {
+	s.logger = logger
+}
+
+// ActiveFeatureLocation describes where the active feature is currently stored.
+func (s *Service) ActiveFeatureLocation() string {
+	return s.activeStore.Location()
+}
+
+// DeleteFeature moves a feature directory into the trash (.d3/.trash/<name>-<timestamp>).
+// The feature can be brought back with RestoreFeature until the trash is emptied.
 // If the deleted feature is the currently active one, it also clears the active feature state.
 // Returns true if the active context was cleared as a result of this deletion.
 func (s *Service) DeleteFeature(ctx context.Context, featureName string) (bool, error) {
-	activeContextCleared := false
-	featurePath := filepath.Join(s.featuresDir, featureName)
-
-	currentActiveFeature, err := s.GetActiveFeature()
-	if err != nil {
-		// Log a warning, but this might not be fatal for the deletion itself if the active feature file is just unreadable.
-		// However, we need to know if featureName *was* the active one.
-		fmt.Fprintf(os.Stderr, "Warning: could not read active feature state while trying to delete %s: %v\n", featureName, err)
-		// If we can't read it, we can't be sure it's not featureName. Proceeding cautiously by not assuming it *isn't* featureName.
+	// Validate before touching anything so a traversal name can never reach the filesystem
+	if err := ValidateName(featureName); err != nil {
+		return false, err
 	}
 
-	if currentActiveFeature == featureName {
-		if clearErr := s.ClearActiveFeature(); clearErr != nil {
-			// If we can't clear the active feature, we should probably stop and report this.
-			return false, fmt.Errorf("failed to clear active feature state for %s before deletion: %w", featureName, clearErr)
-		}
-		activeContextCleared = true
+	featurePath := filepath.Join(s.featuresDir, featureName)
+	activeContextCleared, err := s.clearIfActive(featureName)
+	if err != nil {
+		return false, err
 	}
 
-	// Check if feature directory exists before attempting to remove
+	// Check if feature directory exists before attempting to move it
 	if _, err := s.fs.Stat(featurePath); os.IsNotExist(err) {
-		return activeContextCleared, fmt.Errorf("feature '%s' not found at %s", featureName, featurePath)
+		return activeContextCleared, d3err.New(d3err.FeatureNotFound, "feature '%s' not found at %s", featureName, featurePath)
 	} else if err != nil {
 		return activeContextCleared, fmt.Errorf("failed to check feature '%s': %w", featureName, err)
 	}
 
-	// Remove the entire feature directory
-	if errRemove := s.fs.RemoveAll(featurePath); errRemove != nil {
-		return activeContextCleared, fmt.Errorf("failed to delete feature '%s': %w", featureName, errRemove)
+	if _, err := s.moveToTrash(featureName, featurePath); err != nil {
+		return activeContextCleared, err
+	}
+
+	// Prune expired trash entries; failing to do so does not undo the deletion
+	if s.trashRetention > 0 {
+		if _, err := s.EmptyTrash(ctx, s.trashRetention); err != nil {
+			s.logger.Warn("failed to prune expired trash entries", "error", err)
+		}
 	}
 
 	return activeContextCleared, nil
 }
+
+// clearIfActive clears the active feature state when featureName is the active feature.
+// Returns true if the active context was cleared.
+func (s *Service) clearIfActive(featureName string) (bool, error) {
+	currentActiveFeature, err := s.GetActiveFeature()
+	if err != nil {
+		// Log a warning, but this might not be fatal for the deletion itself if the active feature file is just unreadable.
+		s.logger.Warn("could not read active feature state while deleting a feature", "feature", featureName, "error", err)
+	}
+
+	if currentActiveFeature != featureName {
+		return false, nil
+	}
+	if clearErr := s.ClearActiveFeature(); clearErr != nil {
+		// If we can't clear the active feature, we should probably stop and report this.
+		return false, fmt.Errorf("failed to clear active feature state for %s before deletion: %w", featureName, clearErr)
+	}
+	return true, nil
+}
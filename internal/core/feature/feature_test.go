@@ -1,15 +1,19 @@
 package feature
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io/fs"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/imcclaskey/d3/internal/core/d3err"
 	"github.com/imcclaskey/d3/internal/core/phase"
 	portsmocks "github.com/imcclaskey/d3/internal/core/ports/mocks"
 	"github.com/imcclaskey/d3/internal/testutil"
@@ -26,9 +30,23 @@ func newTestService(t *testing.T, ctrl *gomock.Controller) (*Service, *portsmock
 	mockFS := portsmocks.NewMockFileSystem(ctrl)
 	// NewService now uses activeFeatureFileName (".feature") internally
 	s := NewService(projectRoot, featuresDir, d3Dir, mockFS)
+	s.now = func() time.Time { return testNow }
 	return s, mockFS
 }
 
+// testNow is the fixed clock used by services created with newTestService
+var testNow = time.Date(2025, 5, 1, 12, 30, 0, 0, time.UTC)
+
+// expectMoveToTrash sets the expectations for a successful soft delete of featureName,
+// including the pruning pass over an empty trash directory.
+func expectMoveToTrash(s *Service, mockFS *portsmocks.MockFileSystem, featureName string) {
+	featurePath := filepath.Join(s.featuresDir, featureName)
+	trashPath := filepath.Join(s.trashDir, featureName+"-20250501T123000.000Z")
+	mockFS.EXPECT().MkdirAll(s.trashDir, os.FileMode(0755)).Return(nil).Times(1)
+	mockFS.EXPECT().Rename(featurePath, trashPath).Return(nil).Times(1)
+	mockFS.EXPECT().ReadDir(s.trashDir).Return([]fs.DirEntry{}, nil).Times(1)
+}
+
 func TestService_CreateFeature(t *testing.T) {
 	type args struct {
 		ctx         context.Context
@@ -40,7 +58,16 @@ func TestService_CreateFeature(t *testing.T) {
 		setupMocks func(s *Service, mockFS *portsmocks.MockFileSystem, featureName string)
 		wantInfo   *FeatureInfo
 		wantErr    bool
+		wantCode   d3err.Code
 	}{
+		{
+			name:       "invalid name is rejected before touching the filesystem",
+			args:       args{ctx: context.Background(), featureName: "../outside"},
+			setupMocks: func(s *Service, mockFS *portsmocks.MockFileSystem, featureName string) {},
+			wantInfo:   nil,
+			wantErr:    true,
+			wantCode:   d3err.InvalidName,
+		},
 		{
 			name: "feature already exists (Stat returns nil error)",
 			args: args{ctx: context.Background(), featureName: "existing-feature"},
@@ -50,6 +77,7 @@ func TestService_CreateFeature(t *testing.T) {
 			},
 			wantInfo: nil,
 			wantErr:  true,
+			wantCode: d3err.FeatureExists,
 		},
 		{
 			name: "Stat returns unexpected error",
@@ -118,6 +146,9 @@ func TestService_CreateFeature(t *testing.T) {
 				t.Errorf("Service.CreateFeature() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if tt.wantCode != "" && d3err.CodeOf(err) != tt.wantCode {
+				t.Errorf("Service.CreateFeature() error code = %s, want %s", d3err.CodeOf(err), tt.wantCode)
+			}
 			if !tt.wantErr {
 				if gotInfo == nil || gotInfo.Name != tt.wantInfo.Name {
 					t.Errorf("Service.CreateFeature() gotInfo.Name = %v, want %v", gotInfo.Name, tt.wantInfo.Name)
@@ -132,6 +163,24 @@ func TestService_CreateFeature(t *testing.T) {
 	}
 }
 
+func TestService_SetInitialPhase(t *testing.T) {
+	ctx := context.Background()
+	s, memFS := newMemService(t, "/p")
+	s.SetInitialPhase(phase.Design)
+
+	if _, err := s.CreateFeature(ctx, "login"); err != nil {
+		t.Fatalf("CreateFeature() error = %v", err)
+	}
+	if data, _ := memFS.ReadFile("/p/.d3/features/login/.phase"); string(data) != "design" {
+		t.Errorf("CreateFeature() wrote .phase %q, want design", data)
+	}
+
+	memFS.MkdirAll("/p/.d3/features/legacy", 0755)
+	if got, err := s.GetFeaturePhase(ctx, "legacy"); err != nil || got != phase.Design {
+		t.Errorf("GetFeaturePhase() without .phase = %v, %v, want design", got, err)
+	}
+}
+
 func TestService_FeatureExists(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -550,7 +599,16 @@ func TestService_DeleteFeature(t *testing.T) {
 		setupMocks           func(s *Service, mockFS *portsmocks.MockFileSystem, featureName string, activeFeatureContent string)
 		wantCleared          bool
 		wantErr              bool
+		wantLog              string // Substring expected in the service log output
 	}{
+		{
+			name:                 "traversal name is rejected before any filesystem access",
+			featureName:          "../../src",
+			activeFeatureContent: "",
+			setupMocks:           func(s *Service, mockFS *portsmocks.MockFileSystem, featureName string, activeFeatureContent string) {},
+			wantCleared:          false,
+			wantErr:              true,
+		},
 		{
 			name:                 "delete existing feature, not active",
 			featureName:          "feat-to-delete",
@@ -561,8 +619,8 @@ func TestService_DeleteFeature(t *testing.T) {
 				mockFS.EXPECT().ReadFile(s.activeFeatureFilePath).Return([]byte(activeFeatureContent), nil).Times(1)
 				// Stat checks if feature directory exists
 				mockFS.EXPECT().Stat(featurePath).Return(testutil.MockFileInfo{FIsDir: true}, nil).Times(1)
-				// RemoveAll deletes the feature directory
-				mockFS.EXPECT().RemoveAll(featurePath).Return(nil).Times(1)
+				// The feature directory is moved into the trash
+				expectMoveToTrash(s, mockFS, featureName)
 			},
 			wantCleared: false,
 			wantErr:     false,
@@ -577,7 +635,7 @@ func TestService_DeleteFeature(t *testing.T) {
 				// ClearActiveFeature will remove .feature file
 				mockFS.EXPECT().Remove(s.activeFeatureFilePath).Return(nil).Times(1)
 				mockFS.EXPECT().Stat(featurePath).Return(testutil.MockFileInfo{FIsDir: true}, nil).Times(1)
-				mockFS.EXPECT().RemoveAll(featurePath).Return(nil).Times(1)
+				expectMoveToTrash(s, mockFS, featureName)
 			},
 			wantCleared: true,
 			wantErr:     false,
@@ -620,14 +678,15 @@ func TestService_DeleteFeature(t *testing.T) {
 			wantErr:     true,
 		},
 		{
-			name:                 "RemoveAll fails",
+			name:                 "Rename into trash fails",
 			featureName:          "removeall-fail-delete",
 			activeFeatureContent: "other-feat",
 			setupMocks: func(s *Service, mockFS *portsmocks.MockFileSystem, featureName string, activeFeatureContent string) {
 				featurePath := filepath.Join(s.featuresDir, featureName)
 				mockFS.EXPECT().ReadFile(s.activeFeatureFilePath).Return([]byte(activeFeatureContent), nil).Times(1)
 				mockFS.EXPECT().Stat(featurePath).Return(testutil.MockFileInfo{FIsDir: true}, nil).Times(1)
-				mockFS.EXPECT().RemoveAll(featurePath).Return(fmt.Errorf("remove all failed")).Times(1)
+				mockFS.EXPECT().MkdirAll(s.trashDir, os.FileMode(0755)).Return(nil).Times(1)
+				mockFS.EXPECT().Rename(featurePath, gomock.Any()).Return(fmt.Errorf("rename failed")).Times(1)
 			},
 			wantCleared: false,
 			wantErr:     true,
@@ -642,10 +701,27 @@ func TestService_DeleteFeature(t *testing.T) {
 				// Stat for the feature to delete (should still be called as error from GetActive is warning)
 				featurePath := filepath.Join(s.featuresDir, featureName)
 				mockFS.EXPECT().Stat(featurePath).Return(testutil.MockFileInfo{FIsDir: true}, nil).Times(1)
-				mockFS.EXPECT().RemoveAll(featurePath).Return(nil).Times(1)
+				expectMoveToTrash(s, mockFS, featureName)
 			},
 			wantCleared: false, // Not cleared because currentActiveFeature would be empty due to error
 			wantErr:     false, // Delete itself succeeds, GetActiveFeature error is a warning
+			wantLog:     "could not read active feature state",
+		},
+		{
+			name:                 "pruning expired trash fails",
+			featureName:          "prune-fail-delete",
+			activeFeatureContent: "other-feat",
+			setupMocks: func(s *Service, mockFS *portsmocks.MockFileSystem, featureName string, activeFeatureContent string) {
+				featurePath := filepath.Join(s.featuresDir, featureName)
+				mockFS.EXPECT().ReadFile(s.activeFeatureFilePath).Return([]byte(activeFeatureContent), nil).Times(1)
+				mockFS.EXPECT().Stat(featurePath).Return(testutil.MockFileInfo{FIsDir: true}, nil).Times(1)
+				mockFS.EXPECT().MkdirAll(s.trashDir, os.FileMode(0755)).Return(nil).Times(1)
+				mockFS.EXPECT().Rename(featurePath, gomock.Any()).Return(nil).Times(1)
+				mockFS.EXPECT().ReadDir(s.trashDir).Return(nil, fmt.Errorf("permission denied")).Times(1)
+			},
+			wantCleared: false,
+			wantErr:     false, // The feature is already in the trash; pruning is best effort
+			wantLog:     "failed to prune expired trash entries",
 		},
 	}
 
@@ -653,6 +729,8 @@ func TestService_DeleteFeature(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			s, mockFS := newTestService(t, ctrl)
+			var logBuf bytes.Buffer
+			s.SetLogger(slog.New(slog.NewTextHandler(&logBuf, nil)))
 
 			if tt.setupMocks != nil {
 				tt.setupMocks(s, mockFS, tt.featureName, tt.activeFeatureContent)
@@ -667,6 +745,9 @@ func TestService_DeleteFeature(t *testing.T) {
 			if gotCleared != tt.wantCleared {
 				t.Errorf("Service.DeleteFeature() gotCleared = %v, want %v", gotCleared, tt.wantCleared)
 			}
+			if tt.wantLog != "" && !strings.Contains(logBuf.String(), tt.wantLog) {
+				t.Errorf("Service.DeleteFeature() log = %q, want it to contain %q", logBuf.String(), tt.wantLog)
+			}
 		})
 	}
 }
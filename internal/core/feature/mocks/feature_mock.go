@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	feature "github.com/imcclaskey/d3/internal/core/feature"
@@ -80,6 +81,21 @@ func (mr *MockFeatureServicerMockRecorder) DeleteFeature(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFeature", reflect.TypeOf((*MockFeatureServicer)(nil).DeleteFeature), arg0, arg1)
 }
 
+// EmptyTrash mocks base method.
+func (m *MockFeatureServicer) EmptyTrash(arg0 context.Context, arg1 time.Duration) ([]feature.TrashEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EmptyTrash", arg0, arg1)
+	ret0, _ := ret[0].([]feature.TrashEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EmptyTrash indicates an expected call of EmptyTrash.
+func (mr *MockFeatureServicerMockRecorder) EmptyTrash(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EmptyTrash", reflect.TypeOf((*MockFeatureServicer)(nil).EmptyTrash), arg0, arg1)
+}
+
 // FeatureExists mocks base method.
 func (m *MockFeatureServicer) FeatureExists(arg0 string) bool {
 	m.ctrl.T.Helper()
@@ -109,6 +125,36 @@ func (mr *MockFeatureServicerMockRecorder) GetActiveFeature() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveFeature", reflect.TypeOf((*MockFeatureServicer)(nil).GetActiveFeature))
 }
 
+// GetFeatureBranch mocks base method.
+func (m *MockFeatureServicer) GetFeatureBranch(arg0 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeatureBranch", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeatureBranch indicates an expected call of GetFeatureBranch.
+func (mr *MockFeatureServicerMockRecorder) GetFeatureBranch(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeatureBranch", reflect.TypeOf((*MockFeatureServicer)(nil).GetFeatureBranch), arg0)
+}
+
+// GetFeatureDependencies mocks base method.
+func (m *MockFeatureServicer) GetFeatureDependencies(arg0 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeatureDependencies", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeatureDependencies indicates an expected call of GetFeatureDependencies.
+func (mr *MockFeatureServicerMockRecorder) GetFeatureDependencies(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeatureDependencies", reflect.TypeOf((*MockFeatureServicer)(nil).GetFeatureDependencies), arg0)
+}
+
 // GetFeaturePath mocks base method.
 func (m *MockFeatureServicer) GetFeaturePath(arg0 string) string {
 	m.ctrl.T.Helper()
@@ -153,6 +199,66 @@ func (mr *MockFeatureServicerMockRecorder) ListFeatures(arg0 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFeatures", reflect.TypeOf((*MockFeatureServicer)(nil).ListFeatures), arg0)
 }
 
+// ListTrash mocks base method.
+func (m *MockFeatureServicer) ListTrash(arg0 context.Context) ([]feature.TrashEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrash", arg0)
+	ret0, _ := ret[0].([]feature.TrashEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrash indicates an expected call of ListTrash.
+func (mr *MockFeatureServicerMockRecorder) ListTrash(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrash", reflect.TypeOf((*MockFeatureServicer)(nil).ListTrash), arg0)
+}
+
+// PackFeature mocks base method.
+func (m *MockFeatureServicer) PackFeature(arg0 context.Context, arg1 string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PackFeature", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PackFeature indicates an expected call of PackFeature.
+func (mr *MockFeatureServicerMockRecorder) PackFeature(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PackFeature", reflect.TypeOf((*MockFeatureServicer)(nil).PackFeature), arg0, arg1)
+}
+
+// PurgeFeature mocks base method.
+func (m *MockFeatureServicer) PurgeFeature(arg0 context.Context, arg1 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeFeature", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeFeature indicates an expected call of PurgeFeature.
+func (mr *MockFeatureServicerMockRecorder) PurgeFeature(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeFeature", reflect.TypeOf((*MockFeatureServicer)(nil).PurgeFeature), arg0, arg1)
+}
+
+// RestoreFeature mocks base method.
+func (m *MockFeatureServicer) RestoreFeature(arg0 context.Context, arg1 string) (*feature.FeatureInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreFeature", arg0, arg1)
+	ret0, _ := ret[0].(*feature.FeatureInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreFeature indicates an expected call of RestoreFeature.
+func (mr *MockFeatureServicerMockRecorder) RestoreFeature(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreFeature", reflect.TypeOf((*MockFeatureServicer)(nil).RestoreFeature), arg0, arg1)
+}
+
 // SetActiveFeature mocks base method.
 func (m *MockFeatureServicer) SetActiveFeature(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -167,6 +273,34 @@ func (mr *MockFeatureServicerMockRecorder) SetActiveFeature(arg0 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetActiveFeature", reflect.TypeOf((*MockFeatureServicer)(nil).SetActiveFeature), arg0)
 }
 
+// SetFeatureBranch mocks base method.
+func (m *MockFeatureServicer) SetFeatureBranch(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetFeatureBranch", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetFeatureBranch indicates an expected call of SetFeatureBranch.
+func (mr *MockFeatureServicerMockRecorder) SetFeatureBranch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFeatureBranch", reflect.TypeOf((*MockFeatureServicer)(nil).SetFeatureBranch), arg0, arg1)
+}
+
+// SetFeatureDependencies mocks base method.
+func (m *MockFeatureServicer) SetFeatureDependencies(arg0 string, arg1 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetFeatureDependencies", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetFeatureDependencies indicates an expected call of SetFeatureDependencies.
+func (mr *MockFeatureServicerMockRecorder) SetFeatureDependencies(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFeatureDependencies", reflect.TypeOf((*MockFeatureServicer)(nil).SetFeatureDependencies), arg0, arg1)
+}
+
 // SetFeaturePhase mocks base method.
 func (m *MockFeatureServicer) SetFeaturePhase(arg0 context.Context, arg1 string, arg2 phase.Phase) error {
 	m.ctrl.T.Helper()
@@ -180,3 +314,18 @@ func (mr *MockFeatureServicerMockRecorder) SetFeaturePhase(arg0, arg1, arg2 inte
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFeaturePhase", reflect.TypeOf((*MockFeatureServicer)(nil).SetFeaturePhase), arg0, arg1, arg2)
 }
+
+// UnpackFeature mocks base method.
+func (m *MockFeatureServicer) UnpackFeature(arg0 context.Context, arg1 []byte, arg2 string) (*feature.FeatureInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnpackFeature", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*feature.FeatureInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnpackFeature indicates an expected call of UnpackFeature.
+func (mr *MockFeatureServicerMockRecorder) UnpackFeature(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnpackFeature", reflect.TypeOf((*MockFeatureServicer)(nil).UnpackFeature), arg0, arg1, arg2)
+}
@@ -0,0 +1,110 @@
+package feature
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/imcclaskey/d3/internal/core/d3err"
+)
+
+// maxNameLength caps feature names so they stay usable as directory names.
+const maxNameLength = 64
+
+// namePattern describes a valid, normalized feature name: lowercase letters,
+// digits, dots, dashes and underscores, starting with a letter or digit.
+var namePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+
+// whitespacePattern matches runs of whitespace collapsed into a single dash.
+var whitespacePattern = regexp.MustCompile(`\s+`)
+
+// slugSeparatorPattern matches runs of characters SlugifyName replaces with a single dash.
+var slugSeparatorPattern = regexp.MustCompile(`[^a-z0-9._]+`)
+
+// reservedNames lists names the operating system treats specially. d3's own
+// bookkeeping files start with a dot, which namePattern already rejects.
+var reservedNames = map[string]bool{
+	"con": true,
+	"prn": true,
+	"aux": true,
+	"nul": true,
+}
+
+// InvalidNameError reports a feature name rejected by the naming policy.
+type InvalidNameError struct {
+	Name   string
+	Reason string
+}
+
+// Error implements the error interface.
+func (e *InvalidNameError) Error() string {
+	return fmt.Sprintf("invalid feature name %q: %s", e.Name, e.Reason)
+}
+
+// ErrorCode implements d3err.Coder.
+func (e *InvalidNameError) ErrorCode() d3err.Code {
+	return d3err.InvalidName
+}
+
+// Is matches d3err.ErrInvalidName.
+func (e *InvalidNameError) Is(target error) bool {
+	return d3err.CodeOf(target) == d3err.InvalidName
+}
+
+// NormalizeName converts user or AI supplied input into a feature name slug.
+// Surrounding whitespace is trimmed, inner whitespace becomes dashes and the
+// result is lowercased. The normalized name is validated before it is returned.
+func NormalizeName(name string) (string, error) {
+	normalized := strings.TrimSpace(name)
+	normalized = whitespacePattern.ReplaceAllString(normalized, "-")
+	normalized = strings.ToLower(normalized)
+
+	if err := ValidateName(normalized); err != nil {
+		if invalid, ok := err.(*InvalidNameError); ok {
+			invalid.Name = name
+		}
+		return "", err
+	}
+	return normalized, nil
+}
+
+// SlugifyName derives a feature name from free text such as an issue title.
+// Unlike NormalizeName it drops punctuation instead of rejecting it, and it
+// shortens long input at a word boundary to fit the name length limit.
+func SlugifyName(text string) (string, error) {
+	slug := slugSeparatorPattern.ReplaceAllString(strings.ToLower(text), "-")
+	slug = strings.Trim(slug, "-._")
+	if len(slug) > maxNameLength {
+		slug = slug[:maxNameLength]
+		if idx := strings.LastIndex(slug, "-"); idx > maxNameLength/2 {
+			slug = slug[:idx]
+		}
+		slug = strings.Trim(slug, "-._")
+	}
+
+	if err := ValidateName(slug); err != nil {
+		if invalid, ok := err.(*InvalidNameError); ok {
+			invalid.Name = text
+		}
+		return "", err
+	}
+	return slug, nil
+}
+
+// ValidateName checks that name is a normalized feature name that is safe to
+// join onto the features directory. It returns an *InvalidNameError otherwise.
+func ValidateName(name string) error {
+	switch {
+	case name == "":
+		return &InvalidNameError{Name: name, Reason: "name must not be empty"}
+	case len(name) > maxNameLength:
+		return &InvalidNameError{Name: name, Reason: fmt.Sprintf("name must be at most %d characters", maxNameLength)}
+	case strings.ContainsAny(name, `/\`):
+		return &InvalidNameError{Name: name, Reason: "name must not contain path separators"}
+	case reservedNames[name]:
+		return &InvalidNameError{Name: name, Reason: "name is reserved"}
+	case !namePattern.MatchString(name):
+		return &InvalidNameError{Name: name, Reason: "name must start with a letter or digit and contain only lowercase letters, digits, '.', '-' or '_'"}
+	}
+	return nil
+}
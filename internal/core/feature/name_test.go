@@ -0,0 +1,97 @@
+package feature
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeName(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{name: "already normalized", input: "my-feature", want: "my-feature"},
+		{name: "spaces become dashes", input: "My New Feature", want: "my-new-feature"},
+		{name: "surrounding whitespace trimmed", input: "  login flow\t", want: "login-flow"},
+		{name: "dots and underscores allowed", input: "api_v1.2", want: "api_v1.2"},
+		{name: "empty after trimming", input: "   ", wantErr: true},
+		{name: "path traversal", input: "../../src", wantErr: true},
+		{name: "contains slash", input: "a/b", wantErr: true},
+		{name: "contains backslash", input: `a\b`, wantErr: true},
+		{name: "phase file name", input: ".phase", wantErr: true},
+		{name: "active feature file name", input: ".feature", wantErr: true},
+		{name: "reserved device name", input: "NUL", wantErr: true},
+		{name: "leading dot", input: ".hidden", wantErr: true},
+		{name: "leading dash", input: "-flag", wantErr: true},
+		{name: "disallowed characters", input: "feature$", wantErr: true},
+		{name: "too long", input: strings.Repeat("a", maxNameLength+1), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeName(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NormalizeName(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				var invalid *InvalidNameError
+				if !errors.As(err, &invalid) {
+					t.Fatalf("NormalizeName(%q) error = %T, want *InvalidNameError", tt.input, err)
+				}
+				if invalid.Name != tt.input {
+					t.Errorf("InvalidNameError.Name = %q, want original input %q", invalid.Name, tt.input)
+				}
+				return
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeName(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateName_RejectsUnnormalized(t *testing.T) {
+	if err := ValidateName("My Feature"); err == nil {
+		t.Error("ValidateName() accepted a name that was not normalized")
+	}
+	if err := ValidateName("my-feature"); err != nil {
+		t.Errorf("ValidateName() unexpected error for normalized name: %v", err)
+	}
+}
+
+func TestSlugifyName(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{name: "issue title", input: "Login fails when password contains '&'", want: "login-fails-when-password-contains"},
+		{name: "punctuation collapsed", input: "[Bug] API: 500 on /users!", want: "bug-api-500-on-users"},
+		{name: "dots and underscores kept", input: "Upgrade to v1.2 in api_client", want: "upgrade-to-v1.2-in-api_client"},
+		{
+			name:  "long title cut at word boundary",
+			input: "Allow administrators to bulk export every audit log entry for a selected date range as CSV",
+			want:  "allow-administrators-to-bulk-export-every-audit-log-entry-for-a",
+		},
+		{name: "nothing usable", input: "!!! ???", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SlugifyName(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SlugifyName(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("SlugifyName(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+			if !tt.wantErr && len(got) > maxNameLength {
+				t.Errorf("SlugifyName(%q) length = %d, exceeds %d", tt.input, len(got), maxNameLength)
+			}
+		})
+	}
+}
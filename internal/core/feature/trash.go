@@ -0,0 +1,206 @@
+package feature
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/imcclaskey/d3/internal/core/d3err"
+)
+
+const trashDirName = ".trash" // Directory under .d3 holding soft-deleted features
+
+// trashTimestampFormat is appended to a feature name when it is moved to the trash.
+// It contains no dashes so the feature name can be recovered by splitting on the last one.
+const trashTimestampFormat = "20060102T150405.000Z"
+
+// DefaultTrashRetention is how long deleted features are kept before being pruned.
+const DefaultTrashRetention = 30 * 24 * time.Hour
+
+// TrashRetentionEnv names the environment variable that overrides DefaultTrashRetention.
+const TrashRetentionEnv = "D3_TRASH_RETENTION"
+
+// ParseTrashRetention parses a retention period such as "30d", "12h" or "90m".
+// In addition to time.ParseDuration units it accepts a "d" suffix for whole days.
+// An empty value yields DefaultTrashRetention.
+func ParseTrashRetention(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return DefaultTrashRetention, nil
+	}
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, d3err.New(d3err.InvalidArgument, "invalid trash retention %q: expected a whole number of days", value)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, d3err.New(d3err.InvalidArgument, "invalid trash retention %q: %v", value, err)
+	}
+	return d, nil
+}
+
+// TrashEntry describes a soft-deleted feature held in the trash directory.
+type TrashEntry struct {
+	// Name is the original feature name
+	Name string
+	// Path is the location of the entry inside the trash directory
+	Path string
+	// DeletedAt is when the feature was moved to the trash
+	DeletedAt time.Time
+}
+
+// SetTrashRetention changes how long deleted features are kept.
+// A zero or negative retention disables automatic pruning.
+func (s *Service) SetTrashRetention(retention time.Duration) {
+	s.trashRetention = retention
+}
+
+// moveToTrash renames a feature directory into the trash and returns its new path.
+func (s *Service) moveToTrash(featureName, featurePath string) (string, error) {
+	if err := s.fs.MkdirAll(s.trashDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create trash directory: %w", err)
+	}
+
+	trashPath := filepath.Join(s.trashDir, featureName+"-"+s.now().UTC().Format(trashTimestampFormat))
+	if err := s.fs.Rename(featurePath, trashPath); err != nil {
+		return "", fmt.Errorf("failed to move feature '%s' to trash: %w", featureName, err)
+	}
+	s.logger.Debug("moved feature to trash", "feature", featureName, "path", trashPath)
+	return trashPath, nil
+}
+
+// parseTrashEntryName splits a trash directory name into feature name and deletion time.
+func parseTrashEntryName(entryName string) (string, time.Time, bool) {
+	idx := strings.LastIndex(entryName, "-")
+	if idx <= 0 {
+		return "", time.Time{}, false
+	}
+	deletedAt, err := time.Parse(trashTimestampFormat, entryName[idx+1:])
+	if err != nil {
+		return "", time.Time{}, false
+	}
+	return entryName[:idx], deletedAt, true
+}
+
+// ListTrash returns all soft-deleted features, newest first.
+func (s *Service) ListTrash(ctx context.Context) ([]TrashEntry, error) {
+	entries, err := s.fs.ReadDir(s.trashDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []TrashEntry{}, nil
+		}
+		return nil, fmt.Errorf("failed to read trash directory: %w", err)
+	}
+
+	trash := []TrashEntry{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		name, deletedAt, ok := parseTrashEntryName(entry.Name())
+		if !ok {
+			continue
+		}
+		trash = append(trash, TrashEntry{
+			Name:      name,
+			Path:      filepath.Join(s.trashDir, entry.Name()),
+			DeletedAt: deletedAt,
+		})
+	}
+
+	sort.SliceStable(trash, func(i, j int) bool {
+		return trash[i].DeletedAt.After(trash[j].DeletedAt)
+	})
+	return trash, nil
+}
+
+// RestoreFeature moves the most recently deleted copy of a feature back into the features directory.
+func (s *Service) RestoreFeature(ctx context.Context, featureName string) (*FeatureInfo, error) {
+	if err := ValidateName(featureName); err != nil {
+		return nil, err
+	}
+
+	featurePath := filepath.Join(s.featuresDir, featureName)
+	if _, err := s.fs.Stat(featurePath); err == nil {
+		return nil, d3err.New(d3err.FeatureExists, "feature %s already exists, delete or rename it before restoring", featureName)
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to check if feature %s exists: %w", featureName, err)
+	}
+
+	trash, err := s.ListTrash(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range trash {
+		if entry.Name != featureName {
+			continue
+		}
+		if err := s.fs.MkdirAll(s.featuresDir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create features directory: %w", err)
+		}
+		if err := s.fs.Rename(entry.Path, featurePath); err != nil {
+			return nil, fmt.Errorf("failed to restore feature '%s' from trash: %w", featureName, err)
+		}
+		s.logger.Debug("restored feature from trash", "feature", featureName, "from", entry.Path)
+		return &FeatureInfo{Name: featureName, Path: featurePath}, nil
+	}
+
+	return nil, d3err.New(d3err.FeatureNotFound, "feature '%s' not found in trash", featureName)
+}
+
+// EmptyTrash permanently removes trash entries deleted more than olderThan ago.
+// A zero olderThan removes every entry. It returns the entries that were removed.
+func (s *Service) EmptyTrash(ctx context.Context, olderThan time.Duration) ([]TrashEntry, error) {
+	trash, err := s.ListTrash(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := s.now().Add(-olderThan)
+	removed := []TrashEntry{}
+	for _, entry := range trash {
+		if olderThan > 0 && entry.DeletedAt.After(cutoff) {
+			continue
+		}
+		if err := s.fs.RemoveAll(entry.Path); err != nil {
+			return removed, fmt.Errorf("failed to remove trash entry %s: %w", entry.Path, err)
+		}
+		s.logger.Debug("removed trash entry", "path", entry.Path)
+		removed = append(removed, entry)
+	}
+	return removed, nil
+}
+
+// PurgeFeature permanently removes a feature directory, bypassing the trash.
+// Like DeleteFeature, it clears the active feature state when the purged feature is active.
+func (s *Service) PurgeFeature(ctx context.Context, featureName string) (bool, error) {
+	if err := ValidateName(featureName); err != nil {
+		return false, err
+	}
+
+	featurePath := filepath.Join(s.featuresDir, featureName)
+	activeContextCleared, err := s.clearIfActive(featureName)
+	if err != nil {
+		return false, err
+	}
+
+	if _, err := s.fs.Stat(featurePath); os.IsNotExist(err) {
+		return activeContextCleared, d3err.New(d3err.FeatureNotFound, "feature '%s' not found at %s", featureName, featurePath)
+	} else if err != nil {
+		return activeContextCleared, fmt.Errorf("failed to check feature '%s': %w", featureName, err)
+	}
+
+	if errRemove := s.fs.RemoveAll(featurePath); errRemove != nil {
+		return activeContextCleared, fmt.Errorf("failed to purge feature '%s': %w", featureName, errRemove)
+	}
+	return activeContextCleared, nil
+}
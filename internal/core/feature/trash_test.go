@@ -0,0 +1,284 @@
+package feature
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	portsmocks "github.com/imcclaskey/d3/internal/core/ports/mocks"
+	"github.com/imcclaskey/d3/internal/testutil"
+)
+
+func TestParseTrashEntryName(t *testing.T) {
+	tests := []struct {
+		name      string
+		entry     string
+		wantName  string
+		wantTime  time.Time
+		wantValid bool
+	}{
+		{
+			name:      "simple name",
+			entry:     "login-20250501T123000.000Z",
+			wantName:  "login",
+			wantTime:  testNow,
+			wantValid: true,
+		},
+		{
+			name:      "name containing dashes",
+			entry:     "my-big-feature-20250501T123000.000Z",
+			wantName:  "my-big-feature",
+			wantTime:  testNow,
+			wantValid: true,
+		},
+		{name: "missing timestamp", entry: "login", wantValid: false},
+		{name: "malformed timestamp", entry: "login-yesterday", wantValid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotName, gotTime, ok := parseTrashEntryName(tt.entry)
+			if ok != tt.wantValid {
+				t.Fatalf("parseTrashEntryName(%q) ok = %v, want %v", tt.entry, ok, tt.wantValid)
+			}
+			if !ok {
+				return
+			}
+			if gotName != tt.wantName || !gotTime.Equal(tt.wantTime) {
+				t.Errorf("parseTrashEntryName(%q) = (%q, %v), want (%q, %v)", tt.entry, gotName, gotTime, tt.wantName, tt.wantTime)
+			}
+		})
+	}
+}
+
+func TestService_ListTrash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	s, mockFS := newTestService(t, ctrl)
+
+	mockFS.EXPECT().ReadDir(s.trashDir).Return([]fs.DirEntry{
+		MockDirEntry{EntryName: "old-20250101T000000.000Z", EntryIsDir: true},
+		MockDirEntry{EntryName: "new-20250401T000000.000Z", EntryIsDir: true},
+		MockDirEntry{EntryName: "not-a-trash-entry", EntryIsDir: true},
+		MockDirEntry{EntryName: "stray-20250301T000000.000Z", EntryIsDir: false},
+	}, nil).Times(1)
+
+	got, err := s.ListTrash(context.Background())
+	if err != nil {
+		t.Fatalf("ListTrash() unexpected error: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("ListTrash() returned %d entries, want 2: %+v", len(got), got)
+	}
+	if got[0].Name != "new" || got[1].Name != "old" {
+		t.Errorf("ListTrash() order = [%s %s], want newest first [new old]", got[0].Name, got[1].Name)
+	}
+	if got[0].Path != filepath.Join(s.trashDir, "new-20250401T000000.000Z") {
+		t.Errorf("ListTrash() path = %s", got[0].Path)
+	}
+}
+
+func TestService_ListTrash_NoTrashDir(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	s, mockFS := newTestService(t, ctrl)
+
+	mockFS.EXPECT().ReadDir(s.trashDir).Return(nil, os.ErrNotExist).Times(1)
+
+	got, err := s.ListTrash(context.Background())
+	if err != nil || len(got) != 0 {
+		t.Errorf("ListTrash() = %v, %v; want empty list and nil error", got, err)
+	}
+}
+
+func TestService_RestoreFeature(t *testing.T) {
+	tests := []struct {
+		name        string
+		featureName string
+		setupMocks  func(s *Service, mockFS *portsmocks.MockFileSystem, featurePath string)
+		wantErr     bool
+	}{
+		{
+			name:        "restores newest entry",
+			featureName: "login",
+			setupMocks: func(s *Service, mockFS *portsmocks.MockFileSystem, featurePath string) {
+				mockFS.EXPECT().Stat(featurePath).Return(nil, os.ErrNotExist).Times(1)
+				mockFS.EXPECT().ReadDir(s.trashDir).Return([]fs.DirEntry{
+					MockDirEntry{EntryName: "login-20250101T000000.000Z", EntryIsDir: true},
+					MockDirEntry{EntryName: "login-20250401T000000.000Z", EntryIsDir: true},
+				}, nil).Times(1)
+				mockFS.EXPECT().MkdirAll(s.featuresDir, os.FileMode(0755)).Return(nil).Times(1)
+				mockFS.EXPECT().Rename(filepath.Join(s.trashDir, "login-20250401T000000.000Z"), featurePath).Return(nil).Times(1)
+			},
+			wantErr: false,
+		},
+		{
+			name:        "feature with the same name already exists",
+			featureName: "login",
+			setupMocks: func(s *Service, mockFS *portsmocks.MockFileSystem, featurePath string) {
+				mockFS.EXPECT().Stat(featurePath).Return(testutil.MockFileInfo{FIsDir: true}, nil).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name:        "no trash entry for feature",
+			featureName: "login",
+			setupMocks: func(s *Service, mockFS *portsmocks.MockFileSystem, featurePath string) {
+				mockFS.EXPECT().Stat(featurePath).Return(nil, os.ErrNotExist).Times(1)
+				mockFS.EXPECT().ReadDir(s.trashDir).Return([]fs.DirEntry{
+					MockDirEntry{EntryName: "other-20250101T000000.000Z", EntryIsDir: true},
+				}, nil).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name:        "invalid name",
+			featureName: "../x",
+			setupMocks:  func(s *Service, mockFS *portsmocks.MockFileSystem, featurePath string) {},
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			s, mockFS := newTestService(t, ctrl)
+			featurePath := filepath.Join(s.featuresDir, tt.featureName)
+			tt.setupMocks(s, mockFS, featurePath)
+
+			info, err := s.RestoreFeature(context.Background(), tt.featureName)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RestoreFeature() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (info == nil || info.Path != featurePath) {
+				t.Errorf("RestoreFeature() info = %+v, want path %s", info, featurePath)
+			}
+		})
+	}
+}
+
+func TestService_EmptyTrash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	s, mockFS := newTestService(t, ctrl)
+
+	expired := filepath.Join(s.trashDir, "old-20250101T000000.000Z")
+	mockFS.EXPECT().ReadDir(s.trashDir).Return([]fs.DirEntry{
+		MockDirEntry{EntryName: "old-20250101T000000.000Z", EntryIsDir: true},
+		MockDirEntry{EntryName: "recent-20250430T000000.000Z", EntryIsDir: true},
+	}, nil).Times(1)
+	mockFS.EXPECT().RemoveAll(expired).Return(nil).Times(1)
+
+	removed, err := s.EmptyTrash(context.Background(), 7*24*time.Hour)
+	if err != nil {
+		t.Fatalf("EmptyTrash() unexpected error: %v", err)
+	}
+	if len(removed) != 1 || removed[0].Name != "old" {
+		t.Errorf("EmptyTrash() removed = %+v, want only 'old'", removed)
+	}
+}
+
+func TestService_EmptyTrash_All(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	s, mockFS := newTestService(t, ctrl)
+
+	mockFS.EXPECT().ReadDir(s.trashDir).Return([]fs.DirEntry{
+		MockDirEntry{EntryName: "a-20250101T000000.000Z", EntryIsDir: true},
+		MockDirEntry{EntryName: "b-20250430T000000.000Z", EntryIsDir: true},
+	}, nil).Times(1)
+	mockFS.EXPECT().RemoveAll(gomock.Any()).Return(nil).Times(2)
+
+	removed, err := s.EmptyTrash(context.Background(), 0)
+	if err != nil || len(removed) != 2 {
+		t.Errorf("EmptyTrash(0) = %+v, %v; want both entries removed", removed, err)
+	}
+}
+
+func TestService_PurgeFeature(t *testing.T) {
+	tests := []struct {
+		name        string
+		featureName string
+		active      string
+		setupMocks  func(s *Service, mockFS *portsmocks.MockFileSystem, featurePath string)
+		wantCleared bool
+		wantErr     bool
+	}{
+		{
+			name:        "purges inactive feature",
+			featureName: "gone",
+			active:      "other",
+			setupMocks: func(s *Service, mockFS *portsmocks.MockFileSystem, featurePath string) {
+				mockFS.EXPECT().Stat(featurePath).Return(testutil.MockFileInfo{FIsDir: true}, nil).Times(1)
+				mockFS.EXPECT().RemoveAll(featurePath).Return(nil).Times(1)
+			},
+		},
+		{
+			name:        "purges active feature and clears context",
+			featureName: "gone",
+			active:      "gone",
+			setupMocks: func(s *Service, mockFS *portsmocks.MockFileSystem, featurePath string) {
+				mockFS.EXPECT().Remove(s.activeFeatureFilePath).Return(nil).Times(1)
+				mockFS.EXPECT().Stat(featurePath).Return(testutil.MockFileInfo{FIsDir: true}, nil).Times(1)
+				mockFS.EXPECT().RemoveAll(featurePath).Return(nil).Times(1)
+			},
+			wantCleared: true,
+		},
+		{
+			name:        "RemoveAll fails",
+			featureName: "gone",
+			active:      "other",
+			setupMocks: func(s *Service, mockFS *portsmocks.MockFileSystem, featurePath string) {
+				mockFS.EXPECT().Stat(featurePath).Return(testutil.MockFileInfo{FIsDir: true}, nil).Times(1)
+				mockFS.EXPECT().RemoveAll(featurePath).Return(fmt.Errorf("remove failed")).Times(1)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			s, mockFS := newTestService(t, ctrl)
+			featurePath := filepath.Join(s.featuresDir, tt.featureName)
+			mockFS.EXPECT().ReadFile(s.activeFeatureFilePath).Return([]byte(tt.active), nil).Times(1)
+			tt.setupMocks(s, mockFS, featurePath)
+
+			cleared, err := s.PurgeFeature(context.Background(), tt.featureName)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PurgeFeature() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if cleared != tt.wantCleared {
+				t.Errorf("PurgeFeature() cleared = %v, want %v", cleared, tt.wantCleared)
+			}
+		})
+	}
+}
+
+func TestParseTrashRetention(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{value: "", want: DefaultTrashRetention},
+		{value: "7d", want: 7 * 24 * time.Hour},
+		{value: "0d", want: 0},
+		{value: "36h", want: 36 * time.Hour},
+		{value: "soon", wantErr: true},
+		{value: "-1d", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseTrashRetention(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTrashRetention(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ParseTrashRetention(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
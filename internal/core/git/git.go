@@ -0,0 +1,210 @@
+// Package git wraps the local git binary for the few repository operations d3 needs.
+// Every operation is local; nothing here talks to a remote.
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+//go:generate mockgen -package=mocks -destination=mocks/git_mock.go . Client
+
+// Client defines the git operations used by d3.
+// This allows for mocking git in tests.
+type Client interface {
+	IsRepository(ctx context.Context) bool
+	CurrentBranch(ctx context.Context) (string, error)
+	BranchExists(ctx context.Context, name string) (bool, error)
+	CreateBranch(ctx context.Context, name string) error
+	SwitchBranch(ctx context.Context, name string) error
+	HooksDir(ctx context.Context) (string, error)
+	StagedFiles(ctx context.Context) ([]string, error)
+	AddTrailer(ctx context.Context, messageFile, key, value string) error
+	CommitsWithTrailer(ctx context.Context, key, value string) ([]Commit, error)
+}
+
+// Commit summarizes a commit found in the local history.
+type Commit struct {
+	Hash    string
+	Author  string
+	Date    time.Time
+	Subject string
+}
+
+// ErrNoBranch is returned by CurrentBranch when HEAD is detached.
+var ErrNoBranch = errors.New("HEAD is not on a branch")
+
+// runFunc executes git with args inside dir and returns its trimmed standard output.
+type runFunc func(ctx context.Context, dir string, args ...string) (string, error)
+
+// CLI implements Client by running the git executable.
+type CLI struct {
+	dir string
+	run runFunc
+}
+
+// New creates a git client operating on the repository containing dir.
+func New(dir string) *CLI {
+	return &CLI{dir: dir, run: runGit}
+}
+
+// runGit runs the git binary and folds stderr into the returned error.
+func runGit(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			return "", fmt.Errorf("git %s: %w", strings.Join(args, " "), err)
+		}
+		return "", fmt.Errorf("git %s: %s: %w", strings.Join(args, " "), msg, err)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// IsRepository reports whether the client directory is inside a git work tree.
+// It returns false when git is not installed.
+func (c *CLI) IsRepository(ctx context.Context) bool {
+	out, err := c.run(ctx, c.dir, "rev-parse", "--is-inside-work-tree")
+	return err == nil && out == "true"
+}
+
+// CurrentBranch returns the short name of the checked-out branch.
+func (c *CLI) CurrentBranch(ctx context.Context) (string, error) {
+	out, err := c.run(ctx, c.dir, "symbolic-ref", "--quiet", "--short", "HEAD")
+	if err != nil {
+		// symbolic-ref fails on a detached HEAD; distinguish that from git failing outright
+		if _, revErr := c.run(ctx, c.dir, "rev-parse", "--verify", "HEAD"); revErr == nil {
+			return "", ErrNoBranch
+		}
+		return "", fmt.Errorf("failed to determine current branch: %w", err)
+	}
+	return out, nil
+}
+
+// BranchExists reports whether a local branch with the given name exists.
+func (c *CLI) BranchExists(ctx context.Context, name string) (bool, error) {
+	if err := c.validateBranchName(ctx, name); err != nil {
+		return false, err
+	}
+	out, err := c.run(ctx, c.dir, "branch", "--list", name)
+	if err != nil {
+		return false, fmt.Errorf("failed to list branches: %w", err)
+	}
+	return out != "", nil
+}
+
+// CreateBranch creates a new branch from HEAD and checks it out.
+func (c *CLI) CreateBranch(ctx context.Context, name string) error {
+	if err := c.validateBranchName(ctx, name); err != nil {
+		return err
+	}
+	if _, err := c.run(ctx, c.dir, "checkout", "-b", name); err != nil {
+		return fmt.Errorf("failed to create branch '%s': %w", name, err)
+	}
+	return nil
+}
+
+// SwitchBranch checks out an existing branch.
+func (c *CLI) SwitchBranch(ctx context.Context, name string) error {
+	if err := c.validateBranchName(ctx, name); err != nil {
+		return err
+	}
+	if _, err := c.run(ctx, c.dir, "checkout", name); err != nil {
+		return fmt.Errorf("failed to switch to branch '%s': %w", name, err)
+	}
+	return nil
+}
+
+// validateBranchName rejects names git would refuse, and names that could be read as options.
+func (c *CLI) validateBranchName(ctx context.Context, name string) error {
+	if name == "" || strings.HasPrefix(name, "-") {
+		return fmt.Errorf("invalid branch name '%s'", name)
+	}
+	if _, err := c.run(ctx, c.dir, "check-ref-format", "--branch", name); err != nil {
+		return fmt.Errorf("invalid branch name '%s'", name)
+	}
+	return nil
+}
+
+// HooksDir returns the absolute path of the directory git runs hooks from.
+// It honours core.hooksPath and linked worktrees.
+func (c *CLI) HooksDir(ctx context.Context) (string, error) {
+	out, err := c.run(ctx, c.dir, "rev-parse", "--git-path", "hooks")
+	if err != nil {
+		return "", fmt.Errorf("failed to locate git hooks directory: %w", err)
+	}
+	if !filepath.IsAbs(out) {
+		out = filepath.Join(c.dir, out)
+	}
+	return out, nil
+}
+
+// StagedFiles returns the paths, relative to the repository root, staged for the next commit.
+func (c *CLI) StagedFiles(ctx context.Context) ([]string, error) {
+	out, err := c.run(ctx, c.dir, "diff", "--cached", "--name-only", "-z")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list staged files: %w", err)
+	}
+	files := []string{}
+	for _, file := range strings.Split(out, "\x00") {
+		if file != "" {
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+// AddTrailer appends a "key: value" trailer to a commit message file unless the key is already present.
+func (c *CLI) AddTrailer(ctx context.Context, messageFile, key, value string) error {
+	trailer := fmt.Sprintf("%s: %s", key, value)
+	if _, err := c.run(ctx, c.dir, "interpret-trailers", "--in-place", "--if-exists", "doNothing", "--trailer", trailer, messageFile); err != nil {
+		return fmt.Errorf("failed to add trailer '%s': %w", trailer, err)
+	}
+	return nil
+}
+
+// logFieldSep and logRecordSep delimit fields and commits in the CommitsWithTrailer log format.
+const (
+	logFieldSep  = "\x1f"
+	logRecordSep = "\x1e"
+)
+
+// CommitsWithTrailer returns commits reachable from HEAD carrying the trailer "key: value", newest first.
+func (c *CLI) CommitsWithTrailer(ctx context.Context, key, value string) ([]Commit, error) {
+	format := strings.Join([]string{"%H", "%an", "%aI", "%s", "%(trailers:key=" + key + ",valueonly,separator=%x1f)"}, "%x1f") + "%x1e"
+	out, err := c.run(ctx, c.dir, "log", "--format="+format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read git log: %w", err)
+	}
+
+	commits := []Commit{}
+	for _, record := range strings.Split(out, logRecordSep) {
+		fields := strings.Split(strings.TrimSpace(record), logFieldSep)
+		if len(fields) < 5 {
+			continue
+		}
+		matched := false
+		for _, trailerValue := range fields[4:] {
+			if strings.TrimSpace(trailerValue) == value {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+		date, _ := time.Parse(time.RFC3339, fields[2])
+		commits = append(commits, Commit{Hash: fields[0], Author: fields[1], Date: date, Subject: fields[3]})
+	}
+	return commits, nil
+}
@@ -0,0 +1,188 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeRunner records git invocations and answers them from a table keyed by the joined arguments.
+type fakeRunner struct {
+	responses map[string]string
+	failures  map[string]bool
+	calls     []string
+}
+
+func (f *fakeRunner) run(ctx context.Context, dir string, args ...string) (string, error) {
+	key := strings.Join(args, " ")
+	f.calls = append(f.calls, key)
+	if f.failures[key] {
+		return "", fmt.Errorf("git %s failed", key)
+	}
+	return f.responses[key], nil
+}
+
+func newTestCLI(f *fakeRunner) *CLI {
+	return &CLI{dir: "/repo", run: f.run}
+}
+
+func TestCLI_IsRepository(t *testing.T) {
+	inside := &fakeRunner{responses: map[string]string{"rev-parse --is-inside-work-tree": "true"}}
+	if !newTestCLI(inside).IsRepository(context.Background()) {
+		t.Errorf("IsRepository() = false inside a work tree")
+	}
+
+	outside := &fakeRunner{failures: map[string]bool{"rev-parse --is-inside-work-tree": true}}
+	if newTestCLI(outside).IsRepository(context.Background()) {
+		t.Errorf("IsRepository() = true when git fails")
+	}
+}
+
+func TestCLI_CurrentBranch(t *testing.T) {
+	tests := []struct {
+		name    string
+		runner  *fakeRunner
+		want    string
+		wantErr error
+	}{
+		{
+			name:   "on a branch",
+			runner: &fakeRunner{responses: map[string]string{"symbolic-ref --quiet --short HEAD": "feature/login"}},
+			want:   "feature/login",
+		},
+		{
+			name: "detached HEAD",
+			runner: &fakeRunner{
+				failures:  map[string]bool{"symbolic-ref --quiet --short HEAD": true},
+				responses: map[string]string{"rev-parse --verify HEAD": "abc123"},
+			},
+			wantErr: ErrNoBranch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newTestCLI(tt.runner).CurrentBranch(context.Background())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CurrentBranch() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("CurrentBranch() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCLI_BranchExists(t *testing.T) {
+	f := &fakeRunner{responses: map[string]string{"branch --list feature/login": "  feature/login"}}
+	cli := newTestCLI(f)
+
+	exists, err := cli.BranchExists(context.Background(), "feature/login")
+	if err != nil || !exists {
+		t.Errorf("BranchExists(feature/login) = %v, %v; want true, nil", exists, err)
+	}
+	exists, err = cli.BranchExists(context.Background(), "feature/other")
+	if err != nil || exists {
+		t.Errorf("BranchExists(feature/other) = %v, %v; want false, nil", exists, err)
+	}
+}
+
+func TestCLI_CreateAndSwitchBranch(t *testing.T) {
+	f := &fakeRunner{}
+	cli := newTestCLI(f)
+
+	if err := cli.CreateBranch(context.Background(), "feature/login"); err != nil {
+		t.Fatalf("CreateBranch() unexpected error: %v", err)
+	}
+	if err := cli.SwitchBranch(context.Background(), "main"); err != nil {
+		t.Fatalf("SwitchBranch() unexpected error: %v", err)
+	}
+
+	want := []string{
+		"check-ref-format --branch feature/login",
+		"checkout -b feature/login",
+		"check-ref-format --branch main",
+		"checkout main",
+	}
+	if strings.Join(f.calls, "\n") != strings.Join(want, "\n") {
+		t.Errorf("git calls = %q, want %q", f.calls, want)
+	}
+}
+
+func TestCLI_InvalidBranchName(t *testing.T) {
+	f := &fakeRunner{failures: map[string]bool{"check-ref-format --branch bad..name": true}}
+	cli := newTestCLI(f)
+
+	if err := cli.CreateBranch(context.Background(), "bad..name"); err == nil {
+		t.Errorf("CreateBranch(bad..name) expected error")
+	}
+	if err := cli.SwitchBranch(context.Background(), "--force"); err == nil {
+		t.Errorf("SwitchBranch(--force) expected error")
+	}
+	for _, call := range f.calls {
+		if strings.HasPrefix(call, "checkout") {
+			t.Errorf("unexpected checkout call %q for invalid branch name", call)
+		}
+	}
+}
+
+func TestCLI_HooksDir(t *testing.T) {
+	f := &fakeRunner{responses: map[string]string{"rev-parse --git-path hooks": ".git/hooks"}}
+	got, err := newTestCLI(f).HooksDir(context.Background())
+	if err != nil || got != filepath.Join("/repo", ".git", "hooks") {
+		t.Errorf("HooksDir() = %q, %v; want /repo/.git/hooks", got, err)
+	}
+}
+
+func TestCLI_StagedFiles(t *testing.T) {
+	f := &fakeRunner{responses: map[string]string{"diff --cached --name-only -z": "main.go\x00.d3/features/a b/plan.md\x00"}}
+	got, err := newTestCLI(f).StagedFiles(context.Background())
+	if err != nil {
+		t.Fatalf("StagedFiles() unexpected error: %v", err)
+	}
+	if len(got) != 2 || got[0] != "main.go" || got[1] != ".d3/features/a b/plan.md" {
+		t.Errorf("StagedFiles() = %q", got)
+	}
+}
+
+func TestCLI_AddTrailer(t *testing.T) {
+	f := &fakeRunner{}
+	if err := newTestCLI(f).AddTrailer(context.Background(), "/repo/.git/COMMIT_EDITMSG", "D3-Feature", "login"); err != nil {
+		t.Fatalf("AddTrailer() unexpected error: %v", err)
+	}
+	want := "interpret-trailers --in-place --if-exists doNothing --trailer D3-Feature: login /repo/.git/COMMIT_EDITMSG"
+	if len(f.calls) != 1 || f.calls[0] != want {
+		t.Errorf("git calls = %q, want %q", f.calls, want)
+	}
+}
+
+func TestCLI_CommitsWithTrailer(t *testing.T) {
+	record := func(fields ...string) string { return strings.Join(fields, "\x1f") + "\x1e\n" }
+	out := record("aaa", "Ann", "2025-05-02T10:00:00Z", "deliver login", "login") +
+		record("bbb", "Bob", "2025-05-01T10:00:00Z", "unrelated", "") +
+		record("ccc", "Cy", "2025-04-30T10:00:00Z", "shared work", "payments", "login") +
+		record("ddd", "Di", "2025-04-29T10:00:00Z", "similar name", "login-v2")
+
+	f := &fakeRunner{responses: map[string]string{}}
+	cli := newTestCLI(f)
+	cli.run = func(ctx context.Context, dir string, args ...string) (string, error) {
+		if args[0] != "log" || !strings.Contains(args[1], "%(trailers:key=D3-Feature,valueonly") {
+			t.Errorf("unexpected git invocation %q", args)
+		}
+		return strings.TrimSpace(out), nil
+	}
+
+	got, err := cli.CommitsWithTrailer(context.Background(), "D3-Feature", "login")
+	if err != nil {
+		t.Fatalf("CommitsWithTrailer() unexpected error: %v", err)
+	}
+	if len(got) != 2 || got[0].Hash != "aaa" || got[1].Hash != "ccc" {
+		t.Fatalf("CommitsWithTrailer() = %+v, want commits aaa and ccc", got)
+	}
+	if got[0].Author != "Ann" || got[0].Subject != "deliver login" || got[0].Date.Day() != 2 {
+		t.Errorf("CommitsWithTrailer() first commit = %+v", got[0])
+	}
+}
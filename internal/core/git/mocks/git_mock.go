@@ -0,0 +1,167 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/imcclaskey/d3/internal/core/git (interfaces: Client)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	git "github.com/imcclaskey/d3/internal/core/git"
+)
+
+// MockClient is a mock of Client interface.
+type MockClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockClientMockRecorder
+}
+
+// MockClientMockRecorder is the mock recorder for MockClient.
+type MockClientMockRecorder struct {
+	mock *MockClient
+}
+
+// NewMockClient creates a new mock instance.
+func NewMockClient(ctrl *gomock.Controller) *MockClient {
+	mock := &MockClient{ctrl: ctrl}
+	mock.recorder = &MockClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClient) EXPECT() *MockClientMockRecorder {
+	return m.recorder
+}
+
+// AddTrailer mocks base method.
+func (m *MockClient) AddTrailer(arg0 context.Context, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTrailer", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTrailer indicates an expected call of AddTrailer.
+func (mr *MockClientMockRecorder) AddTrailer(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTrailer", reflect.TypeOf((*MockClient)(nil).AddTrailer), arg0, arg1, arg2, arg3)
+}
+
+// BranchExists mocks base method.
+func (m *MockClient) BranchExists(arg0 context.Context, arg1 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BranchExists", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BranchExists indicates an expected call of BranchExists.
+func (mr *MockClientMockRecorder) BranchExists(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BranchExists", reflect.TypeOf((*MockClient)(nil).BranchExists), arg0, arg1)
+}
+
+// CommitsWithTrailer mocks base method.
+func (m *MockClient) CommitsWithTrailer(arg0 context.Context, arg1, arg2 string) ([]git.Commit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommitsWithTrailer", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]git.Commit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CommitsWithTrailer indicates an expected call of CommitsWithTrailer.
+func (mr *MockClientMockRecorder) CommitsWithTrailer(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitsWithTrailer", reflect.TypeOf((*MockClient)(nil).CommitsWithTrailer), arg0, arg1, arg2)
+}
+
+// CreateBranch mocks base method.
+func (m *MockClient) CreateBranch(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBranch", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateBranch indicates an expected call of CreateBranch.
+func (mr *MockClientMockRecorder) CreateBranch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBranch", reflect.TypeOf((*MockClient)(nil).CreateBranch), arg0, arg1)
+}
+
+// CurrentBranch mocks base method.
+func (m *MockClient) CurrentBranch(arg0 context.Context) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CurrentBranch", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CurrentBranch indicates an expected call of CurrentBranch.
+func (mr *MockClientMockRecorder) CurrentBranch(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CurrentBranch", reflect.TypeOf((*MockClient)(nil).CurrentBranch), arg0)
+}
+
+// HooksDir mocks base method.
+func (m *MockClient) HooksDir(arg0 context.Context) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HooksDir", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HooksDir indicates an expected call of HooksDir.
+func (mr *MockClientMockRecorder) HooksDir(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HooksDir", reflect.TypeOf((*MockClient)(nil).HooksDir), arg0)
+}
+
+// IsRepository mocks base method.
+func (m *MockClient) IsRepository(arg0 context.Context) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsRepository", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsRepository indicates an expected call of IsRepository.
+func (mr *MockClientMockRecorder) IsRepository(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsRepository", reflect.TypeOf((*MockClient)(nil).IsRepository), arg0)
+}
+
+// StagedFiles mocks base method.
+func (m *MockClient) StagedFiles(arg0 context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StagedFiles", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StagedFiles indicates an expected call of StagedFiles.
+func (mr *MockClientMockRecorder) StagedFiles(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StagedFiles", reflect.TypeOf((*MockClient)(nil).StagedFiles), arg0)
+}
+
+// SwitchBranch mocks base method.
+func (m *MockClient) SwitchBranch(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SwitchBranch", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SwitchBranch indicates an expected call of SwitchBranch.
+func (mr *MockClientMockRecorder) SwitchBranch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SwitchBranch", reflect.TypeOf((*MockClient)(nil).SwitchBranch), arg0, arg1)
+}
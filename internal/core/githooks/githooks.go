@@ -0,0 +1,184 @@
+// Package githooks installs the git hooks that tie commits to d3 features.
+// The hooks are thin shell scripts that call back into 'd3 githooks run', so all
+// behaviour lives in d3 itself and upgrades apply without reinstalling.
+package githooks
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/imcclaskey/d3/internal/core/d3err"
+	"github.com/imcclaskey/d3/internal/core/phase"
+	"github.com/imcclaskey/d3/internal/core/ports"
+)
+
+// TrailerKey is the commit trailer recording which feature a commit belongs to.
+const TrailerKey = "D3-Feature"
+
+// Hook names managed by d3.
+const (
+	PrepareCommitMsg = "prepare-commit-msg"
+	PreCommit        = "pre-commit"
+)
+
+// managedMarker identifies hook scripts written by d3 so they can be replaced or removed safely.
+const managedMarker = "# Managed by d3."
+
+// PreCommitMode controls what the pre-commit hook does when code is committed too early.
+type PreCommitMode string
+
+const (
+	// PreCommitOff skips installing the pre-commit hook.
+	PreCommitOff PreCommitMode = "off"
+	// PreCommitWarn prints a warning but lets the commit through.
+	PreCommitWarn PreCommitMode = "warn"
+	// PreCommitBlock rejects the commit.
+	PreCommitBlock PreCommitMode = "block"
+)
+
+// ParsePreCommitMode validates a pre-commit mode given on the command line.
+func ParsePreCommitMode(value string) (PreCommitMode, error) {
+	switch mode := PreCommitMode(strings.ToLower(strings.TrimSpace(value))); mode {
+	case PreCommitOff, PreCommitWarn, PreCommitBlock:
+		return mode, nil
+	default:
+		return "", d3err.New(d3err.InvalidArgument, "invalid pre-commit mode %q: expected off, warn or block", value)
+	}
+}
+
+// InstallOptions configures Install.
+type InstallOptions struct {
+	// PreCommit selects the pre-commit behaviour; PreCommitOff leaves the hook out
+	PreCommit PreCommitMode
+	// Force replaces existing hooks that were not written by d3
+	Force bool
+}
+
+// Script returns the shell script for a d3-managed hook.
+func Script(hook string, mode PreCommitMode) string {
+	invocation := fmt.Sprintf(`exec d3 githooks run %s -- "$@"`, hook)
+	if hook == PreCommit {
+		invocation = fmt.Sprintf(`exec d3 githooks run %s --mode %s -- "$@"`, hook, mode)
+	}
+	return fmt.Sprintf(`#!/bin/sh
+%s Reinstall with 'd3 githooks install', remove with 'd3 githooks uninstall'.
+command -v d3 >/dev/null 2>&1 || exit 0
+%s
+`, managedMarker, invocation)
+}
+
+// IsManaged reports whether hook script content was written by d3.
+func IsManaged(content []byte) bool {
+	return strings.Contains(string(content), managedMarker)
+}
+
+// Install writes the d3 hooks into hooksDir and returns the paths it wrote.
+// Existing hooks not managed by d3 are left untouched and reported as an error unless opts.Force is set.
+func Install(fs ports.FileSystem, hooksDir string, opts InstallOptions) ([]string, error) {
+	if err := fs.MkdirAll(hooksDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create hooks directory: %w", err)
+	}
+
+	installed := []string{}
+	hooks := []string{PrepareCommitMsg}
+	if opts.PreCommit != PreCommitOff {
+		hooks = append(hooks, PreCommit)
+	} else if _, err := removeManaged(fs, filepath.Join(hooksDir, PreCommit)); err != nil {
+		return installed, err
+	}
+
+	for _, hook := range hooks {
+		hookPath := filepath.Join(hooksDir, hook)
+		existing, err := fs.ReadFile(hookPath)
+		if err == nil {
+			if !IsManaged(existing) && !opts.Force {
+				return installed, fmt.Errorf("%s already exists and was not installed by d3; use --force to replace it", hookPath)
+			}
+			// Remove first so the new file is created with executable permissions
+			if err := fs.Remove(hookPath); err != nil {
+				return installed, fmt.Errorf("failed to replace %s: %w", hookPath, err)
+			}
+		} else if !os.IsNotExist(err) {
+			return installed, fmt.Errorf("failed to read %s: %w", hookPath, err)
+		}
+
+		if err := fs.WriteFile(hookPath, []byte(Script(hook, opts.PreCommit)), 0755); err != nil {
+			return installed, fmt.Errorf("failed to write %s: %w", hookPath, err)
+		}
+		installed = append(installed, hookPath)
+	}
+	return installed, nil
+}
+
+// Uninstall removes the d3-managed hooks from hooksDir and returns the paths it removed.
+// Hooks written by anything else are kept.
+func Uninstall(fs ports.FileSystem, hooksDir string) ([]string, error) {
+	removed := []string{}
+	for _, hook := range []string{PrepareCommitMsg, PreCommit} {
+		hookPath := filepath.Join(hooksDir, hook)
+		ok, err := removeManaged(fs, hookPath)
+		if err != nil {
+			return removed, err
+		}
+		if ok {
+			removed = append(removed, hookPath)
+		}
+	}
+	return removed, nil
+}
+
+// removeManaged deletes hookPath if it exists and is managed by d3.
+func removeManaged(fs ports.FileSystem, hookPath string) (bool, error) {
+	content, err := fs.ReadFile(hookPath)
+	if os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", hookPath, err)
+	}
+	if !IsManaged(content) {
+		return false, nil
+	}
+	if err := fs.Remove(hookPath); err != nil {
+		return false, fmt.Errorf("failed to remove %s: %w", hookPath, err)
+	}
+	return true, nil
+}
+
+// GuardsPhase reports whether committing code is premature in phase p.
+func GuardsPhase(p phase.Phase) bool {
+	return p == phase.Define || p == phase.Design
+}
+
+// CodeFiles returns the staged files that live outside the .d3 directory and the given document
+// directories, such as a features directory relocated by .d3/config.yaml. Directories are
+// relative to the repository root.
+func CodeFiles(staged []string, docDirs ...string) []string {
+	dirs := []string{".d3"}
+	for _, dir := range docDirs {
+		if dir == "" {
+			continue
+		}
+		dirs = append(dirs, filepath.ToSlash(filepath.Clean(dir)))
+	}
+
+	code := []string{}
+	for _, file := range staged {
+		file = filepath.ToSlash(file)
+		if !inAnyDir(file, dirs) {
+			code = append(code, file)
+		}
+	}
+	return code
+}
+
+// inAnyDir reports whether the slash-separated path is one of dirs or lies below one of them.
+func inAnyDir(path string, dirs []string) bool {
+	for _, dir := range dirs {
+		if path == dir || strings.HasPrefix(path, dir+"/") {
+			return true
+		}
+	}
+	return false
+}
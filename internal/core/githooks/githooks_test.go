@@ -0,0 +1,170 @@
+package githooks
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/imcclaskey/d3/internal/core/d3err"
+	"github.com/imcclaskey/d3/internal/core/phase"
+	portsmocks "github.com/imcclaskey/d3/internal/core/ports/mocks"
+)
+
+const hooksDir = "/repo/.git/hooks"
+
+func TestScript(t *testing.T) {
+	prepare := Script(PrepareCommitMsg, PreCommitOff)
+	if !strings.HasPrefix(prepare, "#!/bin/sh\n") || !IsManaged([]byte(prepare)) {
+		t.Errorf("Script(prepare-commit-msg) is not a managed shell script:\n%s", prepare)
+	}
+	if !strings.Contains(prepare, `exec d3 githooks run prepare-commit-msg -- "$@"`) {
+		t.Errorf("Script(prepare-commit-msg) does not invoke d3:\n%s", prepare)
+	}
+
+	preCommit := Script(PreCommit, PreCommitBlock)
+	if !strings.Contains(preCommit, "--mode block") {
+		t.Errorf("Script(pre-commit, block) does not pass the mode:\n%s", preCommit)
+	}
+}
+
+func TestParsePreCommitMode(t *testing.T) {
+	for _, value := range []string{"off", "warn", "Block"} {
+		if _, err := ParsePreCommitMode(value); err != nil {
+			t.Errorf("ParsePreCommitMode(%q) unexpected error: %v", value, err)
+		}
+	}
+	if _, err := ParsePreCommitMode("loud"); !errors.Is(err, d3err.ErrInvalidArgument) {
+		t.Errorf("ParsePreCommitMode(loud) error = %v, want ErrInvalidArgument", err)
+	}
+}
+
+func TestInstall(t *testing.T) {
+	prepareHook := filepath.Join(hooksDir, PrepareCommitMsg)
+	preCommitHook := filepath.Join(hooksDir, PreCommit)
+	managed := []byte(Script(PrepareCommitMsg, PreCommitOff))
+
+	tests := []struct {
+		name       string
+		opts       InstallOptions
+		setupMocks func(mockFS *portsmocks.MockFileSystem)
+		want       []string
+		wantErr    bool
+	}{
+		{
+			name: "fresh install with pre-commit",
+			opts: InstallOptions{PreCommit: PreCommitWarn},
+			setupMocks: func(mockFS *portsmocks.MockFileSystem) {
+				mockFS.EXPECT().MkdirAll(hooksDir, os.FileMode(0755)).Return(nil)
+				mockFS.EXPECT().ReadFile(prepareHook).Return(nil, os.ErrNotExist)
+				mockFS.EXPECT().WriteFile(prepareHook, gomock.Any(), os.FileMode(0755)).Return(nil)
+				mockFS.EXPECT().ReadFile(preCommitHook).Return(nil, os.ErrNotExist)
+				mockFS.EXPECT().WriteFile(preCommitHook, []byte(Script(PreCommit, PreCommitWarn)), os.FileMode(0755)).Return(nil)
+			},
+			want: []string{prepareHook, preCommitHook},
+		},
+		{
+			name: "replaces managed hook and removes managed pre-commit when off",
+			opts: InstallOptions{PreCommit: PreCommitOff},
+			setupMocks: func(mockFS *portsmocks.MockFileSystem) {
+				mockFS.EXPECT().MkdirAll(hooksDir, os.FileMode(0755)).Return(nil)
+				mockFS.EXPECT().ReadFile(preCommitHook).Return([]byte(Script(PreCommit, PreCommitBlock)), nil)
+				mockFS.EXPECT().Remove(preCommitHook).Return(nil)
+				mockFS.EXPECT().ReadFile(prepareHook).Return(managed, nil)
+				mockFS.EXPECT().Remove(prepareHook).Return(nil)
+				mockFS.EXPECT().WriteFile(prepareHook, managed, os.FileMode(0755)).Return(nil)
+			},
+			want: []string{prepareHook},
+		},
+		{
+			name: "refuses to replace foreign hook",
+			opts: InstallOptions{PreCommit: PreCommitOff},
+			setupMocks: func(mockFS *portsmocks.MockFileSystem) {
+				mockFS.EXPECT().MkdirAll(hooksDir, os.FileMode(0755)).Return(nil)
+				mockFS.EXPECT().ReadFile(preCommitHook).Return(nil, os.ErrNotExist)
+				mockFS.EXPECT().ReadFile(prepareHook).Return([]byte("#!/bin/sh\nhusky\n"), nil)
+			},
+			want:    []string{},
+			wantErr: true,
+		},
+		{
+			name: "force replaces foreign hook",
+			opts: InstallOptions{PreCommit: PreCommitOff, Force: true},
+			setupMocks: func(mockFS *portsmocks.MockFileSystem) {
+				mockFS.EXPECT().MkdirAll(hooksDir, os.FileMode(0755)).Return(nil)
+				mockFS.EXPECT().ReadFile(preCommitHook).Return(nil, os.ErrNotExist)
+				mockFS.EXPECT().ReadFile(prepareHook).Return([]byte("#!/bin/sh\nhusky\n"), nil)
+				mockFS.EXPECT().Remove(prepareHook).Return(nil)
+				mockFS.EXPECT().WriteFile(prepareHook, managed, os.FileMode(0755)).Return(nil)
+			},
+			want: []string{prepareHook},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockFS := portsmocks.NewMockFileSystem(ctrl)
+			tt.setupMocks(mockFS)
+
+			got, err := Install(mockFS, hooksDir, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Install() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Install() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUninstall(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockFS := portsmocks.NewMockFileSystem(ctrl)
+	prepareHook := filepath.Join(hooksDir, PrepareCommitMsg)
+	preCommitHook := filepath.Join(hooksDir, PreCommit)
+
+	mockFS.EXPECT().ReadFile(prepareHook).Return([]byte(Script(PrepareCommitMsg, PreCommitOff)), nil)
+	mockFS.EXPECT().Remove(prepareHook).Return(nil)
+	mockFS.EXPECT().ReadFile(preCommitHook).Return([]byte("#!/bin/sh\nlint\n"), nil)
+
+	got, err := Uninstall(mockFS, hooksDir)
+	if err != nil {
+		t.Fatalf("Uninstall() unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got, []string{prepareHook}) {
+		t.Errorf("Uninstall() = %v, want only the managed hook removed", got)
+	}
+}
+
+func TestGuardsPhase(t *testing.T) {
+	tests := map[phase.Phase]bool{
+		phase.Define:  true,
+		phase.Design:  true,
+		phase.Deliver: false,
+		phase.None:    false,
+	}
+	for p, want := range tests {
+		if got := GuardsPhase(p); got != want {
+			t.Errorf("GuardsPhase(%q) = %v, want %v", p, got, want)
+		}
+	}
+}
+
+func TestCodeFiles(t *testing.T) {
+	staged := []string{".d3/features/login/define/problem.md", "main.go", ".d3x/notes", "internal/app.go"}
+	want := []string{"main.go", ".d3x/notes", "internal/app.go"}
+	if got := CodeFiles(staged); !reflect.DeepEqual(got, want) {
+		t.Errorf("CodeFiles() = %v, want %v", got, want)
+	}
+
+	staged = []string{"docs/features/login/.phase", "docs/featuresx.md", "main.go"}
+	want = []string{"docs/featuresx.md", "main.go"}
+	if got := CodeFiles(staged, "docs/features"); !reflect.DeepEqual(got, want) {
+		t.Errorf("CodeFiles() with docs/features = %v, want %v", got, want)
+	}
+}
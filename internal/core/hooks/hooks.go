@@ -0,0 +1,173 @@
+// Package hooks runs project scripts when d3 events happen, such as a feature being created or
+// moving to another phase. Hooks are configured per event in .d3/config.yaml and run through the
+// shell in the project root.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/imcclaskey/d3/internal/core/d3err"
+)
+
+// Event names a point in an operation at which hooks run. Pre events run before the operation
+// changes anything and abort it when a hook fails; post events run after it succeeded.
+type Event string
+
+const (
+	PreCreate       Event = "pre_create"
+	PostCreate      Event = "post_create"
+	PrePhaseChange  Event = "pre_phase_change"
+	PostPhaseChange Event = "post_phase_change"
+	PreEnter        Event = "pre_enter"
+	PostEnter       Event = "post_enter"
+	PreExit         Event = "pre_exit"
+	PostExit        Event = "post_exit"
+	PreDelete       Event = "pre_delete"
+	PostDelete      Event = "post_delete"
+)
+
+// Events lists every event, in the order they are documented.
+var Events = []Event{
+	PreCreate, PostCreate,
+	PrePhaseChange, PostPhaseChange,
+	PreEnter, PostEnter,
+	PreExit, PostExit,
+	PreDelete, PostDelete,
+}
+
+// IsPre reports whether hooks for the event run before the operation and can abort it.
+func (e Event) IsPre() bool {
+	return strings.HasPrefix(string(e), "pre_")
+}
+
+// ParseEvent validates an event name.
+func ParseEvent(name string) (Event, error) {
+	for _, e := range Events {
+		if string(e) == name {
+			return e, nil
+		}
+	}
+	return "", d3err.New(d3err.InvalidArgument, "unknown hook event %q", name)
+}
+
+// DefaultTimeout is how long a hook may run when its configuration sets no timeout.
+const DefaultTimeout = time.Minute
+
+// maxOutput caps the hook output quoted in errors and logs.
+const maxOutput = 4096
+
+// Hook is one command to run for an event.
+type Hook struct {
+	// Command is run with "sh -c" ("cmd /C" on Windows) in the project root.
+	Command string
+	// Timeout stops the command when it runs longer. Zero selects DefaultTimeout.
+	Timeout time.Duration
+}
+
+// Env describes the event a hook runs for. It is passed to the command as D3_* environment
+// variables; fields that do not apply to the event are empty.
+type Env struct {
+	Event       Event
+	ProjectRoot string
+	Feature     string
+	FromPhase   string
+	ToPhase     string
+}
+
+// Vars returns the environment variables describing e.
+func (e Env) Vars() []string {
+	return []string{
+		"D3_EVENT=" + string(e.Event),
+		"D3_PROJECT_ROOT=" + e.ProjectRoot,
+		"D3_FEATURE=" + e.Feature,
+		"D3_FROM_PHASE=" + e.FromPhase,
+		"D3_TO_PHASE=" + e.ToPhase,
+	}
+}
+
+// Executor runs hooks as shell commands.
+type Executor struct {
+	hooks  map[Event][]Hook
+	logger *slog.Logger
+}
+
+// NewExecutor creates an executor for the hooks configured per event.
+func NewExecutor(hooks map[Event][]Hook) *Executor {
+	return &Executor{hooks: hooks, logger: slog.Default()}
+}
+
+// SetLogger replaces the logger that records hook runs and the failures of post hooks.
+func (e *Executor) SetLogger(logger *slog.Logger) {
+	e.logger = logger
+}
+
+// Run runs the hooks for env.Event one after another, in the project root, stopping at the first
+// failure. The error carries the command's output and, for pre events, the GateFailed code, so
+// that the operation reports why it was refused.
+func (e *Executor) Run(ctx context.Context, env Env) error {
+	for _, hook := range e.hooks[env.Event] {
+		if err := e.runOne(ctx, hook, env); err != nil {
+			if env.Event.IsPre() {
+				return d3err.Wrap(d3err.GateFailed, err)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// runOne runs a single hook and reports a non-zero exit or a timeout with the command's output.
+func (e *Executor) runOne(ctx context.Context, hook Hook, env Env) error {
+	timeout := hook.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var output bytes.Buffer
+	cmd := shellCommand(ctx, hook.Command)
+	cmd.Dir = env.ProjectRoot
+	cmd.Env = append(os.Environ(), env.Vars()...)
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	// Commands that leave children holding the output open must not outlive the timeout
+	cmd.WaitDelay = time.Second
+
+	started := time.Now()
+	err := cmd.Run()
+	e.logger.Debug("ran hook", "event", env.Event, "command", hook.Command, "duration", time.Since(started), "error", err)
+	if err == nil {
+		return nil
+	}
+
+	reason := err.Error()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		reason = fmt.Sprintf("timed out after %s", timeout)
+	}
+	message := fmt.Sprintf("%s hook %q failed: %s", env.Event, hook.Command, reason)
+	if out := strings.TrimSpace(output.String()); out != "" {
+		if len(out) > maxOutput {
+			out = "..." + out[len(out)-maxOutput:]
+		}
+		message += "\n" + out
+	}
+	return errors.New(message)
+}
+
+// shellCommand runs command through the platform's shell.
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", command)
+}
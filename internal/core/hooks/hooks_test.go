@@ -0,0 +1,104 @@
+package hooks
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/imcclaskey/d3/internal/core/d3err"
+)
+
+func TestParseEvent(t *testing.T) {
+	for _, event := range Events {
+		got, err := ParseEvent(string(event))
+		if err != nil || got != event {
+			t.Errorf("ParseEvent(%q) = %q, %v", event, got, err)
+		}
+	}
+	if _, err := ParseEvent("before_create"); d3err.CodeOf(err) != d3err.InvalidArgument {
+		t.Errorf("ParseEvent(before_create) error = %v, want InvalidArgument", err)
+	}
+	if !PrePhaseChange.IsPre() || PostPhaseChange.IsPre() {
+		t.Error("IsPre() misclassifies the phase change events")
+	}
+}
+
+func TestExecutor_Run(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook commands below are POSIX shell")
+	}
+
+	tests := []struct {
+		name     string
+		hooks    []Hook
+		event    Event
+		wantErr  []string
+		wantCode d3err.Code
+	}{
+		{name: "no hooks for event", event: PreCreate},
+		{
+			name:  "hooks succeed and see the environment",
+			event: PrePhaseChange,
+			hooks: []Hook{
+				{Command: `echo "$D3_EVENT $D3_FEATURE $D3_FROM_PHASE $D3_TO_PHASE" > out.txt`},
+				{Command: `test "$(cat out.txt)" = "pre_phase_change login define design"`},
+			},
+		},
+		{
+			name:     "failing pre hook carries its output and the gate code",
+			event:    PrePhaseChange,
+			hooks:    []Hook{{Command: "echo lint failed >&2; exit 3"}, {Command: "touch never-run"}},
+			wantErr:  []string{`pre_phase_change hook "echo lint failed >&2; exit 3" failed: exit status 3`, "lint failed"},
+			wantCode: d3err.GateFailed,
+		},
+		{
+			name:     "failing post hook is not a gate failure",
+			event:    PostPhaseChange,
+			hooks:    []Hook{{Command: "exit 1"}},
+			wantErr:  []string{"post_phase_change hook"},
+			wantCode: d3err.Unknown,
+		},
+		{
+			name:     "timeout",
+			event:    PreExit,
+			hooks:    []Hook{{Command: "sleep 5", Timeout: 50 * time.Millisecond}},
+			wantErr:  []string{"timed out after 50ms"},
+			wantCode: d3err.GateFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			executor := NewExecutor(map[Event][]Hook{tt.event: tt.hooks})
+			env := Env{Event: tt.event, ProjectRoot: root, Feature: "login", FromPhase: "define", ToPhase: "design"}
+
+			err := executor.Run(context.Background(), env)
+			if len(tt.wantErr) == 0 {
+				if err != nil {
+					t.Fatalf("Run() error = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("Run() error = nil, want an error")
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Run() error = %q, want it to contain %q", err, want)
+				}
+			}
+			if got := d3err.CodeOf(err); got != tt.wantCode {
+				t.Errorf("Run() error code = %s, want %s", got, tt.wantCode)
+			}
+			if _, statErr := os.Stat(filepath.Join(root, "never-run")); !errors.Is(statErr, os.ErrNotExist) {
+				t.Error("Run() kept running hooks after a failure")
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package issue
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// GitHubJSON imports issues exported as JSON by the GitHub REST API or by
+// `gh issue view --json title,body,labels,comments,number,url`.
+type GitHubJSON struct{}
+
+// githubIssue covers the fields shared by the REST API and gh CLI shapes.
+// Labels and comments differ between the two, so they are decoded separately.
+type githubIssue struct {
+	Title    string          `json:"title"`
+	Body     string          `json:"body"`
+	Number   json.Number     `json:"number"`
+	URL      string          `json:"url"`
+	HTMLURL  string          `json:"html_url"`
+	Labels   json.RawMessage `json:"labels"`
+	Comments json.RawMessage `json:"comments"`
+}
+
+type githubLogin struct {
+	Login string `json:"login"`
+}
+
+type githubComment struct {
+	Body   string       `json:"body"`
+	Author *githubLogin `json:"author"` // gh CLI
+	User   *githubLogin `json:"user"`   // REST API
+}
+
+// Name implements Importer.
+func (GitHubJSON) Name() string { return "github-json" }
+
+// Detect implements Importer. It accepts JSON objects that have a title.
+func (GitHubJSON) Detect(filename string, data []byte) bool {
+	if ext := strings.ToLower(filepath.Ext(filename)); ext != ".json" && ext != "" {
+		return false
+	}
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		return false
+	}
+	var probe struct {
+		Title *string `json:"title"`
+	}
+	return json.Unmarshal(trimmed, &probe) == nil && probe.Title != nil
+}
+
+// Parse implements Importer.
+func (GitHubJSON) Parse(data []byte) (*Issue, error) {
+	var raw githubIssue
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid issue JSON: %w", err)
+	}
+
+	issue := &Issue{
+		Title:  raw.Title,
+		Body:   raw.Body,
+		Number: raw.Number.String(),
+		URL:    raw.HTMLURL,
+	}
+	if issue.URL == "" {
+		issue.URL = raw.URL
+	}
+
+	labels, err := parseGitHubLabels(raw.Labels)
+	if err != nil {
+		return nil, err
+	}
+	issue.Labels = labels
+
+	comments, err := parseGitHubComments(raw.Comments)
+	if err != nil {
+		return nil, err
+	}
+	issue.Comments = comments
+	return issue, nil
+}
+
+// parseGitHubLabels accepts a list of label objects or of plain label names.
+func parseGitHubLabels(raw json.RawMessage) ([]string, error) {
+	if isJSONNull(raw) {
+		return nil, nil
+	}
+	var plain []string
+	if err := json.Unmarshal(raw, &plain); err == nil {
+		return plain, nil
+	}
+	var names []string
+	var objects []struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(raw, &objects); err != nil {
+		return nil, fmt.Errorf("invalid labels: %w", err)
+	}
+	for _, label := range objects {
+		names = append(names, label.Name)
+	}
+	return names, nil
+}
+
+// parseGitHubComments accepts a list of comments. The REST API issue object only carries a
+// comment count, which is ignored.
+func parseGitHubComments(raw json.RawMessage) ([]Comment, error) {
+	if isJSONNull(raw) {
+		return nil, nil
+	}
+	if _, err := strconv.Atoi(string(bytes.TrimSpace(raw))); err == nil {
+		return nil, nil
+	}
+	var decoded []githubComment
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return nil, fmt.Errorf("invalid comments: %w", err)
+	}
+	comments := make([]Comment, 0, len(decoded))
+	for _, c := range decoded {
+		comment := Comment{Body: c.Body}
+		if c.Author != nil {
+			comment.Author = c.Author.Login
+		} else if c.User != nil {
+			comment.Author = c.User.Login
+		}
+		comments = append(comments, comment)
+	}
+	return comments, nil
+}
+
+func isJSONNull(raw json.RawMessage) bool {
+	trimmed := bytes.TrimSpace(raw)
+	return len(trimmed) == 0 || string(trimmed) == "null"
+}
@@ -0,0 +1,93 @@
+package issue
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGitHubJSON_Parse(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want Issue
+	}{
+		{
+			name: "gh cli shape",
+			data: `{
+				"number": 42,
+				"title": "Login fails for SSO users",
+				"body": "Users see a blank page.",
+				"url": "https://github.com/acme/app/issues/42",
+				"labels": [{"id": "L1", "name": "bug"}, {"id": "L2", "name": "auth"}],
+				"comments": [{"author": {"login": "octocat"}, "body": "Reproduced on staging."}]
+			}`,
+			want: Issue{
+				Title:    "Login fails for SSO users",
+				Body:     "Users see a blank page.",
+				Number:   "42",
+				URL:      "https://github.com/acme/app/issues/42",
+				Labels:   []string{"bug", "auth"},
+				Comments: []Comment{{Author: "octocat", Body: "Reproduced on staging."}},
+			},
+		},
+		{
+			name: "rest api shape",
+			data: `{
+				"number": 7,
+				"title": "Add dark mode",
+				"body": null,
+				"url": "https://api.github.com/repos/acme/app/issues/7",
+				"html_url": "https://github.com/acme/app/issues/7",
+				"labels": [{"name": "enhancement"}],
+				"comments": 3
+			}`,
+			want: Issue{
+				Title:    "Add dark mode",
+				Number:   "7",
+				URL:      "https://github.com/acme/app/issues/7",
+				Labels:   []string{"enhancement"},
+				Comments: nil,
+			},
+		},
+		{
+			name: "plain label names and rest comments",
+			data: `{"title": "t", "labels": ["a", "b"], "comments": [{"user": {"login": "dev"}, "body": "hi"}]}`,
+			want: Issue{
+				Title:    "t",
+				Labels:   []string{"a", "b"},
+				Comments: []Comment{{Author: "dev", Body: "hi"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GitHubJSON{}.Parse([]byte(tt.data))
+			if err != nil {
+				t.Fatalf("Parse() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("Parse() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGitHubJSON_Detect(t *testing.T) {
+	tests := []struct {
+		filename string
+		data     string
+		want     bool
+	}{
+		{"issue.json", `{"title": "x"}`, true},
+		{"issue", `{"title": "x"}`, true},
+		{"issue.json", `{"name": "x"}`, false},
+		{"issue.json", `[{"title": "x"}]`, false},
+		{"issue.md", `{"title": "x"}`, false},
+	}
+	for _, tt := range tests {
+		if got := (GitHubJSON{}).Detect(tt.filename, []byte(tt.data)); got != tt.want {
+			t.Errorf("Detect(%q, %q) = %v, want %v", tt.filename, tt.data, got, tt.want)
+		}
+	}
+}
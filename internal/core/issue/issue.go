@@ -0,0 +1,116 @@
+// Package issue parses issue-tracker exports into a common form so they can seed a feature's
+// problem statement. Each export format is handled by an Importer registered with a Registry.
+package issue
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Comment is a single comment on an issue.
+type Comment struct {
+	Author string
+	Body   string
+}
+
+// Issue is the tracker-independent content of an imported issue.
+type Issue struct {
+	Title    string
+	Body     string
+	Labels   []string
+	Comments []Comment
+	// Number is the tracker's identifier for the issue, such as "42" or "PROJ-7". Optional.
+	Number string
+	// URL links back to the issue in its tracker. Optional.
+	URL string
+	// Source names the importer that produced the issue.
+	Source string
+}
+
+// Importer parses one issue export format.
+type Importer interface {
+	// Name identifies the format, for example "github-json".
+	Name() string
+	// Detect reports whether the file looks like this format.
+	Detect(filename string, data []byte) bool
+	// Parse decodes the file into an Issue.
+	Parse(data []byte) (*Issue, error)
+}
+
+// Registry holds the available importers. Detection tries them in registration order.
+type Registry struct {
+	importers []Importer
+}
+
+// NewRegistry creates a registry with the given importers.
+func NewRegistry(importers ...Importer) *Registry {
+	r := &Registry{}
+	for _, imp := range importers {
+		r.Register(imp)
+	}
+	return r
+}
+
+// DefaultRegistry returns a registry with every built-in importer.
+func DefaultRegistry() *Registry {
+	return NewRegistry(GitHubJSON{}, MarkdownFrontmatter{})
+}
+
+// Register adds an importer, replacing any existing importer with the same name.
+func (r *Registry) Register(imp Importer) {
+	for i, existing := range r.importers {
+		if existing.Name() == imp.Name() {
+			r.importers[i] = imp
+			return
+		}
+	}
+	r.importers = append(r.importers, imp)
+}
+
+// Names returns the names of the registered importers, sorted.
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.importers))
+	for _, imp := range r.importers {
+		names = append(names, imp.Name())
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Parse decodes an issue file. An empty format detects the importer from the file;
+// otherwise the importer with that name is used.
+func (r *Registry) Parse(filename string, data []byte, format string) (*Issue, error) {
+	imp, err := r.lookup(filename, data, format)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := imp.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s as %s: %w", filename, imp.Name(), err)
+	}
+	parsed.Title = strings.TrimSpace(parsed.Title)
+	if parsed.Title == "" {
+		return nil, fmt.Errorf("issue in %s has no title", filename)
+	}
+	parsed.Source = imp.Name()
+	return parsed, nil
+}
+
+// lookup selects the importer for a file.
+func (r *Registry) lookup(filename string, data []byte, format string) (Importer, error) {
+	if format != "" {
+		for _, imp := range r.importers {
+			if imp.Name() == format {
+				return imp, nil
+			}
+		}
+		return nil, fmt.Errorf("unknown issue format %q: expected one of %s", format, strings.Join(r.Names(), ", "))
+	}
+	for _, imp := range r.importers {
+		if imp.Detect(filename, data) {
+			return imp, nil
+		}
+	}
+	return nil, fmt.Errorf("could not detect the issue format of %s: use --format with one of %s", filename, strings.Join(r.Names(), ", "))
+}
@@ -0,0 +1,78 @@
+package issue
+
+import (
+	"strings"
+	"testing"
+)
+
+// stubImporter is a minimal Importer for exercising the registry.
+type stubImporter struct {
+	name  string
+	ext   string
+	title string
+}
+
+func (s stubImporter) Name() string { return s.name }
+
+func (s stubImporter) Detect(filename string, data []byte) bool {
+	return strings.HasSuffix(filename, s.ext)
+}
+
+func (s stubImporter) Parse(data []byte) (*Issue, error) {
+	return &Issue{Title: s.title, Body: string(data)}, nil
+}
+
+func TestRegistry_Parse(t *testing.T) {
+	registry := DefaultRegistry()
+	registry.Register(stubImporter{name: "jira-csv", ext: ".csv", title: "  From Jira  "})
+
+	tests := []struct {
+		name       string
+		filename   string
+		data       string
+		format     string
+		wantSource string
+		wantTitle  string
+		wantErr    string
+	}{
+		{name: "detects github json", filename: "issue.json", data: `{"title": "Crash on start"}`, wantSource: "github-json", wantTitle: "Crash on start"},
+		{name: "detects markdown", filename: "issue.md", data: "# Crash on start\n", wantSource: "markdown", wantTitle: "Crash on start"},
+		{name: "detects registered importer", filename: "export.csv", data: "x", wantSource: "jira-csv", wantTitle: "From Jira"},
+		{name: "explicit format", filename: "issue.txt", data: "---\ntitle: Explicit\n---\n", format: "markdown", wantSource: "markdown", wantTitle: "Explicit"},
+		{name: "unknown format", filename: "issue.json", data: "{}", format: "gitlab", wantErr: `unknown issue format "gitlab"`},
+		{name: "undetectable", filename: "issue.txt", data: "plain", wantErr: "could not detect"},
+		{name: "missing title", filename: "issue.md", data: "no heading here", wantErr: "has no title"},
+		{name: "parse error", filename: "issue.json", data: `{"title": "x", "labels": 5}`, format: "github-json", wantErr: "failed to parse issue.json as github-json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := registry.Parse(tt.filename, []byte(tt.data), tt.format)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Parse() error = %v, want to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse() unexpected error: %v", err)
+			}
+			if got.Source != tt.wantSource || got.Title != tt.wantTitle {
+				t.Errorf("Parse() = source %q title %q, want source %q title %q", got.Source, got.Title, tt.wantSource, tt.wantTitle)
+			}
+		})
+	}
+}
+
+func TestRegistry_RegisterReplaces(t *testing.T) {
+	registry := NewRegistry(stubImporter{name: "a", ext: ".a", title: "first"})
+	registry.Register(stubImporter{name: "a", ext: ".a", title: "second"})
+
+	if names := registry.Names(); len(names) != 1 {
+		t.Fatalf("Names() = %v, want a single importer", names)
+	}
+	got, err := registry.Parse("x.a", nil, "")
+	if err != nil || got.Title != "second" {
+		t.Errorf("Parse() = %+v, %v; want the replacement importer", got, err)
+	}
+}
@@ -0,0 +1,137 @@
+package issue
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MarkdownFrontmatter imports a markdown file whose YAML frontmatter carries the issue metadata:
+//
+//	---
+//	title: Login fails for SSO users
+//	labels: [bug, auth]
+//	number: 42
+//	url: https://tracker.example.com/issues/42
+//	---
+//	Issue body in markdown.
+//
+// Without a title in the frontmatter, the first level-one heading is used and removed from the body.
+type MarkdownFrontmatter struct{}
+
+// byteOrderMark is stripped from the start of files saved by some Windows editors.
+const byteOrderMark = "\uFEFF"
+
+type frontmatter struct {
+	Title  string    `yaml:"title"`
+	Labels yaml.Node `yaml:"labels"`
+	Number string    `yaml:"number"`
+	ID     string    `yaml:"id"`
+	URL    string    `yaml:"url"`
+}
+
+// Name implements Importer.
+func (MarkdownFrontmatter) Name() string { return "markdown" }
+
+// Detect implements Importer. It accepts files with a markdown extension, or any file
+// that starts with a frontmatter block.
+func (MarkdownFrontmatter) Detect(filename string, data []byte) bool {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".md", ".markdown":
+		return true
+	}
+	data = bytes.TrimPrefix(data, []byte(byteOrderMark))
+	return bytes.HasPrefix(data, []byte("---\n")) || bytes.HasPrefix(data, []byte("---\r\n"))
+}
+
+// Parse implements Importer.
+func (MarkdownFrontmatter) Parse(data []byte) (*Issue, error) {
+	text := strings.ReplaceAll(strings.TrimPrefix(string(data), byteOrderMark), "\r\n", "\n")
+	meta, body, err := splitFrontmatter(text)
+	if err != nil {
+		return nil, err
+	}
+
+	var fm frontmatter
+	if meta != "" {
+		if err := yaml.Unmarshal([]byte(meta), &fm); err != nil {
+			return nil, fmt.Errorf("invalid frontmatter: %w", err)
+		}
+	}
+
+	labels, err := frontmatterLabels(&fm.Labels)
+	if err != nil {
+		return nil, err
+	}
+
+	issue := &Issue{
+		Title:  fm.Title,
+		Body:   strings.TrimSpace(body),
+		Labels: labels,
+		Number: fm.Number,
+		URL:    fm.URL,
+	}
+	if issue.Number == "" {
+		issue.Number = fm.ID
+	}
+	if issue.Title == "" {
+		issue.Title, issue.Body = takeTitleHeading(issue.Body)
+	}
+	return issue, nil
+}
+
+// splitFrontmatter separates a leading "---" delimited block from the rest of the document.
+func splitFrontmatter(text string) (meta, body string, err error) {
+	if !strings.HasPrefix(text, "---\n") {
+		return "", text, nil
+	}
+	rest := text[len("---\n"):]
+	if strings.HasPrefix(rest, "---\n") {
+		return "", rest[len("---\n"):], nil
+	}
+	end := strings.Index(rest, "\n---\n")
+	if end < 0 {
+		if strings.HasSuffix(rest, "\n---") {
+			return rest[:len(rest)-len("\n---")], "", nil
+		}
+		return "", "", fmt.Errorf("frontmatter is not closed with '---'")
+	}
+	return rest[:end], rest[end+len("\n---\n"):], nil
+}
+
+// frontmatterLabels accepts labels as a YAML list or as a comma-separated string.
+func frontmatterLabels(node *yaml.Node) ([]string, error) {
+	switch node.Kind {
+	case 0:
+		return nil, nil
+	case yaml.ScalarNode:
+		var labels []string
+		for _, label := range strings.Split(node.Value, ",") {
+			if label = strings.TrimSpace(label); label != "" {
+				labels = append(labels, label)
+			}
+		}
+		return labels, nil
+	default:
+		var labels []string
+		if err := node.Decode(&labels); err != nil {
+			return nil, fmt.Errorf("invalid labels in frontmatter: %w", err)
+		}
+		return labels, nil
+	}
+}
+
+// takeTitleHeading removes the first level-one heading from body and returns it as the title.
+func takeTitleHeading(body string) (title, rest string) {
+	lines := strings.Split(body, "\n")
+	for i, line := range lines {
+		if heading, ok := strings.CutPrefix(line, "# "); ok {
+			rest := append(append([]string{}, lines[:i]...), lines[i+1:]...)
+			return strings.TrimSpace(heading), strings.TrimSpace(strings.Join(rest, "\n"))
+		}
+	}
+	return "", body
+}
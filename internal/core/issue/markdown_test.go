@@ -0,0 +1,61 @@
+package issue
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMarkdownFrontmatter_Parse(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    Issue
+		wantErr bool
+	}{
+		{
+			name: "frontmatter with list labels",
+			data: "---\ntitle: Login fails\nlabels: [bug, auth]\nnumber: 42\nurl: https://tracker/42\n---\n\nUsers see a blank page.\n",
+			want: Issue{Title: "Login fails", Body: "Users see a blank page.", Labels: []string{"bug", "auth"}, Number: "42", URL: "https://tracker/42"},
+		},
+		{
+			name: "comma separated labels and id",
+			data: "---\ntitle: Export CSV\nlabels: reporting, customer-request\nid: PROJ-7\n---\nBody\n",
+			want: Issue{Title: "Export CSV", Body: "Body", Labels: []string{"reporting", "customer-request"}, Number: "PROJ-7"},
+		},
+		{
+			name: "title from heading",
+			data: "---\nlabels:\n  - ux\n---\n# Dark mode\n\nPlease add it.\n",
+			want: Issue{Title: "Dark mode", Body: "Please add it.", Labels: []string{"ux"}},
+		},
+		{
+			name: "no frontmatter",
+			data: "# Dark mode\r\n\r\nPlease add it.\r\n",
+			want: Issue{Title: "Dark mode", Body: "Please add it."},
+		},
+		{
+			name:    "unclosed frontmatter",
+			data:    "---\ntitle: x\n",
+			wantErr: true,
+		},
+		{
+			name:    "invalid yaml",
+			data:    "---\ntitle: [x\n---\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MarkdownFrontmatter{}.Parse([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("Parse() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
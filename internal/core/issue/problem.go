@@ -0,0 +1,184 @@
+package issue
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Problem document sections, in the order the define phase template expects them.
+const (
+	sectionProblem      = "Problem Statement"
+	sectionGoals        = "Feature Goals"
+	sectionRequirements = "Core Requirements"
+	sectionExclusions   = "Scope Exclusions"
+)
+
+var problemSections = []string{sectionProblem, sectionGoals, sectionRequirements, sectionExclusions}
+
+// sectionKeywords maps words found in issue headings to the problem section they belong to.
+// Headings that match nothing stay with the problem statement.
+var sectionKeywords = []struct {
+	keyword string
+	section string
+}{
+	{"out of scope", sectionExclusions},
+	{"non-goal", sectionExclusions},
+	{"exclusion", sectionExclusions},
+	{"goal", sectionGoals},
+	{"success", sectionGoals},
+	{"outcome", sectionGoals},
+	{"requirement", sectionRequirements},
+	{"acceptance", sectionRequirements},
+	{"criteria", sectionRequirements},
+}
+
+// placeholder marks a section the issue did not provide, for the define phase to fill in.
+const placeholder = "_To be defined._"
+
+// ProblemMarkdown lays out an issue as a define-phase problem.md. Body sections whose headings
+// name goals, requirements or exclusions are moved under the matching template heading;
+// everything else becomes the problem statement. Labels, comments and a link back to the
+// tracker follow as reference material.
+func ProblemMarkdown(issue *Issue) string {
+	sections := splitBody(issue.Body)
+
+	var b strings.Builder
+	b.WriteString("# Problem: " + issue.Title + "\n")
+	for _, name := range problemSections {
+		fmt.Fprintf(&b, "\n## %s\n\n", name)
+		if content := strings.TrimSpace(strings.Join(sections[name], "\n\n")); content != "" {
+			b.WriteString(content + "\n")
+		} else {
+			b.WriteString(placeholder + "\n")
+		}
+	}
+
+	b.WriteString("\n## Source Issue\n\n")
+	if issue.Number != "" {
+		fmt.Fprintf(&b, "- **Issue:** %s\n", issue.Number)
+	}
+	if issue.URL != "" {
+		fmt.Fprintf(&b, "- **Link:** %s\n", issue.URL)
+	}
+	if len(issue.Labels) > 0 {
+		fmt.Fprintf(&b, "- **Labels:** %s\n", strings.Join(issue.Labels, ", "))
+	}
+	fmt.Fprintf(&b, "- **Imported from:** %s\n", issue.Source)
+
+	if len(issue.Comments) > 0 {
+		b.WriteString("\n### Comments\n")
+		for _, c := range issue.Comments {
+			author := c.Author
+			if author == "" {
+				author = "unknown"
+			}
+			fmt.Fprintf(&b, "\n**%s:**\n\n%s\n", author, shiftHeadings(strings.TrimSpace(c.Body), 4))
+		}
+	}
+	return b.String()
+}
+
+// splitBody groups the issue body by problem section, keyed on the headings it contains.
+func splitBody(body string) map[string][]string {
+	sections := map[string][]string{}
+	current := sectionProblem
+	var chunk []string
+	flush := func() {
+		if text := strings.TrimSpace(strings.Join(chunk, "\n")); text != "" {
+			sections[current] = append(sections[current], text)
+		}
+		chunk = nil
+	}
+
+	inFence := false
+	for _, line := range strings.Split(body, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+		}
+		if !inFence {
+			if title, ok := headingText(line); ok {
+				if section := sectionFor(title); section != "" {
+					flush()
+					current = section
+					continue
+				}
+			}
+		}
+		chunk = append(chunk, line)
+	}
+	flush()
+
+	for name, chunks := range sections {
+		for i, c := range chunks {
+			chunks[i] = shiftHeadings(c, 3)
+		}
+		sections[name] = chunks
+	}
+	return sections
+}
+
+// sectionFor returns the problem section a heading belongs to, or "" if it matches none.
+func sectionFor(title string) string {
+	lower := strings.ToLower(title)
+	for _, k := range sectionKeywords {
+		if strings.Contains(lower, k.keyword) {
+			return k.section
+		}
+	}
+	return ""
+}
+
+// headingText returns the text of an ATX heading line.
+func headingText(line string) (string, bool) {
+	if !strings.HasPrefix(line, "#") {
+		return "", false
+	}
+	level := len(line) - len(strings.TrimLeft(line, "#"))
+	if level > 6 || (len(line) > level && line[level] != ' ') {
+		return "", false
+	}
+	return strings.TrimSpace(line[level:]), true
+}
+
+// shiftHeadings rewrites headings outside code fences so the shallowest one sits at level min,
+// keeping issue headings nested below the template's own.
+func shiftHeadings(md string, min int) string {
+	lines := strings.Split(md, "\n")
+	shallowest := 7
+	inFence := false
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if _, ok := headingText(line); ok && !inFence {
+			if level := len(line) - len(strings.TrimLeft(line, "#")); level < shallowest {
+				shallowest = level
+			}
+		}
+	}
+	if shallowest >= min {
+		return md
+	}
+
+	shift := min - shallowest
+	inFence = false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if _, ok := headingText(line); ok && !inFence {
+			level := len(line) - len(strings.TrimLeft(line, "#"))
+			newLevel := level + shift
+			if newLevel > 6 {
+				newLevel = 6
+			}
+			lines[i] = strings.Repeat("#", newLevel) + line[level:]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
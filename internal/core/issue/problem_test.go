@@ -0,0 +1,58 @@
+package issue
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestProblemMarkdown(t *testing.T) {
+	issue := &Issue{
+		Title: "Login fails for SSO users",
+		Body: "Users on SSO see a blank page after login.\n\n" +
+			"## Steps to reproduce\n\n1. Sign in with SSO\n\n" +
+			"## Acceptance criteria\n\n- SSO users land on the dashboard\n\n" +
+			"## Out of scope\n\n- Password login\n\n" +
+			"```\n# not a heading\n```\n",
+		Labels:   []string{"bug", "auth"},
+		Number:   "42",
+		URL:      "https://github.com/acme/app/issues/42",
+		Source:   "github-json",
+		Comments: []Comment{{Author: "octocat", Body: "# Logs\nsee attached"}, {Body: "+1"}},
+	}
+
+	got := ProblemMarkdown(issue)
+
+	wantInOrder := []string{
+		"# Problem: Login fails for SSO users",
+		"## Problem Statement\n\nUsers on SSO see a blank page after login.\n\n### Steps to reproduce\n\n1. Sign in with SSO",
+		"## Feature Goals\n\n_To be defined._",
+		"## Core Requirements\n\n- SSO users land on the dashboard",
+		"## Scope Exclusions\n\n- Password login\n\n```\n# not a heading\n```",
+		"## Source Issue",
+		"- **Issue:** 42",
+		"- **Link:** https://github.com/acme/app/issues/42",
+		"- **Labels:** bug, auth",
+		"- **Imported from:** github-json",
+		"### Comments",
+		"**octocat:**\n\n#### Logs\nsee attached",
+		"**unknown:**\n\n+1",
+	}
+	pos := 0
+	for _, want := range wantInOrder {
+		idx := strings.Index(got[pos:], want)
+		if idx < 0 {
+			t.Fatalf("ProblemMarkdown() missing (or out of order) %q in:\n%s", want, got)
+		}
+		pos += idx + len(want)
+	}
+}
+
+func TestProblemMarkdown_EmptyBody(t *testing.T) {
+	got := ProblemMarkdown(&Issue{Title: "Dark mode", Source: "markdown"})
+	if strings.Count(got, placeholder) != len(problemSections) {
+		t.Errorf("ProblemMarkdown() should mark every section as to be defined:\n%s", got)
+	}
+	if strings.Contains(got, "**Issue:**") || strings.Contains(got, "### Comments") {
+		t.Errorf("ProblemMarkdown() included empty metadata:\n%s", got)
+	}
+}
@@ -0,0 +1,172 @@
+// Package linediff computes line-based differences between two texts and renders them in the
+// unified diff format, without shelling out to diff or git.
+package linediff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Context is the number of unchanged lines shown around each change.
+const Context = 3
+
+// Op is the kind of an Edit.
+type Op int
+
+const (
+	// Equal keeps a line present in both texts.
+	Equal Op = iota
+	// Delete removes a line of the old text.
+	Delete
+	// Insert adds a line of the new text.
+	Insert
+)
+
+// prefixes mark the lines of a unified diff hunk.
+var prefixes = map[Op]string{Equal: " ", Delete: "-", Insert: "+"}
+
+// Edit is one line of the edit script turning the old text into the new one.
+type Edit struct {
+	Op   Op
+	Text string
+}
+
+// Lines splits text into lines. A final newline does not start another line.
+func Lines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// Diff returns a shortest edit script from a to b, using Myers' algorithm. Its memory grows with
+// the square of the number of differences, which suits documents rather than large data files.
+func Diff(a, b []string) []Edit {
+	n, m := len(a), len(b)
+	limit := n + m
+	offset := limit + 1
+	v := make([]int, 2*limit+3)
+	// trace holds v as it was before each round, for walking the path back
+	var trace [][]int
+
+	for d := 0; d <= limit; d++ {
+		trace = append(trace, append([]int(nil), v...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrack(trace, a, b, offset)
+			}
+		}
+	}
+	return nil
+}
+
+// backtrack walks the rounds recorded by Diff from the end of both texts to their start.
+func backtrack(trace [][]int, a, b []string, offset int) []Edit {
+	var edits []Edit
+	x, y := len(a), len(b)
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+		prevK := k - 1
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prevK = k + 1
+		}
+		prevX := v[offset+prevK]
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			edits = append(edits, Edit{Op: Equal, Text: a[x-1]})
+			x--
+			y--
+		}
+		if d > 0 {
+			if x == prevX {
+				edits = append(edits, Edit{Op: Insert, Text: b[y-1]})
+			} else {
+				edits = append(edits, Edit{Op: Delete, Text: a[x-1]})
+			}
+		}
+		x, y = prevX, prevY
+	}
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+	return edits
+}
+
+// Unified renders the differences between two texts as a unified diff with Context lines of
+// context, labelling them fromName and toName. Equal texts yield an empty string.
+func Unified(fromName, toName, from, to string) string {
+	edits := Diff(Lines(from), Lines(to))
+
+	// Group changes whose surrounding context overlaps into hunks of [start, end) edits
+	type hunk struct{ start, end int }
+	var hunks []hunk
+	for i, edit := range edits {
+		if edit.Op == Equal {
+			continue
+		}
+		start, end := max(i-Context, 0), min(i+Context+1, len(edits))
+		if len(hunks) > 0 && start <= hunks[len(hunks)-1].end {
+			hunks[len(hunks)-1].end = end
+			continue
+		}
+		hunks = append(hunks, hunk{start: start, end: end})
+	}
+	if len(hunks) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", fromName, toName)
+	// aLine and bLine count the lines of each text before the current edit
+	aLine, bLine, next := 0, 0, 0
+	for _, h := range hunks {
+		for ; next < h.start; next++ {
+			aLine, bLine = advance(edits[next].Op, aLine, bLine)
+		}
+		aStart, bStart := aLine, bLine
+		var body strings.Builder
+		for ; next < h.end; next++ {
+			edit := edits[next]
+			body.WriteString(prefixes[edit.Op] + edit.Text + "\n")
+			aLine, bLine = advance(edit.Op, aLine, bLine)
+		}
+		fmt.Fprintf(&b, "@@ -%s +%s @@\n%s", hunkRange(aStart, aLine-aStart), hunkRange(bStart, bLine-bStart), body.String())
+	}
+	return b.String()
+}
+
+// advance moves the line counters of both texts past an edit.
+func advance(op Op, aLine, bLine int) (int, int) {
+	switch op {
+	case Delete:
+		return aLine + 1, bLine
+	case Insert:
+		return aLine, bLine + 1
+	default:
+		return aLine + 1, bLine + 1
+	}
+}
+
+// hunkRange formats the "start,count" of a hunk header. An empty range names the line before it.
+func hunkRange(before, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", before)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", before+1)
+	}
+	return fmt.Sprintf("%d,%d", before+1, count)
+}
@@ -0,0 +1,100 @@
+package linediff
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+	}{
+		{name: "equal", a: "a\nb\n", b: "a\nb\n"},
+		{name: "both empty", a: "", b: ""},
+		{name: "from empty", a: "", b: "a\nb\n"},
+		{name: "to empty", a: "a\nb\n", b: ""},
+		{name: "replace middle", a: "a\nb\nc\n", b: "a\nx\nc\n"},
+		{name: "interleaved", a: "a\nb\nc\na\nb\nb\na\n", b: "c\nb\na\nb\na\nc\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := Lines(tt.a), Lines(tt.b)
+			edits := Diff(a, b)
+
+			// Applying the script must turn a into b, and its equal lines must be a longest
+			// common subsequence
+			var gotA, gotB []string
+			changes := 0
+			for _, edit := range edits {
+				switch edit.Op {
+				case Equal:
+					gotA, gotB = append(gotA, edit.Text), append(gotB, edit.Text)
+				case Delete:
+					gotA = append(gotA, edit.Text)
+					changes++
+				case Insert:
+					gotB = append(gotB, edit.Text)
+					changes++
+				}
+			}
+			if strings.Join(gotA, "\n") != strings.Join(a, "\n") || strings.Join(gotB, "\n") != strings.Join(b, "\n") {
+				t.Fatalf("Diff() = %+v does not transform %q into %q", edits, tt.a, tt.b)
+			}
+			if want := len(a) + len(b) - 2*lcs(a, b); changes != want {
+				t.Errorf("Diff() made %d changes, want %d", changes, want)
+			}
+		})
+	}
+}
+
+func TestUnified(t *testing.T) {
+	from := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n"
+	to := "1\n2\n3\n4\nfive\n6\n7\n8\n9\n10\n11\n12\n13\n"
+	want := `--- old
++++ new
+@@ -2,7 +2,7 @@
+ 2
+ 3
+ 4
+-5
++five
+ 6
+ 7
+ 8
+@@ -10,3 +10,4 @@
+ 10
+ 11
+ 12
++13
+`
+	if got := Unified("old", "new", from, to); got != want {
+		t.Errorf("Unified() =\n%s\nwant\n%s", got, want)
+	}
+
+	if got := Unified("old", "new", from, from); got != "" {
+		t.Errorf("Unified() of equal texts = %q, want empty", got)
+	}
+
+	if got, want := Unified("old", "new", "", "a\n"), "--- old\n+++ new\n@@ -0,0 +1 @@\n+a\n"; got != want {
+		t.Errorf("Unified() from empty = %q, want %q", got, want)
+	}
+}
+
+// lcs returns the length of the longest common subsequence of a and b.
+func lcs(a, b []string) int {
+	table := make([][]int, len(a)+1)
+	for i := range table {
+		table[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				table[i][j] = table[i+1][j+1] + 1
+			} else {
+				table[i][j] = max(table[i+1][j], table[i][j+1])
+			}
+		}
+	}
+	return table[0][0]
+}
@@ -0,0 +1,92 @@
+// Package lock serializes the changes that d3 processes sharing a project make, such as the CLI
+// and a running MCP server, through a lock file in the .d3 directory.
+package lock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/imcclaskey/d3/internal/core/d3err"
+)
+
+// FileName is the lock file inside the .d3 directory.
+const FileName = ".lock"
+
+const (
+	// DefaultWait is how long Lock waits for another process to release the lock.
+	DefaultWait = 2 * time.Second
+	// StaleAfter is the age after which a lock file is taken to be left behind by a process that
+	// crashed. It is well above hooks.DefaultTimeout, so that slow hooks keep their lock.
+	StaleAfter = 10 * time.Minute
+
+	pollInterval = 50 * time.Millisecond
+)
+
+// File is a lock held by creating a file exclusively. It coordinates processes, not goroutines.
+type File struct {
+	path string
+	wait time.Duration
+}
+
+// New returns the lock for the lock file at path.
+func New(path string) *File {
+	return &File{path: path, wait: DefaultWait}
+}
+
+// SetWait replaces how long Lock waits for the lock. Zero makes Lock fail at once when the lock
+// is held.
+func (f *File) SetWait(wait time.Duration) {
+	f.wait = wait
+}
+
+// Lock acquires the lock and returns the function that releases it. While another process holds
+// the lock it retries until the wait time is over, and then fails with a d3err.Locked error.
+func (f *File) Lock(ctx context.Context) (func(), error) {
+	deadline := time.Now().Add(f.wait)
+	for {
+		file, err := os.OpenFile(f.path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			_, _ = fmt.Fprintf(file, "%d\n", os.Getpid())
+			_ = file.Close()
+			return func() { _ = os.Remove(f.path) }, nil
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return nil, fmt.Errorf("failed to create lock file %s: %w", f.path, err)
+		}
+		if f.removeStale() {
+			continue
+		}
+		if !time.Now().Before(deadline) {
+			return nil, d3err.New(d3err.Locked, "project is locked by another d3 process%s; delete %s if no d3 command is running", f.holder(), f.path)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// removeStale deletes the lock file if it is older than StaleAfter and reports whether it did.
+func (f *File) removeStale() bool {
+	info, err := os.Stat(f.path)
+	if err != nil || time.Since(info.ModTime()) < StaleAfter {
+		return false
+	}
+	return os.Remove(f.path) == nil
+}
+
+// holder describes the process recorded in the lock file, if it can be read.
+func (f *File) holder() string {
+	data, err := os.ReadFile(f.path)
+	pid := strings.TrimSpace(string(data))
+	if err != nil || pid == "" {
+		return ""
+	}
+	return " (pid " + pid + ")"
+}
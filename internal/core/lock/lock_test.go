@@ -0,0 +1,56 @@
+package lock
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/imcclaskey/d3/internal/core/d3err"
+)
+
+func TestFile_Lock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	first := New(path)
+	unlock, err := first.Lock(context.Background())
+	if err != nil {
+		t.Fatalf("Lock() error = %v", err)
+	}
+
+	second := New(path)
+	second.SetWait(0)
+	if _, err := second.Lock(context.Background()); !errors.Is(err, d3err.ErrLocked) {
+		t.Errorf("Lock() while held error = %v, want ErrLocked", err)
+	}
+
+	unlock()
+	unlockAgain, err := second.Lock(context.Background())
+	if err != nil {
+		t.Fatalf("Lock() after release error = %v", err)
+	}
+	unlockAgain()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("lock file still exists after unlock: %v", err)
+	}
+}
+
+func TestFile_Lock_Stale(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	if err := os.WriteFile(path, []byte("1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-2 * StaleAfter)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	l := New(path)
+	l.SetWait(0)
+	unlock, err := l.Lock(context.Background())
+	if err != nil {
+		t.Fatalf("Lock() over a stale lock error = %v", err)
+	}
+	unlock()
+}
@@ -0,0 +1,131 @@
+// Package outline parses the section structure of a markdown document: its headings, where they
+// are and whether the sections under them have content.
+package outline
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// atxHeading matches "## Title", with optional closing hashes.
+	atxHeading = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	// boldHeading matches a line that is only bold text, optionally numbered, such as
+	// "2. **Delivery Steps**", the section format the phase templates describe.
+	boldHeading = regexp.MustCompile(`^(?:[0-9]+[.)][ \t]+)?(?:\*\*|__)([^*_]+?)(?:\*\*|__):?[ \t]*$`)
+	// fence opens or closes a fenced code block.
+	fence = regexp.MustCompile("^ {0,3}(```|~~~)")
+	// numbering matches section numbers such as "2." or "1.3)" before a heading title.
+	numbering = regexp.MustCompile(`^[0-9]+(?:\.[0-9]+)*[.)]?\s+`)
+)
+
+// boldHeadingLevel is the level given to bold-line headings: below a document title, beside
+// "##" sections.
+const boldHeadingLevel = 2
+
+// Section is a heading and the lines below it up to the next heading of the same or a higher
+// level.
+type Section struct {
+	// Title is the heading text as written, without the markers.
+	Title string
+	// Level is 1 to 6 for "#" to "######".
+	Level int
+	// Line is the 1-based line of the heading.
+	Line int
+	// Empty reports whether the section, including its subsections, has no text other than
+	// headings and HTML comments.
+	Empty bool
+}
+
+// Key returns the title normalized for comparison. See Normalize.
+func (s Section) Key() string {
+	return Normalize(s.Title)
+}
+
+// Outline is the list of sections of a document, in document order.
+type Outline struct {
+	Sections []Section
+}
+
+// Parse reads the outline of markdown content. Headings inside fenced code blocks are ignored.
+func Parse(content string) *Outline {
+	lines := strings.Split(content, "\n")
+	o := &Outline{Sections: []Section{}}
+	// hasText records, per line, whether it is body text
+	hasText := make([]bool, len(lines))
+
+	inFence, inComment := false, false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if fence.MatchString(line) {
+			inFence = !inFence
+			hasText[i] = true
+			continue
+		}
+		if inFence {
+			hasText[i] = trimmed != ""
+			continue
+		}
+		if inComment || strings.HasPrefix(trimmed, "<!--") {
+			inComment = !strings.Contains(trimmed, "-->")
+			continue
+		}
+		if level, title, ok := heading(line); ok {
+			o.Sections = append(o.Sections, Section{Title: title, Level: level, Line: i + 1})
+			continue
+		}
+		hasText[i] = trimmed != ""
+	}
+
+	for i := range o.Sections {
+		section := &o.Sections[i]
+		end := len(lines)
+		for _, next := range o.Sections[i+1:] {
+			if next.Level <= section.Level {
+				end = next.Line - 1
+				break
+			}
+		}
+		section.Empty = true
+		for line := section.Line; line < end; line++ {
+			if hasText[line] {
+				section.Empty = false
+				break
+			}
+		}
+	}
+	return o
+}
+
+// Find returns the first section whose normalized title equals the normalized name.
+func (o *Outline) Find(name string) (Section, bool) {
+	key := Normalize(name)
+	for _, section := range o.Sections {
+		if section.Key() == key {
+			return section, true
+		}
+	}
+	return Section{}, false
+}
+
+// heading recognizes ATX and bold-line headings.
+func heading(line string) (int, string, bool) {
+	if match := atxHeading.FindStringSubmatch(line); match != nil {
+		return len(match[1]), strings.TrimSpace(match[2]), true
+	}
+	if match := boldHeading.FindStringSubmatch(line); match != nil {
+		return boldHeadingLevel, strings.TrimSpace(match[1]), true
+	}
+	return 0, "", false
+}
+
+// Normalize reduces a heading title to a comparable key: section numbers, emphasis markers and a
+// trailing colon are dropped, "&" reads as "and", and case and spacing are ignored. "2. **Technical
+// Constraints & Requirements:**" and "technical constraints and requirements" are equal.
+func Normalize(title string) string {
+	title = strings.NewReplacer("*", "", "_", "", "`", "").Replace(title)
+	title = numbering.ReplaceAllString(strings.TrimSpace(title), "")
+	title = strings.TrimSuffix(strings.TrimSpace(title), ":")
+	title = strings.ReplaceAll(title, "&", " and ")
+	return strings.ToLower(strings.Join(strings.Fields(title), " "))
+}
@@ -0,0 +1,66 @@
+package outline
+
+import (
+	"reflect"
+	"testing"
+)
+
+const document = `# Feature: login
+
+## 1. Problem Statement
+Users cannot log in.
+
+## Feature Goals
+<!-- list measurable goals -->
+
+## Core Requirements
+### Must
+- R1: User can log in
+### Should
+
+2. **Scope Exclusions**
+` + "```" + `
+# not a heading
+` + "```" + `
+`
+
+func TestParse(t *testing.T) {
+	want := []Section{
+		{Title: "Feature: login", Level: 1, Line: 1, Empty: false},
+		{Title: "1. Problem Statement", Level: 2, Line: 3, Empty: false},
+		{Title: "Feature Goals", Level: 2, Line: 6, Empty: true},
+		{Title: "Core Requirements", Level: 2, Line: 9, Empty: false},
+		{Title: "Must", Level: 3, Line: 10, Empty: false},
+		{Title: "Should", Level: 3, Line: 12, Empty: true},
+		{Title: "Scope Exclusions", Level: 2, Line: 14, Empty: false},
+	}
+	if got := Parse(document).Sections; !reflect.DeepEqual(got, want) {
+		t.Errorf("Parse() =\n%+v\nwant\n%+v", got, want)
+	}
+	if got := Parse("").Sections; len(got) != 0 {
+		t.Errorf("Parse(\"\") = %+v, want no sections", got)
+	}
+}
+
+func TestOutline_Find(t *testing.T) {
+	o := Parse(document)
+	if section, ok := o.Find("problem statement"); !ok || section.Line != 3 {
+		t.Errorf("Find(problem statement) = %+v, %v", section, ok)
+	}
+	if _, ok := o.Find("Technical Approach Overview"); ok {
+		t.Error("Find() found a section that does not exist")
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]string{
+		"2. **Technical Constraints & Requirements:**": "technical constraints and requirements",
+		"  Delivery   Steps ":                          "delivery steps",
+		"1.3) `Scope` Exclusions":                      "scope exclusions",
+	}
+	for title, want := range tests {
+		if got := Normalize(title); got != want {
+			t.Errorf("Normalize(%q) = %q, want %q", title, got, want)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 
 	"github.com/imcclaskey/d3/internal/core/ports"
 )
@@ -19,6 +20,28 @@ const (
 	Deliver Phase = "deliver"
 )
 
+// Lifecycle lists the phases a feature moves through, in order.
+var Lifecycle = []Phase{Define, Design, Deliver}
+
+// Index returns the position of p in Lifecycle. None and unknown phases count as define, the
+// phase a feature without a phase file is treated as being in.
+func (p Phase) Index() int {
+	if i := slices.Index(Lifecycle, p); i >= 0 {
+		return i
+	}
+	return 0
+}
+
+// Before reports whether p comes earlier in the lifecycle than other.
+func (p Phase) Before(other Phase) bool {
+	return p.Index() < other.Index()
+}
+
+// Valid reports whether p is one of the phases in Lifecycle.
+func (p Phase) Valid() bool {
+	return slices.Contains(Lifecycle, p)
+}
+
 // PhaseFileMap defines the standard file associated with each phase.
 var PhaseFileMap = map[Phase]string{
 	Define:  "problem.md",
@@ -43,9 +66,7 @@ func NewService(fs ports.FileSystem) *Service {
 // files if they are missing.
 func (s *Service) EnsurePhaseFiles(featureRoot string) error {
 	// Process phases in a consistent order
-	orderedPhases := []Phase{Define, Design, Deliver}
-
-	for _, p := range orderedPhases {
+	for _, p := range Lifecycle {
 		phaseDir := filepath.Join(featureRoot, string(p))
 		filename := PhaseFileMap[p]
 		filePath := filepath.Join(phaseDir, filename)
@@ -80,9 +101,7 @@ func (s *Service) EnsurePhaseFiles(featureRoot string) error {
 // files if they are missing.
 func EnsurePhaseFiles(fs ports.FileSystem, featureRoot string) error {
 	// Process phases in a consistent order
-	orderedPhases := []Phase{Define, Design, Deliver}
-
-	for _, p := range orderedPhases {
+	for _, p := range Lifecycle {
 		phaseDir := filepath.Join(featureRoot, string(p))
 		filename := PhaseFileMap[p]
 		filePath := filepath.Join(phaseDir, filename)
@@ -110,3 +110,35 @@ func TestEnsurePhaseFiles(t *testing.T) {
 		})
 	}
 }
+
+func TestPhaseOrder(t *testing.T) {
+	tests := []struct {
+		phase      Phase
+		wantIndex  int
+		wantValid  bool
+		wantBefore Phase
+	}{
+		{phase: Define, wantIndex: 0, wantValid: true, wantBefore: Design},
+		{phase: Design, wantIndex: 1, wantValid: true, wantBefore: Deliver},
+		{phase: Deliver, wantIndex: 2, wantValid: true},
+		{phase: None, wantIndex: 0, wantValid: false, wantBefore: Design},
+		{phase: "deploy", wantIndex: 0, wantValid: false, wantBefore: Design},
+	}
+	for _, tt := range tests {
+		if got := tt.phase.Index(); got != tt.wantIndex {
+			t.Errorf("Phase(%q).Index() = %d, want %d", tt.phase, got, tt.wantIndex)
+		}
+		if got := tt.phase.Valid(); got != tt.wantValid {
+			t.Errorf("Phase(%q).Valid() = %v, want %v", tt.phase, got, tt.wantValid)
+		}
+		if tt.wantBefore != None && !tt.phase.Before(tt.wantBefore) {
+			t.Errorf("Phase(%q).Before(%q) = false, want true", tt.phase, tt.wantBefore)
+		}
+		if tt.phase.Before(tt.phase) {
+			t.Errorf("Phase(%q).Before itself = true, want false", tt.phase)
+		}
+	}
+	if Deliver.Before(Define) {
+		t.Error("Deliver.Before(Define) = true, want false")
+	}
+}
@@ -0,0 +1,152 @@
+package progress
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// StatusPending is the status of a task that has not been started.
+const StatusPending = "pending"
+
+// StepTypes are the delivery step types the design template prescribes.
+var StepTypes = []string{"code", "test", "verify", "commit"}
+
+// stepPattern matches a typed delivery step of plan.md: a list item with an optional step ID and
+// a type tag, e.g. "- [code] Add the field", "1. S2 [test] Run the tests" or
+// "- **[verify]** Check the output".
+var stepPattern = regexp.MustCompile(`^\s*(?:[-*+]|[0-9]+[.)])\s+(?:[*_]*([A-Z]{1,5}-?[0-9]+)[*_]*[:.)]?\s+)?[*_]*\[(?i:(` + strings.Join(StepTypes, "|") + `))\][*_]*[\s:.-]*(.*)$`)
+
+// PlanStep is a typed delivery step of plan.md.
+type PlanStep struct {
+	// ID is the step's traceability ID, such as "S1", if it declares one.
+	ID          string
+	Type        string
+	Description string
+}
+
+// ParsePlan returns the typed delivery steps of plan.md content in document order. Lines that
+// are not list items carrying a [code], [test], [verify] or [commit] tag are ignored.
+func ParsePlan(plan string) []PlanStep {
+	var steps []PlanStep
+	for _, line := range strings.Split(plan, "\n") {
+		match := stepPattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		description := strings.TrimSpace(strings.Trim(strings.TrimSpace(match[3]), "*_"))
+		if description == "" {
+			continue
+		}
+		steps = append(steps, PlanStep{ID: match[1], Type: strings.ToLower(match[2]), Description: description})
+	}
+	return steps
+}
+
+// TasksFromPlan turns delivery steps into pending tasks with IDs counting from 1. A step's ID
+// becomes the task's only ref.
+func TasksFromPlan(steps []PlanStep) []Task {
+	tasks := make([]Task, 0, len(steps))
+	for i, step := range steps {
+		task := Task{ID: strconv.Itoa(i + 1), Description: step.Description, Type: step.Type, Status: StatusPending}
+		if step.ID != "" {
+			task.Refs = []string{step.ID}
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks
+}
+
+// taskEntry is the layout of a task written to progress.yaml, with numeric IDs and refs on one line.
+type taskEntry struct {
+	ID          int      `yaml:"id"`
+	Description string   `yaml:"description"`
+	Type        string   `yaml:"type,omitempty"`
+	Status      string   `yaml:"status"`
+	Refs        []string `yaml:"refs,omitempty,flow"`
+}
+
+// Marshal encodes tasks as the top-level list progress.yaml holds. Task IDs must be numeric.
+func Marshal(tasks []Task) ([]byte, error) {
+	entries := make([]taskEntry, 0, len(tasks))
+	for _, task := range tasks {
+		id, err := strconv.Atoi(task.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode progress.yaml: task ID %q is not a number", task.ID)
+		}
+		entries = append(entries, taskEntry{ID: id, Description: task.Description, Type: task.Type, Status: task.Status, Refs: task.Refs})
+	}
+	data, err := yaml.Marshal(entries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode progress.yaml: %w", err)
+	}
+	return data, nil
+}
+
+// Drift compares the delivery steps of plan.md with existing tasks and describes each
+// difference: steps no task matches, tasks no step matches and tasks whose type differs from
+// their step. A task matches a step that it references by ID, or otherwise a step with the same
+// description, ignoring case, spacing and a trailing period.
+func Drift(steps []PlanStep, tasks []Task) []string {
+	var drift []string
+	matched := make([]bool, len(tasks))
+	for _, step := range steps {
+		i := matchTask(step, tasks, matched)
+		if i < 0 {
+			drift = append(drift, fmt.Sprintf("plan step %q has no task in progress.yaml", stepLabel(step)))
+			continue
+		}
+		matched[i] = true
+		if tasks[i].Type != "" && !strings.EqualFold(tasks[i].Type, step.Type) {
+			drift = append(drift, fmt.Sprintf("task %s is typed %q but plan step %q is %q", tasks[i].ID, tasks[i].Type, stepLabel(step), step.Type))
+		}
+	}
+	for i, task := range tasks {
+		if !matched[i] {
+			drift = append(drift, fmt.Sprintf("task %s %q does not match any plan step", task.ID, task.Description))
+		}
+	}
+	return drift
+}
+
+// matchTask returns the index of the first unmatched task for step, or -1.
+func matchTask(step PlanStep, tasks []Task, matched []bool) int {
+	if step.ID != "" {
+		for i, task := range tasks {
+			if !matched[i] && containsFold(task.Refs, step.ID) {
+				return i
+			}
+		}
+	}
+	want := normalizeDescription(step.Description)
+	for i, task := range tasks {
+		if !matched[i] && normalizeDescription(task.Description) == want {
+			return i
+		}
+	}
+	return -1
+}
+
+// stepLabel names a step by its ID or, without one, its description.
+func stepLabel(step PlanStep) string {
+	if step.ID != "" {
+		return step.ID
+	}
+	return step.Description
+}
+
+func normalizeDescription(description string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.Join(strings.Fields(description), " ")), ".")
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,115 @@
+package progress
+
+import (
+	"reflect"
+	"testing"
+)
+
+const plan = `## 2. Delivery Steps
+
+1. [code] Add field ` + "`completed_at`" + ` to the Feature struct and write unit tests.
+2. **[test]** Run unit tests for the project service.
+- S3 [verify] Manually check the feature directory (R1)
+- **S4**: [Commit] Commit the complete command
+- [docs] Not a delivery step type
+- Plain bullet with no type
+
+## 3. Technical Constraints
+`
+
+func TestParsePlan(t *testing.T) {
+	want := []PlanStep{
+		{Type: "code", Description: "Add field `completed_at` to the Feature struct and write unit tests."},
+		{Type: "test", Description: "Run unit tests for the project service."},
+		{ID: "S3", Type: "verify", Description: "Manually check the feature directory (R1)"},
+		{ID: "S4", Type: "commit", Description: "Commit the complete command"},
+	}
+	if got := ParsePlan(plan); !reflect.DeepEqual(got, want) {
+		t.Errorf("ParsePlan() = %+v, want %+v", got, want)
+	}
+	if got := ParsePlan(""); got != nil {
+		t.Errorf("ParsePlan(\"\") = %+v, want nil", got)
+	}
+}
+
+func TestTasksFromPlan_Marshal(t *testing.T) {
+	tasks := TasksFromPlan(ParsePlan(plan))
+	data, err := Marshal(tasks)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	want := "- id: 1\n" +
+		"  description: Add field `completed_at` to the Feature struct and write unit tests.\n" +
+		"  type: code\n" +
+		"  status: pending\n" +
+		"- id: 2\n" +
+		"  description: Run unit tests for the project service.\n" +
+		"  type: test\n" +
+		"  status: pending\n" +
+		"- id: 3\n" +
+		"  description: Manually check the feature directory (R1)\n" +
+		"  type: verify\n" +
+		"  status: pending\n" +
+		"  refs: [S3]\n" +
+		"- id: 4\n" +
+		"  description: Commit the complete command\n" +
+		"  type: commit\n" +
+		"  status: pending\n" +
+		"  refs: [S4]\n"
+	if string(data) != want {
+		t.Errorf("Marshal() =\n%s\nwant\n%s", data, want)
+	}
+
+	// The written file reads back as the same tasks
+	parsed, err := Parse(data)
+	if err != nil || !reflect.DeepEqual(parsed, tasks) {
+		t.Errorf("Parse(Marshal()) = %+v, %v, want %+v", parsed, err, tasks)
+	}
+
+	if _, err := Marshal([]Task{{ID: "a"}}); err == nil {
+		t.Error("Marshal() with a non-numeric ID error = nil, want an error")
+	}
+}
+
+func TestDrift(t *testing.T) {
+	steps := ParsePlan(plan)
+	tests := []struct {
+		name  string
+		tasks []Task
+		want  []string
+	}{
+		{name: "in sync", tasks: TasksFromPlan(steps)},
+		{
+			name: "matched by description and ref despite edits",
+			tasks: []Task{
+				{ID: "1", Description: "add field  `completed_at` to the Feature struct and write unit tests", Type: "code"},
+				{ID: "2", Description: "Run unit tests for the project service.", Type: "test"},
+				{ID: "3", Description: "Reworded check", Type: "verify", Refs: []string{"s3"}},
+				{ID: "4", Description: "Commit", Type: "commit", Refs: []string{"S4"}},
+			},
+		},
+		{
+			name: "missing, extra and retyped tasks",
+			tasks: []Task{
+				{ID: "1", Description: "Add field `completed_at` to the Feature struct and write unit tests.", Type: "test"},
+				{ID: "2", Description: "Refactor the loader", Type: "code"},
+				{ID: "3", Refs: []string{"S3"}, Type: "verify"},
+				{ID: "4", Refs: []string{"S4"}},
+			},
+			want: []string{
+				`task 1 is typed "test" but plan step "Add field ` + "`completed_at`" + ` to the Feature struct and write unit tests." is "code"`,
+				`plan step "Run unit tests for the project service." has no task in progress.yaml`,
+				`task 2 "Refactor the loader" does not match any plan step`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Drift(steps, tt.tasks); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Drift() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+// Package progress reads the deliver-phase progress.yaml task list.
+package progress
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Task is a single delivery task from progress.yaml.
+type Task struct {
+	ID          string `json:"id" yaml:"id"`
+	Description string `json:"description" yaml:"description"`
+	Type        string `json:"type,omitempty" yaml:"type,omitempty"`
+	Status      string `json:"status" yaml:"status"`
+	// Review is "needed" while the task waits to be checked against a changed plan or problem.
+	Review string `json:"review,omitempty" yaml:"review,omitempty"`
+	// Refs lists the plan step and requirement IDs the task implements, such as "S2" or "R1".
+	Refs []string `json:"refs,omitempty" yaml:"refs,omitempty"`
+}
+
+// Done reports whether the task status counts as finished.
+func (t Task) Done() bool {
+	switch strings.ToLower(strings.TrimSpace(t.Status)) {
+	case "complete", "completed", "done":
+		return true
+	default:
+		return false
+	}
+}
+
+// Complete reports whether there is at least one task and every task is done.
+func Complete(tasks []Task) bool {
+	for _, task := range tasks {
+		if !task.Done() {
+			return false
+		}
+	}
+	return len(tasks) > 0
+}
+
+// Parse reads tasks from progress.yaml content. The file is written by an AI assistant,
+// so parsing is lenient: the task list may be the document itself or sit under a "tasks"
+// key, and keys are matched case-insensitively. Empty content yields no tasks.
+func Parse(data []byte) ([]Task, error) {
+	var doc interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse progress.yaml: %w", err)
+	}
+
+	var items []interface{}
+	switch v := doc.(type) {
+	case nil:
+		return []Task{}, nil
+	case []interface{}:
+		items = v
+	case map[string]interface{}:
+		list, ok := lookup(v, "tasks").([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("failed to parse progress.yaml: expected a list of tasks")
+		}
+		items = list
+	default:
+		return nil, fmt.Errorf("failed to parse progress.yaml: expected a list of tasks")
+	}
+
+	tasks := make([]Task, 0, len(items))
+	for i, item := range items {
+		fields, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("failed to parse progress.yaml: task %d is not a mapping", i+1)
+		}
+		tasks = append(tasks, Task{
+			ID:          scalar(lookup(fields, "id")),
+			Description: scalar(lookup(fields, "description")),
+			Type:        scalar(lookup(fields, "type")),
+			Status:      scalar(lookup(fields, "status")),
+			Review:      scalar(lookup(fields, "review")),
+			Refs:        list(lookup(fields, "refs")),
+		})
+	}
+	return tasks, nil
+}
+
+// lookup returns the value for key, ignoring case.
+func lookup(fields map[string]interface{}, key string) interface{} {
+	for k, v := range fields {
+		if strings.EqualFold(k, key) {
+			return v
+		}
+	}
+	return nil
+}
+
+// list reads a YAML sequence of scalars, or a single scalar separated by commas or spaces.
+// A missing value yields nil.
+func list(v interface{}) []string {
+	var values []string
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case []interface{}:
+		for _, item := range v {
+			values = append(values, strings.Fields(strings.ReplaceAll(scalar(item), ",", " "))...)
+		}
+	default:
+		values = strings.Fields(strings.ReplaceAll(scalar(v), ",", " "))
+	}
+	return values
+}
+
+// scalar renders a YAML scalar as a trimmed string.
+func scalar(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	return strings.TrimSpace(fmt.Sprint(v))
+}
@@ -0,0 +1,72 @@
+package progress
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    []Task
+		wantErr bool
+	}{
+		{
+			name: "top-level list",
+			data: "- id: 1\n  description: Build form\n  type: code\n  status: complete\n",
+			want: []Task{{ID: "1", Description: "Build form", Type: "code", Status: "complete"}},
+		},
+		{
+			name: "tasks key with capitalized fields",
+			data: "tasks:\n  - ID: 2\n    Description: Write tests\n    Type: test\n    Status: pending\n",
+			want: []Task{{ID: "2", Description: "Write tests", Type: "test", Status: "pending"}},
+		},
+		{
+			name: "refs as a list or a string",
+			data: "- id: 1\n  refs: [S1, R2]\n- id: 2\n  refs: \"S2, R1 R3\"\n",
+			want: []Task{{ID: "1", Refs: []string{"S1", "R2"}}, {ID: "2", Refs: []string{"S2", "R1", "R3"}}},
+		},
+		{name: "empty file", data: "", want: []Task{}},
+		{name: "mapping without tasks", data: "feature: login\n", wantErr: true},
+		{name: "task is not a mapping", data: "- just a string\n", wantErr: true},
+		{name: "invalid yaml", data: "tasks: [\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTask_Done(t *testing.T) {
+	for status, want := range map[string]bool{"complete": true, "Completed": true, "done": true, "pending": false, "": false} {
+		if got := (Task{Status: status}).Done(); got != want {
+			t.Errorf("Task{Status: %q}.Done() = %v, want %v", status, got, want)
+		}
+	}
+}
+
+func TestComplete(t *testing.T) {
+	tests := []struct {
+		tasks []Task
+		want  bool
+	}{
+		{tasks: nil, want: false},
+		{tasks: []Task{{Status: "done"}, {Status: "complete"}}, want: true},
+		{tasks: []Task{{Status: "done"}, {Status: "pending"}}, want: false},
+		{tasks: []Task{{Status: "done"}, {Status: "complete", Review: "needed"}}, want: true},
+	}
+	for _, tt := range tests {
+		if got := Complete(tt.tasks); got != tt.want {
+			t.Errorf("Complete(%+v) = %v, want %v", tt.tasks, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package progress
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ReviewNeeded is the review value of a task whose plan or problem changed after it was written.
+const ReviewNeeded = "needed"
+
+// NeedsReview reports whether the task is flagged for review.
+func (t Task) NeedsReview() bool {
+	return strings.EqualFold(strings.TrimSpace(t.Review), ReviewNeeded)
+}
+
+// MarkNeedsReview sets "review: needed" on every task in progress.yaml content and returns the
+// new content and how many tasks changed. The document is edited in place, so the status and
+// other fields and comments are kept. Content without tasks is returned unchanged.
+func MarkNeedsReview(data []byte) ([]byte, int, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, 0, fmt.Errorf("failed to parse progress.yaml: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		return data, 0, nil
+	}
+
+	tasks := doc.Content[0]
+	if tasks.Kind == yaml.MappingNode {
+		tasks = mappingValue(tasks, "tasks")
+	}
+	if tasks == nil || tasks.Kind != yaml.SequenceNode {
+		return nil, 0, fmt.Errorf("failed to parse progress.yaml: expected a list of tasks")
+	}
+
+	changed := 0
+	for _, task := range tasks.Content {
+		if task.Kind != yaml.MappingNode {
+			continue
+		}
+		review := mappingValue(task, "review")
+		if review == nil {
+			task.Content = append(task.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "review"},
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: ReviewNeeded})
+			changed++
+			continue
+		}
+		if strings.EqualFold(strings.TrimSpace(review.Value), ReviewNeeded) {
+			continue
+		}
+		review.Kind, review.Tag, review.Style, review.Value, review.Content = yaml.ScalarNode, "!!str", 0, ReviewNeeded, nil
+		changed++
+	}
+	if changed == 0 {
+		return data, 0, nil
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return nil, 0, fmt.Errorf("failed to encode progress.yaml: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, 0, fmt.Errorf("failed to encode progress.yaml: %w", err)
+	}
+	return buf.Bytes(), changed, nil
+}
+
+// mappingValue returns the value node for key in a mapping node, ignoring case, or nil.
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if strings.EqualFold(mapping.Content[i].Value, key) {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package progress
+
+import (
+	"testing"
+)
+
+func TestMarkNeedsReview(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		want        string
+		wantChanged int
+		wantErr     bool
+	}{
+		{
+			name:        "top-level list keeps other fields and comments",
+			data:        "# Tasks for login\n- id: 1\n  description: Build form\n  status: complete # done on Monday\n  files: [form.go]\n- id: 2\n  description: Write tests\n  status: pending\n",
+			want:        "# Tasks for login\n- id: 1\n  description: Build form\n  status: complete # done on Monday\n  files: [form.go]\n  review: needed\n- id: 2\n  description: Write tests\n  status: pending\n  review: needed\n",
+			wantChanged: 2,
+		},
+		{
+			name:        "tasks key",
+			data:        "tasks:\n  - ID: 1\n    Review: Needed\n  - ID: 2\n    Status: pending\n    Review: done\n",
+			want:        "tasks:\n  - ID: 1\n    Review: Needed\n  - ID: 2\n    Status: pending\n    Review: needed\n",
+			wantChanged: 1,
+		},
+		{name: "already flagged", data: "- id: 1\n  status: complete\n  review: needed\n", want: "- id: 1\n  status: complete\n  review: needed\n"},
+		{name: "empty", data: "", want: ""},
+		{name: "not a task list", data: "name: login\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, changed, err := MarkNeedsReview([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("MarkNeedsReview() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if string(got) != tt.want || changed != tt.wantChanged {
+				t.Errorf("MarkNeedsReview() = %q, %d, want %q, %d", got, changed, tt.want, tt.wantChanged)
+			}
+			before, err := Parse([]byte(tt.data))
+			if err != nil {
+				t.Fatalf("Parse() of original content error = %v", err)
+			}
+			tasks, err := Parse(got)
+			if err != nil {
+				t.Fatalf("Parse() of marked content error = %v", err)
+			}
+			for i, task := range tasks {
+				if !task.NeedsReview() {
+					t.Errorf("task %s review = %q, want needed", task.ID, task.Review)
+				}
+				if task.Status != before[i].Status {
+					t.Errorf("task %s status = %q, want %q kept", task.ID, task.Status, before[i].Status)
+				}
+			}
+		})
+	}
+}
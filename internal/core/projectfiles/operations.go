@@ -5,10 +5,12 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/imcclaskey/d3/internal/core/config"
 	"github.com/imcclaskey/d3/internal/core/ports"
 )
 
@@ -32,7 +34,6 @@ type MCPRootConfig struct {
 
 // Constants for d3 server entry in mcp.json
 const (
-	D3ServerName     = "d3"
 	D3Command        = "d3"
 	D3ServeArgPrefix = "serve --workdir "
 
@@ -41,15 +42,36 @@ const (
 )
 
 // DefaultFileOperator implements file operations for project initialization.
-type DefaultFileOperator struct{}
+type DefaultFileOperator struct {
+	logger               *slog.Logger
+	serverName           string
+	gitignorePatterns    []string
+	cursorignorePatterns []string
+}
 
-// NewDefaultFileOperator creates a new DefaultFileOperator.
+// NewDefaultFileOperator creates a DefaultFileOperator using the default project configuration.
 func NewDefaultFileOperator() *DefaultFileOperator {
-	return &DefaultFileOperator{}
+	return NewFileOperator(config.Default(""))
+}
+
+// NewFileOperator creates a DefaultFileOperator that writes the MCP server name and ignore
+// entries from cfg.
+func NewFileOperator(cfg config.Config) *DefaultFileOperator {
+	return &DefaultFileOperator{
+		logger:               slog.Default(),
+		serverName:           cfg.MCPServerName,
+		gitignorePatterns:    cfg.GitignorePatterns,
+		cursorignorePatterns: cfg.CursorignorePatterns,
+	}
+}
+
+// SetLogger replaces the logger that receives warnings about files that had to be replaced.
+func (op *DefaultFileOperator) SetLogger(logger *slog.Logger) {
+	op.logger = logger
 }
 
 // EnsureMCPJSON creates or updates mcp.json in the project root.
-// It ensures the 'd3' server entry has the correct command and workdir.
+// It ensures the configured d3 server entry has the correct command and workdir.
 // It always attempts to preserve other entries if mcp.json exists and is valid.
 func (op *DefaultFileOperator) EnsureMCPJSON(fs ports.FileSystem, projectRoot string) error {
 	mcpPath := filepath.Join(projectRoot, ".cursor", "mcp.json")
@@ -75,7 +97,7 @@ func (op *DefaultFileOperator) EnsureMCPJSON(fs ports.FileSystem, projectRoot st
 			}
 		} else {
 			// If existing file is corrupt, warn and proceed to create a new one with the d3 entry.
-			fmt.Fprintf(os.Stderr, "warning: mcp.json is corrupted or unparsable, creating new with d3 entry: %v\n", jsonErr)
+			op.log().Warn("mcp.json is corrupted or unparsable, creating new with d3 entry", "path", mcpPath, "error", jsonErr)
 			rootConfig.MCPServers = make(MCPServersMap) // Reset to ensure a clean state for the d3 entry
 		}
 	} else if !os.IsNotExist(errReadFile) { // Some other error reading the file (not just "doesn't exist")
@@ -83,7 +105,7 @@ func (op *DefaultFileOperator) EnsureMCPJSON(fs ports.FileSystem, projectRoot st
 	}
 	// If os.IsNotExist(errReadFile), we just proceed with the new rootConfig, which is correct.
 
-	rootConfig.MCPServers[D3ServerName] = d3ServerDetail
+	rootConfig.MCPServers[op.serverName] = d3ServerDetail
 
 	jsonData, err := json.MarshalIndent(rootConfig, "", "  ")
 	if err != nil {
@@ -136,28 +158,41 @@ func (op *DefaultFileOperator) EnsureIgnoreFileEntries(fs ports.FileSystem, igno
 // EnsureRootGitignoreEntries manages D3-specific entries in the root .gitignore file.
 func (op *DefaultFileOperator) EnsureRootGitignoreEntries(fs ports.FileSystem, projectRootAbs string) error {
 	gitignorePath := filepath.Join(projectRootAbs, ".gitignore")
-
-	// These are the patterns we want to ensure are in the gitignore file
-	d3Patterns := []string{
-		".cursor/rules/d3/",          // d3 rules directory
-		".cursor/rules/d3/*.gen.mdc", // generated rule files
-		".d3/.feature",               // active feature marker
-		".d3/features/*/.phase",      // phase markers
-	}
-
-	return op.EnsureIgnoreFileEntries(fs, gitignorePath, d3Patterns, D3IgnoreSectionMarker)
+	return op.EnsureIgnoreFileEntries(fs, gitignorePath, op.gitignorePatterns, D3IgnoreSectionMarker)
 }
 
 // EnsureRootCursorignoreEntries manages D3-specific entries in the root .cursorignore file.
 func (op *DefaultFileOperator) EnsureRootCursorignoreEntries(fs ports.FileSystem, projectRootAbs string) error {
 	cursorignorePath := filepath.Join(projectRootAbs, ".cursorignore")
+	return op.EnsureIgnoreFileEntries(fs, cursorignorePath, op.cursorignorePatterns, D3IgnoreSectionMarker)
+}
 
-	// These are the patterns we want to ensure are in the cursorignore file
-	d3Patterns := []string{
-		".d3/templates/",
+// MissingIgnoreEntries reports whether content has a section starting with sectionMarker and
+// which of patterns that section lacks. The section ends where updateIgnoreFileContent would
+// end it: at a blank line or at a comment that does not mention d3.
+func MissingIgnoreEntries(content []byte, patterns []string, sectionMarker string) (hasSection bool, missing []string) {
+	present := make(map[string]bool)
+	inSection := false
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == sectionMarker:
+			inSection = true
+			hasSection = true
+		case inSection && (line == "" || (strings.HasPrefix(line, "#") && !strings.Contains(line, "d3"))):
+			inSection = false
+		case inSection:
+			present[line] = true
+		}
 	}
 
-	return op.EnsureIgnoreFileEntries(fs, cursorignorePath, d3Patterns, D3IgnoreSectionMarker)
+	for _, pattern := range patterns {
+		if !present[pattern] {
+			missing = append(missing, pattern)
+		}
+	}
+	return hasSection, missing
 }
 
 // updateIgnoreFileContent handles updating an existing ignore file
@@ -238,3 +273,12 @@ func (op *DefaultFileOperator) EnsureProjectFiles(fs ports.FileSystem, d3DirAbs
 
 	return nil
 }
+
+// log returns the operator's logger, falling back to the default logger for a zero
+// DefaultFileOperator.
+func (op *DefaultFileOperator) log() *slog.Logger {
+	if op.logger == nil {
+		return slog.Default()
+	}
+	return op.logger
+}
@@ -10,7 +10,9 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/imcclaskey/d3/internal/core/config"
 	portsmocks "github.com/imcclaskey/d3/internal/core/ports/mocks"
+	"github.com/imcclaskey/d3/internal/testutil"
 )
 
 func TestEnsureMCPJSON(t *testing.T) {
@@ -29,7 +31,7 @@ func TestEnsureMCPJSON(t *testing.T) {
 			projectRoot: "/testroot",
 			expectedMCPConfig: &MCPRootConfig{
 				MCPServers: MCPServersMap{
-					D3ServerName: MCPServerDetail{
+					config.DefaultMCPServerName: MCPServerDetail{
 						Command: D3Command,
 						Args:    MCPServerArgs{fmt.Sprintf("%s%s", D3ServeArgPrefix, "/testroot")},
 					},
@@ -43,7 +45,7 @@ func TestEnsureMCPJSON(t *testing.T) {
 			readFileErr:       os.ErrNotExist,
 			expectedMCPConfig: &MCPRootConfig{
 				MCPServers: MCPServersMap{
-					D3ServerName: MCPServerDetail{
+					config.DefaultMCPServerName: MCPServerDetail{
 						Command: D3Command,
 						Args:    MCPServerArgs{fmt.Sprintf("%s%s", D3ServeArgPrefix, "/testroot2")},
 					},
@@ -68,8 +70,8 @@ func TestEnsureMCPJSON(t *testing.T) {
 			}`),
 			expectedMCPConfig: &MCPRootConfig{
 				MCPServers: MCPServersMap{
-					"otherServer": {Command: "other", Args: MCPServerArgs{"arg1"}},
-					D3ServerName:  {Command: D3Command, Args: MCPServerArgs{fmt.Sprintf("%s%s", D3ServeArgPrefix, "/testroot3")}},
+					"otherServer":               {Command: "other", Args: MCPServerArgs{"arg1"}},
+					config.DefaultMCPServerName: {Command: D3Command, Args: MCPServerArgs{fmt.Sprintf("%s%s", D3ServeArgPrefix, "/testroot3")}},
 				},
 			},
 		},
@@ -80,7 +82,7 @@ func TestEnsureMCPJSON(t *testing.T) {
 			initialMCPContent: []byte("{\"invalid_json\": ..."),
 			expectedMCPConfig: &MCPRootConfig{
 				MCPServers: MCPServersMap{
-					D3ServerName: MCPServerDetail{
+					config.DefaultMCPServerName: MCPServerDetail{
 						Command: D3Command,
 						Args:    MCPServerArgs{fmt.Sprintf("%s%s", D3ServeArgPrefix, "/testroot4")},
 					},
@@ -150,8 +152,8 @@ func TestEnsureMCPJSON(t *testing.T) {
 								if len(parsedConfig.MCPServers) != len(tt.expectedMCPConfig.MCPServers) {
 									t.Errorf("Expected %d server entries in written data, got %d", len(tt.expectedMCPConfig.MCPServers), len(parsedConfig.MCPServers))
 								}
-								d3Expected, okExpected := tt.expectedMCPConfig.MCPServers[D3ServerName]
-								d3ActualInWrite, okActualInWrite := parsedConfig.MCPServers[D3ServerName]
+								d3Expected, okExpected := tt.expectedMCPConfig.MCPServers[config.DefaultMCPServerName]
+								d3ActualInWrite, okActualInWrite := parsedConfig.MCPServers[config.DefaultMCPServerName]
 								if okExpected != okActualInWrite || (okExpected && (d3ActualInWrite.Command != d3Expected.Command || d3ActualInWrite.Args[0] != d3Expected.Args[0])) {
 									t.Errorf("Mismatched d3 entry in written data. Expected: %+v, Got: %+v", d3Expected, d3ActualInWrite)
 								}
@@ -178,8 +180,8 @@ func TestEnsureMCPJSON(t *testing.T) {
 				if len(capturedConfigForTest.MCPServers) != len(tt.expectedMCPConfig.MCPServers) {
 					t.Errorf("Captured config (from written file) has %d server entries, want %d", len(capturedConfigForTest.MCPServers), len(tt.expectedMCPConfig.MCPServers))
 				}
-				d3Expected, okExpected := tt.expectedMCPConfig.MCPServers[D3ServerName]
-				d3ActualReturned, okActualReturned := capturedConfigForTest.MCPServers[D3ServerName]
+				d3Expected, okExpected := tt.expectedMCPConfig.MCPServers[config.DefaultMCPServerName]
+				d3ActualReturned, okActualReturned := capturedConfigForTest.MCPServers[config.DefaultMCPServerName]
 				if okExpected != okActualReturned || (okExpected && (d3ActualReturned.Command != d3Expected.Command || d3ActualReturned.Args[0] != d3Expected.Args[0])) {
 					t.Errorf("Captured d3 entry (from written file) mismatch. Expected: %+v, Got: %+v", d3Expected, d3ActualReturned)
 				}
@@ -189,6 +191,35 @@ func TestEnsureMCPJSON(t *testing.T) {
 }
 
 // TestEnsureRootGitignoreEntries tests the EnsureRootGitignoreEntries function
+func TestNewFileOperator_UsesConfig(t *testing.T) {
+	cfg := config.Default("/p")
+	cfg.MCPServerName = "d3-docs"
+	cfg.GitignorePatterns = []string{"docs/features/*/.phase"}
+	memFS := testutil.NewMemFS()
+	memFS.MkdirAll("/p/.cursor", 0755)
+	op := NewFileOperator(cfg)
+
+	if err := op.EnsureMCPJSON(memFS, "/p"); err != nil {
+		t.Fatalf("EnsureMCPJSON() error = %v", err)
+	}
+	data, _ := memFS.ReadFile("/p/.cursor/mcp.json")
+	var written MCPRootConfig
+	if err := json.Unmarshal(data, &written); err != nil {
+		t.Fatalf("EnsureMCPJSON() wrote invalid JSON: %v", err)
+	}
+	if _, ok := written.MCPServers["d3-docs"]; !ok || len(written.MCPServers) != 1 {
+		t.Errorf("EnsureMCPJSON() servers = %v, want only d3-docs", written.MCPServers)
+	}
+
+	if err := op.EnsureRootGitignoreEntries(memFS, "/p"); err != nil {
+		t.Fatalf("EnsureRootGitignoreEntries() error = %v", err)
+	}
+	data, _ = memFS.ReadFile("/p/.gitignore")
+	if string(data) != "# d3\ndocs/features/*/.phase\n" {
+		t.Errorf("EnsureRootGitignoreEntries() wrote %q", data)
+	}
+}
+
 func TestEnsureRootGitignoreEntries(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -602,3 +633,30 @@ other/pattern
 		})
 	}
 }
+
+func TestMissingIgnoreEntries(t *testing.T) {
+	patterns := []string{".d3/.feature", ".d3/.trash/"}
+	tests := []struct {
+		name        string
+		content     string
+		wantSection bool
+		wantMissing []string
+	}{
+		{name: "empty file", content: "", wantSection: false, wantMissing: patterns},
+		{name: "complete section", content: "node_modules/\n\n# d3\n.d3/.feature\n.d3/.trash/\n", wantSection: true},
+		{name: "section missing an entry", content: "# d3\n.d3/.feature\n", wantSection: true, wantMissing: []string{".d3/.trash/"}},
+		{name: "entries outside the section do not count", content: "# d3\n.d3/.feature\n\n.d3/.trash/\n", wantSection: true, wantMissing: []string{".d3/.trash/"}},
+		{name: "no section", content: ".d3/.feature\n.d3/.trash/\n", wantSection: false, wantMissing: patterns},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hasSection, missing := MissingIgnoreEntries([]byte(tt.content), patterns, D3IgnoreSectionMarker)
+			if hasSection != tt.wantSection {
+				t.Errorf("MissingIgnoreEntries() hasSection = %v, want %v", hasSection, tt.wantSection)
+			}
+			if strings.Join(missing, ",") != strings.Join(tt.wantMissing, ",") {
+				t.Errorf("MissingIgnoreEntries() missing = %v, want %v", missing, tt.wantMissing)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/imcclaskey/d3/internal/core/d3err"
+	"github.com/imcclaskey/d3/internal/core/phase"
+)
+
+// Format selects the output encoding of a report.
+type Format string
+
+const (
+	FormatTable    Format = "table"
+	FormatMarkdown Format = "markdown"
+	FormatJSON     Format = "json"
+)
+
+// ParseFormat validates a format name, accepting "md" as shorthand for markdown.
+func ParseFormat(value string) (Format, error) {
+	switch f := Format(strings.ToLower(strings.TrimSpace(value))); f {
+	case "", FormatTable:
+		return FormatTable, nil
+	case "md", FormatMarkdown:
+		return FormatMarkdown, nil
+	case FormatJSON:
+		return f, nil
+	default:
+		return "", d3err.New(d3err.InvalidArgument, "invalid report format %q: expected table, markdown or json", value)
+	}
+}
+
+// Render encodes a report in the given format.
+func Render(r *Report, format Format) (string, error) {
+	switch format {
+	case FormatTable:
+		return renderTable(r)
+	case FormatMarkdown:
+		return renderMarkdown(r), nil
+	case FormatJSON:
+		data, err := json.MarshalIndent(r, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("failed to encode report: %w", err)
+		}
+		return string(data) + "\n", nil
+	default:
+		return "", fmt.Errorf("unsupported report format %q", format)
+	}
+}
+
+// renderTable lays out the report as aligned columns for the terminal.
+func renderTable(r *Report) (string, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n\n", summaryLine(r))
+
+	if len(r.Features) == 0 {
+		b.WriteString("No features.\n")
+		return b.String(), nil
+	}
+
+	tw := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "FEATURE\tPHASE\tIN PHASE\tLAST CHANGE\tTASKS\tFLAGS")
+	for _, f := range r.Features {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			f.Name, phaseLabel(f.Phase), age(f.PhaseSince, r.GeneratedAt), age(f.LastModified, r.GeneratedAt), tasks(f), flags(f))
+	}
+	if err := tw.Flush(); err != nil {
+		return "", fmt.Errorf("failed to render report table: %w", err)
+	}
+
+	if warnings := warningLines(r); len(warnings) > 0 {
+		b.WriteString("\nWarnings:\n")
+		for _, w := range warnings {
+			b.WriteString("  " + w + "\n")
+		}
+	}
+	return b.String(), nil
+}
+
+// renderMarkdown lays out the report as a markdown document.
+func renderMarkdown(r *Report) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# d3 Report\n\n_Generated %s._ %s\n", r.GeneratedAt.Format(time.RFC3339), summaryLine(r))
+
+	b.WriteString("\n## Features\n\n")
+	if len(r.Features) == 0 {
+		b.WriteString("_No features._\n")
+		return b.String()
+	}
+	b.WriteString("| Feature | Phase | In phase | Last change | Tasks | Flags |\n")
+	b.WriteString("|---------|-------|----------|-------------|-------|-------|\n")
+	for _, f := range r.Features {
+		fmt.Fprintf(&b, "| %s | %s | %s | %s | %s | %s |\n",
+			f.Name, phaseLabel(f.Phase), age(f.PhaseSince, r.GeneratedAt), age(f.LastModified, r.GeneratedAt), tasks(f), flags(f))
+	}
+
+	if warnings := warningLines(r); len(warnings) > 0 {
+		b.WriteString("\n## Needs Attention\n\n")
+		for _, w := range warnings {
+			b.WriteString("- " + strings.ReplaceAll(w, "|", `\|`) + "\n")
+		}
+	}
+	return b.String()
+}
+
+// summaryLine states the feature count per phase and the stale count.
+func summaryLine(r *Report) string {
+	total := 0
+	for _, n := range r.PhaseCounts {
+		total += n
+	}
+	parts := []string{}
+	for _, p := range phase.Lifecycle {
+		parts = append(parts, fmt.Sprintf("%s %d", p, r.PhaseCounts[string(p)]))
+	}
+	if n := r.PhaseCounts["none"]; n > 0 {
+		parts = append(parts, fmt.Sprintf("none %d", n))
+	}
+	return fmt.Sprintf("%d features (%s); %d stale (no changes in %s).", total, strings.Join(parts, ", "), r.StaleCount(), r.StaleAfter)
+}
+
+// warningLines prefixes each feature warning with the feature name.
+func warningLines(r *Report) []string {
+	var lines []string
+	for _, f := range r.Features {
+		for _, w := range f.Warnings {
+			lines = append(lines, f.Name+": "+w)
+		}
+	}
+	return lines
+}
+
+// age renders the time elapsed since t in days, or "-" when unknown.
+func age(t *time.Time, now time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	days := int(now.Sub(*t).Hours() / 24)
+	if days < 1 {
+		return "<1d"
+	}
+	return fmt.Sprintf("%dd", days)
+}
+
+// tasks renders task completion, or "-" when the feature has no tasks.
+func tasks(f FeatureSummary) string {
+	if f.TasksTotal == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%d/%d", f.TasksDone, f.TasksTotal)
+}
+
+// flags renders short markers for features that need attention.
+func flags(f FeatureSummary) string {
+	var out []string
+	if f.Stale {
+		out = append(out, "stale")
+	}
+	if len(f.Warnings) > 0 {
+		out = append(out, fmt.Sprintf("%d warning(s)", len(f.Warnings)))
+	}
+	if len(out) == 0 {
+		return "-"
+	}
+	return strings.Join(out, ", ")
+}
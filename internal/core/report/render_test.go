@@ -0,0 +1,92 @@
+package report
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/imcclaskey/d3/internal/core/d3err"
+)
+
+func sampleReport() *Report {
+	since := testNow.Add(-40 * 24 * time.Hour)
+	recent := testNow.Add(-2 * 24 * time.Hour)
+	return &Report{
+		GeneratedAt: testNow,
+		StaleAfter:  "14d",
+		PhaseCounts: map[string]int{"define": 0, "design": 1, "deliver": 1},
+		Features: []FeatureSummary{
+			{Name: "search", Phase: "design", PhaseSince: &since, LastModified: &since, Stale: true, Warnings: []string{"problem.md is empty but the feature is in design"}},
+			{Name: "billing", Phase: "deliver", PhaseSince: &recent, LastModified: &recent, TasksTotal: 4, TasksDone: 3},
+		},
+	}
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		format Format
+		want   []string
+	}{
+		{
+			format: FormatTable,
+			want: []string{
+				"2 features (define 0, design 1, deliver 1); 1 stale (no changes in 14d).",
+				"FEATURE  PHASE    IN PHASE  LAST CHANGE  TASKS  FLAGS",
+				"search   design   40d       40d          -      stale, 1 warning(s)",
+				"billing  deliver  2d        2d           3/4    -",
+				"Warnings:\n  search: problem.md is empty but the feature is in design",
+			},
+		},
+		{
+			format: FormatMarkdown,
+			want: []string{
+				"# d3 Report",
+				"| search | design | 40d | 40d | - | stale, 1 warning(s) |",
+				"| billing | deliver | 2d | 2d | 3/4 | - |",
+				"## Needs Attention\n\n- search: problem.md is empty",
+			},
+		},
+		{
+			format: FormatJSON,
+			want:   []string{`"stale_after": "14d"`, `"name": "search"`, `"tasks_done": 3`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			got, err := Render(sampleReport(), tt.format)
+			if err != nil {
+				t.Fatalf("Render() unexpected error: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("Render(%s) missing %q in:\n%s", tt.format, want, got)
+				}
+			}
+			if tt.format == FormatJSON && !json.Valid([]byte(got)) {
+				t.Errorf("Render(json) produced invalid JSON")
+			}
+		})
+	}
+}
+
+func TestRender_NoFeatures(t *testing.T) {
+	r := &Report{GeneratedAt: testNow, StaleAfter: "14d", PhaseCounts: map[string]int{}}
+	got, err := Render(r, FormatTable)
+	if err != nil || !strings.Contains(got, "No features.") {
+		t.Errorf("Render() = %q, %v", got, err)
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for input, want := range map[string]Format{"": FormatTable, "TABLE": FormatTable, "md": FormatMarkdown, "json": FormatJSON} {
+		if got, err := ParseFormat(input); err != nil || got != want {
+			t.Errorf("ParseFormat(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+	if _, err := ParseFormat("csv"); !errors.Is(err, d3err.ErrInvalidArgument) {
+		t.Errorf("ParseFormat(csv) error = %v, want ErrInvalidArgument", err)
+	}
+}
@@ -0,0 +1,242 @@
+// Package report summarizes every feature in a project: phase distribution, time in phase,
+// task completion and features that need attention.
+package report
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/imcclaskey/d3/internal/core/d3err"
+	"github.com/imcclaskey/d3/internal/core/feature"
+	"github.com/imcclaskey/d3/internal/core/phase"
+	"github.com/imcclaskey/d3/internal/core/ports"
+	"github.com/imcclaskey/d3/internal/core/progress"
+)
+
+// DefaultStaleAfter is how long a feature may go unmodified before it is flagged as stale.
+const DefaultStaleAfter = 14 * 24 * time.Hour
+
+// StaleAfterEnv names the environment variable that overrides DefaultStaleAfter.
+const StaleAfterEnv = "D3_STALE_AFTER"
+
+// ParseStaleAfter parses a staleness window such as "14d" or "72h".
+// In addition to time.ParseDuration units it accepts a "d" suffix for whole days.
+// An empty value yields DefaultStaleAfter.
+func ParseStaleAfter(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return DefaultStaleAfter, nil
+	}
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return 0, d3err.New(d3err.InvalidArgument, "invalid stale window %q: expected a positive number of days", value)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, d3err.New(d3err.InvalidArgument, "invalid stale window %q: %v", value, err)
+	}
+	if d <= 0 {
+		return 0, d3err.New(d3err.InvalidArgument, "invalid stale window %q: must be positive", value)
+	}
+	return d, nil
+}
+
+// FeatureSource is the subset of feature operations the report needs.
+type FeatureSource interface {
+	ListFeatures(ctx context.Context) ([]feature.FeatureInfo, error)
+	GetFeaturePhase(ctx context.Context, featureName string) (phase.Phase, error)
+}
+
+// FeatureSummary is one feature's line in the report.
+type FeatureSummary struct {
+	Name  string      `json:"name"`
+	Phase phase.Phase `json:"phase"`
+	// PhaseSince is when the feature entered its current phase, taken from its .phase file.
+	PhaseSince *time.Time `json:"phase_since,omitempty"`
+	// LastModified is the newest modification time of any file in the feature.
+	LastModified *time.Time `json:"last_modified,omitempty"`
+	TasksTotal   int        `json:"tasks_total"`
+	TasksDone    int        `json:"tasks_done"`
+	Stale        bool       `json:"stale"`
+	// Warnings lists problems that need attention, such as empty artifacts from earlier phases.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// Report is the project-wide summary.
+type Report struct {
+	GeneratedAt time.Time      `json:"generated_at"`
+	StaleAfter  string         `json:"stale_after"`
+	PhaseCounts map[string]int `json:"phase_counts"`
+	// Features are ordered by time in current phase, longest first.
+	Features []FeatureSummary `json:"features"`
+}
+
+// StaleCount returns the number of stale features.
+func (r *Report) StaleCount() int {
+	n := 0
+	for _, f := range r.Features {
+		if f.Stale {
+			n++
+		}
+	}
+	return n
+}
+
+// Build collects a summary of every feature. A feature whose data cannot be read is still
+// listed, with the problem recorded as a warning.
+func Build(ctx context.Context, fs ports.FileSystem, features FeatureSource, now time.Time, staleAfter time.Duration) (*Report, error) {
+	infos, err := features.ListFeatures(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list features: %w", err)
+	}
+
+	report := &Report{
+		GeneratedAt: now.UTC(),
+		StaleAfter:  formatDuration(staleAfter),
+		PhaseCounts: map[string]int{},
+		Features:    []FeatureSummary{},
+	}
+	for _, p := range phase.Lifecycle {
+		report.PhaseCounts[string(p)] = 0
+	}
+
+	for _, info := range infos {
+		summary := summarize(ctx, fs, features, info, now, staleAfter)
+		report.PhaseCounts[phaseLabel(summary.Phase)]++
+		report.Features = append(report.Features, summary)
+	}
+
+	sort.SliceStable(report.Features, func(i, j int) bool {
+		a, b := report.Features[i].PhaseSince, report.Features[j].PhaseSince
+		switch {
+		case a == nil || b == nil:
+			return a == nil && b != nil
+		case !a.Equal(*b):
+			return a.Before(*b)
+		default:
+			return report.Features[i].Name < report.Features[j].Name
+		}
+	})
+	return report, nil
+}
+
+// summarize gathers the report line for one feature.
+func summarize(ctx context.Context, fs ports.FileSystem, features FeatureSource, info feature.FeatureInfo, now time.Time, staleAfter time.Duration) FeatureSummary {
+	summary := FeatureSummary{Name: info.Name}
+
+	currentPhase, err := features.GetFeaturePhase(ctx, info.Name)
+	if err != nil {
+		summary.Warnings = append(summary.Warnings, err.Error())
+	}
+	summary.Phase = currentPhase
+
+	if stat, err := fs.Stat(filepath.Join(info.Path, ".phase")); err == nil {
+		since := stat.ModTime().UTC()
+		summary.PhaseSince = &since
+	}
+
+	lastModified, err := newestModTime(fs, info.Path)
+	if err != nil {
+		summary.Warnings = append(summary.Warnings, err.Error())
+	} else if !lastModified.IsZero() {
+		lastModified = lastModified.UTC()
+		summary.LastModified = &lastModified
+		summary.Stale = now.Sub(lastModified) > staleAfter
+	}
+
+	// Artifacts of phases the feature has moved past should have been written.
+	for _, p := range phase.Lifecycle {
+		if !p.Before(currentPhase) {
+			continue
+		}
+		artifact := phase.PhaseFileMap[p]
+		if empty, err := artifactEmpty(fs, filepath.Join(info.Path, string(p), artifact)); err != nil {
+			summary.Warnings = append(summary.Warnings, err.Error())
+		} else if empty {
+			summary.Warnings = append(summary.Warnings, fmt.Sprintf("%s is empty but the feature is in %s", artifact, currentPhase))
+		}
+	}
+
+	if currentPhase == phase.Deliver {
+		progressPath := filepath.Join(info.Path, string(phase.Deliver), phase.PhaseFileMap[phase.Deliver])
+		data, err := fs.ReadFile(progressPath)
+		if err != nil && !os.IsNotExist(err) {
+			summary.Warnings = append(summary.Warnings, fmt.Sprintf("failed to read %s: %v", progressPath, err))
+		} else if tasks, err := progress.Parse(data); err != nil {
+			summary.Warnings = append(summary.Warnings, err.Error())
+		} else {
+			summary.TasksTotal = len(tasks)
+			for _, task := range tasks {
+				if task.Done() {
+					summary.TasksDone++
+				}
+			}
+		}
+	}
+	return summary
+}
+
+// artifactEmpty reports whether a phase file is missing or contains only whitespace.
+func artifactEmpty(fs ports.FileSystem, path string) (bool, error) {
+	data, err := fs.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return true, nil
+		}
+		return false, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return strings.TrimSpace(string(data)) == "", nil
+}
+
+// newestModTime returns the latest modification time of any file below dir.
+func newestModTime(fs ports.FileSystem, dir string) (time.Time, error) {
+	entries, err := fs.ReadDir(dir)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+	var newest time.Time
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		var modTime time.Time
+		if entry.IsDir() {
+			if modTime, err = newestModTime(fs, path); err != nil {
+				return time.Time{}, err
+			}
+		} else {
+			info, err := entry.Info()
+			if err != nil {
+				return time.Time{}, fmt.Errorf("failed to stat %s: %w", path, err)
+			}
+			modTime = info.ModTime()
+		}
+		if modTime.After(newest) {
+			newest = modTime
+		}
+	}
+	return newest, nil
+}
+
+// phaseLabel returns the phase name, or "none" for a feature without a phase.
+func phaseLabel(p phase.Phase) string {
+	if p == phase.None {
+		return "none"
+	}
+	return string(p)
+}
+
+// formatDuration shows whole days as "Nd" and anything else in time.Duration form.
+func formatDuration(d time.Duration) string {
+	if d > 0 && d%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%dd", d/(24*time.Hour))
+	}
+	return d.String()
+}
@@ -0,0 +1,131 @@
+package report
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/imcclaskey/d3/internal/core/d3err"
+	"github.com/imcclaskey/d3/internal/core/feature"
+	"github.com/imcclaskey/d3/internal/testutil"
+)
+
+var testNow = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+// newTestProject builds an in-memory project and a feature service over it.
+func newTestProject(t *testing.T) (*testutil.MemFS, *feature.Service) {
+	t.Helper()
+	memFS := testutil.NewMemFS()
+	if err := memFS.MkdirAll("/p/.d3/features", 0755); err != nil {
+		t.Fatal(err)
+	}
+	return memFS, feature.NewService("/p", "/p/.d3/features", "/p/.d3", memFS)
+}
+
+// addFile writes a feature file last modified daysAgo days before testNow.
+func addFile(memFS *testutil.MemFS, path, content string, daysAgo int) {
+	memFS.AddFile("/p/.d3/features/"+path, content)
+	memFS.SetModTime("/p/.d3/features/"+path, testNow.Add(-time.Duration(daysAgo)*24*time.Hour))
+}
+
+func TestBuild(t *testing.T) {
+	memFS, features := newTestProject(t)
+
+	// In define for 3 days, recently edited
+	addFile(memFS, "login/.phase", "define", 3)
+	addFile(memFS, "login/define/problem.md", "# Problem", 1)
+
+	// In design for 40 days with an empty problem.md and no changes since
+	addFile(memFS, "search/.phase", "design", 40)
+	addFile(memFS, "search/define/problem.md", "  \n", 40)
+	addFile(memFS, "search/design/plan.md", "", 40)
+
+	// In deliver for 10 days with tasks
+	addFile(memFS, "billing/.phase", "deliver", 10)
+	addFile(memFS, "billing/define/problem.md", "# Problem", 20)
+	addFile(memFS, "billing/design/plan.md", "# Plan", 12)
+	addFile(memFS, "billing/deliver/progress.yaml", "- id: T1\n  description: a\n  status: done\n- id: T2\n  description: b\n  status: pending\n", 2)
+
+	r, err := Build(context.Background(), memFS, features, testNow, DefaultStaleAfter)
+	if err != nil {
+		t.Fatalf("Build() unexpected error: %v", err)
+	}
+
+	wantCounts := map[string]int{"define": 1, "design": 1, "deliver": 1}
+	for p, n := range wantCounts {
+		if r.PhaseCounts[p] != n {
+			t.Errorf("PhaseCounts[%s] = %d, want %d", p, r.PhaseCounts[p], n)
+		}
+	}
+
+	var order []string
+	for _, f := range r.Features {
+		order = append(order, f.Name)
+	}
+	if got := strings.Join(order, ","); got != "search,billing,login" {
+		t.Errorf("Build() order = %s, want longest in phase first: search,billing,login", got)
+	}
+
+	search, billing, login := r.Features[0], r.Features[1], r.Features[2]
+	if !search.Stale || billing.Stale || login.Stale {
+		t.Errorf("Stale flags = search %v billing %v login %v, want only search", search.Stale, billing.Stale, login.Stale)
+	}
+	if len(search.Warnings) != 1 || !strings.Contains(search.Warnings[0], "problem.md is empty but the feature is in design") {
+		t.Errorf("search warnings = %v", search.Warnings)
+	}
+	if len(billing.Warnings) != 0 || len(login.Warnings) != 0 {
+		t.Errorf("unexpected warnings: billing %v, login %v", billing.Warnings, login.Warnings)
+	}
+	if billing.TasksTotal != 2 || billing.TasksDone != 1 {
+		t.Errorf("billing tasks = %d/%d, want 1/2", billing.TasksDone, billing.TasksTotal)
+	}
+	if billing.LastModified == nil || !billing.LastModified.Equal(testNow.Add(-2*24*time.Hour)) {
+		t.Errorf("billing LastModified = %v, want newest file time", billing.LastModified)
+	}
+	if r.StaleCount() != 1 {
+		t.Errorf("StaleCount() = %d, want 1", r.StaleCount())
+	}
+}
+
+func TestBuild_ProgressParseWarning(t *testing.T) {
+	memFS, features := newTestProject(t)
+	addFile(memFS, "api/.phase", "deliver", 1)
+	addFile(memFS, "api/define/problem.md", "x", 1)
+	addFile(memFS, "api/design/plan.md", "x", 1)
+	addFile(memFS, "api/deliver/progress.yaml", "tasks: [", 1)
+
+	r, err := Build(context.Background(), memFS, features, testNow, DefaultStaleAfter)
+	if err != nil {
+		t.Fatalf("Build() unexpected error: %v", err)
+	}
+	if len(r.Features) != 1 || len(r.Features[0].Warnings) != 1 || !strings.Contains(r.Features[0].Warnings[0], "progress.yaml") {
+		t.Errorf("Build() features = %+v, want a progress.yaml warning", r.Features)
+	}
+}
+
+func TestParseStaleAfter(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{input: "", want: DefaultStaleAfter},
+		{input: "7d", want: 7 * 24 * time.Hour},
+		{input: "36h", want: 36 * time.Hour},
+		{input: "0d", wantErr: true},
+		{input: "-1h", wantErr: true},
+		{input: "soon", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseStaleAfter(tt.input)
+		if (err != nil) != tt.wantErr || (tt.wantErr && !errors.Is(err, d3err.ErrInvalidArgument)) {
+			t.Errorf("ParseStaleAfter(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseStaleAfter(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
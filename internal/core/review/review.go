@@ -0,0 +1,205 @@
+// Package review tracks the artifacts a backward phase change leaves potentially stale. Moving a
+// feature back to an earlier phase marks every later artifact: plan.md gets a stale marker comment
+// and the tasks of progress.yaml get "review: needed", keeping their status. The marks stay until the artifacts
+// are revisited, and Pending lists them so that the next forward move can say what to revisit.
+package review
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/imcclaskey/d3/internal/core/phase"
+	"github.com/imcclaskey/d3/internal/core/ports"
+	"github.com/imcclaskey/d3/internal/core/progress"
+)
+
+// staleMarker starts the comment that marks plan.md as stale.
+const staleMarker = "<!-- d3:stale"
+
+// Item is one artifact, or one task of an artifact, to revisit.
+type Item struct {
+	Phase phase.Phase `json:"phase"`
+	// File is the artifact's file name, such as "plan.md".
+	File string `json:"file"`
+	// Task is the ID of the task to review, for progress.yaml.
+	Task    string `json:"task,omitempty"`
+	Message string `json:"message"`
+}
+
+// String renders the item as "file: message", or "file task ID: message" for a task.
+func (i Item) String() string {
+	if i.Task != "" {
+		return fmt.Sprintf("%s task %s: %s", i.File, i.Task, i.Message)
+	}
+	return fmt.Sprintf("%s: %s", i.File, i.Message)
+}
+
+// Downstream returns the phases after target, whose artifacts a move back to target can leave
+// stale. Moving forward, or staying, has no downstream phases.
+func Downstream(from, to phase.Phase) []phase.Phase {
+	if !to.Before(from) {
+		return nil
+	}
+	return phase.Lifecycle[to.Index()+1:]
+}
+
+// Mark flags the artifacts of the phases after to, in the feature at featurePath, after a move
+// back from phase from: plan.md gets a stale marker and every task of progress.yaml
+// "review: needed". Missing and blank artifacts, and artifacts already marked, are left
+// alone. Mark returns a summary of what it flagged, such as "plan.md" or "3 task(s) in
+// progress.yaml", and the paths it wrote.
+func Mark(fs ports.FileSystem, featurePath string, from, to phase.Phase, now time.Time) ([]string, []string, error) {
+	var flagged, written []string
+	for _, p := range Downstream(from, to) {
+		file := artifactPath(featurePath, p)
+		data, err := fs.ReadFile(file)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return flagged, written, fmt.Errorf("failed to read %s: %w", phase.PhaseFileMap[p], err)
+		}
+		if strings.TrimSpace(string(data)) == "" {
+			continue
+		}
+
+		var marked []byte
+		switch p {
+		case phase.Design:
+			if _, stale := StaleReason(string(data)); stale {
+				continue
+			}
+			marked = []byte(MarkStale(string(data), from, to, now))
+			flagged = append(flagged, phase.PhaseFileMap[p])
+		case phase.Deliver:
+			var changed int
+			marked, changed, err = progress.MarkNeedsReview(data)
+			if err != nil {
+				return flagged, written, err
+			}
+			if changed == 0 {
+				continue
+			}
+			flagged = append(flagged, fmt.Sprintf("%d task(s) in %s", changed, phase.PhaseFileMap[p]))
+		default:
+			continue
+		}
+		if err := fs.WriteFile(file, marked, 0644); err != nil {
+			return flagged, written, fmt.Errorf("failed to mark %s: %w", phase.PhaseFileMap[p], err)
+		}
+		written = append(written, file)
+	}
+	return flagged, written, nil
+}
+
+// MarkStale prepends the stale marker comment to plan.md content.
+func MarkStale(content string, from, to phase.Phase, now time.Time) string {
+	marker := fmt.Sprintf("%s since %s: the feature moved back from %s to %s. Revisit this plan against problem.md, then delete this comment. -->",
+		staleMarker, now.UTC().Format("2006-01-02"), from, to)
+	return marker + "\n" + content
+}
+
+// StaleReason returns the text of the stale marker comment in content, if it has one.
+func StaleReason(content string) (string, bool) {
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, staleMarker) {
+			continue
+		}
+		reason := strings.TrimSuffix(strings.TrimPrefix(line, staleMarker), "-->")
+		return strings.TrimSpace(reason), true
+	}
+	return "", false
+}
+
+// Pending lists what still needs revisiting in the artifacts of the given phases: a stale
+// plan.md and the tasks of progress.yaml flagged "review: needed". Unreadable task lists are
+// reported as errors; missing artifacts have nothing pending.
+func Pending(fs ports.FileSystem, featurePath string, phases []phase.Phase) ([]Item, error) {
+	items := []Item{}
+	for _, p := range phases {
+		data, err := fs.ReadFile(artifactPath(featurePath, p))
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", phase.PhaseFileMap[p], err)
+		}
+
+		switch p {
+		case phase.Design:
+			if reason, stale := StaleReason(string(data)); stale {
+				items = append(items, Item{Phase: p, File: phase.PhaseFileMap[p], Message: "marked stale " + reason})
+			}
+		case phase.Deliver:
+			tasks, err := progress.Parse(data)
+			if err != nil {
+				return nil, err
+			}
+			for _, task := range tasks {
+				if task.NeedsReview() {
+					items = append(items, Item{Phase: p, File: phase.PhaseFileMap[p], Task: task.ID, Message: fmt.Sprintf("%q needs review", task.Description)})
+				}
+			}
+		}
+	}
+	return items, nil
+}
+
+// From returns the phases from p on, whose artifacts a feature entering p will revisit now or
+// later.
+func From(p phase.Phase) []phase.Phase {
+	return phase.Lifecycle[p.Index():]
+}
+
+// Summary condenses items into one clause per artifact, such as "plan.md is marked stale" and
+// "tasks 1, 2 in progress.yaml need review".
+func Summary(items []Item) []string {
+	var summary, tasks []string
+	for _, item := range items {
+		if item.Task != "" {
+			tasks = append(tasks, item.Task)
+			continue
+		}
+		summary = append(summary, item.File+" is marked stale")
+	}
+	switch len(tasks) {
+	case 0:
+	case 1:
+		summary = append(summary, fmt.Sprintf("task %s in %s needs review", tasks[0], phase.PhaseFileMap[phase.Deliver]))
+	default:
+		summary = append(summary, fmt.Sprintf("tasks %s in %s need review", strings.Join(tasks, ", "), phase.PhaseFileMap[phase.Deliver]))
+	}
+	return summary
+}
+
+// RuleSection renders the items as a section for a generated phase rule, telling the assistant
+// what to revisit and how to clear the marks. No items yield an empty string.
+func RuleSection(items []Item) string {
+	if len(items) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("\n## Needs Revisiting\n\n")
+	b.WriteString("The feature moved back to an earlier phase after these artifacts were written, so they may no longer match it. Revisit them before continuing:\n\n")
+	stalePlan, tasks := false, false
+	for _, item := range items {
+		fmt.Fprintf(&b, "*   %s\n", item.String())
+		stalePlan = stalePlan || item.Task == ""
+		tasks = tasks || item.Task != ""
+	}
+	b.WriteString("\n")
+	if stalePlan {
+		b.WriteString("Once plan.md matches problem.md again, delete its `d3:stale` comment.\n")
+	}
+	if tasks {
+		b.WriteString("Delete the task's `review` field once it is reviewed against plan.md, or remove the task if the plan dropped it.\n")
+	}
+	return b.String()
+}
+
+// artifactPath returns the path of a phase's artifact in the feature at featurePath.
+func artifactPath(featurePath string, p phase.Phase) string {
+	return filepath.Join(featurePath, string(p), phase.PhaseFileMap[p])
+}
@@ -0,0 +1,90 @@
+package review
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/imcclaskey/d3/internal/core/phase"
+	"github.com/imcclaskey/d3/internal/testutil"
+)
+
+const featurePath = "/p/.d3/features/login"
+
+var now = time.Date(2026, 10, 18, 14, 0, 0, 0, time.UTC)
+
+func TestDownstream(t *testing.T) {
+	tests := []struct {
+		from, to phase.Phase
+		want     []phase.Phase
+	}{
+		{phase.Deliver, phase.Design, []phase.Phase{phase.Deliver}},
+		{phase.Design, phase.Define, []phase.Phase{phase.Design, phase.Deliver}},
+		{phase.Deliver, phase.Define, []phase.Phase{phase.Design, phase.Deliver}},
+		{phase.Define, phase.Design, nil},
+	}
+	for _, tt := range tests {
+		if got := Downstream(tt.from, tt.to); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Downstream(%s, %s) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestMarkAndPending(t *testing.T) {
+	memFS := testutil.NewMemFS()
+	memFS.AddFile(featurePath+"/design/plan.md", "## Delivery Steps\n- S1 [code] Add the form\n")
+	memFS.AddFile(featurePath+"/deliver/progress.yaml", "- id: 1\n  description: Add the form\n  status: complete\n- id: 2\n  description: Run the tests\n  status: pending\n")
+
+	flagged, written, err := Mark(memFS, featurePath, phase.Deliver, phase.Define, now)
+	if err != nil {
+		t.Fatalf("Mark() error = %v", err)
+	}
+	if !reflect.DeepEqual(flagged, []string{"plan.md", "2 task(s) in progress.yaml"}) || len(written) != 2 {
+		t.Errorf("Mark() = %v, %v", flagged, written)
+	}
+	plan, _ := memFS.ReadFile(featurePath + "/design/plan.md")
+	if !strings.HasPrefix(string(plan), "<!-- d3:stale since 2026-10-18: the feature moved back from deliver to define.") {
+		t.Errorf("plan.md = %q, want a stale marker first", plan)
+	}
+
+	// Marks are not stacked
+	if flagged, _, err := Mark(memFS, featurePath, phase.Design, phase.Define, now); err != nil || len(flagged) != 0 {
+		t.Errorf("Mark() again = %v, %v, want nothing new", flagged, err)
+	}
+
+	items, err := Pending(memFS, featurePath, From(phase.Design))
+	if err != nil {
+		t.Fatalf("Pending() error = %v", err)
+	}
+	want := []Item{
+		{Phase: phase.Design, File: "plan.md", Message: "marked stale since 2026-10-18: the feature moved back from deliver to define. Revisit this plan against problem.md, then delete this comment."},
+		{Phase: phase.Deliver, File: "progress.yaml", Task: "1", Message: `"Add the form" needs review`},
+		{Phase: phase.Deliver, File: "progress.yaml", Task: "2", Message: `"Run the tests" needs review`},
+	}
+	if !reflect.DeepEqual(items, want) {
+		t.Errorf("Pending() =\n%+v\nwant\n%+v", items, want)
+	}
+	if got := Summary(items); !reflect.DeepEqual(got, []string{"plan.md is marked stale", "tasks 1, 2 in progress.yaml need review"}) {
+		t.Errorf("Summary() = %v", got)
+	}
+
+	section := RuleSection(items)
+	for _, want := range []string{"## Needs Revisiting", "*   progress.yaml task 2: \"Run the tests\" needs review", "delete its `d3:stale` comment", "Delete the task's `review` field once it is reviewed against plan.md"} {
+		if !strings.Contains(section, want) {
+			t.Errorf("RuleSection() = %q, want it to contain %q", section, want)
+		}
+	}
+	if RuleSection(nil) != "" {
+		t.Error("RuleSection() without items is not empty")
+	}
+}
+
+func TestMark_SkipsMissingAndBlankArtifacts(t *testing.T) {
+	memFS := testutil.NewMemFS()
+	memFS.AddFile(featurePath+"/design/plan.md", "\n")
+	flagged, written, err := Mark(memFS, featurePath, phase.Deliver, phase.Define, now)
+	if err != nil || len(flagged) != 0 || len(written) != 0 {
+		t.Errorf("Mark() = %v, %v, %v, want nothing marked", flagged, written, err)
+	}
+}
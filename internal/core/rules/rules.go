@@ -2,11 +2,16 @@ package rules
 
 import (
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/imcclaskey/d3/internal/core/config"
+	"github.com/imcclaskey/d3/internal/core/d3err"
+	"github.com/imcclaskey/d3/internal/core/phase"
 	"github.com/imcclaskey/d3/internal/core/ports"
+	"github.com/imcclaskey/d3/internal/core/review"
 )
 
 // Generator defines the interface for rule content generation
@@ -18,31 +23,52 @@ type Generator interface {
 	GeneratePrefix(feature, phase string) string
 }
 
+// CustomDirName is the directory inside .d3 holding custom templates and schema overrides.
+const CustomDirName = "rules"
+
 // RuleGenerator generates rule content
 type RuleGenerator struct {
 	projectRoot string
+	d3Dir       string
+	featuresDir string
 	fs          ports.FileSystem
 }
 
-// NewRuleGenerator creates a new rule generator
-func NewRuleGenerator(projectRoot string, fs ports.FileSystem) *RuleGenerator {
+// NewRuleGenerator creates a new rule generator for the resolved project configuration. A zero
+// configuration disables custom templates and the "Needs Revisiting" section of phase rules.
+func NewRuleGenerator(cfg config.Config, fs ports.FileSystem) *RuleGenerator {
 	return &RuleGenerator{
-		projectRoot: projectRoot,
+		projectRoot: cfg.ProjectRoot,
+		d3Dir:       cfg.D3Dir,
+		featuresDir: cfg.FeaturesDir,
 		fs:          fs,
 	}
 }
 
 // getCustomTemplateDir returns the path to the custom templates directory
 func (g *RuleGenerator) getCustomTemplateDir() string {
-	if g.projectRoot == "" {
+	if g.d3Dir == "" {
 		return ""
 	}
-	return filepath.Join(g.projectRoot, ".d3", "rules")
+	return filepath.Join(g.d3Dir, CustomDirName)
+}
+
+// featureDir returns the feature's directory relative to the project root, with forward
+// slashes, for the links of generated rules.
+func (g *RuleGenerator) featureDir(feature string) string {
+	featuresDir := config.DefaultFeaturesDir
+	if g.featuresDir != "" {
+		featuresDir = g.featuresDir
+		if rel, err := filepath.Rel(g.projectRoot, g.featuresDir); err == nil && g.projectRoot != "" {
+			featuresDir = rel
+		}
+	}
+	return filepath.ToSlash(filepath.Join(featuresDir, feature))
 }
 
 // tryReadCustomTemplate attempts to read a custom template file
 func (g *RuleGenerator) tryReadCustomTemplate(templateName string) (string, bool, error) {
-	if g.fs == nil || g.projectRoot == "" {
+	if g.fs == nil || g.d3Dir == "" {
 		return "", false, nil
 	}
 
@@ -83,16 +109,31 @@ func (g *RuleGenerator) GeneratePhaseContent(feature, phase string) (string, err
 	if !exists {
 		template, exists = Templates[phase]
 		if !exists {
-			return "", fmt.Errorf("template for phase '%s' not found", phase)
+			return "", d3err.New(d3err.InvalidPhase, "template for phase '%s' not found", phase)
 		}
 	}
 
 	// Render template with replacements
 	rendered := template
+	rendered = strings.ReplaceAll(rendered, "{{feature_dir}}", g.featureDir(feature))
 	rendered = strings.ReplaceAll(rendered, "{{feature}}", feature)
 	rendered = strings.ReplaceAll(rendered, "{{phase}}", phase)
 
-	return rendered, nil
+	return rendered + g.reviewSection(feature, phase), nil
+}
+
+// reviewSection lists what still needs revisiting in the phase's own artifact. A task list that
+// cannot be read leaves the section out rather than failing rule generation, since the assistant
+// edits it by hand.
+func (g *RuleGenerator) reviewSection(feature, p string) string {
+	if g.fs == nil || g.featuresDir == "" || feature == "" {
+		return ""
+	}
+	items, err := review.Pending(g.fs, filepath.Join(g.featuresDir, feature), []phase.Phase{phase.Phase(p)})
+	if err != nil {
+		return ""
+	}
+	return review.RuleSection(items)
 }
 
 // GenerateCoreContent generates the core rule content with the current context
@@ -111,7 +152,7 @@ func (g *RuleGenerator) GenerateCoreContent(feature, phase string) (string, erro
 	if !exists {
 		coreTemplate, exists = Templates["core"]
 		if !exists {
-			return "", fmt.Errorf("core template not found")
+			return "", d3err.New(d3err.InvalidPhase, "core template not found")
 		}
 	}
 
@@ -134,6 +175,14 @@ func (g *RuleGenerator) GeneratePrefix(feature, phase string) string {
 	return fmt.Sprintf("%s - %s", feature, phase)
 }
 
+// Generated rule files live in GeneratedDirName under the Cursor rules directory.
+const (
+	GeneratedDirName     = "d3"
+	CoreRuleFileName     = "core.gen.mdc"
+	PhaseRuleFileName    = "phase.gen.mdc"
+	GeneratedRulePattern = "*.gen.mdc"
+)
+
 // Service provides rule management operations
 type Service struct {
 	projectRoot    string
@@ -141,25 +190,31 @@ type Service struct {
 	customRulesDir string
 	generator      Generator
 	fs             ports.FileSystem
+	logger         *slog.Logger
 }
 
-// NewService creates a new rules service
-func NewService(projectRoot, cursorRulesDir string, generator Generator, fs ports.FileSystem) *Service {
-	customRulesDir := filepath.Join(projectRoot, ".d3", "rules")
+// NewService creates a new rules service for the resolved project configuration
+func NewService(cfg config.Config, generator Generator, fs ports.FileSystem) *Service {
 	return &Service{
-		projectRoot:    projectRoot,
-		cursorRulesDir: cursorRulesDir,
-		customRulesDir: customRulesDir,
+		projectRoot:    cfg.ProjectRoot,
+		cursorRulesDir: cfg.CursorRulesDir,
+		customRulesDir: filepath.Join(cfg.D3Dir, CustomDirName),
 		generator:      generator,
 		fs:             fs,
+		logger:         slog.Default(),
 	}
 }
 
+// SetLogger replaces the logger that receives warnings and notices from rule operations.
+func (s *Service) SetLogger(logger *slog.Logger) {
+	s.logger = logger
+}
+
 // RefreshRules generates core rule files based on current state
 func (s *Service) RefreshRules(feature string, phase string) error {
 
 	// Ensure d3 directory exists
-	d3Dir := filepath.Join(s.cursorRulesDir, "d3")
+	d3Dir := filepath.Join(s.cursorRulesDir, GeneratedDirName)
 	if err := s.fs.MkdirAll(d3Dir, 0755); err != nil {
 		return fmt.Errorf("failed to create rule directory: %w", err)
 	}
@@ -171,13 +226,13 @@ func (s *Service) RefreshRules(feature string, phase string) error {
 			return fmt.Errorf("failed to generate core rule: %w", err)
 		}
 		// Write core rule file
-		corePath := filepath.Join(d3Dir, "core.gen.mdc")
+		corePath := filepath.Join(d3Dir, CoreRuleFileName)
 		if err := s.fs.WriteFile(corePath, []byte(coreContent), 0644); err != nil {
 			return fmt.Errorf("failed to write core rule file: %w", err)
 		}
 	} else {
 		// Delete core rule file if it exists
-		corePath := filepath.Join(d3Dir, "core.gen.mdc")
+		corePath := filepath.Join(d3Dir, CoreRuleFileName)
 		if err := s.fs.Remove(corePath); err != nil && !os.IsNotExist(err) {
 			return fmt.Errorf("failed to delete core rule file: %w", err)
 		}
@@ -191,25 +246,93 @@ func (s *Service) RefreshRules(feature string, phase string) error {
 		}
 
 		// Write phase rule file
-		phasePath := filepath.Join(d3Dir, "phase.gen.mdc")
+		phasePath := filepath.Join(d3Dir, PhaseRuleFileName)
 		if err := s.fs.WriteFile(phasePath, []byte(phaseContent), 0644); err != nil {
 			return fmt.Errorf("failed to write phase rule file: %w", err)
 		}
 	} else {
 		// Delete phase rule file if it exists
-		phasePath := filepath.Join(d3Dir, "phase.gen.mdc")
+		phasePath := filepath.Join(d3Dir, PhaseRuleFileName)
 		if err := s.fs.Remove(phasePath); err != nil && !os.IsNotExist(err) {
 			return fmt.Errorf("failed to delete phase rule file: %w", err)
 		}
 	}
 
+	s.logger.Debug("refreshed generated rules", "feature", feature, "phase", phase, "dir", d3Dir)
 	return nil
 }
 
+// SyncRules reconciles the generated rule files with the given feature and phase. It computes
+// the rules RefreshRules would write, rewrites only the files whose content differs and removes
+// any other generated files, such as rules left behind when no feature is active. It returns the
+// paths it wrote or removed, which is empty when the rules were already up to date.
+func (s *Service) SyncRules(feature string, phase string) ([]string, error) {
+	d3Dir := filepath.Join(s.cursorRulesDir, GeneratedDirName)
+
+	expected := map[string]string{}
+	if feature != "" {
+		coreContent, err := s.generator.GenerateCoreContent(feature, phase)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate core rule: %w", err)
+		}
+		expected[filepath.Join(d3Dir, CoreRuleFileName)] = coreContent
+
+		if phase == "define" || phase == "design" || phase == "deliver" {
+			phaseContent, err := s.generator.GeneratePhaseContent(feature, phase)
+			if err != nil {
+				return nil, fmt.Errorf("failed to generate phase rule: %w", err)
+			}
+			expected[filepath.Join(d3Dir, PhaseRuleFileName)] = phaseContent
+		}
+	}
+
+	existing, err := s.fs.Glob(filepath.Join(d3Dir, GeneratedRulePattern))
+	if err != nil {
+		return nil, fmt.Errorf("error finding generated rule files in %s: %w", d3Dir, err)
+	}
+
+	var changed []string
+	for _, path := range existing {
+		if _, ok := expected[path]; ok {
+			continue
+		}
+		if err := s.fs.Remove(path); err != nil && !os.IsNotExist(err) {
+			return changed, fmt.Errorf("failed to remove rule file %s: %w", path, err)
+		}
+		changed = append(changed, path)
+	}
+
+	if len(expected) > 0 {
+		if err := s.fs.MkdirAll(d3Dir, 0755); err != nil {
+			return changed, fmt.Errorf("failed to create rule directory: %w", err)
+		}
+	}
+	// Write in a fixed order so that results and logs are stable
+	for _, name := range []string{CoreRuleFileName, PhaseRuleFileName} {
+		path := filepath.Join(d3Dir, name)
+		content, ok := expected[path]
+		if !ok {
+			continue
+		}
+		if current, err := s.fs.ReadFile(path); err == nil && string(current) == content {
+			continue
+		}
+		if err := s.fs.WriteFile(path, []byte(content), 0644); err != nil {
+			return changed, fmt.Errorf("failed to write rule file %s: %w", path, err)
+		}
+		changed = append(changed, path)
+	}
+
+	if len(changed) > 0 {
+		s.logger.Debug("synced generated rules", "feature", feature, "phase", phase, "changed", changed)
+	}
+	return changed, nil
+}
+
 // ClearGeneratedRules removes all files matching *.gen.mdc in the rule directory.
 func (s *Service) ClearGeneratedRules() error {
-	d3RuleDir := filepath.Join(s.cursorRulesDir, "d3")
-	pattern := filepath.Join(d3RuleDir, "*.gen.mdc")
+	d3RuleDir := filepath.Join(s.cursorRulesDir, GeneratedDirName)
+	pattern := filepath.Join(d3RuleDir, GeneratedRulePattern)
 
 	// Use the injected filesystem to find matching files
 	matches, err := s.fs.Glob(pattern)
@@ -223,7 +346,7 @@ func (s *Service) ClearGeneratedRules() error {
 		err := s.fs.Remove(match)
 		if err != nil {
 			// Log the error but continue trying to remove others
-			fmt.Fprintf(os.Stderr, "warning: failed to remove rule file %s: %v\n", match, err)
+			s.logger.Warn("failed to remove rule file", "path", match, "error", err)
 			// Store the first error encountered
 			if firstErr == nil {
 				firstErr = fmt.Errorf("failed to remove rule file %s: %w", match, err)
@@ -251,9 +374,10 @@ func (s *Service) InitCustomRulesDir() error {
 
 		templatePath := filepath.Join(s.customRulesDir, templateName+".md")
 
-		// Skip if file already exists to avoid overwriting user modifications
+		// Skip if file already exists to avoid overwriting user modifications. Logged as a warning
+		// so that the CLI, whose logger drops info records, still reports it
 		if _, err := s.fs.Stat(templatePath); err == nil {
-			fmt.Fprintf(os.Stderr, "Custom template %s already exists, skipping\n", templatePath)
+			s.logger.Warn("custom template already exists, skipping", "path", templatePath)
 			continue
 		} else if !os.IsNotExist(err) {
 			return fmt.Errorf("error checking template file %s: %w", templatePath, err)
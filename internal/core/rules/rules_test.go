@@ -1,17 +1,26 @@
 package rules
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
 	"testing"
 
 	_ "embed"
 
 	"github.com/golang/mock/gomock"
 
+	"github.com/imcclaskey/d3/internal/core/config"
+	"github.com/imcclaskey/d3/internal/core/d3err"
 	portsmocks "github.com/imcclaskey/d3/internal/core/ports/mocks"
 	rulesmocks "github.com/imcclaskey/d3/internal/core/rules/mocks" // Import generated mock for Generator
+	"github.com/imcclaskey/d3/internal/testutil"
 )
 
 func TestRuleGenerator_GeneratePrefix(t *testing.T) {
@@ -35,7 +44,7 @@ func TestRuleGenerator_GeneratePrefix(t *testing.T) {
 	// Create a mock filesystem since the constructor now requires it
 	ctrl := gomock.NewController(t)
 	mockFS := portsmocks.NewMockFileSystem(ctrl)
-	g := NewRuleGenerator("", mockFS)
+	g := NewRuleGenerator(config.Config{}, mockFS)
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -77,7 +86,7 @@ func TestRuleGenerator_GeneratePhaseContent(t *testing.T) {
 	// Create a mock filesystem since the constructor now requires it
 	ctrl := gomock.NewController(t)
 	mockFS := portsmocks.NewMockFileSystem(ctrl)
-	g := NewRuleGenerator("", mockFS)
+	g := NewRuleGenerator(config.Config{}, mockFS)
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -119,7 +128,7 @@ func TestRuleGenerator_GenerateCoreContent(t *testing.T) {
 	// Create a mock filesystem since the constructor now requires it
 	ctrl := gomock.NewController(t)
 	mockFS := portsmocks.NewMockFileSystem(ctrl)
-	g := NewRuleGenerator("", mockFS)
+	g := NewRuleGenerator(config.Config{}, mockFS)
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -138,8 +147,8 @@ func TestRuleGenerator_GenerateCoreContent(t *testing.T) {
 	t.Run("missing core template", func(t *testing.T) {
 		delete(Templates, "core") // Remove core template
 		_, err := g.GenerateCoreContent("any", "any")
-		if err == nil {
-			t.Errorf("GenerateCoreContent() did not return error when core template was missing")
+		if !errors.Is(err, d3err.ErrInvalidPhase) {
+			t.Errorf("GenerateCoreContent() error = %v, want ErrInvalidPhase when core template was missing", err)
 		}
 	})
 }
@@ -222,8 +231,8 @@ func TestRuleGenerator_tryReadCustomTemplate(t *testing.T) {
 			}
 
 			g := &RuleGenerator{
-				projectRoot: projectRoot,
-				fs:          mockFS,
+				d3Dir: filepath.Join(projectRoot, ".d3"),
+				fs:    mockFS,
 			}
 
 			// Special case for "nil filesystem" test
@@ -330,8 +339,8 @@ func TestRuleGenerator_GeneratePhaseContentWithCustomTemplate(t *testing.T) {
 			}
 
 			g := &RuleGenerator{
-				projectRoot: projectRoot,
-				fs:          mockFS,
+				d3Dir: filepath.Join(projectRoot, ".d3"),
+				fs:    mockFS,
 			}
 
 			got, err := g.GeneratePhaseContent(tt.feature, tt.phase)
@@ -346,6 +355,71 @@ func TestRuleGenerator_GeneratePhaseContentWithCustomTemplate(t *testing.T) {
 	}
 }
 
+func TestRuleGenerator_GeneratePhaseContentFeatureDir(t *testing.T) {
+	relocated := config.Default("/p")
+	relocated.FeaturesDir = "/p/docs/features"
+
+	tests := []struct {
+		name    string
+		cfg     config.Config
+		want    string
+		wantNot string
+	}{
+		{name: "default features dir", cfg: config.Default("/p"), want: "(mdc:.d3/features/login/define/problem.md)", wantNot: "{{feature_dir}}"},
+		{name: "relocated features dir", cfg: relocated, want: "(mdc:docs/features/login/define/problem.md)", wantNot: ".d3/features"},
+		{name: "no configuration", cfg: config.Config{}, want: "(mdc:.d3/features/login/define/problem.md)", wantNot: "{{feature_dir}}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewRuleGenerator(tt.cfg, testutil.NewMemFS())
+			for _, p := range []string{"define", "design", "deliver"} {
+				got, err := g.GeneratePhaseContent("login", p)
+				if err != nil {
+					t.Fatalf("GeneratePhaseContent(login, %s) error = %v", p, err)
+				}
+				if strings.Contains(got, tt.wantNot) {
+					t.Errorf("GeneratePhaseContent(login, %s) contains %q", p, tt.wantNot)
+				}
+				if p == "define" && !strings.Contains(got, tt.want) {
+					t.Errorf("GeneratePhaseContent(login, define) does not link %q", tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestRuleGenerator_GeneratePhaseContentNeedsRevisiting(t *testing.T) {
+	memFS := testutil.NewMemFS()
+	memFS.AddFile("/p/.d3/features/login/design/plan.md", "<!-- d3:stale since 2026-10-18: the feature moved back from deliver to define. -->\n# Plan\n")
+	memFS.AddFile("/p/.d3/features/login/deliver/progress.yaml", "- id: 1\n  description: Add the form\n  status: complete\n  review: needed\n")
+
+	g := NewRuleGenerator(config.Default("/p"), memFS)
+
+	tests := []struct {
+		feature string
+		phase   string
+		want    string
+		wantNot string
+	}{
+		{feature: "login", phase: "design", want: "*   plan.md: marked stale since 2026-10-18", wantNot: "progress.yaml"},
+		{feature: "login", phase: "deliver", want: "*   progress.yaml task 1: \"Add the form\" needs review", wantNot: "plan.md:"},
+		{feature: "login", phase: "define", wantNot: "## Needs Revisiting"},
+		{feature: "signup", phase: "design", wantNot: "## Needs Revisiting"},
+	}
+	for _, tt := range tests {
+		got, err := g.GeneratePhaseContent(tt.feature, tt.phase)
+		if err != nil {
+			t.Fatalf("GeneratePhaseContent(%s, %s) error = %v", tt.feature, tt.phase, err)
+		}
+		if tt.want != "" && !strings.Contains(got, tt.want) {
+			t.Errorf("GeneratePhaseContent(%s, %s) = %q, want it to contain %q", tt.feature, tt.phase, got, tt.want)
+		}
+		if strings.Contains(got, tt.wantNot) {
+			t.Errorf("GeneratePhaseContent(%s, %s) = %q, want it not to contain %q", tt.feature, tt.phase, got, tt.wantNot)
+		}
+	}
+}
+
 func TestRuleGenerator_GenerateCoreContentWithCustomTemplate(t *testing.T) {
 	projectRoot := "/test/project"
 	customTemplateDir := filepath.Join(projectRoot, ".d3", "rules")
@@ -418,8 +492,8 @@ func TestRuleGenerator_GenerateCoreContentWithCustomTemplate(t *testing.T) {
 			}
 
 			g := &RuleGenerator{
-				projectRoot: projectRoot,
-				fs:          mockFS,
+				d3Dir: filepath.Join(projectRoot, ".d3"),
+				fs:    mockFS,
 			}
 
 			got, err := g.GenerateCoreContent(tt.feature, tt.phase)
@@ -452,6 +526,7 @@ func TestService_InitCustomRulesDir(t *testing.T) {
 		name       string
 		setupMocks func(mockFS *portsmocks.MockFileSystem)
 		wantErr    bool
+		wantLog    string
 	}{
 		{
 			name: "successful initialization",
@@ -498,6 +573,7 @@ func TestService_InitCustomRulesDir(t *testing.T) {
 				}
 			},
 			wantErr: false,
+			wantLog: "custom template already exists, skipping",
 		},
 		{
 			name: "stat error",
@@ -543,12 +619,80 @@ func TestService_InitCustomRulesDir(t *testing.T) {
 				tt.setupMocks(mockFS)
 			}
 
-			s := NewService(projectRoot, cursorRulesDir, mockGen, mockFS)
+			var logBuf bytes.Buffer
+			s := NewService(config.Config{ProjectRoot: projectRoot, D3Dir: filepath.Join(projectRoot, ".d3"), CursorRulesDir: cursorRulesDir}, mockGen, mockFS)
+			// The CLI logs at warn level, so the skip notice must be a warning to be seen
+			s.SetLogger(slog.New(slog.NewTextHandler(&logBuf, &slog.HandlerOptions{Level: slog.LevelWarn})))
 			err := s.InitCustomRulesDir()
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Service.InitCustomRulesDir() error = %v, wantErr %v", err, tt.wantErr)
 			}
+			if tt.wantLog != "" && !strings.Contains(logBuf.String(), tt.wantLog) {
+				t.Errorf("Service.InitCustomRulesDir() log = %q, want it to contain %q", logBuf.String(), tt.wantLog)
+			}
+		})
+	}
+}
+
+func TestService_ClearGeneratedRules(t *testing.T) {
+	projectRoot := "/test/project"
+	cursorRulesDir := filepath.Join(projectRoot, ".cursor", "rules")
+	pattern := filepath.Join(cursorRulesDir, "d3", "*.gen.mdc")
+	coreRule := filepath.Join(cursorRulesDir, "d3", "core.gen.mdc")
+	phaseRule := filepath.Join(cursorRulesDir, "d3", "phase.gen.mdc")
+
+	tests := []struct {
+		name       string
+		setupMocks func(mockFS *portsmocks.MockFileSystem)
+		wantErr    bool
+		wantLog    string
+	}{
+		{
+			name: "removes every generated rule",
+			setupMocks: func(mockFS *portsmocks.MockFileSystem) {
+				mockFS.EXPECT().Glob(pattern).Return([]string{coreRule, phaseRule}, nil).Times(1)
+				mockFS.EXPECT().Remove(coreRule).Return(nil).Times(1)
+				mockFS.EXPECT().Remove(phaseRule).Return(nil).Times(1)
+			},
+			wantErr: false,
+		},
+		{
+			name: "glob fails",
+			setupMocks: func(mockFS *portsmocks.MockFileSystem) {
+				mockFS.EXPECT().Glob(pattern).Return(nil, fmt.Errorf("bad pattern")).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "remove failure is logged and the rest are still removed",
+			setupMocks: func(mockFS *portsmocks.MockFileSystem) {
+				mockFS.EXPECT().Glob(pattern).Return([]string{coreRule, phaseRule}, nil).Times(1)
+				mockFS.EXPECT().Remove(coreRule).Return(fmt.Errorf("permission denied")).Times(1)
+				mockFS.EXPECT().Remove(phaseRule).Return(nil).Times(1)
+			},
+			wantErr: true,
+			wantLog: "failed to remove rule file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockFS := portsmocks.NewMockFileSystem(ctrl)
+			tt.setupMocks(mockFS)
+
+			var logBuf bytes.Buffer
+			s := NewService(config.Config{ProjectRoot: projectRoot, D3Dir: filepath.Join(projectRoot, ".d3"), CursorRulesDir: cursorRulesDir}, rulesmocks.NewMockGenerator(ctrl), mockFS)
+			s.SetLogger(slog.New(slog.NewTextHandler(&logBuf, nil)))
+			err := s.ClearGeneratedRules()
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.ClearGeneratedRules() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantLog != "" && !strings.Contains(logBuf.String(), tt.wantLog) {
+				t.Errorf("Service.ClearGeneratedRules() log = %q, want it to contain %q", logBuf.String(), tt.wantLog)
+			}
 		})
 	}
 }
@@ -737,7 +881,7 @@ func TestService_RefreshRules(t *testing.T) {
 				tt.setupMocks(mockFS, mockGen)
 			}
 
-			s := NewService(projectRoot, cursorRulesDir, mockGen, mockFS)
+			s := NewService(config.Config{ProjectRoot: projectRoot, D3Dir: filepath.Join(projectRoot, ".d3"), CursorRulesDir: cursorRulesDir}, mockGen, mockFS)
 			err := s.RefreshRules(tt.feature, tt.phase)
 
 			if (err != nil) != tt.wantErr {
@@ -746,3 +890,98 @@ func TestService_RefreshRules(t *testing.T) {
 		})
 	}
 }
+
+func TestService_SyncRules(t *testing.T) {
+	projectRoot := "/p"
+	cursorRulesDir := filepath.Join(projectRoot, ".cursor", "rules")
+	coreRule := filepath.Join(cursorRulesDir, "d3", "core.gen.mdc")
+	phaseRule := filepath.Join(cursorRulesDir, "d3", "phase.gen.mdc")
+	oldRule := filepath.Join(cursorRulesDir, "d3", "old.gen.mdc")
+	userRule := filepath.Join(cursorRulesDir, "d3", "notes.mdc")
+
+	tests := []struct {
+		name        string
+		feature     string
+		phase       string
+		files       map[string]string
+		wantChanged []string
+		wantFiles   map[string]string
+		wantGone    []string
+	}{
+		{
+			name:        "missing rules are written",
+			feature:     "login",
+			phase:       "design",
+			wantChanged: []string{coreRule, phaseRule},
+			wantFiles:   map[string]string{coreRule: "core login design", phaseRule: "phase login design"},
+		},
+		{
+			name:        "matching rules are left alone",
+			feature:     "login",
+			phase:       "design",
+			files:       map[string]string{coreRule: "core login design", phaseRule: "phase login design"},
+			wantChanged: nil,
+		},
+		{
+			name:        "only differing rules are rewritten",
+			feature:     "login",
+			phase:       "deliver",
+			files:       map[string]string{coreRule: "core login deliver", phaseRule: "phase login design"},
+			wantChanged: []string{phaseRule},
+			wantFiles:   map[string]string{phaseRule: "phase login deliver"},
+		},
+		{
+			name:        "unexpected generated rules are removed",
+			feature:     "login",
+			phase:       "design",
+			files:       map[string]string{coreRule: "core login design", phaseRule: "phase login design", oldRule: "x", userRule: "mine"},
+			wantChanged: []string{oldRule},
+			wantFiles:   map[string]string{userRule: "mine"},
+			wantGone:    []string{oldRule},
+		},
+		{
+			name:        "no active feature removes every generated rule",
+			files:       map[string]string{coreRule: "core login design", phaseRule: "phase login design"},
+			wantChanged: []string{coreRule, phaseRule},
+			wantGone:    []string{coreRule, phaseRule},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockGen := rulesmocks.NewMockGenerator(ctrl)
+			mockGen.EXPECT().GenerateCoreContent(gomock.Any(), gomock.Any()).DoAndReturn(func(feature, phase string) (string, error) {
+				return "core " + feature + " " + phase, nil
+			}).AnyTimes()
+			mockGen.EXPECT().GeneratePhaseContent(gomock.Any(), gomock.Any()).DoAndReturn(func(feature, phase string) (string, error) {
+				return "phase " + feature + " " + phase, nil
+			}).AnyTimes()
+
+			memFS := testutil.NewMemFS()
+			for path, content := range tt.files {
+				memFS.AddFile(path, content)
+			}
+			service := NewService(config.Config{ProjectRoot: projectRoot, D3Dir: filepath.Join(projectRoot, ".d3"), CursorRulesDir: cursorRulesDir}, mockGen, memFS)
+
+			changed, err := service.SyncRules(tt.feature, tt.phase)
+			if err != nil {
+				t.Fatalf("SyncRules() error = %v", err)
+			}
+			sort.Strings(changed)
+			if !reflect.DeepEqual(changed, tt.wantChanged) {
+				t.Errorf("SyncRules() changed = %v, want %v", changed, tt.wantChanged)
+			}
+			for path, want := range tt.wantFiles {
+				if got, _ := memFS.ReadFile(path); string(got) != want {
+					t.Errorf("%s = %q, want %q", path, got, want)
+				}
+			}
+			for _, path := range tt.wantGone {
+				if exists, _ := memFS.Exists(path); exists {
+					t.Errorf("%s still exists", path)
+				}
+			}
+		})
+	}
+}
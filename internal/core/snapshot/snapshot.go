@@ -0,0 +1,207 @@
+// Package snapshot keeps earlier versions of a feature's phase artifacts. Each snapshot is a
+// directory below the feature's snapshots directory, named after the time it was taken and the
+// transition that took it, such as "20261018T142233Z-deliver-to-design", holding copies of the
+// artifacts at their usual paths within the feature, such as "deliver/progress.yaml".
+package snapshot
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/imcclaskey/d3/internal/core/d3err"
+	"github.com/imcclaskey/d3/internal/core/phase"
+	"github.com/imcclaskey/d3/internal/core/ports"
+)
+
+// Dir is the directory inside a feature directory that holds its snapshots.
+const Dir = "snapshots"
+
+// ReasonRevert is the reason of the snapshot taken of the artifacts a revert replaces.
+const ReasonRevert = "before-revert"
+
+// timeLayout is the UTC timestamp that starts every snapshot ID.
+const timeLayout = "20060102T150405Z"
+
+// Snapshot describes one stored snapshot.
+type Snapshot struct {
+	ID   string    `json:"id"`
+	Time time.Time `json:"time"`
+	// Reason is the transition that took the snapshot, such as "deliver-to-design", or
+	// ReasonRevert.
+	Reason string `json:"reason"`
+	// Files lists the artifacts held, as slash-separated paths relative to the feature
+	// directory, such as "design/plan.md".
+	Files []string `json:"files"`
+}
+
+// Transition returns the reason of a snapshot taken when a feature moves between two phases.
+func Transition(from, to phase.Phase) string {
+	return fmt.Sprintf("%s-to-%s", from, to)
+}
+
+// Artifact returns the path of a phase's artifact relative to the feature directory.
+func Artifact(p phase.Phase) string {
+	return path.Join(string(p), phase.PhaseFileMap[p])
+}
+
+// Take copies the artifacts of the given phases into a new snapshot of the feature at
+// featurePath. Missing and blank artifacts are skipped; when nothing is left, no snapshot is
+// written and Take returns nil. IDs stay unique by moving the timestamp past existing snapshots.
+func Take(fs ports.FileSystem, featurePath, reason string, phases []phase.Phase, now time.Time) (*Snapshot, error) {
+	contents := map[string][]byte{}
+	var files []string
+	for _, p := range phases {
+		file := Artifact(p)
+		data, err := fs.ReadFile(filepath.Join(featurePath, filepath.FromSlash(file)))
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		if strings.TrimSpace(string(data)) == "" {
+			continue
+		}
+		contents[file] = data
+		files = append(files, file)
+	}
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	// Timestamps are unique across reasons, so that IDs sort in the order snapshots were taken
+	taken := now.UTC().Truncate(time.Second)
+	for {
+		pattern := filepath.Join(featurePath, Dir, taken.Format(timeLayout)+"-*")
+		existing, err := fs.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check %s: %w", pattern, err)
+		}
+		if len(existing) == 0 {
+			break
+		}
+		taken = taken.Add(time.Second)
+	}
+	dir := filepath.Join(featurePath, Dir, taken.Format(timeLayout)+"-"+reason)
+
+	for _, file := range files {
+		target := filepath.Join(dir, filepath.FromSlash(file))
+		if err := fs.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create snapshot directory: %w", err)
+		}
+		if err := fs.WriteFile(target, contents[file], 0644); err != nil {
+			return nil, fmt.Errorf("failed to write snapshot of %s: %w", file, err)
+		}
+	}
+	return &Snapshot{ID: filepath.Base(dir), Time: taken, Reason: reason, Files: files}, nil
+}
+
+// List returns the snapshots of the feature at featurePath, oldest first. Directories whose
+// names are not snapshot IDs are ignored.
+func List(fs ports.FileSystem, featurePath string) ([]Snapshot, error) {
+	dir := filepath.Join(featurePath, Dir)
+	entries, err := fs.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return []Snapshot{}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+
+	snapshots := []Snapshot{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		snap, ok := parseID(entry.Name())
+		if !ok {
+			continue
+		}
+		for _, p := range phase.Lifecycle {
+			file := Artifact(p)
+			exists, err := fs.Exists(filepath.Join(dir, snap.ID, filepath.FromSlash(file)))
+			if err != nil {
+				return nil, fmt.Errorf("failed to check snapshot %s: %w", snap.ID, err)
+			}
+			if exists {
+				snap.Files = append(snap.Files, file)
+			}
+		}
+		snapshots = append(snapshots, snap)
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].ID < snapshots[j].ID
+	})
+	return snapshots, nil
+}
+
+// Find returns the snapshot whose ID is ref, or the only one whose ID starts with ref. Unknown
+// and ambiguous references are reported with the InvalidArgument code.
+func Find(fs ports.FileSystem, featurePath, ref string) (*Snapshot, error) {
+	snapshots, err := List(fs, featurePath)
+	if err != nil {
+		return nil, err
+	}
+	var matches []Snapshot
+	for _, snap := range snapshots {
+		if snap.ID == ref {
+			return &snap, nil
+		}
+		if ref != "" && strings.HasPrefix(snap.ID, ref) {
+			matches = append(matches, snap)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, d3err.New(d3err.InvalidArgument, "snapshot %q not found", ref)
+	case 1:
+		return &matches[0], nil
+	default:
+		return nil, d3err.New(d3err.InvalidArgument, "snapshot %q is ambiguous: it matches %d snapshots", ref, len(matches))
+	}
+}
+
+// ReadFile returns the content of one of the snapshot's files.
+func (s *Snapshot) ReadFile(fs ports.FileSystem, featurePath, file string) ([]byte, error) {
+	name := filepath.Join(featurePath, Dir, s.ID, filepath.FromSlash(file))
+	data, err := fs.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s of snapshot %s: %w", file, s.ID, err)
+	}
+	return data, nil
+}
+
+// Select returns the snapshot's files matching artifact: a phase, such as "design", an artifact
+// path, such as "design/plan.md", or its file name, such as "plan.md". An empty artifact selects
+// every file. An artifact the snapshot does not hold is reported with the InvalidArgument code.
+func (s *Snapshot) Select(artifact string) ([]string, error) {
+	artifact = strings.Trim(filepath.ToSlash(strings.TrimSpace(artifact)), "/")
+	if artifact == "" {
+		return s.Files, nil
+	}
+	var files []string
+	for _, file := range s.Files {
+		if file == artifact || path.Base(file) == artifact || strings.SplitN(file, "/", 2)[0] == artifact {
+			files = append(files, file)
+		}
+	}
+	if len(files) == 0 {
+		return nil, d3err.New(d3err.InvalidArgument, "snapshot %s holds no artifact matching %q; it holds %s", s.ID, artifact, strings.Join(s.Files, ", "))
+	}
+	return files, nil
+}
+
+// parseID splits a snapshot directory name into its time and reason.
+func parseID(id string) (Snapshot, bool) {
+	if len(id) <= len(timeLayout)+1 || id[len(timeLayout)] != '-' {
+		return Snapshot{}, false
+	}
+	taken, err := time.Parse(timeLayout, id[:len(timeLayout)])
+	if err != nil {
+		return Snapshot{}, false
+	}
+	return Snapshot{ID: id, Time: taken, Reason: id[len(timeLayout)+1:]}, true
+}
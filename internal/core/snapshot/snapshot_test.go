@@ -0,0 +1,118 @@
+package snapshot
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/imcclaskey/d3/internal/core/d3err"
+	"github.com/imcclaskey/d3/internal/core/phase"
+	"github.com/imcclaskey/d3/internal/testutil"
+)
+
+const featurePath = "/p/.d3/features/login"
+
+func TestTakeAndList(t *testing.T) {
+	memFS := testutil.NewMemFS()
+	memFS.AddFile(featurePath+"/design/plan.md", "## Delivery Steps\n- [code] Add the form\n")
+	memFS.AddFile(featurePath+"/deliver/progress.yaml", "  \n")
+	now := time.Date(2026, 10, 18, 14, 22, 33, 500, time.UTC)
+
+	first, err := Take(memFS, featurePath, Transition(phase.Design, phase.Deliver), []phase.Phase{phase.Design, phase.Deliver}, now)
+	if err != nil {
+		t.Fatalf("Take() error = %v", err)
+	}
+	want := &Snapshot{ID: "20261018T142233Z-design-to-deliver", Time: now.Truncate(time.Second), Reason: "design-to-deliver", Files: []string{"design/plan.md"}}
+	if !reflect.DeepEqual(first, want) {
+		t.Errorf("Take() = %+v, want %+v", first, want)
+	}
+	if data, _ := memFS.ReadFile(featurePath + "/snapshots/20261018T142233Z-design-to-deliver/design/plan.md"); string(data) != "## Delivery Steps\n- [code] Add the form\n" {
+		t.Errorf("snapshot content = %q", data)
+	}
+
+	// A second snapshot within the same second gets the next free timestamp
+	second, err := Take(memFS, featurePath, ReasonRevert, []phase.Phase{phase.Design}, now)
+	if err != nil || second.ID != "20261018T142234Z-before-revert" {
+		t.Fatalf("Take() = %+v, %v, want the next second", second, err)
+	}
+
+	// Blank artifacts are not worth a snapshot
+	if snap, err := Take(memFS, featurePath, "deliver-to-design", []phase.Phase{phase.Deliver, phase.Define}, now); snap != nil || err != nil {
+		t.Errorf("Take() of blank and missing artifacts = %+v, %v, want nil", snap, err)
+	}
+
+	memFS.MkdirAll(featurePath+"/snapshots/notes", 0755)
+	snapshots, err := List(memFS, featurePath)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(snapshots) != 2 || !reflect.DeepEqual(snapshots[0], *first) || snapshots[1].ID != second.ID {
+		t.Errorf("List() = %+v", snapshots)
+	}
+}
+
+func TestList_NoSnapshots(t *testing.T) {
+	snapshots, err := List(testutil.NewMemFS(), featurePath)
+	if err != nil || snapshots == nil || len(snapshots) != 0 {
+		t.Errorf("List() = %#v, %v, want an empty list", snapshots, err)
+	}
+}
+
+func TestFind(t *testing.T) {
+	memFS := testutil.NewMemFS()
+	for _, id := range []string{"20261018T142233Z-design-to-deliver", "20261018T150000Z-deliver-to-design", "20261019T090000Z-before-revert"} {
+		memFS.AddFile(featurePath+"/snapshots/"+id+"/design/plan.md", "plan")
+	}
+
+	tests := []struct {
+		ref      string
+		wantID   string
+		wantCode d3err.Code
+	}{
+		{ref: "20261018T150000Z-deliver-to-design", wantID: "20261018T150000Z-deliver-to-design"},
+		{ref: "20261019", wantID: "20261019T090000Z-before-revert"},
+		{ref: "20261018", wantCode: d3err.InvalidArgument},
+		{ref: "2025", wantCode: d3err.InvalidArgument},
+		{ref: "", wantCode: d3err.InvalidArgument},
+	}
+	for _, tt := range tests {
+		snap, err := Find(memFS, featurePath, tt.ref)
+		if tt.wantCode != "" {
+			if d3err.CodeOf(err) != tt.wantCode {
+				t.Errorf("Find(%q) error = %v, want code %s", tt.ref, err, tt.wantCode)
+			}
+			continue
+		}
+		if err != nil || snap.ID != tt.wantID {
+			t.Errorf("Find(%q) = %+v, %v, want %s", tt.ref, snap, err, tt.wantID)
+		}
+	}
+}
+
+func TestSnapshot_Select(t *testing.T) {
+	snap := &Snapshot{ID: "20261018T150000Z-deliver-to-define", Files: []string{"design/plan.md", "deliver/progress.yaml"}}
+	tests := []struct {
+		artifact string
+		want     []string
+		wantCode d3err.Code
+	}{
+		{artifact: "", want: []string{"design/plan.md", "deliver/progress.yaml"}},
+		{artifact: "design", want: []string{"design/plan.md"}},
+		{artifact: "deliver/progress.yaml", want: []string{"deliver/progress.yaml"}},
+		{artifact: "plan.md", want: []string{"design/plan.md"}},
+		{artifact: "define", wantCode: d3err.InvalidArgument},
+		{artifact: "tech.md", wantCode: d3err.InvalidArgument},
+	}
+	for _, tt := range tests {
+		got, err := snap.Select(tt.artifact)
+		if tt.wantCode != "" {
+			if d3err.CodeOf(err) != tt.wantCode {
+				t.Errorf("Select(%q) error = %v, want code %s", tt.artifact, err, tt.wantCode)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Select(%q) = %v, %v, want %v", tt.artifact, got, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,317 @@
+// Package trace links a feature's requirements, plan steps and delivery tasks through stable
+// IDs, and reports the gaps between them.
+//
+// Requirements are declared in problem.md and plan steps in plan.md by starting a list item or
+// heading with an ID such as "R1", "AC-2" or "S3":
+//
+//   - R1: User can filter the table by date range
+//   - S2 [code] Add the date filter to the query builder (R1)
+//
+// Any later mention of a declared ID's prefix is a reference. Tasks in progress.yaml reference
+// steps and requirements through their refs field or their description.
+package trace
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/imcclaskey/d3/internal/core/progress"
+)
+
+// Source files of references, as named in reports.
+const (
+	ProblemFile  = "problem.md"
+	PlanFile     = "plan.md"
+	ProgressFile = "progress.yaml"
+)
+
+var (
+	// idPattern matches an ID: a short uppercase prefix, an optional dash and a number.
+	idPattern = regexp.MustCompile(`\b[A-Z]{1,5}-?[0-9]+\b`)
+	// declarationPattern matches a list item or heading that starts with an ID, optionally
+	// bold or bracketed: "- R1: ...", "1. **AC-2** ...", "### [S3] ...".
+	declarationPattern = regexp.MustCompile(`^\s*(?:[-*+]|[0-9]+[.)]|#{1,6})\s+[*_\[]*([A-Z]{1,5}-?[0-9]+)[*_\]]*(?:[:.)]|\s|$)(.*)$`)
+	// listItemPattern matches the marker of a list item.
+	listItemPattern = regexp.MustCompile(`^(?:[-*+]|[0-9]+[.)])\s`)
+)
+
+// Requirement is an ID declared in problem.md.
+type Requirement struct {
+	ID   string `json:"id"`
+	Text string `json:"text"`
+	// Steps and Tasks list the plan steps and task IDs that reference the requirement.
+	Steps []string `json:"steps"`
+	Tasks []string `json:"tasks"`
+}
+
+// Step is an ID declared in plan.md.
+type Step struct {
+	ID   string `json:"id"`
+	Text string `json:"text"`
+	// Requirements lists the requirement IDs the step references.
+	Requirements []string `json:"requirements"`
+	// Tasks lists the IDs of the tasks that reference the step.
+	Tasks []string `json:"tasks"`
+}
+
+// Reference is a mention of an ID that is not declared anywhere.
+type Reference struct {
+	ID     string `json:"id"`
+	Source string `json:"source"`
+	// Location is "line N" for documents and "task N" for progress.yaml.
+	Location string `json:"location"`
+}
+
+// String renders the reference for reports, e.g. "R9 (plan.md, line 12)".
+func (r Reference) String() string {
+	return fmt.Sprintf("%s (%s, %s)", r.ID, r.Source, r.Location)
+}
+
+// Report is the traceability of one feature.
+type Report struct {
+	Feature      string        `json:"feature"`
+	Requirements []Requirement `json:"requirements"`
+	Steps        []Step        `json:"steps"`
+	// Uncovered lists requirements no step or task references. It is only checked once plan.md
+	// declares steps or progress.yaml has tasks.
+	Uncovered []string `json:"uncovered"`
+	// StepsWithoutTasks lists steps no task references. It is only checked once progress.yaml
+	// has tasks.
+	StepsWithoutTasks []string `json:"steps_without_tasks"`
+	// Dangling lists references to IDs that are never declared.
+	Dangling []Reference `json:"dangling"`
+	Warnings []string    `json:"warnings,omitempty"`
+}
+
+// OK reports whether the report found no gaps.
+func (r *Report) OK() bool {
+	return len(r.Uncovered) == 0 && len(r.StepsWithoutTasks) == 0 && len(r.Dangling) == 0
+}
+
+// Issues returns one line per gap, for gate failures and summaries.
+func (r *Report) Issues() []string {
+	var issues []string
+	for _, id := range r.Uncovered {
+		issues = append(issues, fmt.Sprintf("requirement %s is not covered by any plan step or task", id))
+	}
+	for _, id := range r.StepsWithoutTasks {
+		issues = append(issues, fmt.Sprintf("plan step %s has no tasks in %s", id, ProgressFile))
+	}
+	for _, ref := range r.Dangling {
+		issues = append(issues, fmt.Sprintf("reference to undeclared ID %s", ref))
+	}
+	return issues
+}
+
+// Analyze traces a feature's problem.md and plan.md content and its parsed tasks.
+func Analyze(featureName, problem, plan string, tasks []progress.Task) *Report {
+	r := &Report{Feature: featureName, Requirements: []Requirement{}, Steps: []Step{}, Uncovered: []string{}, StepsWithoutTasks: []string{}, Dangling: []Reference{}}
+
+	requirementIndex := map[string]int{}
+	requirementPrefixes := map[string]bool{}
+	for _, line := range strings.Split(problem, "\n") {
+		id, text, ok := declaration(line)
+		if !ok {
+			continue
+		}
+		if _, seen := requirementIndex[id]; seen {
+			r.Warnings = append(r.Warnings, fmt.Sprintf("requirement %s is declared more than once in %s", id, ProblemFile))
+			continue
+		}
+		requirementIndex[id] = len(r.Requirements)
+		requirementPrefixes[prefix(id)] = true
+		r.Requirements = append(r.Requirements, Requirement{ID: id, Text: text, Steps: []string{}, Tasks: []string{}})
+	}
+
+	// A plan line that starts with an ID of a requirement prefix mentions the requirement
+	// rather than declaring a step
+	stepIndex := map[string]int{}
+	stepPrefixes := map[string]bool{}
+	planLines := strings.Split(plan, "\n")
+	stepOfLine := make([]int, len(planLines))
+	current := -1
+	for i, line := range planLines {
+		if id, text, ok := declaration(line); ok && !requirementPrefixes[prefix(id)] {
+			if _, seen := stepIndex[id]; seen {
+				r.Warnings = append(r.Warnings, fmt.Sprintf("step %s is declared more than once in %s", id, PlanFile))
+			} else {
+				stepIndex[id] = len(r.Steps)
+				stepPrefixes[prefix(id)] = true
+				r.Steps = append(r.Steps, Step{ID: id, Text: text, Requirements: []string{}, Tasks: []string{}})
+			}
+			current = stepIndex[id]
+		} else if strings.TrimSpace(line) == "" || strings.HasPrefix(strings.TrimSpace(line), "#") || !isContinuation(line) {
+			current = -1
+		}
+		stepOfLine[i] = current
+	}
+
+	known := func(id string) bool {
+		p := prefix(id)
+		return requirementPrefixes[p] || stepPrefixes[p]
+	}
+	dangling := func(id, source, location string) {
+		r.Dangling = append(r.Dangling, Reference{ID: id, Source: source, Location: location})
+	}
+
+	for i, line := range planLines {
+		for _, id := range idPattern.FindAllString(line, -1) {
+			if !known(id) {
+				continue
+			}
+			if req, ok := requirementIndex[id]; ok {
+				if s := stepOfLine[i]; s >= 0 {
+					step := &r.Steps[s]
+					step.Requirements = appendUnique(step.Requirements, id)
+					r.Requirements[req].Steps = appendUnique(r.Requirements[req].Steps, step.ID)
+				}
+			} else if _, ok := stepIndex[id]; !ok {
+				dangling(id, PlanFile, fmt.Sprintf("line %d", i+1))
+			}
+		}
+	}
+
+	for i, task := range tasks {
+		taskID := task.ID
+		if taskID == "" {
+			taskID = fmt.Sprint(i + 1)
+		}
+		var ids []string
+		for _, id := range append(append([]string{}, task.Refs...), idPattern.FindAllString(task.Description, -1)...) {
+			ids = appendUnique(ids, id)
+		}
+		for _, id := range ids {
+			if req, ok := requirementIndex[id]; ok {
+				r.Requirements[req].Tasks = appendUnique(r.Requirements[req].Tasks, taskID)
+			} else if step, ok := stepIndex[id]; ok {
+				r.Steps[step].Tasks = appendUnique(r.Steps[step].Tasks, taskID)
+			} else if known(id) || contains(task.Refs, id) {
+				// Explicit refs are always meant as IDs, whatever their prefix
+				dangling(id, ProgressFile, "task "+taskID)
+			}
+		}
+	}
+
+	if len(r.Steps) > 0 || len(tasks) > 0 {
+		for _, req := range r.Requirements {
+			if len(req.Steps) == 0 && len(req.Tasks) == 0 {
+				r.Uncovered = append(r.Uncovered, req.ID)
+			}
+		}
+	}
+	if len(tasks) > 0 {
+		for _, step := range r.Steps {
+			if len(step.Tasks) == 0 {
+				r.StepsWithoutTasks = append(r.StepsWithoutTasks, step.ID)
+			}
+		}
+	}
+	return r
+}
+
+// declaration returns the ID and text of a line that declares one.
+func declaration(line string) (id, text string, ok bool) {
+	match := declarationPattern.FindStringSubmatch(line)
+	if match == nil {
+		return "", "", false
+	}
+	return match[1], strings.TrimSpace(strings.TrimLeft(match[2], " :-–—")), true
+}
+
+// isContinuation reports whether a line continues the list item above it: an indented line or
+// a lazy continuation that is not itself a new list item.
+func isContinuation(line string) bool {
+	trimmed := strings.TrimLeft(line, " \t")
+	if len(trimmed) < len(line) {
+		return true
+	}
+	return !listItemPattern.MatchString(trimmed)
+}
+
+// prefix returns the non-numeric part of an ID, including a trailing dash: "AC-" for "AC-2".
+func prefix(id string) string {
+	return strings.TrimRight(id, "0123456789")
+}
+
+func appendUnique(values []string, value string) []string {
+	if contains(values, value) {
+		return values
+	}
+	return append(values, value)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Render formats a report for the terminal and for assistants.
+func Render(r *Report) string {
+	var b strings.Builder
+	covered := len(r.Requirements) - len(r.Uncovered)
+	withTasks := len(r.Steps) - len(r.StepsWithoutTasks)
+	fmt.Fprintf(&b, "Traceability of '%s': %d requirement(s), %d covered; %d plan step(s), %d with tasks.\n", r.Feature, len(r.Requirements), covered, len(r.Steps), withTasks)
+	if len(r.Requirements) == 0 {
+		b.WriteString("No requirement IDs are declared in problem.md. Start requirement list items with an ID such as R1.\n")
+	}
+
+	section := func(title string, lines []string) {
+		if len(lines) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "\n%s:\n", title)
+		for _, line := range lines {
+			fmt.Fprintf(&b, "  - %s\n", line)
+		}
+	}
+	var uncovered, unplanned, dangling []string
+	for _, id := range r.Uncovered {
+		uncovered = append(uncovered, labelled(id, r.requirementText(id)))
+	}
+	for _, id := range r.StepsWithoutTasks {
+		unplanned = append(unplanned, labelled(id, r.stepText(id)))
+	}
+	for _, ref := range r.Dangling {
+		dangling = append(dangling, ref.String())
+	}
+	section("Uncovered requirements", uncovered)
+	section("Plan steps with no tasks", unplanned)
+	section("Dangling references", dangling)
+	section("Warnings", r.Warnings)
+
+	if r.OK() && len(r.Requirements) > 0 {
+		b.WriteString("No gaps found.\n")
+	}
+	return b.String()
+}
+
+func (r *Report) requirementText(id string) string {
+	for _, req := range r.Requirements {
+		if req.ID == id {
+			return req.Text
+		}
+	}
+	return ""
+}
+
+func (r *Report) stepText(id string) string {
+	for _, step := range r.Steps {
+		if step.ID == id {
+			return step.Text
+		}
+	}
+	return ""
+}
+
+// labelled renders "ID: text", leaving out empty text.
+func labelled(id, text string) string {
+	if text == "" {
+		return id
+	}
+	return id + ": " + text
+}
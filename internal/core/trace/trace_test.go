@@ -0,0 +1,124 @@
+package trace
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/imcclaskey/d3/internal/core/progress"
+)
+
+const problem = `# Problem
+
+## Core Requirements
+- R1: User can filter the table by date range
+- **R2** Filters survive a page reload
+1. [AC-1] Filtering a 10k row table takes under 100ms
+
+## Scope Exclusions
+- Saved filters (see R1)
+`
+
+const plan = `## Delivery Steps
+- S1 [code] Add the date filter to the query builder (R1, AC-1)
+- S2 [code] Persist filters in the URL
+  Covers R2.
+- S3 [test] Run the query builder tests
+- S4 [commit] Commit the filter work for R7
+
+Note: R2 is the riskiest requirement.
+`
+
+func TestAnalyze(t *testing.T) {
+	tasks := []progress.Task{
+		{ID: "1", Description: "Add the date filter", Refs: []string{"S1"}},
+		{ID: "2", Description: "Persist filters (S2)"},
+		{ID: "3", Description: "Commit", Refs: []string{"S9", "T1"}},
+	}
+
+	r := Analyze("filters", problem, plan, tasks)
+
+	wantRequirements := []Requirement{
+		{ID: "R1", Text: "User can filter the table by date range", Steps: []string{"S1"}, Tasks: []string{}},
+		{ID: "R2", Text: "Filters survive a page reload", Steps: []string{"S2"}, Tasks: []string{}},
+		{ID: "AC-1", Text: "Filtering a 10k row table takes under 100ms", Steps: []string{"S1"}, Tasks: []string{}},
+	}
+	if !reflect.DeepEqual(r.Requirements, wantRequirements) {
+		t.Errorf("Requirements = %+v, want %+v", r.Requirements, wantRequirements)
+	}
+	if got := len(r.Steps); got != 4 {
+		t.Fatalf("len(Steps) = %d, want 4", got)
+	}
+	if step := r.Steps[0]; !reflect.DeepEqual(step.Requirements, []string{"R1", "AC-1"}) || !reflect.DeepEqual(step.Tasks, []string{"1"}) {
+		t.Errorf("Steps[0] = %+v", step)
+	}
+	if !reflect.DeepEqual(r.Uncovered, []string{}) {
+		t.Errorf("Uncovered = %v, want none", r.Uncovered)
+	}
+	if !reflect.DeepEqual(r.StepsWithoutTasks, []string{"S3", "S4"}) {
+		t.Errorf("StepsWithoutTasks = %v, want [S3 S4]", r.StepsWithoutTasks)
+	}
+	wantDangling := []Reference{
+		{ID: "R7", Source: PlanFile, Location: "line 6"},
+		{ID: "S9", Source: ProgressFile, Location: "task 3"},
+		{ID: "T1", Source: ProgressFile, Location: "task 3"},
+	}
+	if !reflect.DeepEqual(r.Dangling, wantDangling) {
+		t.Errorf("Dangling = %+v, want %+v", r.Dangling, wantDangling)
+	}
+	if r.OK() || len(r.Issues()) != 5 {
+		t.Errorf("Issues() = %v, want 5 issues", r.Issues())
+	}
+}
+
+func TestAnalyze_CoverageChecksWaitForLaterPhases(t *testing.T) {
+	r := Analyze("filters", problem, "", nil)
+	if !r.OK() {
+		t.Errorf("Analyze() with an empty plan reported %v, want no issues", r.Issues())
+	}
+
+	r = Analyze("filters", problem, "- S1 [code] Add the filter (R1)\n", nil)
+	if !reflect.DeepEqual(r.Uncovered, []string{"R2", "AC-1"}) {
+		t.Errorf("Uncovered = %v, want [R2 AC-1]", r.Uncovered)
+	}
+	if len(r.StepsWithoutTasks) != 0 {
+		t.Errorf("StepsWithoutTasks = %v before any task exists, want none", r.StepsWithoutTasks)
+	}
+
+	r = Analyze("filters", problem, "", []progress.Task{{ID: "1", Description: "Covers R1, R2 and AC-1"}})
+	if !r.OK() {
+		t.Errorf("Analyze() with tasks covering every requirement reported %v", r.Issues())
+	}
+}
+
+func TestAnalyze_IgnoresUnknownPrefixes(t *testing.T) {
+	r := Analyze("filters", "- R1: Support HTTP2 and UTF-8\n", "- S1 [code] Use SHA256 for R1\n", []progress.Task{{ID: "1", Description: "Upgrade to TLS13", Refs: []string{"S1"}}})
+	if !r.OK() {
+		t.Errorf("Analyze() reported %v, want no issues", r.Issues())
+	}
+}
+
+func TestAnalyze_DuplicateDeclarations(t *testing.T) {
+	r := Analyze("filters", "- R1: first\n- R1: again\n", "- S1 step\n- S1 again\n", nil)
+	if len(r.Requirements) != 1 || len(r.Steps) != 1 || len(r.Warnings) != 2 {
+		t.Errorf("Analyze() = %d requirements, %d steps, warnings %v", len(r.Requirements), len(r.Steps), r.Warnings)
+	}
+}
+
+func TestRender(t *testing.T) {
+	r := Analyze("filters", problem, plan, []progress.Task{{ID: "1", Refs: []string{"S1", "S2", "S3", "S4"}}})
+	text := Render(r)
+	for _, want := range []string{
+		"Traceability of 'filters': 3 requirement(s), 3 covered; 4 plan step(s), 4 with tasks.",
+		"Dangling references:\n  - R7 (plan.md, line 6)",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Render() = %q, want it to contain %q", text, want)
+		}
+	}
+
+	text = Render(Analyze("filters", "", "", nil))
+	if !strings.Contains(text, "No requirement IDs are declared") {
+		t.Errorf("Render() without requirements = %q", text)
+	}
+}
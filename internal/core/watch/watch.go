@@ -0,0 +1,210 @@
+// Package watch detects changes to project files by polling, using only the standard library
+// and ports.FileSystem, so that it behaves the same on every platform and in tests.
+package watch
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/imcclaskey/d3/internal/core/config"
+	"github.com/imcclaskey/d3/internal/core/d3err"
+	"github.com/imcclaskey/d3/internal/core/phase"
+	"github.com/imcclaskey/d3/internal/core/ports"
+)
+
+const (
+	// DefaultInterval is how often watched files are polled.
+	DefaultInterval = time.Second
+	// DefaultDebounce is how long changes must settle before they are reported, so that an
+	// editor saving several files, or writing one in steps, triggers a single regeneration.
+	DefaultDebounce = 300 * time.Millisecond
+)
+
+// DefaultPatterns are the watched files of a project using the default features directory.
+var DefaultPatterns = Patterns(config.DefaultFeaturesDir)
+
+// Patterns returns the files that affect generated rules or are d3 artifacts, relative to the
+// project root: the active feature markers, phase files, phase documents and other documents
+// of the features under featuresDir, custom rule templates and tech.md.
+func Patterns(featuresDir string) []string {
+	featuresDir = filepath.ToSlash(featuresDir)
+	return []string{
+		".d3/.feature",
+		".d3/sessions/*",
+		featuresDir + "/*/.phase",
+		featuresDir + "/*/*.md",
+		featuresDir + "/*/" + string(phase.Define) + "/" + phase.PhaseFileMap[phase.Define],
+		featuresDir + "/*/" + string(phase.Design) + "/" + phase.PhaseFileMap[phase.Design],
+		featuresDir + "/*/" + string(phase.Deliver) + "/" + phase.PhaseFileMap[phase.Deliver],
+		".d3/rules/*.md",
+		".d3/tech.md",
+	}
+}
+
+// Options configures a Watcher. Zero values select the defaults; a negative Debounce reports
+// changes on the first poll that finds nothing new.
+type Options struct {
+	// Patterns are filepath.Match globs relative to the project root.
+	Patterns []string
+	// Interval is how often the patterns are polled.
+	Interval time.Duration
+	// Debounce is how long no further change must be seen before changes are reported.
+	Debounce time.Duration
+}
+
+// ValidatePatterns reports the first malformed glob in patterns.
+func ValidatePatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return d3err.New(d3err.InvalidArgument, "invalid watch pattern %q: %v", pattern, err)
+		}
+	}
+	return nil
+}
+
+// fileState is what a poll records about a file. A change to either field counts as a change.
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// Watcher polls files matching a set of glob patterns and reports which ones were created,
+// modified or removed.
+type Watcher struct {
+	fs       ports.FileSystem
+	root     string
+	patterns []string
+	interval time.Duration
+	debounce time.Duration
+	logger   *slog.Logger
+
+	last map[string]fileState
+}
+
+// New creates a watcher for patterns relative to projectRoot.
+func New(fs ports.FileSystem, projectRoot string, opts Options) *Watcher {
+	w := &Watcher{
+		fs:       fs,
+		root:     projectRoot,
+		patterns: opts.Patterns,
+		interval: opts.Interval,
+		debounce: opts.Debounce,
+		logger:   slog.Default(),
+	}
+	if len(w.patterns) == 0 {
+		w.patterns = DefaultPatterns
+	}
+	if w.interval <= 0 {
+		w.interval = DefaultInterval
+	}
+	if w.debounce == 0 {
+		w.debounce = DefaultDebounce
+	}
+	return w
+}
+
+// SetLogger replaces the logger that receives poll failures.
+func (w *Watcher) SetLogger(logger *slog.Logger) {
+	w.logger = logger
+}
+
+// Run polls until ctx is done. Once changes have been seen and no further change has occurred
+// for the debounce period, onChange is called with the changed paths, sorted. Files existing
+// when Run starts are not reported.
+func (w *Watcher) Run(ctx context.Context, onChange func(ctx context.Context, changed []string)) {
+	if _, err := w.Poll(); err != nil {
+		w.logger.Warn("failed to poll watched files", "error", err)
+	}
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	pending := map[string]bool{}
+	var lastChange time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			changed, err := w.Poll()
+			if err != nil {
+				w.logger.Warn("failed to poll watched files", "error", err)
+				continue
+			}
+			for _, path := range changed {
+				pending[path] = true
+			}
+			if len(changed) > 0 {
+				lastChange = now
+				continue
+			}
+			if len(pending) == 0 || now.Sub(lastChange) < w.debounce {
+				continue
+			}
+
+			paths := make([]string, 0, len(pending))
+			for path := range pending {
+				paths = append(paths, path)
+			}
+			sort.Strings(paths)
+			pending = map[string]bool{}
+			w.logger.Debug("watched files changed", "paths", paths)
+			onChange(ctx, paths)
+		}
+	}
+}
+
+// Poll takes a snapshot of the watched files and returns the paths that changed since the
+// previous call, sorted. The first call records the initial state and reports nothing.
+func (w *Watcher) Poll() ([]string, error) {
+	current, err := w.snapshot()
+	if err != nil {
+		return nil, err
+	}
+	previous := w.last
+	w.last = current
+	if previous == nil {
+		return nil, nil
+	}
+
+	var changed []string
+	for path, state := range current {
+		if old, ok := previous[path]; !ok || old != state {
+			changed = append(changed, path)
+		}
+	}
+	for path := range previous {
+		if _, ok := current[path]; !ok {
+			changed = append(changed, path)
+		}
+	}
+	sort.Strings(changed)
+	return changed, nil
+}
+
+// snapshot records the state of every file matching the patterns.
+func (w *Watcher) snapshot() (map[string]fileState, error) {
+	states := map[string]fileState{}
+	for _, pattern := range w.patterns {
+		matches, err := w.fs.Glob(filepath.Join(w.root, pattern))
+		if err != nil {
+			return nil, fmt.Errorf("failed to match watch pattern %q: %w", pattern, err)
+		}
+		for _, path := range matches {
+			info, err := w.fs.Stat(path)
+			if err != nil {
+				// Removed between the glob and the stat; the next poll reports it
+				continue
+			}
+			if info.IsDir() {
+				continue
+			}
+			states[path] = fileState{modTime: info.ModTime(), size: info.Size()}
+		}
+	}
+	return states, nil
+}
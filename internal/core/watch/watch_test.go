@@ -0,0 +1,152 @@
+package watch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/imcclaskey/d3/internal/core/ports"
+	"github.com/imcclaskey/d3/internal/testutil"
+)
+
+func TestWatcher_Poll(t *testing.T) {
+	base := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		change func(memFS *testutil.MemFS)
+		want   []string
+	}{
+		{
+			name:   "no change",
+			change: func(memFS *testutil.MemFS) {},
+			want:   nil,
+		},
+		{
+			name:   "modified file",
+			change: func(memFS *testutil.MemFS) { memFS.SetModTime("/p/.d3/features/login/.phase", base.Add(time.Second)) },
+			want:   []string{"/p/.d3/features/login/.phase"},
+		},
+		{
+			name:   "resized file",
+			change: func(memFS *testutil.MemFS) { memFS.AddFile("/p/.d3/features/login/problem.md", "# Problem\nmore") },
+			want:   []string{"/p/.d3/features/login/problem.md"},
+		},
+		{
+			name: "created and removed files",
+			change: func(memFS *testutil.MemFS) {
+				memFS.AddFile("/p/.d3/rules/design.md", "custom")
+				memFS.Remove("/p/.d3/.feature")
+			},
+			want: []string{"/p/.d3/.feature", "/p/.d3/rules/design.md"},
+		},
+		{
+			name:   "files outside the patterns are ignored",
+			change: func(memFS *testutil.MemFS) { memFS.AddFile("/p/README.md", "hello") },
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memFS := testutil.NewMemFS()
+			memFS.AddFile("/p/.d3/.feature", "login")
+			memFS.AddFile("/p/.d3/features/login/.phase", "define")
+			memFS.AddFile("/p/.d3/features/login/problem.md", "# Problem")
+			memFS.SetModTime("/p/.d3/features/login/.phase", base)
+
+			w := New(memFS, "/p", Options{})
+			if changed, err := w.Poll(); err != nil || changed != nil {
+				t.Fatalf("first Poll() = %v, %v, want nothing", changed, err)
+			}
+
+			tt.change(memFS)
+			changed, err := w.Poll()
+			if err != nil {
+				t.Fatalf("Poll() error = %v", err)
+			}
+			if !reflect.DeepEqual(changed, tt.want) {
+				t.Errorf("Poll() = %v, want %v", changed, tt.want)
+			}
+			if again, _ := w.Poll(); again != nil {
+				t.Errorf("Poll() after reporting = %v, want nothing", again)
+			}
+		})
+	}
+}
+
+func TestWatcher_Run(t *testing.T) {
+	root := t.TempDir()
+	phaseFile := filepath.Join(root, ".d3", "features", "login", ".phase")
+	problemFile := filepath.Join(root, ".d3", "features", "login", "problem.md")
+	if err := os.MkdirAll(filepath.Dir(phaseFile), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(phaseFile, []byte("define"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	w := New(ports.RealFileSystem{}, root, Options{Interval: 5 * time.Millisecond, Debounce: 50 * time.Millisecond})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reports := make(chan []string, 4)
+	started := make(chan struct{})
+	go func() {
+		close(started)
+		w.Run(ctx, func(ctx context.Context, changed []string) { reports <- changed })
+	}()
+	<-started
+	// Let the initial snapshot be taken before editing
+	time.Sleep(20 * time.Millisecond)
+
+	// Two edits in quick succession are reported together
+	if err := os.WriteFile(phaseFile, []byte("design"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(10 * time.Millisecond)
+	if err := os.WriteFile(problemFile, []byte("# Problem"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case changed := <-reports:
+		want := []string{phaseFile, problemFile}
+		if !reflect.DeepEqual(changed, want) {
+			t.Errorf("Run() reported %v, want %v", changed, want)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Run() did not report the changes")
+	}
+
+	select {
+	case changed := <-reports:
+		t.Errorf("Run() reported %v again without further changes", changed)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestValidatePatterns(t *testing.T) {
+	if err := ValidatePatterns(DefaultPatterns); err != nil {
+		t.Errorf("ValidatePatterns(DefaultPatterns) error = %v", err)
+	}
+	if err := ValidatePatterns([]string{"docs/[.md"}); err == nil {
+		t.Error("ValidatePatterns() with a malformed glob error = nil")
+	}
+}
+
+func TestPatterns(t *testing.T) {
+	got := Patterns("docs/features")
+	for _, want := range []string{"docs/features/*/.phase", "docs/features/*/*.md", "docs/features/*/define/problem.md", "docs/features/*/deliver/progress.yaml", ".d3/.feature"} {
+		found := false
+		for _, pattern := range got {
+			found = found || pattern == want
+		}
+		if !found {
+			t.Errorf("Patterns(docs/features) = %v, want it to contain %q", got, want)
+		}
+	}
+}
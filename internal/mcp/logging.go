@@ -0,0 +1,286 @@
+package mcp
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/imcclaskey/d3/internal/mcp/tools"
+)
+
+// loggerName identifies d3 in log notifications sent to the client.
+const loggerName = "d3"
+
+// redacted replaces the value of sensitive tool arguments in logs.
+const redacted = "[REDACTED]"
+
+// sensitiveArgFragments marks tool arguments whose values must not be logged. An argument is
+// sensitive when its lowercased name contains any of these fragments.
+var sensitiveArgFragments = []string{"token", "secret", "password", "credential", "api_key", "apikey", "authorization"}
+
+// clientLevel holds the minimum level the client asked for with logging/setLevel. Until the
+// client sets a level, no log notifications are sent.
+type clientLevel struct {
+	mu    sync.RWMutex
+	level slog.Level
+	set   bool
+}
+
+// Set records the level requested by the client.
+func (c *clientLevel) Set(level slog.Level) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.level = level
+	c.set = true
+}
+
+// Enabled reports whether records at level should be sent to the client.
+func (c *clientLevel) Enabled(level slog.Level) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.set && level >= c.level
+}
+
+// slogLevel maps an MCP logging level onto the closest slog level.
+func slogLevel(level mcp.LoggingLevel) (slog.Level, bool) {
+	switch level {
+	case mcp.LoggingLevelDebug:
+		return slog.LevelDebug, true
+	case mcp.LoggingLevelInfo, mcp.LoggingLevelNotice:
+		return slog.LevelInfo, true
+	case mcp.LoggingLevelWarning:
+		return slog.LevelWarn, true
+	case mcp.LoggingLevelError, mcp.LoggingLevelCritical, mcp.LoggingLevelAlert, mcp.LoggingLevelEmergency:
+		return slog.LevelError, true
+	default:
+		return 0, false
+	}
+}
+
+// mcpLevel maps a slog level onto the MCP logging level sent to the client.
+func mcpLevel(level slog.Level) mcp.LoggingLevel {
+	switch {
+	case level < slog.LevelInfo:
+		return mcp.LoggingLevelDebug
+	case level < slog.LevelWarn:
+		return mcp.LoggingLevelInfo
+	case level < slog.LevelError:
+		return mcp.LoggingLevelWarning
+	default:
+		return mcp.LoggingLevelError
+	}
+}
+
+// clientHandler is a slog.Handler that forwards records to the client as
+// notifications/message, at or above the level the client set.
+type clientHandler struct {
+	notify func(method string, params map[string]any)
+	level  *clientLevel
+	attrs  map[string]any
+	group  string
+}
+
+// newClientHandler returns a handler that sends records through notify.
+func newClientHandler(notify func(method string, params map[string]any), level *clientLevel) *clientHandler {
+	return &clientHandler{notify: notify, level: level}
+}
+
+// Enabled implements slog.Handler.
+func (h *clientHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.level.Enabled(level)
+}
+
+// Handle implements slog.Handler. The record's message and attributes become the
+// notification's data object.
+func (h *clientHandler) Handle(_ context.Context, r slog.Record) error {
+	data := map[string]any{"message": r.Message}
+	for key, value := range h.attrs {
+		data[key] = value
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		addAttr(data, h.group, a)
+		return true
+	})
+
+	notification := mcp.NewLoggingMessageNotification(mcpLevel(r.Level), loggerName, data)
+	h.notify(notification.Method, map[string]any{
+		"level":  notification.Params.Level,
+		"logger": notification.Params.Logger,
+		"data":   notification.Params.Data,
+	})
+	return nil
+}
+
+// WithAttrs implements slog.Handler. Attributes are flattened under the current group.
+func (h *clientHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.attrs = make(map[string]any, len(h.attrs)+len(attrs))
+	for key, value := range h.attrs {
+		clone.attrs[key] = value
+	}
+	for _, a := range attrs {
+		addAttr(clone.attrs, h.group, a)
+	}
+	return &clone
+}
+
+// WithGroup implements slog.Handler. Groups are flattened into dotted keys.
+func (h *clientHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	clone := *h
+	clone.group = joinKey(h.group, name)
+	return &clone
+}
+
+// addAttr stores a resolved attribute in data, flattening groups into dotted keys.
+func addAttr(data map[string]any, prefix string, a slog.Attr) {
+	value := a.Value.Resolve()
+	if value.Kind() == slog.KindGroup {
+		for _, member := range value.Group() {
+			addAttr(data, joinKey(prefix, a.Key), member)
+		}
+		return
+	}
+	if a.Key == "" {
+		return
+	}
+	key := joinKey(prefix, a.Key)
+	switch value.Kind() {
+	case slog.KindDuration:
+		data[key] = value.Duration().String()
+	case slog.KindTime:
+		data[key] = value.Time().Format(time.RFC3339Nano)
+	case slog.KindAny:
+		if err, ok := value.Any().(error); ok {
+			data[key] = err.Error()
+		} else {
+			data[key] = value.Any()
+		}
+	default:
+		data[key] = value.Any()
+	}
+}
+
+// joinKey joins a group prefix and a key with a dot.
+func joinKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+// fanoutHandler sends each record to every handler that accepts its level.
+type fanoutHandler []slog.Handler
+
+// Enabled implements slog.Handler.
+func (f fanoutHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, h := range f {
+		if h.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+// Handle implements slog.Handler. It returns the first error but still tries every handler.
+func (f fanoutHandler) Handle(ctx context.Context, r slog.Record) error {
+	var firstErr error
+	for _, h := range f {
+		if !h.Enabled(ctx, r.Level) {
+			continue
+		}
+		if err := h.Handle(ctx, r.Clone()); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// WithAttrs implements slog.Handler.
+func (f fanoutHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handlers := make(fanoutHandler, len(f))
+	for i, h := range f {
+		handlers[i] = h.WithAttrs(attrs)
+	}
+	return handlers
+}
+
+// WithGroup implements slog.Handler.
+func (f fanoutHandler) WithGroup(name string) slog.Handler {
+	handlers := make(fanoutHandler, len(f))
+	for i, h := range f {
+		handlers[i] = h.WithGroup(name)
+	}
+	return handlers
+}
+
+// toolLogging returns middleware that logs every tool call with its name, redacted arguments,
+// duration and outcome.
+func toolLogging(logger *slog.Logger) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			start := time.Now()
+			result, err := next(ctx, request)
+
+			attrs := []any{
+				"tool", request.Params.Name,
+				"args", redactArgs(request.Params.Arguments),
+				"duration", time.Since(start),
+			}
+			switch {
+			case err != nil:
+				logger.Error("tool call failed", append(attrs, "result", "error", "error", err)...)
+			case result != nil && result.IsError:
+				attrs = append(attrs, "result", "error")
+				if code, ok := result.Meta[tools.ErrorCodeMetaKey]; ok {
+					attrs = append(attrs, "error_code", code)
+				}
+				logger.Warn("tool call returned an error", append(attrs, "error", resultText(result))...)
+			default:
+				logger.Info("tool call", append(attrs, "result", "ok")...)
+			}
+			return result, err
+		}
+	}
+}
+
+// redactArgs returns a copy of a tool's arguments with sensitive values replaced.
+func redactArgs(args map[string]interface{}) map[string]interface{} {
+	clean := make(map[string]interface{}, len(args))
+	for key, value := range args {
+		if isSensitiveArg(key) {
+			clean[key] = redacted
+			continue
+		}
+		clean[key] = value
+	}
+	return clean
+}
+
+// isSensitiveArg reports whether an argument's value must not be logged.
+func isSensitiveArg(name string) bool {
+	lower := strings.ToLower(name)
+	for _, fragment := range sensitiveArgFragments {
+		if strings.Contains(lower, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// resultText returns the first text content of a tool result.
+func resultText(result *mcp.CallToolResult) string {
+	for _, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			return text.Text
+		}
+	}
+	return ""
+}
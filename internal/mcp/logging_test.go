@@ -0,0 +1,187 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/imcclaskey/d3/internal/mcp/tools"
+)
+
+func TestSlogLevel(t *testing.T) {
+	tests := []struct {
+		level  mcp.LoggingLevel
+		want   slog.Level
+		wantOK bool
+	}{
+		{level: mcp.LoggingLevelDebug, want: slog.LevelDebug, wantOK: true},
+		{level: mcp.LoggingLevelNotice, want: slog.LevelInfo, wantOK: true},
+		{level: mcp.LoggingLevelWarning, want: slog.LevelWarn, wantOK: true},
+		{level: mcp.LoggingLevelEmergency, want: slog.LevelError, wantOK: true},
+		{level: "verbose", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.level), func(t *testing.T) {
+			got, ok := slogLevel(tt.level)
+			if ok != tt.wantOK || (ok && got != tt.want) {
+				t.Errorf("slogLevel(%q) = %v, %v, want %v, %v", tt.level, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestMCPLevel(t *testing.T) {
+	tests := []struct {
+		level slog.Level
+		want  mcp.LoggingLevel
+	}{
+		{level: slog.LevelDebug, want: mcp.LoggingLevelDebug},
+		{level: slog.LevelInfo, want: mcp.LoggingLevelInfo},
+		{level: slog.LevelWarn, want: mcp.LoggingLevelWarning},
+		{level: slog.LevelError + 4, want: mcp.LoggingLevelError},
+	}
+	for _, tt := range tests {
+		if got := mcpLevel(tt.level); got != tt.want {
+			t.Errorf("mcpLevel(%v) = %q, want %q", tt.level, got, tt.want)
+		}
+	}
+}
+
+func TestClientHandler(t *testing.T) {
+	var sent []map[string]any
+	level := &clientLevel{}
+	handler := newClientHandler(func(method string, params map[string]any) {
+		if method != "notifications/message" {
+			t.Errorf("notify() method = %q, want notifications/message", method)
+		}
+		sent = append(sent, params)
+	}, level)
+	logger := slog.New(handler).With("component", "project")
+
+	logger.Error("before set level")
+	if len(sent) != 0 {
+		t.Fatalf("sent %d notifications before the client set a level", len(sent))
+	}
+
+	level.Set(slog.LevelWarn)
+	logger.Info("below the client level")
+	logger.WithGroup("op").Warn("feature created", "feature", "login", "duration", 1500*time.Millisecond, "error", errors.New("disk full"))
+
+	if len(sent) != 1 {
+		t.Fatalf("sent %d notifications, want 1", len(sent))
+	}
+	if sent[0]["level"] != mcp.LoggingLevelWarning || sent[0]["logger"] != loggerName {
+		t.Errorf("notification params = %v", sent[0])
+	}
+	data, _ := sent[0]["data"].(map[string]any)
+	want := map[string]any{
+		"message":     "feature created",
+		"component":   "project",
+		"op.feature":  "login",
+		"op.duration": "1.5s",
+		"op.error":    "disk full",
+	}
+	for key, value := range want {
+		if data[key] != value {
+			t.Errorf("data[%q] = %v, want %v", key, data[key], value)
+		}
+	}
+}
+
+func TestFanoutHandler(t *testing.T) {
+	var infoBuf, errorBuf bytes.Buffer
+	logger := slog.New(fanoutHandler{
+		slog.NewTextHandler(&infoBuf, &slog.HandlerOptions{Level: slog.LevelInfo}),
+		slog.NewTextHandler(&errorBuf, &slog.HandlerOptions{Level: slog.LevelError}),
+	})
+
+	logger.Info("routine")
+	logger.Error("broken")
+
+	if !bytes.Contains(infoBuf.Bytes(), []byte("routine")) || !bytes.Contains(infoBuf.Bytes(), []byte("broken")) {
+		t.Errorf("info handler got %q, want both records", infoBuf.String())
+	}
+	if bytes.Contains(errorBuf.Bytes(), []byte("routine")) || !bytes.Contains(errorBuf.Bytes(), []byte("broken")) {
+		t.Errorf("error handler got %q, want only the error record", errorBuf.String())
+	}
+}
+
+func TestRedactArgs(t *testing.T) {
+	args := map[string]interface{}{"feature_name": "login", "api_token": "abc", "Password": "hunter2"}
+	got := redactArgs(args)
+
+	if got["feature_name"] != "login" {
+		t.Errorf("redactArgs() feature_name = %v, want login", got["feature_name"])
+	}
+	if got["api_token"] != redacted || got["Password"] != redacted {
+		t.Errorf("redactArgs() = %v, want sensitive values redacted", got)
+	}
+	if args["api_token"] != "abc" {
+		t.Error("redactArgs() modified its input")
+	}
+}
+
+func TestToolLogging(t *testing.T) {
+	tests := []struct {
+		name       string
+		result     *mcp.CallToolResult
+		err        error
+		wantLevel  string
+		wantResult string
+		wantCode   string
+	}{
+		{name: "success", result: mcp.NewToolResultText("done"), wantLevel: "INFO", wantResult: "ok"},
+		{
+			name: "tool error",
+			result: func() *mcp.CallToolResult {
+				r := mcp.NewToolResultError("feature login already exists")
+				r.Meta = map[string]interface{}{tools.ErrorCodeMetaKey: "feature_exists"}
+				return r
+			}(),
+			wantLevel:  "WARN",
+			wantResult: "error",
+			wantCode:   "feature_exists",
+		},
+		{name: "handler error", err: errors.New("boom"), wantLevel: "ERROR", wantResult: "error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&buf, nil))
+			handler := toolLogging(logger)(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return tt.result, tt.err
+			})
+
+			var request mcp.CallToolRequest
+			request.Params.Name = "d3_feature_create"
+			request.Params.Arguments = map[string]interface{}{"feature_name": "login", "secret": "s3cr3t"}
+			if _, err := handler(context.Background(), request); err != tt.err {
+				t.Fatalf("handler() error = %v, want %v", err, tt.err)
+			}
+
+			var record map[string]any
+			if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+				t.Fatalf("log is not a single JSON record: %v\n%s", err, buf.String())
+			}
+			if record["level"] != tt.wantLevel || record["tool"] != "d3_feature_create" || record["result"] != tt.wantResult {
+				t.Errorf("log record = %v", record)
+			}
+			if _, ok := record["duration"]; !ok {
+				t.Error("log record has no duration")
+			}
+			args, _ := record["args"].(map[string]any)
+			if args["feature_name"] != "login" || args["secret"] != redacted {
+				t.Errorf("log record args = %v", args)
+			}
+			if tt.wantCode != "" && record["error_code"] != tt.wantCode {
+				t.Errorf("log record error_code = %v, want %s", record["error_code"], tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/imcclaskey/d3/internal/core/phase"
+)
+
+// phaseFileName is the file in a feature directory holding the feature's current phase.
+const phaseFileName = ".phase"
+
+// registerResources exposes the feature artifacts and tech.md as file:// resources, so that
+// clients can read them and, with serve --watch, learn when they are edited.
+func (s *Server) registerResources() {
+	featuresURI := fileURI(s.cfg.FeaturesDir)
+	for _, p := range phase.Lifecycle {
+		name := phase.PhaseFileMap[p]
+		template := mcp.NewResourceTemplate(
+			featuresURI+"/{feature}/"+string(p)+"/"+name,
+			name,
+			mcp.WithTemplateDescription(fmt.Sprintf("The %s phase document of a feature", p)),
+			mcp.WithTemplateMIMEType(artifactMIMEType(name)),
+		)
+		s.mcp.AddResourceTemplate(template, s.readArtifact)
+	}
+	s.mcp.AddResourceTemplate(mcp.NewResourceTemplate(
+		featuresURI+"/{feature}/"+phaseFileName,
+		phaseFileName,
+		mcp.WithTemplateDescription("The current phase of a feature"),
+		mcp.WithTemplateMIMEType("text/plain"),
+	), s.readArtifact)
+	s.mcp.AddResource(mcp.NewResource(
+		fileURI(s.techPath()),
+		"tech.md",
+		mcp.WithResourceDescription("The project's technical context"),
+		mcp.WithMIMEType("text/markdown"),
+	), s.readArtifact)
+}
+
+// readArtifact returns the contents of the artifact a resource URI names.
+func (s *Server) readArtifact(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	u, err := url.Parse(request.Params.URI)
+	if err != nil || u.Scheme != "file" {
+		return nil, fmt.Errorf("invalid resource URI %q", request.Params.URI)
+	}
+	path := filepath.FromSlash(u.Path)
+	if !s.isArtifact(path) {
+		return nil, fmt.Errorf("%s is not a d3 artifact", path)
+	}
+	data, err := s.fs.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return []mcp.ResourceContents{mcp.TextResourceContents{
+		URI:      request.Params.URI,
+		MIMEType: artifactMIMEType(path),
+		Text:     string(data),
+	}}, nil
+}
+
+// isArtifact reports whether path is one of the exposed resources: a feature's phase document or
+// .phase file, or tech.md.
+func (s *Server) isArtifact(path string) bool {
+	if path == s.techPath() {
+		return true
+	}
+	rel, err := filepath.Rel(s.cfg.FeaturesDir, path)
+	if err != nil {
+		return false
+	}
+	parts := strings.Split(filepath.ToSlash(rel), "/")
+	if parts[0] == ".." || parts[0] == "." {
+		return false
+	}
+	switch len(parts) {
+	case 2:
+		return parts[1] == phaseFileName
+	case 3:
+		name, ok := phase.PhaseFileMap[phase.Phase(parts[1])]
+		return ok && parts[2] == name
+	}
+	return false
+}
+
+// techPath returns the path of the project's tech.md.
+func (s *Server) techPath() string {
+	return filepath.Join(s.cfg.D3Dir, "tech.md")
+}
+
+// artifactMIMEType returns the MIME type reported for an artifact.
+func artifactMIMEType(path string) string {
+	switch filepath.Ext(path) {
+	case ".md":
+		return "text/markdown"
+	case ".yaml":
+		return "application/yaml"
+	}
+	return "text/plain"
+}
+
+// fileURI returns the file:// URI identifying a file as a resource. Apostrophes are escaped as
+// well, since URI templates do not allow them.
+func fileURI(path string) string {
+	uri := (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+	return strings.ReplaceAll(uri, "'", "%27")
+}
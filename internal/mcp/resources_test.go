@@ -0,0 +1,71 @@
+package mcp
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/imcclaskey/d3/internal/core/config"
+)
+
+func TestServer_isArtifact(t *testing.T) {
+	s := &Server{cfg: config.Default("/p")}
+	tests := map[string]bool{
+		"/p/.d3/features/login/define/problem.md":             true,
+		"/p/.d3/features/login/design/plan.md":                true,
+		"/p/.d3/features/login/deliver/progress.yaml":         true,
+		"/p/.d3/features/login/.phase":                        true,
+		"/p/.d3/tech.md":                                      true,
+		"/p/.d3/features/login/define/plan.md":                false,
+		"/p/.d3/features/login/notes.md":                      false,
+		"/p/.d3/features/login/snapshots/1/define/problem.md": false,
+		"/p/.d3/rules/core.md":                                false,
+		"/p/.d3/.feature":                                     false,
+		"/elsewhere/login/define/problem.md":                  false,
+	}
+	for path, want := range tests {
+		if got := s.isArtifact(path); got != want {
+			t.Errorf("isArtifact(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestServer_readArtifact(t *testing.T) {
+	c := startStdioServer(t)
+	c.send(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{},"clientInfo":{"name":"test","version":"1"}}}`)
+	c.next(responseTo(1))
+	c.send(`{"jsonrpc":"2.0","method":"notifications/initialized"}`)
+
+	plan := filepath.Join(c.server.cfg.FeaturesDir, "login", "design", "plan.md")
+	if err := os.MkdirAll(filepath.Dir(plan), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(plan, []byte("# Plan\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	c.send(`{"jsonrpc":"2.0","id":2,"method":"resources/read","params":{"uri":"` + fileURI(plan) + `"}}`)
+	response := c.next(responseTo(2))
+	result, _ := response["result"].(map[string]any)
+	contents, _ := result["contents"].([]any)
+	if len(contents) != 1 {
+		t.Fatalf("resources/read = %v, want one content", response)
+	}
+	content, _ := contents[0].(map[string]any)
+	if content["text"] != "# Plan\n" || content["mimeType"] != "text/markdown" {
+		t.Errorf("resources/read content = %v", content)
+	}
+}
+
+func TestFileURI(t *testing.T) {
+	tests := map[string]string{
+		"/p/.d3/tech.md":               "file:///p/.d3/tech.md",
+		"/my project/.d3/rules/a b.md": "file:///my%20project/.d3/rules/a%20b.md",
+		"/bob's/.d3/tech.md":           "file:///bob%27s/.d3/tech.md",
+	}
+	for path, want := range tests {
+		if got := fileURI(path); got != want {
+			t.Errorf("fileURI(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
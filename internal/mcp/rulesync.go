@@ -0,0 +1,45 @@
+package mcp
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/imcclaskey/d3/internal/project"
+)
+
+// rulesSyncer reconciles the generated rules with the on-disk state when the server starts and
+// before each tool call, so that hand edits and branch switches are picked up without a
+// mutating command. Tool calls may run concurrently, so syncs are serialized.
+type rulesSyncer struct {
+	mu      sync.Mutex
+	project project.ProjectService
+	logger  *slog.Logger
+}
+
+// sync reconciles the generated rules. Failures are logged rather than returned: stale rules
+// must not stop a tool call, and tools report an uninitialized project themselves.
+func (r *rulesSyncer) sync(ctx context.Context) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.project.IsInitialized() {
+		return
+	}
+	if _, err := r.project.SyncRules(ctx); err != nil {
+		r.logger.Warn("failed to sync generated rules", "error", err)
+	}
+}
+
+// middleware returns tool middleware that syncs the rules before calling the tool.
+func (r *rulesSyncer) middleware() server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			r.sync(ctx)
+			return next(ctx, request)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/imcclaskey/d3/internal/project"
+)
+
+func TestRulesSyncer_middleware(t *testing.T) {
+	tests := []struct {
+		name       string
+		setupMocks func(mockSvc *project.MockProjectService)
+		wantLog    string
+	}{
+		{
+			name: "syncs before the tool runs",
+			setupMocks: func(mockSvc *project.MockProjectService) {
+				mockSvc.EXPECT().IsInitialized().Return(true).Times(1)
+				mockSvc.EXPECT().SyncRules(gomock.Any()).Return(project.NewResult("Cursor rules are up to date."), nil).Times(1)
+			},
+		},
+		{
+			name: "uninitialized project is left to the tool",
+			setupMocks: func(mockSvc *project.MockProjectService) {
+				mockSvc.EXPECT().IsInitialized().Return(false).Times(1)
+			},
+		},
+		{
+			name: "sync failure is logged and the tool still runs",
+			setupMocks: func(mockSvc *project.MockProjectService) {
+				mockSvc.EXPECT().IsInitialized().Return(true).Times(1)
+				mockSvc.EXPECT().SyncRules(gomock.Any()).Return(nil, fmt.Errorf("phase file for feature login is empty")).Times(1)
+			},
+			wantLog: "failed to sync generated rules",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockSvc := project.NewMockProjectService(ctrl)
+			tt.setupMocks(mockSvc)
+
+			var logs bytes.Buffer
+			syncer := &rulesSyncer{project: mockSvc, logger: slog.New(slog.NewTextHandler(&logs, nil))}
+
+			called := false
+			handler := syncer.middleware()(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				called = true
+				return mcp.NewToolResultText("ok"), nil
+			})
+			if _, err := handler(context.Background(), mcp.CallToolRequest{}); err != nil {
+				t.Fatalf("handler() error = %v", err)
+			}
+
+			if !called {
+				t.Error("middleware did not call the tool")
+			}
+			if tt.wantLog != "" && !strings.Contains(logs.String(), tt.wantLog) {
+				t.Errorf("logs = %q, want to contain %q", logs.String(), tt.wantLog)
+			}
+			if tt.wantLog == "" && logs.Len() > 0 {
+				t.Errorf("unexpected logs: %q", logs.String())
+			}
+		})
+	}
+}
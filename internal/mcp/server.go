@@ -2,54 +2,95 @@
 package mcp
 
 import (
-	"path/filepath"
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/mark3labs/mcp-go/server"
 
+	"github.com/imcclaskey/d3/internal/core/config"
 	"github.com/imcclaskey/d3/internal/core/feature"
-	"github.com/imcclaskey/d3/internal/core/phase"
 	"github.com/imcclaskey/d3/internal/core/ports"
-	"github.com/imcclaskey/d3/internal/core/projectfiles"
-	"github.com/imcclaskey/d3/internal/core/rules"
+	"github.com/imcclaskey/d3/internal/core/watch"
 	"github.com/imcclaskey/d3/internal/mcp/tools"
 	"github.com/imcclaskey/d3/internal/project"
 	"github.com/imcclaskey/d3/internal/version"
 )
 
-// NewServer creates a new MCP server for d3
-func NewServer(workspaceRoot string) *server.MCPServer {
-	// Initialize services
-	fs := ports.RealFileSystem{}
-
-	d3Dir := filepath.Join(workspaceRoot, ".d3")
-	featuresDir := filepath.Join(d3Dir, "features")
-	cursorRulesDir := filepath.Join(workspaceRoot, ".cursor", "rules")
+// Server is the d3 MCP server together with the logging state shared by its services and
+// its transport.
+type Server struct {
+	mcp         *server.MCPServer
+	logger      *slog.Logger
+	clientLevel *clientLevel
+	rules       *rulesSyncer
+	cfg         config.Config
+	fs          ports.FileSystem
+	watchOpts   *watch.Options
+}
 
-	// sessionSvc := session.NewStorage(fs) // REMOVED: session.Storage and NewStorage removed
-	featureSvc := feature.NewService(workspaceRoot, featuresDir, d3Dir, fs)
-	ruleGenerator := rules.NewRuleGenerator(workspaceRoot, fs)
-	rulesSvc := rules.NewService(workspaceRoot, cursorRulesDir, ruleGenerator, fs)
-	phaseSvc := phase.NewService(fs)
-	fileOp := projectfiles.NewDefaultFileOperator()
+// NewServer creates a new MCP server for d3 with services built from the resolved project
+// configuration. Services, tool calls and the transport log through logger, and also to the
+// client once it enables log notifications with logging/setLevel.
+func NewServer(cfg config.Config, logger *slog.Logger) *Server {
+	if logger == nil {
+		logger = slog.Default()
+	}
 
-	// Initialize real project instance. It implements ProjectService.
-	proj := project.New(workspaceRoot, fs /*sessionSvc,*/, featureSvc, rulesSvc, phaseSvc, fileOp) // REMOVED sessionSvc argument
+	// Records go to the given logger and, once the client asks for them, to the client
+	s := &Server{clientLevel: &clientLevel{}, rules: &rulesSyncer{}, cfg: cfg, fs: ports.RealFileSystem{}}
+	notifyClient := func(method string, params map[string]any) {
+		s.mcp.SendNotificationToAllClients(method, params)
+	}
+	s.logger = slog.New(fanoutHandler{logger.Handler(), newClientHandler(notifyClient, s.clientLevel)})
 
 	// Create MCP server
-	mcpServer := server.NewMCPServer(
+	s.mcp = server.NewMCPServer(
 		"d3 - Define, Design, Deliver!",
 		version.Version,
 		server.WithInstructions("d3 is a structured workflow engine for AI-driven development within Cursor"),
 		server.WithToolCapabilities(true),
+		server.WithLogging(),
+		server.WithToolHandlerMiddleware(toolLogging(s.logger.With("component", "mcp"))),
+		server.WithToolHandlerMiddleware(s.rules.middleware()),
 	)
 
+	// Initialize services
+	wireOpts := project.WireOptions{Logger: s.logger, ComponentAttr: true}
+	// D3_TRASH_RETENTION overrides trash_retention of the config; an unparsable value is ignored
+	if value := os.Getenv(feature.TrashRetentionEnv); value != "" {
+		if retention, err := feature.ParseTrashRetention(value); err == nil {
+			wireOpts.TrashRetention = &retention
+		}
+	}
+	// The server process inherits D3_SESSION from the editor that launched it
+	proj, _ := project.Wire(cfg, ports.RealFileSystem{}, wireOpts)
+	s.rules.project = proj
+	s.rules.logger = s.logger.With("component", "mcp")
+
 	// Register tools, proj (a *project.Project) satisfies project.ProjectService.
-	tools.RegisterTools(mcpServer, proj)
+	tools.RegisterTools(s.mcp, proj)
+	s.registerResources()
 
-	return mcpServer
+	return s
 }
 
-// ServeStdio starts the MCP server over stdio
-func ServeStdio(s *server.MCPServer) error {
-	return server.ServeStdio(s)
+// ServeStdio starts the MCP server over stdio and runs until stdin closes or the process
+// receives SIGINT or SIGTERM. The file watcher, if enabled, runs for as long as the server.
+func ServeStdio(s *Server) error {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	s.logger.Info("serving MCP over stdio", "version", version.Version)
+	s.rules.sync(ctx)
+	s.startWatch(ctx)
+	err := s.serve(ctx, os.Stdin, os.Stdout)
+	if err != nil && ctx.Err() == nil {
+		s.logger.Error("MCP server stopped", "error", err)
+		return err
+	}
+	s.logger.Info("MCP server stopped")
+	return nil
 }
@@ -0,0 +1,147 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// methodSetLevel is the request a client sends to choose which log notifications it receives.
+// mcp-go advertises the logging capability but does not route this method, so the stdio
+// transport answers it before handing other messages to the server.
+const methodSetLevel = "logging/setLevel"
+
+// stdioSession is the single client session of a stdio server.
+type stdioSession struct {
+	notifications chan mcp.JSONRPCNotification
+	initialized   atomic.Bool
+}
+
+func (s *stdioSession) SessionID() string { return "stdio" }
+
+func (s *stdioSession) NotificationChannel() chan<- mcp.JSONRPCNotification {
+	return s.notifications
+}
+
+func (s *stdioSession) Initialize() { s.initialized.Store(true) }
+
+func (s *stdioSession) Initialized() bool { return s.initialized.Load() }
+
+var _ server.ClientSession = (*stdioSession)(nil)
+
+// lineWriter writes one JSON message per line. Responses and notifications are written from
+// different goroutines, so writes are serialized.
+type lineWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (lw *lineWriter) write(message any) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+	_, err = fmt.Fprintf(lw.w, "%s\n", data)
+	return err
+}
+
+// serve reads JSON-RPC messages from in, one per line, and writes responses and notifications
+// to out until in is closed or ctx is cancelled.
+func (s *Server) serve(ctx context.Context, in io.Reader, out io.Writer) error {
+	session := &stdioSession{notifications: make(chan mcp.JSONRPCNotification, 100)}
+	if err := s.mcp.RegisterSession(ctx, session); err != nil {
+		return fmt.Errorf("register session: %w", err)
+	}
+	defer s.mcp.UnregisterSession(ctx, session.SessionID())
+	ctx = s.mcp.WithContext(ctx, session)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	writer := &lineWriter{w: out}
+	go func() {
+		for {
+			select {
+			case notification := <-session.notifications:
+				// NotificationParams marshals its fields with a pointer receiver, so the
+				// notification must be addressable for its params to be written
+				if err := writer.write(&notification); err != nil {
+					s.logger.Error("failed to write notification", "method", notification.Method, "error", err)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	lines := make(chan string)
+	readErr := make(chan error, 1)
+	go func() {
+		reader := bufio.NewReader(in)
+		for {
+			line, err := reader.ReadString('\n')
+			if strings.TrimSpace(line) != "" {
+				select {
+				case lines <- line:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if err != nil {
+				readErr <- err
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-readErr:
+			if err == io.EOF {
+				return nil
+			}
+			return fmt.Errorf("failed to read input: %w", err)
+		case line := <-lines:
+			if response := s.handleMessage(ctx, json.RawMessage(line)); response != nil {
+				if err := writer.write(response); err != nil {
+					return fmt.Errorf("failed to write response: %w", err)
+				}
+			}
+		}
+	}
+}
+
+// handleMessage answers logging/setLevel itself and passes every other message to the server.
+func (s *Server) handleMessage(ctx context.Context, message json.RawMessage) mcp.JSONRPCMessage {
+	var request struct {
+		ID     any    `json:"id"`
+		Method string `json:"method"`
+	}
+	if err := json.Unmarshal(message, &request); err != nil || request.Method != methodSetLevel || request.ID == nil {
+		return s.mcp.HandleMessage(ctx, message)
+	}
+
+	var setLevel mcp.SetLevelRequest
+	if err := json.Unmarshal(message, &setLevel); err != nil {
+		return mcp.NewJSONRPCError(request.ID, mcp.INVALID_PARAMS, fmt.Sprintf("invalid %s request: %v", methodSetLevel, err), nil)
+	}
+	level, ok := slogLevel(setLevel.Params.Level)
+	if !ok {
+		return mcp.NewJSONRPCError(request.ID, mcp.INVALID_PARAMS, fmt.Sprintf("unknown logging level %q", setLevel.Params.Level), nil)
+	}
+	s.clientLevel.Set(level)
+	s.logger.Debug("client set log level", "requested", setLevel.Params.Level)
+	return mcp.NewJSONRPCResponse(request.ID, mcp.Result{})
+}
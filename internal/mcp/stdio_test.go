@@ -0,0 +1,130 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/imcclaskey/d3/internal/core/config"
+)
+
+// stdioClient drives a Server over pipes, one JSON message per line.
+type stdioClient struct {
+	t      *testing.T
+	server *Server
+	in     *io.PipeWriter
+	lines  chan map[string]any
+}
+
+func startStdioServer(t *testing.T) *stdioClient {
+	t.Helper()
+	s := NewServer(config.Default(t.TempDir()), slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	inR, inW := io.Pipe()
+	outR, outW := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		done <- s.serve(context.Background(), inR, outW)
+		outW.Close()
+	}()
+
+	c := &stdioClient{t: t, server: s, in: inW, lines: make(chan map[string]any, 16)}
+	go func() {
+		scanner := bufio.NewScanner(outR)
+		for scanner.Scan() {
+			var message map[string]any
+			if err := json.Unmarshal(scanner.Bytes(), &message); err != nil {
+				t.Errorf("server wrote invalid JSON: %v\n%s", err, scanner.Text())
+				continue
+			}
+			c.lines <- message
+		}
+		close(c.lines)
+	}()
+
+	t.Cleanup(func() {
+		inW.Close()
+		if err := <-done; err != nil {
+			t.Errorf("serve() error = %v", err)
+		}
+	})
+	return c
+}
+
+func (c *stdioClient) send(message string) {
+	c.t.Helper()
+	if _, err := fmt.Fprintln(c.in, message); err != nil {
+		c.t.Fatalf("failed to write message: %v", err)
+	}
+}
+
+// next returns the next message the server writes that satisfies match.
+func (c *stdioClient) next(match func(map[string]any) bool) map[string]any {
+	c.t.Helper()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case message, ok := <-c.lines:
+			if !ok {
+				c.t.Fatal("server closed its output")
+			}
+			if match(message) {
+				return message
+			}
+		case <-timeout:
+			c.t.Fatal("timed out waiting for a server message")
+		}
+	}
+}
+
+func responseTo(id float64) func(map[string]any) bool {
+	return func(message map[string]any) bool { return message["id"] == id }
+}
+
+func TestServer_Serve(t *testing.T) {
+	c := startStdioServer(t)
+
+	c.send(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{},"clientInfo":{"name":"test","version":"1"}}}`)
+	initialized := c.next(responseTo(1))
+	result, _ := initialized["result"].(map[string]any)
+	capabilities, _ := result["capabilities"].(map[string]any)
+	if _, ok := capabilities["logging"]; !ok {
+		t.Errorf("initialize capabilities = %v, want logging", capabilities)
+	}
+	c.send(`{"jsonrpc":"2.0","method":"notifications/initialized"}`)
+
+	c.send(`{"jsonrpc":"2.0","id":2,"method":"logging/setLevel","params":{"level":"bogus"}}`)
+	if response := c.next(responseTo(2)); response["error"] == nil {
+		t.Errorf("setLevel with an unknown level = %v, want an error", response)
+	}
+
+	c.send(`{"jsonrpc":"2.0","id":3,"method":"logging/setLevel","params":{"level":"info"}}`)
+	if response := c.next(responseTo(3)); response["error"] != nil || response["result"] == nil {
+		t.Errorf("setLevel = %v, want an empty result", response)
+	}
+
+	c.send(`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"d3_feature_create","arguments":{"name":"login","api_token":"abc"}}}`)
+	if response := c.next(responseTo(4)); response["result"] == nil {
+		t.Errorf("tools/call = %v, want a tool result", response)
+	}
+
+	notification := c.next(func(message map[string]any) bool {
+		params, _ := message["params"].(map[string]any)
+		data, _ := params["data"].(map[string]any)
+		return message["method"] == "notifications/message" && data["tool"] == "d3_feature_create"
+	})
+	params := notification["params"].(map[string]any)
+	data := params["data"].(map[string]any)
+	if params["level"] != "warning" || data["result"] != "error" || data["error_code"] != "not_initialized" {
+		t.Errorf("tool log notification = %v", params)
+	}
+	args, _ := data["args"].(map[string]any)
+	if args["name"] != "login" || args["api_token"] != redacted {
+		t.Errorf("tool log args = %v, want api_token redacted", args)
+	}
+}
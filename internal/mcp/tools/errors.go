@@ -0,0 +1,31 @@
+package tools
+
+import (
+	"fmt"
+
+	"github.com/imcclaskey/d3/internal/core/d3err"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ErrorCodeMetaKey is the _meta key that carries the d3err code of a failed tool call.
+const ErrorCodeMetaKey = "error_code"
+
+// errorResult returns a tool error result carrying a machine-readable code. The first content
+// item is the message; the second is an "error_code: <code>" line that the assistant can act
+// on, and the code is repeated in the result's _meta for clients.
+func errorResult(code d3err.Code, message string) *mcp.CallToolResult {
+	result := mcp.NewToolResultError(message)
+	result.Content = append(result.Content, mcp.NewTextContent(fmt.Sprintf("%s: %s", ErrorCodeMetaKey, code)))
+	result.Meta = map[string]interface{}{ErrorCodeMetaKey: string(code)}
+	return result
+}
+
+// errorResultf is errorResult with a formatted message.
+func errorResultf(code d3err.Code, format string, args ...interface{}) *mcp.CallToolResult {
+	return errorResult(code, fmt.Sprintf(format, args...))
+}
+
+// errorResultFromErr returns a tool error result for err, coded with d3err.CodeOf.
+func errorResultFromErr(message string, err error) *mcp.CallToolResult {
+	return errorResult(d3err.CodeOf(err), message)
+}
@@ -2,11 +2,17 @@ package tools
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 
+	"github.com/imcclaskey/d3/internal/core/check"
+	"github.com/imcclaskey/d3/internal/core/d3err"
+	"github.com/imcclaskey/d3/internal/core/export"
+	"github.com/imcclaskey/d3/internal/core/feature"
+	"github.com/imcclaskey/d3/internal/core/trace"
 	"github.com/imcclaskey/d3/internal/project"
 )
 
@@ -36,7 +42,7 @@ var FeatureExitTool = mcp.NewTool("d3_feature_exit",
 
 // FeatureDeleteTool defines the d3_feature_delete tool
 var FeatureDeleteTool = mcp.NewTool("d3_feature_delete",
-	mcp.WithDescription("Delete a feature and its associated content. This action is permanent."),
+	mcp.WithDescription("Delete a feature by moving it and its associated content to the trash. It can be restored with d3_feature_restore."),
 	mcp.WithString("feature_name",
 		mcp.Required(),
 		mcp.Description("Name of the feature to delete"),
@@ -46,6 +52,47 @@ var FeatureDeleteTool = mcp.NewTool("d3_feature_delete",
 	),
 )
 
+// FeatureRestoreTool defines the d3_feature_restore tool
+var FeatureRestoreTool = mcp.NewTool("d3_feature_restore",
+	mcp.WithDescription("Restore the most recently deleted copy of a feature from the trash."),
+	mcp.WithString("feature_name",
+		mcp.Required(),
+		mcp.Description("Name of the feature to restore"),
+	),
+)
+
+// FeatureExportTool defines the d3_feature_export tool
+var FeatureExportTool = mcp.NewTool("d3_feature_export",
+	mcp.WithDescription("Export a feature's problem, plan and task list as a single document. Use preset 'pr' at the end of deliver to produce a pull-request description."),
+	mcp.WithString("feature_name",
+		mcp.Description("Name of the feature to export. Defaults to the active feature."),
+	),
+	mcp.WithString("format",
+		mcp.Description("Output format: markdown (default), html or json"),
+		mcp.Enum("markdown", "html", "json"),
+	),
+	mcp.WithString("preset",
+		mcp.Description("Content preset: full (default) or pr"),
+		mcp.Enum("full", "pr"),
+	),
+)
+
+// FeatureTraceTool defines the d3_feature_trace tool
+var FeatureTraceTool = mcp.NewTool("d3_feature_trace",
+	mcp.WithDescription("Report how the requirement IDs declared in problem.md (e.g. R1, AC-2) are covered by plan.md steps and progress.yaml tasks: uncovered requirements, plan steps with no tasks and references to undeclared IDs."),
+	mcp.WithString("feature_name",
+		mcp.Description("Name of the feature to trace. Defaults to the active feature."),
+	),
+)
+
+// FeatureCheckTool defines the d3_feature_check tool
+var FeatureCheckTool = mcp.NewTool("d3_feature_check",
+	mcp.WithDescription("Check that the problem.md and plan.md the feature has reached follow the section structure of the define and design templates. Returns file:line diagnostics for missing, empty and misplaced sections."),
+	mcp.WithString("feature_name",
+		mcp.Description("Name of the feature to check. Defaults to the active feature."),
+	),
+)
+
 // HandleFeatureCreate returns a handler for the d3_feature_create tool
 // It now accepts project.ProjectService interface for testability.
 func HandleFeatureCreate(proj project.ProjectService) server.ToolHandlerFunc {
@@ -53,22 +100,26 @@ func HandleFeatureCreate(proj project.ProjectService) server.ToolHandlerFunc {
 		// Extract feature name
 		featureName, ok := request.Params.Arguments["name"].(string)
 		if !ok || featureName == "" {
-			return mcp.NewToolResultError("Feature name 'name' is required"), nil
+			return errorResult(d3err.InvalidArgument, "Feature name 'name' is required"), nil
+		}
+		featureName, err := feature.NormalizeName(featureName)
+		if err != nil {
+			return errorResultf(d3err.InvalidName, "Invalid feature name: %v", err), nil
 		}
 
 		// Check if project is valid
 		if proj == nil {
-			return mcp.NewToolResultError("Internal error: Project context is nil"), nil
+			return errorResult(d3err.Unknown, "Internal error: Project context is nil"), nil
 		}
 
 		// Call the project method to create the feature
 		result, err := proj.CreateFeature(ctx, featureName)
 		if err != nil {
-			if err == project.ErrNotInitialized {
-				return mcp.NewToolResultError("Cannot create feature: project not initialized"), nil
+			if errors.Is(err, project.ErrNotInitialized) {
+				return errorResult(d3err.NotInitialized, "Cannot create feature: project not initialized"), nil
 			}
 			// For other errors, return them as system errors to provide more detail to the MCP client if needed.
-			return mcp.NewToolResultError(fmt.Sprintf("System error creating feature: %v", err)), nil
+			return errorResultFromErr(fmt.Sprintf("System error creating feature: %v", err), err), nil
 		}
 
 		// Return the formatted result
@@ -82,22 +133,26 @@ func HandleFeatureEnter(proj project.ProjectService) server.ToolHandlerFunc {
 		// Extract feature name
 		featureName, ok := request.Params.Arguments["feature_name"].(string)
 		if !ok || featureName == "" {
-			return mcp.NewToolResultError("Feature name 'feature_name' is required"), nil
+			return errorResult(d3err.InvalidArgument, "Feature name 'feature_name' is required"), nil
+		}
+		featureName, err := feature.NormalizeName(featureName)
+		if err != nil {
+			return errorResultf(d3err.InvalidName, "Invalid feature name: %v", err), nil
 		}
 
 		if proj == nil {
-			return mcp.NewToolResultError("Internal error: Project context is nil"), nil
+			return errorResult(d3err.Unknown, "Internal error: Project context is nil"), nil
 		}
 
 		// Call the project method to enter the feature
 		result, err := proj.EnterFeature(ctx, featureName)
 		if err != nil {
 			// Handle specific known errors if necessary, otherwise return generic error
-			if err == project.ErrNotInitialized {
-				return mcp.NewToolResultError("Cannot enter feature: project not initialized"), nil
+			if errors.Is(err, project.ErrNotInitialized) {
+				return errorResult(d3err.NotInitialized, "Cannot enter feature: project not initialized"), nil
 			}
 			// Pass through the error message from EnterFeature
-			return mcp.NewToolResultError(fmt.Sprintf("System error entering feature: %v", err)), nil
+			return errorResultFromErr(fmt.Sprintf("System error entering feature: %v", err), err), nil
 		}
 
 		// Format success result using Result.FormatMCP()
@@ -109,17 +164,17 @@ func HandleFeatureEnter(proj project.ProjectService) server.ToolHandlerFunc {
 func HandleFeatureExit(proj project.ProjectService) server.ToolHandlerFunc {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		if proj == nil {
-			return mcp.NewToolResultError("Internal error: Project context is nil"), nil
+			return errorResult(d3err.Unknown, "Internal error: Project context is nil"), nil
 		}
 
 		// Call the project method to exit the feature
 		result, err := proj.ExitFeature(ctx)
 		if err != nil {
 			// Handle specific known errors if necessary
-			if err == project.ErrNotInitialized {
-				return mcp.NewToolResultError("Cannot exit feature: project not initialized"), nil
+			if errors.Is(err, project.ErrNotInitialized) {
+				return errorResult(d3err.NotInitialized, "Cannot exit feature: project not initialized"), nil
 			}
-			return mcp.NewToolResultError(fmt.Sprintf("System error exiting feature: %v", err)), nil
+			return errorResultFromErr(fmt.Sprintf("System error exiting feature: %v", err), err), nil
 		}
 
 		return mcp.NewToolResultText(result.FormatMCP()), nil
@@ -132,33 +187,167 @@ func HandleFeatureDelete(proj project.ProjectService) server.ToolHandlerFunc {
 		// Extract feature name
 		featureName, ok := request.Params.Arguments["feature_name"].(string)
 		if !ok || featureName == "" {
-			return mcp.NewToolResultError("Feature name 'feature_name' is required"), nil
+			return errorResult(d3err.InvalidArgument, "Feature name 'feature_name' is required"), nil
+		}
+		featureName, err := feature.NormalizeName(featureName)
+		if err != nil {
+			return errorResultf(d3err.InvalidName, "Invalid feature name: %v", err), nil
 		}
 
 		// Extract confirm parameter
 		confirm, _ := request.Params.Arguments["confirm"].(bool) // Defaults to false if not present or wrong type
 
 		if proj == nil {
-			return mcp.NewToolResultError("Internal error: Project context is nil"), nil
+			return errorResult(d3err.Unknown, "Internal error: Project context is nil"), nil
 		}
 
 		// Check for confirmation before proceeding
 		if !confirm {
-			return mcp.NewToolResultError(fmt.Sprintf("Are you sure you want to delete feature '%s'? This action cannot be undone. Please call again with confirm=true.", featureName)), nil
+			return errorResultf(d3err.InvalidArgument, "Are you sure you want to delete feature '%s'? It will be moved to the trash. Please call again with confirm=true.", featureName), nil
 		}
 
 		// Call the project method to delete the feature
 		result, err := proj.DeleteFeature(ctx, featureName)
 		if err != nil {
 			// Handle specific known errors if necessary
-			if err == project.ErrNotInitialized {
-				return mcp.NewToolResultError("Cannot delete feature: project not initialized"), nil
+			if errors.Is(err, project.ErrNotInitialized) {
+				return errorResult(d3err.NotInitialized, "Cannot delete feature: project not initialized"), nil
 			}
 			// Pass through the error message from DeleteFeature (which might indicate feature not found, etc.)
-			return mcp.NewToolResultError(fmt.Sprintf("System error deleting feature '%s': %v", featureName, err)), nil
+			return errorResultFromErr(fmt.Sprintf("System error deleting feature '%s': %v", featureName, err), err), nil
 		}
 
 		// Format success result using Result.FormatMCP()
 		return mcp.NewToolResultText(result.FormatMCP()), nil
 	}
 }
+
+// HandleFeatureRestore returns a handler for the d3_feature_restore tool
+func HandleFeatureRestore(proj project.ProjectService) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Extract feature name
+		featureName, ok := request.Params.Arguments["feature_name"].(string)
+		if !ok || featureName == "" {
+			return errorResult(d3err.InvalidArgument, "Feature name 'feature_name' is required"), nil
+		}
+		featureName, err := feature.NormalizeName(featureName)
+		if err != nil {
+			return errorResultf(d3err.InvalidName, "Invalid feature name: %v", err), nil
+		}
+
+		if proj == nil {
+			return errorResult(d3err.Unknown, "Internal error: Project context is nil"), nil
+		}
+
+		result, err := proj.RestoreFeature(ctx, featureName)
+		if err != nil {
+			if errors.Is(err, project.ErrNotInitialized) {
+				return errorResult(d3err.NotInitialized, "Cannot restore feature: project not initialized"), nil
+			}
+			return errorResultFromErr(fmt.Sprintf("System error restoring feature '%s': %v", featureName, err), err), nil
+		}
+
+		return mcp.NewToolResultText(result.FormatMCP()), nil
+	}
+}
+
+// HandleFeatureExport returns a handler for the d3_feature_export tool
+func HandleFeatureExport(proj project.ProjectService) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		featureName, _ := request.Params.Arguments["feature_name"].(string)
+		if featureName != "" {
+			var err error
+			if featureName, err = feature.NormalizeName(featureName); err != nil {
+				return errorResultf(d3err.InvalidName, "Invalid feature name: %v", err), nil
+			}
+		}
+
+		formatArg, _ := request.Params.Arguments["format"].(string)
+		format, err := export.ParseFormat(formatArg)
+		if err != nil {
+			return errorResult(d3err.InvalidArgument, err.Error()), nil
+		}
+		presetArg, _ := request.Params.Arguments["preset"].(string)
+		preset, err := export.ParsePreset(presetArg)
+		if err != nil {
+			return errorResult(d3err.InvalidArgument, err.Error()), nil
+		}
+
+		if proj == nil {
+			return errorResult(d3err.Unknown, "Internal error: Project context is nil"), nil
+		}
+
+		document, err := proj.ExportFeature(ctx, featureName, format, preset)
+		if err != nil {
+			switch {
+			case errors.Is(err, project.ErrNotInitialized):
+				return errorResult(d3err.NotInitialized, "Cannot export feature: project not initialized"), nil
+			case errors.Is(err, project.ErrNoActiveFeature):
+				return errorResult(d3err.NoActiveFeature, "Cannot export feature: no feature name given and no active feature"), nil
+			}
+			return errorResultFromErr(fmt.Sprintf("System error exporting feature: %v", err), err), nil
+		}
+
+		return mcp.NewToolResultText(document), nil
+	}
+}
+
+// HandleFeatureTrace returns a handler for the d3_feature_trace tool
+func HandleFeatureTrace(proj project.ProjectService) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		featureName, _ := request.Params.Arguments["feature_name"].(string)
+		if featureName != "" {
+			var err error
+			if featureName, err = feature.NormalizeName(featureName); err != nil {
+				return errorResultf(d3err.InvalidName, "Invalid feature name: %v", err), nil
+			}
+		}
+
+		if proj == nil {
+			return errorResult(d3err.Unknown, "Internal error: Project context is nil"), nil
+		}
+
+		report, err := proj.TraceFeature(ctx, featureName)
+		if err != nil {
+			switch {
+			case errors.Is(err, project.ErrNotInitialized):
+				return errorResult(d3err.NotInitialized, "Cannot trace feature: project not initialized"), nil
+			case errors.Is(err, project.ErrNoActiveFeature):
+				return errorResult(d3err.NoActiveFeature, "Cannot trace feature: no feature name given and no active feature"), nil
+			}
+			return errorResultFromErr(fmt.Sprintf("System error tracing feature: %v", err), err), nil
+		}
+
+		return mcp.NewToolResultText(trace.Render(report)), nil
+	}
+}
+
+// HandleFeatureCheck returns a handler for the d3_feature_check tool
+func HandleFeatureCheck(proj project.ProjectService) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		featureName, _ := request.Params.Arguments["feature_name"].(string)
+		if featureName != "" {
+			var err error
+			if featureName, err = feature.NormalizeName(featureName); err != nil {
+				return errorResultf(d3err.InvalidName, "Invalid feature name: %v", err), nil
+			}
+		}
+
+		if proj == nil {
+			return errorResult(d3err.Unknown, "Internal error: Project context is nil"), nil
+		}
+
+		result, err := proj.CheckFeature(ctx, featureName)
+		if err != nil {
+			switch {
+			case errors.Is(err, project.ErrNotInitialized):
+				return errorResult(d3err.NotInitialized, "Cannot check feature: project not initialized"), nil
+			case errors.Is(err, project.ErrNoActiveFeature):
+				return errorResult(d3err.NoActiveFeature, "Cannot check feature: no feature name given and no active feature"), nil
+			}
+			return errorResultFromErr(fmt.Sprintf("System error checking feature: %v", err), err), nil
+		}
+
+		return mcp.NewToolResultText(check.Render(result)), nil
+	}
+}
@@ -2,8 +2,10 @@ package tools
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"github.com/imcclaskey/d3/internal/core/d3err"
 	corephase "github.com/imcclaskey/d3/internal/core/phase"
 	"github.com/imcclaskey/d3/internal/project"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -25,29 +27,29 @@ func HandleMove(proj project.ProjectService) server.ToolHandlerFunc {
 		// Extract phase parameter
 		targetPhaseStr, ok := request.Params.Arguments["to"].(string)
 		if !ok {
-			return mcp.NewToolResultError("Target phase 'to' must be specified"), nil
+			return errorResult(d3err.InvalidArgument, "Target phase 'to' must be specified"), nil
 		}
 
 		if proj == nil {
-			return mcp.NewToolResultError("Internal error: Project context is nil"), nil
+			return errorResult(d3err.Unknown, "Internal error: Project context is nil"), nil
 		}
 
 		// Parse the phase string to a Phase enum
 		targetPhase, err := parsePhaseString(targetPhaseStr)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Invalid phase '%s': %v", targetPhaseStr, err)), nil
+			return errorResultf(d3err.InvalidPhase, "Invalid phase '%s': %v", targetPhaseStr, err), nil
 		}
 
 		// Call project's ChangePhase function with the parsed phase
 		result, err := proj.ChangePhase(ctx, targetPhase)
 		if err != nil {
 			// Specific error check based on common project errors
-			if err == project.ErrNoActiveFeature {
-				return mcp.NewToolResultError("Cannot move phase: no active feature"), nil
-			} else if err == project.ErrNotInitialized {
-				return mcp.NewToolResultError("Cannot move phase: project not initialized"), nil
+			if errors.Is(err, project.ErrNoActiveFeature) {
+				return errorResult(d3err.NoActiveFeature, "Cannot move phase: no active feature"), nil
+			} else if errors.Is(err, project.ErrNotInitialized) {
+				return errorResult(d3err.NotInitialized, "Cannot move phase: project not initialized"), nil
 			}
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to change phase: %v", err)), nil
+			return errorResultFromErr(fmt.Sprintf("Failed to change phase: %v", err), err), nil
 		}
 
 		// Return the formatted result
@@ -65,6 +67,6 @@ func parsePhaseString(phaseStr string) (corephase.Phase, error) {
 	case string(corephase.Deliver):
 		return corephase.Deliver, nil
 	default:
-		return corephase.None, fmt.Errorf("invalid phase: %s", phaseStr)
+		return corephase.None, d3err.New(d3err.InvalidPhase, "invalid phase: %s", phaseStr)
 	}
 }
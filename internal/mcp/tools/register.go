@@ -13,5 +13,9 @@ func RegisterTools(mcpServer *server.MCPServer, proj project.ProjectService) {
 	mcpServer.AddTool(FeatureEnterTool, HandleFeatureEnter(proj))
 	mcpServer.AddTool(FeatureExitTool, HandleFeatureExit(proj))
 	mcpServer.AddTool(FeatureDeleteTool, HandleFeatureDelete(proj))
+	mcpServer.AddTool(FeatureRestoreTool, HandleFeatureRestore(proj))
+	mcpServer.AddTool(FeatureExportTool, HandleFeatureExport(proj))
+	mcpServer.AddTool(FeatureTraceTool, HandleFeatureTrace(proj))
+	mcpServer.AddTool(FeatureCheckTool, HandleFeatureCheck(proj))
 	mcpServer.AddTool(InitTool, HandleInit(proj))
 }
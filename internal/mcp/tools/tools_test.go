@@ -6,7 +6,11 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/imcclaskey/d3/internal/core/check"
+	"github.com/imcclaskey/d3/internal/core/d3err"
+	"github.com/imcclaskey/d3/internal/core/export"
 	"github.com/imcclaskey/d3/internal/core/phase"
+	"github.com/imcclaskey/d3/internal/core/trace"
 	"github.com/imcclaskey/d3/internal/project"
 	"github.com/imcclaskey/d3/internal/testutil"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -63,6 +67,17 @@ func TestHandleMove(t *testing.T) {
 			wantResultText: "Cannot move phase: no active feature",
 			wantIsErrorSet: true,
 		},
+		{
+			name:           "project ChangePhase returns wrapped ErrNoActiveFeature",
+			toolNameForReq: "d3_phase_move",
+			params:         map[string]interface{}{"to": "design"},
+			setupMockProj: func(mockProj *project.MockProjectService) {
+				mockProj.EXPECT().ChangePhase(gomock.Any(), phase.Design).
+					Return(nil, fmt.Errorf("failed to get active feature: %w", project.ErrNoActiveFeature)).Times(1)
+			},
+			wantResultText: "Cannot move phase: no active feature",
+			wantIsErrorSet: true,
+		},
 		{
 			name:           "project ChangePhase returns ErrNotInitialized",
 			toolNameForReq: "d3_phase_move",
@@ -178,6 +193,27 @@ func TestHandleFeatureCreate(t *testing.T) {
 			wantResultText: "Feature created with rules Cursor rules have changed. Stop your current behavior and await further instruction.",
 			wantIsErrorSet: false,
 		},
+		{
+			name:           "feature name is normalized",
+			toolNameForReq: "d3_feature_create",
+			params:         map[string]interface{}{"name": "  My Feature "},
+			setupMockProj: func(mockProj *project.MockProjectService) {
+				mockProj.EXPECT().CreateFeature(gomock.Any(), "my-feature").
+					Return(project.NewResult("Feature 'my-feature' created."), nil).Times(1)
+			},
+			wantResultText: "Feature 'my-feature' created.",
+			wantIsErrorSet: false,
+		},
+		{
+			name:           "invalid feature name",
+			toolNameForReq: "d3_feature_create",
+			params:         map[string]interface{}{"name": "../escape"},
+			setupMockProj: func(mockProj *project.MockProjectService) {
+				// No calls expected
+			},
+			wantResultText: `Invalid feature name: invalid feature name "../escape": name must not contain path separators`,
+			wantIsErrorSet: true,
+		},
 		{
 			name:           "missing feature name",
 			toolNameForReq: "d3_feature_create",
@@ -591,6 +627,7 @@ func TestHandleFeatureDelete(t *testing.T) {
 		setupMockProj  func(mockProj *project.MockProjectService)
 		wantResultText string
 		wantIsErrorSet bool
+		wantErrorCode  d3err.Code
 		wantHandlerErr bool // if the handler itself returns an error, not just result.IsError
 	}{
 		{
@@ -603,6 +640,15 @@ func TestHandleFeatureDelete(t *testing.T) {
 			wantResultText: "Feature 'test-feature-to-delete' deleted successfully.",
 			wantIsErrorSet: false,
 		},
+		{
+			name:   "traversal feature name is rejected",
+			params: map[string]interface{}{"feature_name": "../../src", "confirm": true},
+			setupMockProj: func(mockProj *project.MockProjectService) {
+				// No calls expected
+			},
+			wantResultText: `Invalid feature name: invalid feature name "../../src": name must not contain path separators`,
+			wantIsErrorSet: true,
+		},
 		{
 			name:   "successful deletion of active feature with rules changed",
 			params: map[string]interface{}{"feature_name": "active-feature-deleted", "confirm": true},
@@ -619,8 +665,9 @@ func TestHandleFeatureDelete(t *testing.T) {
 			setupMockProj: func(mockProj *project.MockProjectService) {
 				// DeleteFeature should not be called
 			},
-			wantResultText: "Are you sure you want to delete feature 'test-feature-confirm-missing'? This action cannot be undone. Please call again with confirm=true.",
+			wantResultText: "Are you sure you want to delete feature 'test-feature-confirm-missing'? It will be moved to the trash. Please call again with confirm=true.",
 			wantIsErrorSet: true,
+			wantErrorCode:  d3err.InvalidArgument,
 		},
 		{
 			name:   "deletion requires confirmation (confirm false)",
@@ -628,8 +675,9 @@ func TestHandleFeatureDelete(t *testing.T) {
 			setupMockProj: func(mockProj *project.MockProjectService) {
 				// DeleteFeature should not be called
 			},
-			wantResultText: "Are you sure you want to delete feature 'test-feature-confirm-false'? This action cannot be undone. Please call again with confirm=true.",
+			wantResultText: "Are you sure you want to delete feature 'test-feature-confirm-false'? It will be moved to the trash. Please call again with confirm=true.",
 			wantIsErrorSet: true,
+			wantErrorCode:  d3err.InvalidArgument,
 		},
 		{
 			name:           "missing feature_name parameter",
@@ -637,6 +685,7 @@ func TestHandleFeatureDelete(t *testing.T) {
 			setupMockProj:  func(mockProj *project.MockProjectService) { /* No call expected */ },
 			wantResultText: "Feature name 'feature_name' is required",
 			wantIsErrorSet: true,
+			wantErrorCode:  d3err.InvalidArgument,
 		},
 		{
 			name:           "empty feature_name parameter",
@@ -644,6 +693,7 @@ func TestHandleFeatureDelete(t *testing.T) {
 			setupMockProj:  func(mockProj *project.MockProjectService) { /* No call expected */ },
 			wantResultText: "Feature name 'feature_name' is required",
 			wantIsErrorSet: true,
+			wantErrorCode:  d3err.InvalidArgument,
 		},
 		{
 			name:   "project not initialized",
@@ -654,16 +704,18 @@ func TestHandleFeatureDelete(t *testing.T) {
 			},
 			wantResultText: "Cannot delete feature: project not initialized",
 			wantIsErrorSet: true,
+			wantErrorCode:  d3err.NotInitialized,
 		},
 		{
 			name:   "project service returns error (e.g., feature not found)",
 			params: map[string]interface{}{"feature_name": "non-existent-feature", "confirm": true},
 			setupMockProj: func(mockProj *project.MockProjectService) {
 				mockProj.EXPECT().DeleteFeature(ctx, "non-existent-feature").
-					Return(nil, fmt.Errorf("feature '%s' not found", "non-existent-feature")).Times(1)
+					Return(nil, fmt.Errorf("failed to delete feature: %w", d3err.New(d3err.FeatureNotFound, "feature '%s' not found", "non-existent-feature"))).Times(1)
 			},
-			wantResultText: "System error deleting feature 'non-existent-feature': feature 'non-existent-feature' not found",
+			wantResultText: "System error deleting feature 'non-existent-feature': failed to delete feature: feature 'non-existent-feature' not found",
 			wantIsErrorSet: true,
+			wantErrorCode:  d3err.FeatureNotFound,
 		},
 		{
 			name:           "project service is nil",
@@ -671,6 +723,7 @@ func TestHandleFeatureDelete(t *testing.T) {
 			setupMockProj:  nil,
 			wantResultText: "Internal error: Project context is nil",
 			wantIsErrorSet: true,
+			wantErrorCode:  d3err.Unknown,
 		},
 	}
 
@@ -705,7 +758,19 @@ func TestHandleFeatureDelete(t *testing.T) {
 				t.Errorf("HandleFeatureDelete() result.IsError = %v, wantIsErrorSet %v. Result: %+v", result.IsError, tt.wantIsErrorSet, result)
 			}
 
-			if len(result.Content) == 1 {
+			if tt.wantErrorCode != "" {
+				if got := result.Meta[ErrorCodeMetaKey]; got != string(tt.wantErrorCode) {
+					t.Errorf("HandleFeatureDelete() error code = %v, want %s", got, tt.wantErrorCode)
+				}
+				if len(result.Content) != 2 {
+					t.Fatalf("HandleFeatureDelete() result has %d content items, want message and error code", len(result.Content))
+				}
+				if code, _ := result.Content[1].(mcp.TextContent); code.Text != "error_code: "+string(tt.wantErrorCode) {
+					t.Errorf("HandleFeatureDelete() error code content = %q", code.Text)
+				}
+			}
+
+			if len(result.Content) >= 1 {
 				contentItem := result.Content[0]
 				if textContent, ok := contentItem.(mcp.TextContent); ok {
 					if textContent.Text != tt.wantResultText {
@@ -715,8 +780,232 @@ func TestHandleFeatureDelete(t *testing.T) {
 					t.Errorf("HandleFeatureDelete() result content is not mcp.TextContent, got %T", contentItem)
 				}
 			} else if tt.wantResultText != "" {
-				t.Errorf("HandleFeatureDelete() result has %d content items, want 1 for text %q. Full result: %+v", len(result.Content), tt.wantResultText, result)
+				t.Errorf("HandleFeatureDelete() result has %d content items, want at least 1 for text %q. Full result: %+v", len(result.Content), tt.wantResultText, result)
+			}
+		})
+	}
+}
+
+// assertToolResult checks that an MCP tool result has the expected error flag and text content.
+func assertToolResult(t *testing.T, result *mcp.CallToolResult, wantText string, wantIsError bool) {
+	t.Helper()
+	if result == nil {
+		t.Fatal("tool result is nil")
+	}
+	if result.IsError != wantIsError {
+		t.Errorf("result.IsError = %v, want %v. Result: %+v", result.IsError, wantIsError, result)
+	}
+	if len(result.Content) == 0 {
+		t.Fatalf("result has no content, want text %q", wantText)
+	}
+	content, ok := result.Content[0].(mcp.TextContent)
+	if !ok {
+		t.Fatalf("result content is not TextContent, got %T", result.Content[0])
+	}
+	if content.Text != wantText {
+		t.Errorf("result text = %q, want %q", content.Text, wantText)
+	}
+}
+
+func TestHandleFeatureRestore(t *testing.T) {
+	tests := []struct {
+		name           string
+		params         map[string]interface{}
+		setupMockProj  func(mockProj *project.MockProjectService)
+		wantResultText string
+		wantIsErrorSet bool
+	}{
+		{
+			name:   "successful restore",
+			params: map[string]interface{}{"feature_name": "old-feature"},
+			setupMockProj: func(mockProj *project.MockProjectService) {
+				mockProj.EXPECT().RestoreFeature(gomock.Any(), "old-feature").
+					Return(project.NewResult("Feature 'old-feature' restored from trash."), nil).Times(1)
+			},
+			wantResultText: "Feature 'old-feature' restored from trash.",
+		},
+		{
+			name:           "missing feature_name parameter",
+			params:         map[string]interface{}{},
+			setupMockProj:  func(mockProj *project.MockProjectService) {},
+			wantResultText: "Feature name 'feature_name' is required",
+			wantIsErrorSet: true,
+		},
+		{
+			name:   "restore fails",
+			params: map[string]interface{}{"feature_name": "missing"},
+			setupMockProj: func(mockProj *project.MockProjectService) {
+				mockProj.EXPECT().RestoreFeature(gomock.Any(), "missing").
+					Return(nil, fmt.Errorf("feature 'missing' not found in trash")).Times(1)
+			},
+			wantResultText: "System error restoring feature 'missing': feature 'missing' not found in trash",
+			wantIsErrorSet: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockProjSvc := project.NewMockProjectService(ctrl)
+			tt.setupMockProj(mockProjSvc)
+
+			request := testutil.NewTestCallToolRequest("d3_feature_restore", tt.params)
+			result, err := HandleFeatureRestore(mockProjSvc)(context.Background(), request)
+			if err != nil {
+				t.Fatalf("HandleFeatureRestore() handler error = %v", err)
+			}
+			assertToolResult(t, result, tt.wantResultText, tt.wantIsErrorSet)
+		})
+	}
+}
+
+func TestHandleFeatureExport(t *testing.T) {
+	tests := []struct {
+		name           string
+		params         map[string]interface{}
+		setupMockProj  func(mockProj *project.MockProjectService)
+		wantResultText string
+		wantIsErrorSet bool
+	}{
+		{
+			name:   "exports named feature as pr markdown",
+			params: map[string]interface{}{"feature_name": "Login", "preset": "pr"},
+			setupMockProj: func(mockProj *project.MockProjectService) {
+				mockProj.EXPECT().ExportFeature(gomock.Any(), "login", export.FormatMarkdown, export.PresetPR).
+					Return("## Summary\n", nil).Times(1)
+			},
+			wantResultText: "## Summary\n",
+		},
+		{
+			name:   "defaults to active feature",
+			params: map[string]interface{}{"format": "json"},
+			setupMockProj: func(mockProj *project.MockProjectService) {
+				mockProj.EXPECT().ExportFeature(gomock.Any(), "", export.FormatJSON, export.PresetFull).
+					Return("{}\n", nil).Times(1)
+			},
+			wantResultText: "{}\n",
+		},
+		{
+			name:           "invalid format",
+			params:         map[string]interface{}{"format": "pdf"},
+			setupMockProj:  func(mockProj *project.MockProjectService) {},
+			wantResultText: `invalid export format "pdf": expected markdown, html or json`,
+			wantIsErrorSet: true,
+		},
+		{
+			name:   "no active feature",
+			params: map[string]interface{}{},
+			setupMockProj: func(mockProj *project.MockProjectService) {
+				mockProj.EXPECT().ExportFeature(gomock.Any(), "", export.FormatMarkdown, export.PresetFull).
+					Return("", project.ErrNoActiveFeature).Times(1)
+			},
+			wantResultText: "Cannot export feature: no feature name given and no active feature",
+			wantIsErrorSet: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockProjSvc := project.NewMockProjectService(ctrl)
+			tt.setupMockProj(mockProjSvc)
+
+			request := testutil.NewTestCallToolRequest("d3_feature_export", tt.params)
+			result, err := HandleFeatureExport(mockProjSvc)(context.Background(), request)
+			if err != nil {
+				t.Fatalf("HandleFeatureExport() handler error = %v", err)
+			}
+			assertToolResult(t, result, tt.wantResultText, tt.wantIsErrorSet)
+		})
+	}
+}
+
+func TestHandleFeatureTrace(t *testing.T) {
+	report := trace.Analyze("login", "- R1: User can log in\n", "- S1 [code] Add the form\n", nil)
+	tests := []struct {
+		name           string
+		params         map[string]interface{}
+		setupMockProj  func(mockProj *project.MockProjectService)
+		wantResultText string
+		wantIsErrorSet bool
+	}{
+		{
+			name:   "traces named feature",
+			params: map[string]interface{}{"feature_name": "Login"},
+			setupMockProj: func(mockProj *project.MockProjectService) {
+				mockProj.EXPECT().TraceFeature(gomock.Any(), "login").Return(report, nil).Times(1)
+			},
+			wantResultText: trace.Render(report),
+		},
+		{
+			name:   "no active feature",
+			params: map[string]interface{}{},
+			setupMockProj: func(mockProj *project.MockProjectService) {
+				mockProj.EXPECT().TraceFeature(gomock.Any(), "").Return(nil, project.ErrNoActiveFeature).Times(1)
+			},
+			wantResultText: "Cannot trace feature: no feature name given and no active feature",
+			wantIsErrorSet: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockProjSvc := project.NewMockProjectService(ctrl)
+			tt.setupMockProj(mockProjSvc)
+
+			request := testutil.NewTestCallToolRequest("d3_feature_trace", tt.params)
+			result, err := HandleFeatureTrace(mockProjSvc)(context.Background(), request)
+			if err != nil {
+				t.Fatalf("HandleFeatureTrace() handler error = %v", err)
+			}
+			assertToolResult(t, result, tt.wantResultText, tt.wantIsErrorSet)
+		})
+	}
+}
+
+func TestHandleFeatureCheck(t *testing.T) {
+	result := &check.Result{Feature: "login", Phases: []phase.Phase{phase.Define}, Diagnostics: []check.Diagnostic{
+		{File: ".d3/features/login/define/problem.md", Message: `missing required section "Scope Exclusions"`},
+	}}
+	tests := []struct {
+		name           string
+		params         map[string]interface{}
+		setupMockProj  func(mockProj *project.MockProjectService)
+		wantResultText string
+		wantIsErrorSet bool
+	}{
+		{
+			name:   "checks named feature",
+			params: map[string]interface{}{"feature_name": "Login"},
+			setupMockProj: func(mockProj *project.MockProjectService) {
+				mockProj.EXPECT().CheckFeature(gomock.Any(), "login").Return(result, nil).Times(1)
+			},
+			wantResultText: check.Render(result),
+		},
+		{
+			name:   "no active feature",
+			params: map[string]interface{}{},
+			setupMockProj: func(mockProj *project.MockProjectService) {
+				mockProj.EXPECT().CheckFeature(gomock.Any(), "").Return(nil, project.ErrNoActiveFeature).Times(1)
+			},
+			wantResultText: "Cannot check feature: no feature name given and no active feature",
+			wantIsErrorSet: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockProjSvc := project.NewMockProjectService(ctrl)
+			tt.setupMockProj(mockProjSvc)
+
+			request := testutil.NewTestCallToolRequest("d3_feature_check", tt.params)
+			res, err := HandleFeatureCheck(mockProjSvc)(context.Background(), request)
+			if err != nil {
+				t.Fatalf("HandleFeatureCheck() handler error = %v", err)
 			}
+			assertToolResult(t, res, tt.wantResultText, tt.wantIsErrorSet)
 		})
 	}
 }
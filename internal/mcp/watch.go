@@ -0,0 +1,50 @@
+package mcp
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/imcclaskey/d3/internal/core/config"
+	"github.com/imcclaskey/d3/internal/core/ports"
+	"github.com/imcclaskey/d3/internal/core/watch"
+)
+
+// EnableWatch makes ServeStdio poll project files while serving, regenerating the rules and
+// notifying the client when they change. The d3 artifacts of the configured project are always
+// watched; opts.Patterns adds further globs.
+func (s *Server) EnableWatch(opts watch.Options) {
+	s.watchOpts = &opts
+}
+
+// startWatch starts the file watcher, if enabled, until ctx is done.
+func (s *Server) startWatch(ctx context.Context) {
+	if s.watchOpts == nil {
+		return
+	}
+	opts := *s.watchOpts
+	featuresDir, err := filepath.Rel(s.cfg.ProjectRoot, s.cfg.FeaturesDir)
+	if err != nil {
+		featuresDir = config.DefaultFeaturesDir
+	}
+	opts.Patterns = append(watch.Patterns(featuresDir), opts.Patterns...)
+
+	w := watch.New(ports.RealFileSystem{}, s.cfg.ProjectRoot, opts)
+	w.SetLogger(s.logger.With("component", "watch"))
+	s.logger.Info("watching project files", "patterns", opts.Patterns, "interval", opts.Interval)
+	go w.Run(ctx, s.onWatchedChange)
+}
+
+// onWatchedChange reconciles the generated rules with the edited files and tells the client
+// which exposed artifacts changed with notifications/resources/updated. Other watched files,
+// such as custom rule templates, are not resources and only trigger the sync.
+func (s *Server) onWatchedChange(ctx context.Context, changed []string) {
+	s.logger.Info("project files changed", "paths", changed)
+	s.rules.sync(ctx)
+	for _, path := range changed {
+		if s.isArtifact(path) {
+			s.mcp.SendNotificationToAllClients(mcp.MethodNotificationResourceUpdated, map[string]any{"uri": fileURI(path)})
+		}
+	}
+}
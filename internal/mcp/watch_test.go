@@ -0,0 +1,36 @@
+package mcp
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func TestServer_onWatchedChange(t *testing.T) {
+	c := startStdioServer(t)
+	c.send(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{},"clientInfo":{"name":"test","version":"1"}}}`)
+	c.next(responseTo(1))
+	c.send(`{"jsonrpc":"2.0","method":"notifications/initialized"}`)
+
+	featuresDir := c.server.cfg.FeaturesDir
+	rulesTemplate := filepath.Join(c.server.cfg.D3Dir, "rules", "core.md")
+	problem := filepath.Join(featuresDir, "login", "define", "problem.md")
+
+	// A path that is not an exposed artifact sends nothing before the ping is answered
+	c.server.onWatchedChange(context.Background(), []string{rulesTemplate})
+	c.send(`{"jsonrpc":"2.0","id":2,"method":"ping"}`)
+	if message := c.next(func(message map[string]any) bool {
+		return message["id"] == float64(2) || message["method"] == "notifications/resources/updated"
+	}); message["id"] != float64(2) {
+		t.Errorf("onWatchedChange(%s) sent %v, want no notification", rulesTemplate, message)
+	}
+
+	c.server.onWatchedChange(context.Background(), []string{rulesTemplate, problem})
+	notification := c.next(func(message map[string]any) bool {
+		return message["method"] == "notifications/resources/updated"
+	})
+	params, _ := notification["params"].(map[string]any)
+	if want := fileURI(problem); params["uri"] != want {
+		t.Errorf("resource updated uri = %v, want %q", params["uri"], want)
+	}
+}
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/imcclaskey/d3/internal/project (interfaces: FeatureServicer,RulesServicer,PhaseServicer,FileOperator)
+// Source: github.com/imcclaskey/d3/internal/project (interfaces: FeatureServicer,RulesServicer,PhaseServicer,FileOperator,HookRunner,Locker)
 
 // Package project is a generated GoMock package.
 package project
@@ -10,6 +10,7 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	feature "github.com/imcclaskey/d3/internal/core/feature"
+	hooks "github.com/imcclaskey/d3/internal/core/hooks"
 	phase "github.com/imcclaskey/d3/internal/core/phase"
 	ports "github.com/imcclaskey/d3/internal/core/ports"
 )
@@ -110,6 +111,21 @@ func (mr *MockFeatureServicerMockRecorder) GetActiveFeature() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveFeature", reflect.TypeOf((*MockFeatureServicer)(nil).GetActiveFeature))
 }
 
+// GetFeatureDependencies mocks base method.
+func (m *MockFeatureServicer) GetFeatureDependencies(arg0 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeatureDependencies", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeatureDependencies indicates an expected call of GetFeatureDependencies.
+func (mr *MockFeatureServicerMockRecorder) GetFeatureDependencies(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeatureDependencies", reflect.TypeOf((*MockFeatureServicer)(nil).GetFeatureDependencies), arg0)
+}
+
 // GetFeaturePath mocks base method.
 func (m *MockFeatureServicer) GetFeaturePath(arg0 string) string {
 	m.ctrl.T.Helper()
@@ -154,6 +170,36 @@ func (mr *MockFeatureServicerMockRecorder) ListFeatures(arg0 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFeatures", reflect.TypeOf((*MockFeatureServicer)(nil).ListFeatures), arg0)
 }
 
+// PurgeFeature mocks base method.
+func (m *MockFeatureServicer) PurgeFeature(arg0 context.Context, arg1 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeFeature", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeFeature indicates an expected call of PurgeFeature.
+func (mr *MockFeatureServicerMockRecorder) PurgeFeature(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeFeature", reflect.TypeOf((*MockFeatureServicer)(nil).PurgeFeature), arg0, arg1)
+}
+
+// RestoreFeature mocks base method.
+func (m *MockFeatureServicer) RestoreFeature(arg0 context.Context, arg1 string) (*feature.FeatureInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreFeature", arg0, arg1)
+	ret0, _ := ret[0].(*feature.FeatureInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreFeature indicates an expected call of RestoreFeature.
+func (mr *MockFeatureServicerMockRecorder) RestoreFeature(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreFeature", reflect.TypeOf((*MockFeatureServicer)(nil).RestoreFeature), arg0, arg1)
+}
+
 // SetActiveFeature mocks base method.
 func (m *MockFeatureServicer) SetActiveFeature(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -168,6 +214,20 @@ func (mr *MockFeatureServicerMockRecorder) SetActiveFeature(arg0 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetActiveFeature", reflect.TypeOf((*MockFeatureServicer)(nil).SetActiveFeature), arg0)
 }
 
+// SetFeatureDependencies mocks base method.
+func (m *MockFeatureServicer) SetFeatureDependencies(arg0 string, arg1 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetFeatureDependencies", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetFeatureDependencies indicates an expected call of SetFeatureDependencies.
+func (mr *MockFeatureServicerMockRecorder) SetFeatureDependencies(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFeatureDependencies", reflect.TypeOf((*MockFeatureServicer)(nil).SetFeatureDependencies), arg0, arg1)
+}
+
 // SetFeaturePhase mocks base method.
 func (m *MockFeatureServicer) SetFeaturePhase(arg0 context.Context, arg1 string, arg2 phase.Phase) error {
 	m.ctrl.T.Helper()
@@ -247,6 +307,21 @@ func (mr *MockRulesServicerMockRecorder) RefreshRules(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshRules", reflect.TypeOf((*MockRulesServicer)(nil).RefreshRules), arg0, arg1)
 }
 
+// SyncRules mocks base method.
+func (m *MockRulesServicer) SyncRules(arg0, arg1 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncRules", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SyncRules indicates an expected call of SyncRules.
+func (mr *MockRulesServicerMockRecorder) SyncRules(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncRules", reflect.TypeOf((*MockRulesServicer)(nil).SyncRules), arg0, arg1)
+}
+
 // MockPhaseServicer is a mock of PhaseServicer interface.
 type MockPhaseServicer struct {
 	ctrl     *gomock.Controller
@@ -376,3 +451,78 @@ func (mr *MockFileOperatorMockRecorder) EnsureRootGitignoreEntries(arg0, arg1 in
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureRootGitignoreEntries", reflect.TypeOf((*MockFileOperator)(nil).EnsureRootGitignoreEntries), arg0, arg1)
 }
+
+// MockHookRunner is a mock of HookRunner interface.
+type MockHookRunner struct {
+	ctrl     *gomock.Controller
+	recorder *MockHookRunnerMockRecorder
+}
+
+// MockHookRunnerMockRecorder is the mock recorder for MockHookRunner.
+type MockHookRunnerMockRecorder struct {
+	mock *MockHookRunner
+}
+
+// NewMockHookRunner creates a new mock instance.
+func NewMockHookRunner(ctrl *gomock.Controller) *MockHookRunner {
+	mock := &MockHookRunner{ctrl: ctrl}
+	mock.recorder = &MockHookRunnerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHookRunner) EXPECT() *MockHookRunnerMockRecorder {
+	return m.recorder
+}
+
+// Run mocks base method.
+func (m *MockHookRunner) Run(arg0 context.Context, arg1 hooks.Env) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Run", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockHookRunnerMockRecorder) Run(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockHookRunner)(nil).Run), arg0, arg1)
+}
+
+// MockLocker is a mock of Locker interface.
+type MockLocker struct {
+	ctrl     *gomock.Controller
+	recorder *MockLockerMockRecorder
+}
+
+// MockLockerMockRecorder is the mock recorder for MockLocker.
+type MockLockerMockRecorder struct {
+	mock *MockLocker
+}
+
+// NewMockLocker creates a new mock instance.
+func NewMockLocker(ctrl *gomock.Controller) *MockLocker {
+	mock := &MockLocker{ctrl: ctrl}
+	mock.recorder = &MockLockerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLocker) EXPECT() *MockLockerMockRecorder {
+	return m.recorder
+}
+
+// Lock mocks base method.
+func (m *MockLocker) Lock(arg0 context.Context) (func(), error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lock", arg0)
+	ret0, _ := ret[0].(func())
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Lock indicates an expected call of Lock.
+func (mr *MockLockerMockRecorder) Lock(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lock", reflect.TypeOf((*MockLocker)(nil).Lock), arg0)
+}
@@ -4,12 +4,13 @@ import (
 	"context"
 
 	"github.com/imcclaskey/d3/internal/core/feature"
+	"github.com/imcclaskey/d3/internal/core/hooks"
 	"github.com/imcclaskey/d3/internal/core/phase"
 	"github.com/imcclaskey/d3/internal/core/ports"
 	// "github.com/imcclaskey/d3/internal/core/session"
 )
 
-//go:generate mockgen -package=project -destination=interfaces_mock.go . FeatureServicer,RulesServicer,PhaseServicer,FileOperator
+//go:generate mockgen -package=project -destination=interfaces_mock.go . FeatureServicer,RulesServicer,PhaseServicer,FileOperator,HookRunner,Locker
 
 // FeatureServicer defines the interface for feature management operations.
 type FeatureServicer interface {
@@ -20,14 +21,19 @@ type FeatureServicer interface {
 	GetFeaturePath(featureName string) string
 	ListFeatures(ctx context.Context) ([]feature.FeatureInfo, error)
 	DeleteFeature(ctx context.Context, featureName string) (activeContextCleared bool, err error)
+	PurgeFeature(ctx context.Context, featureName string) (activeContextCleared bool, err error)
+	RestoreFeature(ctx context.Context, featureName string) (*feature.FeatureInfo, error)
 	GetActiveFeature() (string, error)
 	SetActiveFeature(featureName string) error
 	ClearActiveFeature() error
+	GetFeatureDependencies(featureName string) ([]string, error)
+	SetFeatureDependencies(featureName string, dependencies []string) error
 }
 
 // RulesServicer defines the interface for rule management operations.
 type RulesServicer interface {
 	RefreshRules(feature string, phaseStr string) error
+	SyncRules(feature string, phaseStr string) ([]string, error)
 	ClearGeneratedRules() error
 	InitCustomRulesDir() error
 }
@@ -55,3 +61,15 @@ type FileOperator interface {
 
 	EnsureProjectFiles(fs ports.FileSystem, d3DirAbs string) error
 }
+
+// HookRunner runs the commands configured for a lifecycle event. A failing pre hook aborts the
+// operation that triggered it.
+type HookRunner interface {
+	Run(ctx context.Context, env hooks.Env) error
+}
+
+// Locker serializes the changes of d3 processes that share a project. Lock fails with a
+// d3err.Locked error while another process holds the lock.
+type Locker interface {
+	Lock(ctx context.Context) (unlock func(), err error)
+}
@@ -3,19 +3,37 @@ package project
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"log/slog"
 	"os"
+	"path"
 	"path/filepath"
-
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/imcclaskey/d3/internal/core/check"
+	"github.com/imcclaskey/d3/internal/core/config"
+	"github.com/imcclaskey/d3/internal/core/d3err"
+	"github.com/imcclaskey/d3/internal/core/depgraph"
+	"github.com/imcclaskey/d3/internal/core/export"
+	"github.com/imcclaskey/d3/internal/core/feature"
+	"github.com/imcclaskey/d3/internal/core/hooks"
+	"github.com/imcclaskey/d3/internal/core/linediff"
 	"github.com/imcclaskey/d3/internal/core/phase"
 	"github.com/imcclaskey/d3/internal/core/ports"
+	"github.com/imcclaskey/d3/internal/core/progress"
+	"github.com/imcclaskey/d3/internal/core/review"
+	"github.com/imcclaskey/d3/internal/core/rules"
+	"github.com/imcclaskey/d3/internal/core/snapshot"
+	"github.com/imcclaskey/d3/internal/core/trace"
 )
 
-// Common error definitions
+// Common error definitions. Both carry d3err codes, so errors.Is also matches the
+// corresponding d3err sentinels and errors from other packages with the same code.
 var (
-	ErrNotInitialized  = errors.New("project not initialized, please run 'd3 init' first")
-	ErrNoActiveFeature = errors.New("no active feature")
+	ErrNotInitialized  = d3err.New(d3err.NotInitialized, "project not initialized, please run 'd3 init' first")
+	ErrNoActiveFeature = d3err.New(d3err.NoActiveFeature, "no active feature")
 )
 
 // Result represents a simplified response from project operations
@@ -26,6 +44,22 @@ type Result struct {
 
 	// RulesChanged indicates whether rule files were updated during the operation
 	RulesChanged bool
+
+	// ActiveFeatureCleared indicates whether the operation cleared the active feature
+	ActiveFeatureCleared bool
+
+	// Feature is the feature the operation acted on, if any
+	Feature string
+
+	// Phase is the feature's phase after the operation
+	Phase phase.Phase
+
+	// FilesTouched lists files and directories created, modified, moved or removed,
+	// not counting generated rule files (see RulesChanged)
+	FilesTouched []string
+
+	// Warnings lists problems that did not stop the operation
+	Warnings []string
 }
 
 // NewResult creates a new result with the given message
@@ -44,22 +78,52 @@ func NewResultWithRulesChanged(message string) *Result {
 	}
 }
 
+// WithFeature records the feature and phase the operation left behind.
+func (r *Result) WithFeature(featureName string, p phase.Phase) *Result {
+	r.Feature = featureName
+	r.Phase = p
+	return r
+}
+
+// WithFiles records paths the operation touched.
+func (r *Result) WithFiles(paths ...string) *Result {
+	r.FilesTouched = append(r.FilesTouched, paths...)
+	return r
+}
+
+// WithWarnings records problems that did not stop the operation.
+func (r *Result) WithWarnings(warnings ...string) *Result {
+	r.Warnings = append(r.Warnings, warnings...)
+	return r
+}
+
 // FormatCLI formats the result for CLI output
 func (r *Result) FormatCLI() string {
+	message := r.Message
 	if r.RulesChanged {
-		return fmt.Sprintf("%s Cursor rules have been updated.", r.Message)
+		message = fmt.Sprintf("%s Cursor rules have been updated.", r.Message)
 	}
 
-	return r.Message
+	return message + r.formatWarnings()
 }
 
 // FormatMCP formats the result for MCP tool output
 func (r *Result) FormatMCP() string {
+	message := r.Message
 	if r.RulesChanged {
-		return fmt.Sprintf("%s Cursor rules have changed. Stop your current behavior and await further instruction.", r.Message)
+		message = fmt.Sprintf("%s Cursor rules have changed. Stop your current behavior and await further instruction.", r.Message)
 	}
 
-	return r.Message
+	return message + r.formatWarnings()
+}
+
+// formatWarnings renders one "Warning:" line per warning, each preceded by a newline.
+func (r *Result) formatWarnings() string {
+	var b strings.Builder
+	for _, warning := range r.Warnings {
+		fmt.Fprintf(&b, "\nWarning: %s", warning)
+	}
+	return b.String()
 }
 
 //go:generate mockgen -package=project -destination=project_service_mock.go . ProjectService
@@ -73,6 +137,17 @@ type ProjectService interface {
 	EnterFeature(ctx context.Context, featureName string) (*Result, error)
 	ExitFeature(ctx context.Context) (*Result, error)
 	DeleteFeature(ctx context.Context, featureName string) (*Result, error)
+	PurgeFeature(ctx context.Context, featureName string) (*Result, error)
+	RestoreFeature(ctx context.Context, featureName string) (*Result, error)
+	SyncRules(ctx context.Context) (*Result, error)
+	ExportFeature(ctx context.Context, featureName string, format export.Format, preset export.Preset) (string, error)
+	TraceFeature(ctx context.Context, featureName string) (*trace.Report, error)
+	CheckFeature(ctx context.Context, featureName string) (*check.Result, error)
+	FeatureSnapshots(ctx context.Context, featureName string) ([]snapshot.Snapshot, error)
+	DiffArtifact(ctx context.Context, featureName string, from string, to string) (string, error)
+	RevertArtifact(ctx context.Context, featureName string, from string, artifact string) (*Result, error)
+	FeatureGraph(ctx context.Context) (*depgraph.Graph, error)
+	SetDependencies(ctx context.Context, featureName string, add []string, remove []string) (*Result, error)
 	IsInitialized() bool
 	RequiresInitialized() error
 }
@@ -88,40 +163,122 @@ type State struct {
 
 // Project coordinates all d3 services
 type Project struct {
-	state    *State
-	features FeatureServicer
-	rules    RulesServicer
-	phases   PhaseServicer
-	fs       ports.FileSystem
-	fileOp   FileOperator
-}
-
-// New creates a new project instance from project root, now with dependency injection
-// It no longer performs I/O.
-func New(projectRoot string, fs ports.FileSystem, featureSvc FeatureServicer, rulesSvc RulesServicer, phasesSvc PhaseServicer, fileOp FileOperator) *Project {
-	// Inlined logic from newState
-	d3Dir := filepath.Join(projectRoot, ".d3")
-	featuresDir := filepath.Join(d3Dir, "features")
-	cursorRulesDir := filepath.Join(projectRoot, ".cursor", "rules")
+	state        *State
+	initialPhase phase.Phase
+	checkGates   []phase.Phase
+	traceGates   []phase.Phase
+	features     FeatureServicer
+	rules        RulesServicer
+	phases       PhaseServicer
+	fs           ports.FileSystem
+	fileOp       FileOperator
+	hooks        HookRunner
+	locker       Locker
+	session      string
+	logger       *slog.Logger
+}
 
+// New creates a new project instance from the resolved project configuration, now with
+// dependency injection. It no longer performs I/O.
+func New(cfg config.Config, fs ports.FileSystem, featureSvc FeatureServicer, rulesSvc RulesServicer, phasesSvc PhaseServicer, fileOp FileOperator) *Project {
 	state := &State{
-		ProjectRoot:    projectRoot,
-		D3Dir:          d3Dir,
-		FeaturesDir:    featuresDir,
-		CursorRulesDir: cursorRulesDir,
+		ProjectRoot:    cfg.ProjectRoot,
+		D3Dir:          cfg.D3Dir,
+		FeaturesDir:    cfg.FeaturesDir,
+		CursorRulesDir: cfg.CursorRulesDir,
 	}
 
 	proj := &Project{
-		state:    state,
-		rules:    rulesSvc,
-		phases:   phasesSvc,
-		features: featureSvc,
-		fs:       fs,
-		fileOp:   fileOp,
+		state:        state,
+		initialPhase: cfg.InitialPhase,
+		checkGates:   cfg.CheckGates,
+		traceGates:   cfg.TraceGates,
+		rules:        rulesSvc,
+		phases:       phasesSvc,
+		features:     featureSvc,
+		fs:           fs,
+		fileOp:       fileOp,
+		logger:       slog.Default(),
 	}
 	return proj
 }
 
+// SetLogger replaces the logger that records completed operations and their warnings.
+func (p *Project) SetLogger(logger *slog.Logger) {
+	p.logger = logger
+}
+
+// SetHooks installs the runner for the lifecycle hooks of .d3/config.yaml. Without one, no
+// hooks run.
+func (p *Project) SetHooks(runner HookRunner) {
+	p.hooks = runner
+}
+
+// SetLocker installs the lock that serializes changes across d3 processes. Without one, changes
+// are not serialized.
+func (p *Project) SetLocker(locker Locker) {
+	p.locker = locker
+}
+
+// SetSession names the D3_SESSION session whose active feature the project follows. Generated
+// rules are shared by every session of a checkout, so operations that rewrite them warn while a
+// session is set.
+func (p *Project) SetSession(session string) {
+	p.session = session
+}
+
+// sharedRulesWarnings warns that the generated rules, unlike the active feature, are not kept
+// per session and now follow the current session for everyone using the checkout.
+func (p *Project) sharedRulesWarnings() []string {
+	if p.session == "" {
+		return nil
+	}
+	dir := filepath.Join(p.state.CursorRulesDir, rules.GeneratedDirName)
+	return []string{fmt.Sprintf("generated rules in %s are shared by every session of this checkout and now follow session '%s'", dir, p.session)}
+}
+
+// lock acquires the project lock for an operation that changes the project.
+func (p *Project) lock(ctx context.Context) (func(), error) {
+	if p.locker == nil {
+		return func() {}, nil
+	}
+	return p.locker.Lock(ctx)
+}
+
+// runPreHooks runs the hooks of a pre event. Their error is returned unwrapped so that it keeps
+// the GateFailed code and the hook's output.
+func (p *Project) runPreHooks(ctx context.Context, env hooks.Env) error {
+	if p.hooks == nil {
+		return nil
+	}
+	env.ProjectRoot = p.state.ProjectRoot
+	return p.hooks.Run(ctx, env)
+}
+
+// runPostHooks runs the hooks of a post event. The operation already happened, so a failure is
+// returned as a warning for its result.
+func (p *Project) runPostHooks(ctx context.Context, env hooks.Env) []string {
+	if p.hooks == nil {
+		return nil
+	}
+	env.ProjectRoot = p.state.ProjectRoot
+	if err := p.hooks.Run(ctx, env); err != nil {
+		return []string{err.Error()}
+	}
+	return nil
+}
+
+// logged records the outcome of an operation and returns r unchanged. Warnings are attached to
+// the record rather than logged separately, since callers already show them from the result.
+func (p *Project) logged(operation string, r *Result) *Result {
+	attrs := []any{"feature", r.Feature, "phase", string(r.Phase), "rules_changed", r.RulesChanged}
+	if len(r.Warnings) > 0 {
+		attrs = append(attrs, "warnings", r.Warnings)
+	}
+	p.logger.Info(operation, attrs...)
+	return r
+}
+
 // checkInitialized checks if the project seems initialized (internal helper)
 // Called directly by IsInitialized now.
 func (p *Project) checkInitialized() bool {
@@ -152,9 +309,20 @@ func (p *Project) Init(clean bool, refresh bool, customRules bool) (*Result, err
 	if clean {
 		performedClean = true
 		if originalIsCurrentlyInitialized {
+			// The project configuration survives a clean; it describes the project, not its state
+			configPath := config.Path(p.state.ProjectRoot)
+			configData, configErr := p.fs.ReadFile(configPath)
 			if err := p.fs.RemoveAll(p.state.D3Dir); err != nil {
 				return nil, fmt.Errorf("failed to clean existing .d3 directory: %w", err)
 			}
+			if configErr == nil {
+				if err := p.fs.MkdirAll(p.state.D3Dir, 0755); err != nil {
+					return nil, fmt.Errorf("failed to create directory %s: %w", p.state.D3Dir, err)
+				}
+				if err := p.fs.WriteFile(configPath, configData, 0644); err != nil {
+					return nil, fmt.Errorf("failed to restore %s: %w", configPath, err)
+				}
+			}
 			// ClearActiveFeature is called below for all fresh starts
 		}
 		actionMessage = "Project cleaned and re-initialized successfully."
@@ -209,9 +377,11 @@ func (p *Project) Init(clean bool, refresh bool, customRules bool) (*Result, err
 		if err != nil {
 			return nil, fmt.Errorf("failed to get active feature name: %w", err)
 		}
-		phase, err = p.features.GetFeaturePhase(context.Background(), featureName)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get active feature phase: %w", err)
+		if featureName != "" {
+			phase, err = p.features.GetFeaturePhase(context.Background(), featureName)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get active feature phase: %w", err)
+			}
 		}
 	}
 	if err := p.rules.RefreshRules(featureName, string(phase)); err != nil {
@@ -224,7 +394,7 @@ func (p *Project) Init(clean bool, refresh bool, customRules bool) (*Result, err
 		}
 	}
 
-	return NewResultWithRulesChanged(actionMessage), nil
+	return p.logged("project initialized", NewResultWithRulesChanged(actionMessage).WithFeature(featureName, phase).WithFiles(p.state.D3Dir)), nil
 }
 
 // CreateFeature creates a new feature and sets it as the current feature
@@ -232,6 +402,22 @@ func (p *Project) CreateFeature(ctx context.Context, featureName string) (*Resul
 	if err := p.RequiresInitialized(); err != nil {
 		return nil, err
 	}
+	unlock, err := p.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	// Hooks only see names the create accepts, spelled as the feature directory will be
+	featureName, err = feature.NormalizeName(featureName)
+	if err != nil {
+		return nil, err
+	}
+
+	hookEnv := hooks.Env{Event: hooks.PreCreate, Feature: featureName, ToPhase: string(p.initialPhase)}
+	if err := p.runPreHooks(ctx, hookEnv); err != nil {
+		return nil, err
+	}
 
 	featureInfo, err := p.features.CreateFeature(ctx, featureName)
 	if err != nil {
@@ -239,21 +425,28 @@ func (p *Project) CreateFeature(ctx context.Context, featureName string) (*Resul
 	}
 
 	if err := p.features.SetActiveFeature(featureName); err != nil {
-		// Attempt to clean up the created feature directory if setting active fails
-		_, _ = p.features.DeleteFeature(ctx, featureName) // DeleteFeature handles its own errors, ignore both results here
+		// Remove the half-created feature directory; it has no content worth keeping in the trash
+		_, _ = p.features.PurgeFeature(ctx, featureName) // PurgeFeature handles its own errors, ignore both results here
 		return nil, fmt.Errorf("failed to set active feature %s: %w", featureName, err)
 	}
 
+	var warnings []string
 	if err := p.phases.EnsurePhaseFiles(featureInfo.Path); err != nil {
-		fmt.Fprintf(os.Stderr, "warning: failed to ensure phase files for %s: %v\n", featureName, err)
+		warnings = append(warnings, fmt.Sprintf("failed to ensure phase files for %s: %v", featureName, err))
 	}
 
-	// Refresh rules with the new feature and its initial phase (Define)
-	if err := p.rules.RefreshRules(featureName, string(phase.Define)); err != nil {
+	// Refresh rules with the new feature and its configured initial phase
+	if err := p.rules.RefreshRules(featureName, string(p.initialPhase)); err != nil {
 		return nil, fmt.Errorf("failed to refresh rules for new feature %s: %w", featureName, err)
 	}
 
-	return NewResultWithRulesChanged(fmt.Sprintf("Feature '%s' created and set to define phase.", featureName)), nil
+	warnings = append(warnings, p.sharedRulesWarnings()...)
+
+	hookEnv.Event = hooks.PostCreate
+	warnings = append(warnings, p.runPostHooks(ctx, hookEnv)...)
+
+	result := NewResultWithRulesChanged(fmt.Sprintf("Feature '%s' created and set to %s phase.", featureName, p.initialPhase))
+	return p.logged("feature created", result.WithFeature(featureName, p.initialPhase).WithFiles(featureInfo.Path).WithWarnings(warnings...)), nil
 }
 
 // ChangePhase changes the current phase of the active feature
@@ -261,6 +454,15 @@ func (p *Project) ChangePhase(ctx context.Context, targetPhase phase.Phase) (*Re
 	if err := p.RequiresInitialized(); err != nil {
 		return nil, err
 	}
+	unlock, err := p.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	// Reject an unknown phase before any gate or hook sees it
+	if _, ok := phase.PhaseFileMap[targetPhase]; !ok {
+		return nil, d3err.New(d3err.InvalidPhase, "invalid phase: %s (valid phases are: define, design, deliver)", targetPhase)
+	}
 
 	currentFeatureName, err := p.features.GetActiveFeature()
 	if err != nil {
@@ -276,7 +478,22 @@ func (p *Project) ChangePhase(ctx context.Context, targetPhase phase.Phase) (*Re
 	}
 
 	if currentPhase == targetPhase {
-		return NewResult(fmt.Sprintf("Already in the %s phase.", targetPhase)), nil
+		return NewResult(fmt.Sprintf("Already in the %s phase.", targetPhase)).WithFeature(currentFeatureName, currentPhase), nil
+	}
+
+	if err := p.checkGate(currentFeatureName, currentPhase, targetPhase); err != nil {
+		return nil, err
+	}
+	if err := p.traceGate(ctx, currentFeatureName, currentPhase, targetPhase); err != nil {
+		return nil, err
+	}
+	if err := p.dependencyGate(ctx, currentFeatureName, currentPhase, targetPhase); err != nil {
+		return nil, err
+	}
+
+	hookEnv := hooks.Env{Event: hooks.PrePhaseChange, Feature: currentFeatureName, FromPhase: string(currentPhase), ToPhase: string(targetPhase)}
+	if err := p.runPreHooks(ctx, hookEnv); err != nil {
+		return nil, err
 	}
 
 	if err := p.features.SetFeaturePhase(ctx, currentFeatureName, targetPhase); err != nil {
@@ -287,25 +504,182 @@ func (p *Project) ChangePhase(ctx context.Context, targetPhase phase.Phase) (*Re
 		return nil, fmt.Errorf("failed to refresh rules after phase change: %w", err)
 	}
 
+	warnings := p.sharedRulesWarnings()
+	var touched []string
 	featureDirForPhaseFiles := filepath.Join(p.state.FeaturesDir, currentFeatureName)
+
+	// Keep the artifacts of the phase being left, so a later rewrite can be compared and undone.
+	// Moving back also keeps every later artifact, before it is marked for review.
+	var snapshotMessage string
+	downstream := review.Downstream(currentPhase, targetPhase)
+	snapPhases := downstream
+	if len(snapPhases) == 0 {
+		snapPhases = []phase.Phase{currentPhase}
+	}
+	snap, err := snapshot.Take(p.fs, featureDirForPhaseFiles, snapshot.Transition(currentPhase, targetPhase), snapPhases, time.Now())
+	if err != nil {
+		warnings = append(warnings, fmt.Sprintf("failed to snapshot the %s phase: %v", currentPhase, err))
+	} else if snap != nil {
+		snapshotMessage = fmt.Sprintf(" Saved %s as snapshot %s.", strings.Join(snap.Files, ", "), snap.ID)
+		touched = append(touched, filepath.Join(featureDirForPhaseFiles, snapshot.Dir, snap.ID))
+	}
+
+	var reviewMessage string
+	if len(downstream) > 0 {
+		flagged, written, err := review.Mark(p.fs, featureDirForPhaseFiles, currentPhase, targetPhase, time.Now())
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("failed to mark later artifacts for review: %v", err))
+		}
+		if len(flagged) > 0 {
+			reviewMessage = fmt.Sprintf(" Marked for review: %s.", strings.Join(flagged, ", "))
+		}
+		touched = append(touched, written...)
+	}
+
 	if err := p.phases.EnsurePhaseFiles(featureDirForPhaseFiles); err != nil {
-		fmt.Fprintf(os.Stderr, "warning: failed to ensure phase files for %s: %v\n", currentFeatureName, err)
+		warnings = append(warnings, fmt.Sprintf("failed to ensure phase files for %s: %v", currentFeatureName, err))
+	}
+
+	var progressMessage string
+	if targetPhase == phase.Deliver {
+		var generated int
+		var progressWarnings []string
+		progressFile := filepath.Join(p.state.FeaturesDir, currentFeatureName, string(phase.Deliver), phase.PhaseFileMap[phase.Deliver])
+		generated, progressWarnings = p.prepareProgress(currentFeatureName, progressFile)
+		warnings = append(warnings, progressWarnings...)
+		if generated > 0 {
+			progressMessage = fmt.Sprintf(" Generated %d task(s) in progress.yaml from the delivery steps of plan.md.", generated)
+			touched = append(touched, progressFile)
+		}
+	}
+
+	// Moving forward again surfaces what an earlier move back left to revisit
+	if len(downstream) == 0 {
+		pending, err := review.Pending(p.fs, featureDirForPhaseFiles, review.From(targetPhase))
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("failed to check artifacts marked for review: %v", err))
+		} else if len(pending) > 0 {
+			reviewMessage = fmt.Sprintf(" Needs revisiting: %s.", strings.Join(review.Summary(pending), "; "))
+		}
+	}
+
+	message := fmt.Sprintf("Moved to %s phase.", targetPhase) + snapshotMessage + reviewMessage + progressMessage
+
+	hookEnv.Event = hooks.PostPhaseChange
+	warnings = append(warnings, p.runPostHooks(ctx, hookEnv)...)
+
+	phaseFile := filepath.Join(p.state.FeaturesDir, currentFeatureName, ".phase")
+	return p.logged("phase changed", NewResultWithRulesChanged(message).WithFeature(currentFeatureName, targetPhase).WithFiles(phaseFile).WithFiles(touched...).WithWarnings(warnings...)), nil
+}
+
+// checkGate validates the documents of the gated phases a forward move leaves behind and fails
+// with the GateFailed code, listing the diagnostics, if any of them is malformed.
+func (p *Project) checkGate(featureName string, from, to phase.Phase) error {
+	gated := check.Crossed(p.checkGates, from, to)
+	if len(gated) == 0 {
+		return nil
+	}
+	diagnostics, err := p.checkDocuments(featureName, gated)
+	if err != nil {
+		return err
+	}
+	if len(diagnostics) == 0 {
+		return nil
+	}
+	lines := make([]string, 0, len(diagnostics))
+	for _, d := range diagnostics {
+		lines = append(lines, d.String())
+	}
+	return d3err.New(d3err.GateFailed, "cannot move '%s' to the %s phase: %d structural problem(s) found:\n%s", featureName, to, len(diagnostics), strings.Join(lines, "\n"))
+}
+
+// traceGate fails a forward move past a phase listed in trace_gates while the feature's
+// documents have traceability gaps.
+func (p *Project) traceGate(ctx context.Context, featureName string, from, to phase.Phase) error {
+	if len(check.Crossed(p.traceGates, from, to)) == 0 {
+		return nil
+	}
+	report, err := p.traceReport(ctx, featureName)
+	if err != nil {
+		return err
+	}
+	issues := report.Issues()
+	if len(issues) == 0 {
+		return nil
+	}
+	return d3err.New(d3err.GateFailed, "cannot move '%s' to the %s phase: %d traceability gap(s) found:\n%s", featureName, to, len(issues), strings.Join(issues, "\n"))
+}
+
+// checkDocuments validates the document of each phase against its schema, as overridden in
+// .d3/rules. Diagnostics name files relative to the project root.
+func (p *Project) checkDocuments(featureName string, phases []phase.Phase) ([]check.Diagnostic, error) {
+	rulesDir := filepath.Join(p.state.D3Dir, rules.CustomDirName)
+	diagnostics := []check.Diagnostic{}
+	for _, ph := range phases {
+		schema, err := check.LoadSchema(p.fs, rulesDir, ph)
+		if err != nil {
+			return nil, err
+		}
+		path := filepath.Join(p.state.FeaturesDir, featureName, string(ph), phase.PhaseFileMap[ph])
+		name := path
+		if rel, err := filepath.Rel(p.state.ProjectRoot, path); err == nil {
+			name = filepath.ToSlash(rel)
+		}
+		data, err := p.fs.ReadFile(path)
+		if os.IsNotExist(err) {
+			diagnostics = append(diagnostics, check.Diagnostic{File: name, Message: "file does not exist"})
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		diagnostics = append(diagnostics, check.Document(schema, name, string(data))...)
+	}
+	return diagnostics, nil
+}
+
+// prepareProgress fills an empty progress.yaml with one pending task per typed delivery step of
+// plan.md and returns how many it wrote. A task list that already exists is never overwritten;
+// its drift from the plan is returned as warnings instead, as are read and write failures.
+func (p *Project) prepareProgress(featureName, progressFile string) (int, []string) {
+	planFile := filepath.Join(p.state.FeaturesDir, featureName, string(phase.Design), phase.PhaseFileMap[phase.Design])
+	planData, err := p.fs.ReadFile(planFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, []string{fmt.Sprintf("failed to read plan.md: %v", err)}
+	}
+	steps := progress.ParsePlan(string(planData))
+	if len(steps) == 0 {
+		// Nothing to transcribe; the deliver rules ask the assistant to write the task list
+		return 0, nil
 	}
 
-	hasImpact := false
-	phaseDir := filepath.Join(p.state.FeaturesDir, currentFeatureName, string(targetPhase))
-	if _, errStat := p.fs.Stat(phaseDir); errStat == nil {
-		hasImpact = true
-	} else if !os.IsNotExist(errStat) {
-		return nil, fmt.Errorf("failed to check phase directory %s: %w", phaseDir, errStat)
+	progressData, err := p.fs.ReadFile(progressFile)
+	if err != nil && !os.IsNotExist(err) {
+		return 0, []string{fmt.Sprintf("failed to read progress.yaml: %v", err)}
 	}
 
-	message := fmt.Sprintf("Moved to %s phase.", targetPhase)
-	if hasImpact {
-		message += " Note: Existing files were detected for the target phase. Review required."
+	if strings.TrimSpace(string(progressData)) == "" {
+		data, err := progress.Marshal(progress.TasksFromPlan(steps))
+		if err == nil {
+			err = p.fs.WriteFile(progressFile, data, 0644)
+		}
+		if err != nil {
+			return 0, []string{fmt.Sprintf("failed to generate progress.yaml from plan.md: %v", err)}
+		}
+		return len(steps), nil
 	}
 
-	return NewResultWithRulesChanged(message), nil
+	tasks, err := progress.Parse(progressData)
+	if err != nil {
+		return 0, []string{err.Error()}
+	}
+	var warnings []string
+	for _, drift := range progress.Drift(steps, tasks) {
+		warnings = append(warnings, "progress.yaml differs from plan.md: "+drift)
+	}
+	return 0, warnings
 }
 
 // EnterFeature sets the specified feature as the active one, resuming its last phase.
@@ -313,6 +687,16 @@ func (p *Project) EnterFeature(ctx context.Context, featureName string) (*Result
 	if err := p.RequiresInitialized(); err != nil {
 		return nil, err
 	}
+	unlock, err := p.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	featureName, err = feature.NormalizeName(featureName)
+	if err != nil {
+		return nil, err
+	}
 
 	// Check if feature exists and get its phase first
 	retrievedPhase, err := p.features.GetFeaturePhase(ctx, featureName) // This also handles if feature doesn't exist implicitly by FeatureExists check in GetFeaturePhase
@@ -321,6 +705,11 @@ func (p *Project) EnterFeature(ctx context.Context, featureName string) (*Result
 		return nil, fmt.Errorf("cannot enter feature '%s': %w", featureName, err)
 	}
 
+	hookEnv := hooks.Env{Event: hooks.PreEnter, Feature: featureName, ToPhase: string(retrievedPhase)}
+	if err := p.runPreHooks(ctx, hookEnv); err != nil {
+		return nil, err
+	}
+
 	if err := p.features.SetActiveFeature(featureName); err != nil {
 		return nil, fmt.Errorf("failed to set active feature %s: %w", featureName, err)
 	}
@@ -329,8 +718,11 @@ func (p *Project) EnterFeature(ctx context.Context, featureName string) (*Result
 		return nil, fmt.Errorf("failed to refresh rules for feature '%s': %w", featureName, err)
 	}
 
+	hookEnv.Event = hooks.PostEnter
+	warnings := append(p.sharedRulesWarnings(), p.runPostHooks(ctx, hookEnv)...)
+
 	message := fmt.Sprintf("Entered feature '%s' in phase '%s'.", featureName, retrievedPhase)
-	return NewResultWithRulesChanged(message), nil
+	return p.logged("feature entered", NewResultWithRulesChanged(message).WithFeature(featureName, retrievedPhase).WithWarnings(warnings...)), nil
 }
 
 // ExitFeature clears the active feature context.
@@ -338,58 +730,600 @@ func (p *Project) ExitFeature(ctx context.Context) (*Result, error) {
 	if err := p.RequiresInitialized(); err != nil {
 		return nil, err
 	}
+	unlock, err := p.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
 
+	var warnings []string
 	exitedFeatureName, err := p.features.GetActiveFeature()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "warning: error determining active feature during exit: %v\n", err)
+		warnings = append(warnings, fmt.Sprintf("error determining active feature during exit: %v", err))
 	}
 
 	if exitedFeatureName == "" {
 		if ruleErr := p.rules.ClearGeneratedRules(); ruleErr != nil {
-			fmt.Fprintf(os.Stderr, "warning: failed to clear rules during exit (no active feature): %v\n", ruleErr)
+			warnings = append(warnings, fmt.Sprintf("failed to clear rules during exit (no active feature): %v", ruleErr))
 		}
-		return NewResultWithRulesChanged("No active feature to exit. Cursor rules cleared."), nil
+		warnings = append(warnings, p.sharedRulesWarnings()...)
+		return p.logged("feature exited", NewResultWithRulesChanged("No active feature to exit. Cursor rules cleared.").WithWarnings(warnings...)), nil
+	}
+
+	hookEnv := hooks.Env{Event: hooks.PreExit, Feature: exitedFeatureName}
+	if err := p.runPreHooks(ctx, hookEnv); err != nil {
+		return nil, err
 	}
 
 	errClearActive := p.features.ClearActiveFeature()
 
 	if errRules := p.rules.ClearGeneratedRules(); errRules != nil {
-		fmt.Fprintf(os.Stderr, "warning: failed to clear rules during exit: %v\n", errRules)
+		warnings = append(warnings, fmt.Sprintf("failed to clear rules during exit: %v", errRules))
 	}
 
 	if errClearActive != nil {
 		return nil, fmt.Errorf("failed to clear active feature: %w", errClearActive)
 	}
 
-	return NewResultWithRulesChanged(fmt.Sprintf("Exited feature '%s'. No active feature. Cursor rules cleared.", exitedFeatureName)), nil
+	warnings = append(warnings, p.sharedRulesWarnings()...)
+
+	hookEnv.Event = hooks.PostExit
+	warnings = append(warnings, p.runPostHooks(ctx, hookEnv)...)
+
+	result := NewResultWithRulesChanged(fmt.Sprintf("Exited feature '%s'. No active feature. Cursor rules cleared.", exitedFeatureName))
+	return p.logged("feature exited", result.WithFeature(exitedFeatureName, phase.None).WithWarnings(warnings...)), nil
 }
 
-// DeleteFeature removes a feature and its associated data.
+// DeleteFeature moves a feature and its associated data into the trash.
 // If the deleted feature is the active one, it also clears the active feature context.
 func (p *Project) DeleteFeature(ctx context.Context, featureName string) (*Result, error) {
+	return p.deleteFeature(ctx, featureName, false)
+}
+
+// PurgeFeature permanently removes a feature and its associated data, bypassing the trash.
+// If the purged feature is the active one, it also clears the active feature context.
+func (p *Project) PurgeFeature(ctx context.Context, featureName string) (*Result, error) {
+	return p.deleteFeature(ctx, featureName, true)
+}
+
+// deleteFeature deletes or purges a feature between the pre_delete and post_delete hooks.
+func (p *Project) deleteFeature(ctx context.Context, featureName string, purge bool) (*Result, error) {
 	if err := p.RequiresInitialized(); err != nil {
 		return nil, err
 	}
+	unlock, err := p.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	featureName, err = feature.NormalizeName(featureName)
+	if err != nil {
+		return nil, err
+	}
+
+	hookEnv := hooks.Env{Event: hooks.PreDelete, Feature: featureName}
+	if err := p.runPreHooks(ctx, hookEnv); err != nil {
+		return nil, err
+	}
 
-	activeContextCleared, err := p.features.DeleteFeature(ctx, featureName)
+	var activeContextCleared bool
+	if purge {
+		activeContextCleared, err = p.features.PurgeFeature(ctx, featureName)
+	} else {
+		activeContextCleared, err = p.features.DeleteFeature(ctx, featureName)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to delete feature '%s' using service: %w", featureName, err)
 	}
 
-	message := fmt.Sprintf("Feature '%s' deleted successfully.", featureName)
+	message := fmt.Sprintf("Feature '%s' moved to trash. Restore it with 'd3 feature restore %s'.", featureName, featureName)
+	if purge {
+		message = fmt.Sprintf("Feature '%s' permanently deleted.", featureName)
+	}
 	rulesWereImpacted := false
+	var warnings []string
 
 	if activeContextCleared {
 		if err := p.rules.ClearGeneratedRules(); err != nil {
-			message += fmt.Sprintf(" Warning: failed to clear rules after deleting active feature: %v", err)
+			warnings = append(warnings, fmt.Sprintf("failed to clear rules after deleting active feature: %v", err))
 		} else {
 			rulesWereImpacted = true
 		}
 		message += " Active feature context has been cleared."
 	}
 
-	if rulesWereImpacted {
-		return NewResultWithRulesChanged(message), nil
+	// Features depending on the deleted one stay blocked from deliver, so their owners should know
+	if dependents, err := p.dependents(ctx, featureName); err != nil {
+		warnings = append(warnings, fmt.Sprintf("failed to check which features depend on '%s': %v", featureName, err))
+	} else if len(dependents) > 0 {
+		remedy := "until it is restored or the dependency is removed"
+		if purge {
+			remedy = "until the dependency is removed"
+		}
+		warnings = append(warnings, fmt.Sprintf("'%s' is a dependency of %s, which cannot enter the deliver phase %s with 'd3 feature depend <name> %s --remove'",
+			featureName, quotedList(dependents), remedy, featureName))
+	}
+
+	hookEnv.Event = hooks.PostDelete
+	warnings = append(warnings, p.runPostHooks(ctx, hookEnv)...)
+
+	result := NewResult(message).WithWarnings(warnings...)
+	result.RulesChanged = rulesWereImpacted
+	result.ActiveFeatureCleared = activeContextCleared
+	return p.logged("feature deleted", result.WithFeature(featureName, phase.None).WithFiles(filepath.Join(p.state.FeaturesDir, featureName))), nil
+}
+
+// RestoreFeature brings the most recently deleted copy of a feature back from the trash.
+// The restored feature is not entered automatically.
+func (p *Project) RestoreFeature(ctx context.Context, featureName string) (*Result, error) {
+	if err := p.RequiresInitialized(); err != nil {
+		return nil, err
+	}
+	unlock, err := p.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	info, err := p.features.RestoreFeature(ctx, featureName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore feature '%s': %w", featureName, err)
+	}
+
+	result := NewResult(fmt.Sprintf("Feature '%s' restored from trash.", featureName)).WithFeature(featureName, phase.None)
+	if info != nil {
+		result.WithFiles(info.Path)
+	}
+	return p.logged("feature restored", result), nil
+}
+
+// SyncRules reconciles the generated Cursor rules with the active feature and phase as they are
+// on disk, for example after .d3/.feature or a .phase file was edited by hand, a git checkout
+// changed them or the rules directory was deleted. Only rule files that differ are rewritten.
+func (p *Project) SyncRules(ctx context.Context) (*Result, error) {
+	if err := p.RequiresInitialized(); err != nil {
+		return nil, err
+	}
+	unlock, err := p.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	activeFeature, err := p.features.GetActiveFeature()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active feature: %w", err)
+	}
+
+	var warnings []string
+	if activeFeature != "" && !p.features.FeatureExists(activeFeature) {
+		// Clearing the marker is left to 'd3 doctor --fix'; the rules must not describe a missing feature
+		warnings = append(warnings, fmt.Sprintf("active feature '%s' does not exist, so its rules were removed. Run 'd3 doctor --fix' to clear it.", activeFeature))
+		activeFeature = ""
+	}
+
+	currentPhase := phase.None
+	if activeFeature != "" {
+		currentPhase, err = p.features.GetFeaturePhase(ctx, activeFeature)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get phase for feature '%s': %w", activeFeature, err)
+		}
+	}
+
+	changed, err := p.rules.SyncRules(activeFeature, string(currentPhase))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sync rules: %w", err)
+	}
+
+	if len(changed) == 0 {
+		result := NewResult("Cursor rules are up to date.").WithFeature(activeFeature, currentPhase).WithWarnings(warnings...)
+		// Syncing runs before every MCP tool call, so an unchanged state is not worth an info record
+		p.logger.Debug("rules already in sync", "feature", activeFeature, "phase", string(currentPhase))
+		return result, nil
+	}
+	warnings = append(warnings, p.sharedRulesWarnings()...)
+	message := fmt.Sprintf("Rewrote %d generated rule file(s) to match the current state.", len(changed))
+	return p.logged("rules synced", NewResultWithRulesChanged(message).WithFeature(activeFeature, currentPhase).WithWarnings(warnings...)), nil
+}
+
+// ExportFeature renders a feature's problem, plan and task list as a single document.
+// An empty feature name exports the active feature.
+func (p *Project) ExportFeature(ctx context.Context, featureName string, format export.Format, preset export.Preset) (string, error) {
+	if err := p.RequiresInitialized(); err != nil {
+		return "", err
+	}
+
+	featureName, err := p.featureOrActive(featureName)
+	if err != nil {
+		return "", err
+	}
+
+	report, err := export.Build(ctx, p.fs, p.features, featureName, time.Now())
+	if err != nil {
+		return "", fmt.Errorf("failed to export feature '%s': %w", featureName, err)
+	}
+	return export.Render(report, format, preset)
+}
+
+// TraceFeature links a feature's requirement IDs in problem.md to the plan steps and tasks that
+// reference them, and reports uncovered requirements, steps with no tasks and dangling
+// references. An empty feature name traces the active feature.
+func (p *Project) TraceFeature(ctx context.Context, featureName string) (*trace.Report, error) {
+	if err := p.RequiresInitialized(); err != nil {
+		return nil, err
+	}
+
+	featureName, err := p.featureOrActive(featureName)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.traceReport(ctx, featureName)
+}
+
+// traceReport links the requirements, plan steps and tasks of a feature.
+func (p *Project) traceReport(ctx context.Context, featureName string) (*trace.Report, error) {
+	documents, err := export.Build(ctx, p.fs, p.features, featureName, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read documents of feature '%s': %w", featureName, err)
+	}
+	report := trace.Analyze(featureName, documents.Problem.Content, documents.Plan.Content, documents.Tasks)
+	report.Warnings = append(documents.Warnings, report.Warnings...)
+	return report, nil
+}
+
+// CheckFeature validates the structure of the phase documents a feature has reached, problem.md
+// from define on and plan.md from design on, against the phase schemas. An empty feature name
+// checks the active feature.
+func (p *Project) CheckFeature(ctx context.Context, featureName string) (*check.Result, error) {
+	if err := p.RequiresInitialized(); err != nil {
+		return nil, err
+	}
+
+	featureName, err := p.existingFeature(featureName)
+	if err != nil {
+		return nil, err
+	}
+	currentPhase, err := p.features.GetFeaturePhase(ctx, featureName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get phase of feature '%s': %w", featureName, err)
+	}
+
+	reached := check.Reached(currentPhase)
+	diagnostics, err := p.checkDocuments(featureName, reached)
+	if err != nil {
+		return nil, err
+	}
+	return &check.Result{Feature: featureName, Phases: reached, Diagnostics: diagnostics}, nil
+}
+
+// FeatureSnapshots lists the snapshots of a feature's artifacts, oldest first. An empty feature
+// name lists the active feature's snapshots.
+func (p *Project) FeatureSnapshots(ctx context.Context, featureName string) ([]snapshot.Snapshot, error) {
+	if err := p.RequiresInitialized(); err != nil {
+		return nil, err
+	}
+
+	featureName, err := p.existingFeature(featureName)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot.List(p.fs, filepath.Join(p.state.FeaturesDir, featureName))
+}
+
+// DiffArtifact renders a unified diff of the artifacts held by snapshot from against the same
+// artifacts in snapshot to, or in the feature's current files when to is empty. Snapshots are
+// named by ID or unique ID prefix. An empty result means nothing changed.
+func (p *Project) DiffArtifact(ctx context.Context, featureName string, from string, to string) (string, error) {
+	if err := p.RequiresInitialized(); err != nil {
+		return "", err
+	}
+
+	featureName, err := p.existingFeature(featureName)
+	if err != nil {
+		return "", err
+	}
+	featurePath := filepath.Join(p.state.FeaturesDir, featureName)
+	fromSnap, err := snapshot.Find(p.fs, featurePath, from)
+	if err != nil {
+		return "", err
+	}
+	var toSnap *snapshot.Snapshot
+	if to != "" {
+		if toSnap, err = snapshot.Find(p.fs, featurePath, to); err != nil {
+			return "", err
+		}
+	}
+
+	var diff strings.Builder
+	for _, file := range fromSnap.Files {
+		old, err := fromSnap.ReadFile(p.fs, featurePath, file)
+		if err != nil {
+			return "", err
+		}
+		newName := file
+		var current []byte
+		if toSnap != nil {
+			newName = path.Join(snapshot.Dir, toSnap.ID, file)
+			if slices.Contains(toSnap.Files, file) {
+				if current, err = toSnap.ReadFile(p.fs, featurePath, file); err != nil {
+					return "", err
+				}
+			}
+		} else {
+			current, err = p.fs.ReadFile(filepath.Join(featurePath, filepath.FromSlash(file)))
+			if err != nil && !os.IsNotExist(err) {
+				return "", fmt.Errorf("failed to read %s: %w", file, err)
+			}
+		}
+		diff.WriteString(linediff.Unified(path.Join(snapshot.Dir, fromSnap.ID, file), newName, string(old), string(current)))
+	}
+	return diff.String(), nil
+}
+
+// RevertArtifact restores the artifacts held by a snapshot, named by ID or unique ID prefix, or
+// only those matching artifact when it is not empty (see snapshot.Snapshot.Select). The versions
+// it replaces are snapshotted first, so a revert can itself be reverted.
+func (p *Project) RevertArtifact(ctx context.Context, featureName string, from string, artifact string) (*Result, error) {
+	if err := p.RequiresInitialized(); err != nil {
+		return nil, err
+	}
+	unlock, err := p.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	featureName, err = p.existingFeature(featureName)
+	if err != nil {
+		return nil, err
+	}
+	featurePath := filepath.Join(p.state.FeaturesDir, featureName)
+	snap, err := snapshot.Find(p.fs, featurePath, from)
+	if err != nil {
+		return nil, err
+	}
+	files, err := snap.Select(artifact)
+	if err != nil {
+		return nil, err
+	}
+	currentPhase, err := p.features.GetFeaturePhase(ctx, featureName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get phase of feature '%s': %w", featureName, err)
+	}
+
+	phases := make([]phase.Phase, 0, len(files))
+	for _, file := range files {
+		phases = append(phases, phase.Phase(strings.SplitN(file, "/", 2)[0]))
+	}
+	backup, err := snapshot.Take(p.fs, featurePath, snapshot.ReasonRevert, phases, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to snapshot the artifacts being replaced: %w", err)
+	}
+
+	result := NewResult(fmt.Sprintf("Restored %s from snapshot %s.", strings.Join(files, ", "), snap.ID))
+	if backup != nil {
+		result.Message += fmt.Sprintf(" The replaced version was saved as snapshot %s.", backup.ID)
+		result.WithFiles(filepath.Join(featurePath, snapshot.Dir, backup.ID))
+	}
+	for _, file := range files {
+		data, err := snap.ReadFile(p.fs, featurePath, file)
+		if err != nil {
+			return nil, err
+		}
+		target := filepath.Join(featurePath, filepath.FromSlash(file))
+		if err := p.fs.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create %s: %w", filepath.Dir(target), err)
+		}
+		if err := p.fs.WriteFile(target, data, 0644); err != nil {
+			return nil, fmt.Errorf("failed to restore %s: %w", file, err)
+		}
+		result.WithFiles(target)
+	}
+	return p.logged("artifact reverted", result.WithFeature(featureName, currentPhase)), nil
+}
+
+// FeatureGraph returns every feature of the project with its phase, completion and
+// dependencies.
+func (p *Project) FeatureGraph(ctx context.Context) (*depgraph.Graph, error) {
+	if err := p.RequiresInitialized(); err != nil {
+		return nil, err
+	}
+
+	infos, err := p.features.ListFeatures(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list features: %w", err)
+	}
+	nodes := make([]depgraph.Node, 0, len(infos))
+	for _, info := range infos {
+		node, err := p.dependencyNode(ctx, info.Name)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+	return depgraph.New(nodes), nil
+}
+
+// SetDependencies adds dependencies to a feature and removes others from it. Every added
+// dependency must be an existing feature other than the feature itself, and the result must not
+// form a cycle. An empty feature name changes the active feature.
+func (p *Project) SetDependencies(ctx context.Context, featureName string, add []string, remove []string) (*Result, error) {
+	if err := p.RequiresInitialized(); err != nil {
+		return nil, err
+	}
+	unlock, err := p.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	featureName, err = p.existingFeature(featureName)
+	if err != nil {
+		return nil, err
+	}
+	for _, dep := range add {
+		if dep == featureName {
+			return nil, d3err.New(d3err.InvalidArgument, "feature '%s' cannot depend on itself", featureName)
+		}
+		if !p.features.FeatureExists(dep) {
+			return nil, d3err.New(d3err.FeatureNotFound, "feature '%s' does not exist", dep)
+		}
+	}
+
+	current, err := p.features.GetFeatureDependencies(featureName)
+	if err != nil {
+		return nil, err
+	}
+	dependencies := []string{}
+	for _, dep := range current {
+		if !slices.Contains(remove, dep) && !slices.Contains(dependencies, dep) {
+			dependencies = append(dependencies, dep)
+		}
+	}
+	for _, dep := range add {
+		if !slices.Contains(dependencies, dep) {
+			dependencies = append(dependencies, dep)
+		}
+	}
+
+	if len(add) > 0 {
+		graph, err := p.FeatureGraph(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if cycle := graph.WithDependencies(featureName, dependencies).Cycle(); cycle != nil {
+			return nil, d3err.New(d3err.InvalidArgument, "cannot add the dependency: it would form the cycle %s", strings.Join(cycle, " -> "))
+		}
+	}
+
+	if err := p.features.SetFeatureDependencies(featureName, dependencies); err != nil {
+		return nil, err
+	}
+
+	message := fmt.Sprintf("Feature '%s' no longer depends on other features.", featureName)
+	if len(dependencies) > 0 {
+		message = fmt.Sprintf("Feature '%s' now depends on %s.", featureName, quotedList(dependencies))
+	}
+	dependsFile := filepath.Join(p.state.FeaturesDir, featureName, ".depends_on")
+	return p.logged("dependencies changed", NewResult(message).WithFiles(dependsFile)), nil
+}
+
+// dependencyGate fails with the GateFailed code when a feature entering deliver depends on
+// features that are not complete yet, or no longer exist.
+func (p *Project) dependencyGate(ctx context.Context, featureName string, from, to phase.Phase) error {
+	if to != phase.Deliver || from == phase.Deliver {
+		return nil
+	}
+	dependencies, err := p.features.GetFeatureDependencies(featureName)
+	if err != nil || len(dependencies) == 0 {
+		return err
+	}
+
+	nodes := []depgraph.Node{{Name: featureName, DependsOn: dependencies}}
+	for _, dep := range dependencies {
+		if !p.features.FeatureExists(dep) {
+			continue
+		}
+		node, err := p.dependencyNode(ctx, dep)
+		if err != nil {
+			return err
+		}
+		nodes = append(nodes, node)
+	}
+	graph := depgraph.New(nodes)
+	blocking := graph.Blocking(featureName)
+	if len(blocking) == 0 {
+		return nil
+	}
+	labels := make([]string, 0, len(blocking))
+	for _, dep := range blocking {
+		labels = append(labels, fmt.Sprintf("'%s' (%s)", dep, graph.Label(dep)))
+	}
+	return d3err.New(d3err.GateFailed, "cannot move '%s' to the deliver phase: it depends on %s, which must be complete first", featureName, strings.Join(labels, ", "))
+}
+
+// dependents returns the features that depend directly on featureName.
+func (p *Project) dependents(ctx context.Context, featureName string) ([]string, error) {
+	infos, err := p.features.ListFeatures(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list features: %w", err)
+	}
+	nodes := make([]depgraph.Node, 0, len(infos))
+	for _, info := range infos {
+		dependencies, err := p.features.GetFeatureDependencies(info.Name)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, depgraph.Node{Name: info.Name, DependsOn: dependencies})
+	}
+	return depgraph.New(nodes).Dependents(featureName), nil
+}
+
+// dependencyNode describes a feature for the dependency graph. A feature counts as complete in
+// deliver once progress.yaml lists tasks and all of them are done; a task list that cannot be
+// parsed counts as not complete.
+func (p *Project) dependencyNode(ctx context.Context, featureName string) (depgraph.Node, error) {
+	currentPhase, err := p.features.GetFeaturePhase(ctx, featureName)
+	if err != nil {
+		return depgraph.Node{}, fmt.Errorf("failed to get phase of feature '%s': %w", featureName, err)
+	}
+	dependencies, err := p.features.GetFeatureDependencies(featureName)
+	if err != nil {
+		return depgraph.Node{}, err
+	}
+	node := depgraph.Node{Name: featureName, Phase: currentPhase, DependsOn: dependencies}
+	if currentPhase != phase.Deliver {
+		return node, nil
+	}
+	progressFile := filepath.Join(p.state.FeaturesDir, featureName, string(phase.Deliver), phase.PhaseFileMap[phase.Deliver])
+	data, err := p.fs.ReadFile(progressFile)
+	if err != nil && !os.IsNotExist(err) {
+		return depgraph.Node{}, fmt.Errorf("failed to read progress.yaml of feature '%s': %w", featureName, err)
+	}
+	if tasks, err := progress.Parse(data); err == nil {
+		node.Complete = progress.Complete(tasks)
+	}
+	return node, nil
+}
+
+// quotedList renders names as a list of quoted names, such as "'api' and 'auth'".
+func quotedList(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = "'" + name + "'"
+	}
+	if len(quoted) == 1 {
+		return quoted[0]
+	}
+	return strings.Join(quoted[:len(quoted)-1], ", ") + " and " + quoted[len(quoted)-1]
+}
+
+// existingFeature returns featureName, or the active feature when it is empty, after checking
+// that the feature exists.
+func (p *Project) existingFeature(featureName string) (string, error) {
+	featureName, err := p.featureOrActive(featureName)
+	if err != nil {
+		return "", err
+	}
+	if !p.features.FeatureExists(featureName) {
+		return "", d3err.New(d3err.FeatureNotFound, "feature '%s' does not exist", featureName)
+	}
+	return featureName, nil
+}
+
+// featureOrActive returns featureName, or the active feature when it is empty.
+func (p *Project) featureOrActive(featureName string) (string, error) {
+	if featureName != "" {
+		return featureName, nil
+	}
+	activeFeature, err := p.features.GetActiveFeature()
+	if err != nil {
+		return "", fmt.Errorf("failed to get active feature: %w", err)
+	}
+	if activeFeature == "" {
+		return "", ErrNoActiveFeature
 	}
-	return NewResult(message), nil
+	return activeFeature, nil
 }
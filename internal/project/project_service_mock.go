@@ -9,7 +9,12 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	check "github.com/imcclaskey/d3/internal/core/check"
+	depgraph "github.com/imcclaskey/d3/internal/core/depgraph"
+	export "github.com/imcclaskey/d3/internal/core/export"
 	phase "github.com/imcclaskey/d3/internal/core/phase"
+	snapshot "github.com/imcclaskey/d3/internal/core/snapshot"
+	trace "github.com/imcclaskey/d3/internal/core/trace"
 )
 
 // MockProjectService is a mock of ProjectService interface.
@@ -50,6 +55,21 @@ func (mr *MockProjectServiceMockRecorder) ChangePhase(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePhase", reflect.TypeOf((*MockProjectService)(nil).ChangePhase), arg0, arg1)
 }
 
+// CheckFeature mocks base method.
+func (m *MockProjectService) CheckFeature(arg0 context.Context, arg1 string) (*check.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckFeature", arg0, arg1)
+	ret0, _ := ret[0].(*check.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckFeature indicates an expected call of CheckFeature.
+func (mr *MockProjectServiceMockRecorder) CheckFeature(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckFeature", reflect.TypeOf((*MockProjectService)(nil).CheckFeature), arg0, arg1)
+}
+
 // CreateFeature mocks base method.
 func (m *MockProjectService) CreateFeature(arg0 context.Context, arg1 string) (*Result, error) {
 	m.ctrl.T.Helper()
@@ -80,6 +100,21 @@ func (mr *MockProjectServiceMockRecorder) DeleteFeature(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFeature", reflect.TypeOf((*MockProjectService)(nil).DeleteFeature), arg0, arg1)
 }
 
+// DiffArtifact mocks base method.
+func (m *MockProjectService) DiffArtifact(arg0 context.Context, arg1, arg2, arg3 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffArtifact", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffArtifact indicates an expected call of DiffArtifact.
+func (mr *MockProjectServiceMockRecorder) DiffArtifact(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffArtifact", reflect.TypeOf((*MockProjectService)(nil).DiffArtifact), arg0, arg1, arg2, arg3)
+}
+
 // EnterFeature mocks base method.
 func (m *MockProjectService) EnterFeature(arg0 context.Context, arg1 string) (*Result, error) {
 	m.ctrl.T.Helper()
@@ -110,6 +145,51 @@ func (mr *MockProjectServiceMockRecorder) ExitFeature(arg0 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExitFeature", reflect.TypeOf((*MockProjectService)(nil).ExitFeature), arg0)
 }
 
+// ExportFeature mocks base method.
+func (m *MockProjectService) ExportFeature(arg0 context.Context, arg1 string, arg2 export.Format, arg3 export.Preset) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportFeature", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportFeature indicates an expected call of ExportFeature.
+func (mr *MockProjectServiceMockRecorder) ExportFeature(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportFeature", reflect.TypeOf((*MockProjectService)(nil).ExportFeature), arg0, arg1, arg2, arg3)
+}
+
+// FeatureGraph mocks base method.
+func (m *MockProjectService) FeatureGraph(arg0 context.Context) (*depgraph.Graph, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FeatureGraph", arg0)
+	ret0, _ := ret[0].(*depgraph.Graph)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FeatureGraph indicates an expected call of FeatureGraph.
+func (mr *MockProjectServiceMockRecorder) FeatureGraph(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FeatureGraph", reflect.TypeOf((*MockProjectService)(nil).FeatureGraph), arg0)
+}
+
+// FeatureSnapshots mocks base method.
+func (m *MockProjectService) FeatureSnapshots(arg0 context.Context, arg1 string) ([]snapshot.Snapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FeatureSnapshots", arg0, arg1)
+	ret0, _ := ret[0].([]snapshot.Snapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FeatureSnapshots indicates an expected call of FeatureSnapshots.
+func (mr *MockProjectServiceMockRecorder) FeatureSnapshots(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FeatureSnapshots", reflect.TypeOf((*MockProjectService)(nil).FeatureSnapshots), arg0, arg1)
+}
+
 // Init mocks base method.
 func (m *MockProjectService) Init(arg0, arg1, arg2 bool) (*Result, error) {
 	m.ctrl.T.Helper()
@@ -139,6 +219,21 @@ func (mr *MockProjectServiceMockRecorder) IsInitialized() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsInitialized", reflect.TypeOf((*MockProjectService)(nil).IsInitialized))
 }
 
+// PurgeFeature mocks base method.
+func (m *MockProjectService) PurgeFeature(arg0 context.Context, arg1 string) (*Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeFeature", arg0, arg1)
+	ret0, _ := ret[0].(*Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeFeature indicates an expected call of PurgeFeature.
+func (mr *MockProjectServiceMockRecorder) PurgeFeature(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeFeature", reflect.TypeOf((*MockProjectService)(nil).PurgeFeature), arg0, arg1)
+}
+
 // RequiresInitialized mocks base method.
 func (m *MockProjectService) RequiresInitialized() error {
 	m.ctrl.T.Helper()
@@ -152,3 +247,78 @@ func (mr *MockProjectServiceMockRecorder) RequiresInitialized() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequiresInitialized", reflect.TypeOf((*MockProjectService)(nil).RequiresInitialized))
 }
+
+// RestoreFeature mocks base method.
+func (m *MockProjectService) RestoreFeature(arg0 context.Context, arg1 string) (*Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreFeature", arg0, arg1)
+	ret0, _ := ret[0].(*Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreFeature indicates an expected call of RestoreFeature.
+func (mr *MockProjectServiceMockRecorder) RestoreFeature(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreFeature", reflect.TypeOf((*MockProjectService)(nil).RestoreFeature), arg0, arg1)
+}
+
+// RevertArtifact mocks base method.
+func (m *MockProjectService) RevertArtifact(arg0 context.Context, arg1, arg2, arg3 string) (*Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevertArtifact", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevertArtifact indicates an expected call of RevertArtifact.
+func (mr *MockProjectServiceMockRecorder) RevertArtifact(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevertArtifact", reflect.TypeOf((*MockProjectService)(nil).RevertArtifact), arg0, arg1, arg2, arg3)
+}
+
+// SetDependencies mocks base method.
+func (m *MockProjectService) SetDependencies(arg0 context.Context, arg1 string, arg2, arg3 []string) (*Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDependencies", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetDependencies indicates an expected call of SetDependencies.
+func (mr *MockProjectServiceMockRecorder) SetDependencies(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDependencies", reflect.TypeOf((*MockProjectService)(nil).SetDependencies), arg0, arg1, arg2, arg3)
+}
+
+// SyncRules mocks base method.
+func (m *MockProjectService) SyncRules(arg0 context.Context) (*Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncRules", arg0)
+	ret0, _ := ret[0].(*Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SyncRules indicates an expected call of SyncRules.
+func (mr *MockProjectServiceMockRecorder) SyncRules(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncRules", reflect.TypeOf((*MockProjectService)(nil).SyncRules), arg0)
+}
+
+// TraceFeature mocks base method.
+func (m *MockProjectService) TraceFeature(arg0 context.Context, arg1 string) (*trace.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TraceFeature", arg0, arg1)
+	ret0, _ := ret[0].(*trace.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TraceFeature indicates an expected call of TraceFeature.
+func (mr *MockProjectServiceMockRecorder) TraceFeature(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TraceFeature", reflect.TypeOf((*MockProjectService)(nil).TraceFeature), arg0, arg1)
+}
@@ -1,17 +1,29 @@
 package project
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/golang/mock/gomock"
+
+	"github.com/imcclaskey/d3/internal/core/check"
+	"github.com/imcclaskey/d3/internal/core/config"
+	"github.com/imcclaskey/d3/internal/core/d3err"
+	"github.com/imcclaskey/d3/internal/core/depgraph"
+	"github.com/imcclaskey/d3/internal/core/export"
 	"github.com/imcclaskey/d3/internal/core/feature"
+	"github.com/imcclaskey/d3/internal/core/hooks"
 	"github.com/imcclaskey/d3/internal/core/phase"
 	portsmocks "github.com/imcclaskey/d3/internal/core/ports/mocks"
+	"github.com/imcclaskey/d3/internal/core/snapshot"
 	"github.com/imcclaskey/d3/internal/testutil"
 )
 
@@ -27,7 +39,7 @@ func newTestProjectWithMocks(t *testing.T, ctrl *gomock.Controller) (*Project, *
 	mockPhaseSvc := NewMockPhaseServicer(ctrl)
 	mockFileOp := NewMockFileOperator(ctrl)
 
-	proj := New(projectRoot, mockFS, mockFeatureSvc, mockRulesSvc, mockPhaseSvc, mockFileOp)
+	proj := New(config.Default(projectRoot), mockFS, mockFeatureSvc, mockRulesSvc, mockPhaseSvc, mockFileOp)
 	return proj, mockFS, mockFeatureSvc, mockRulesSvc, mockPhaseSvc, mockFileOp
 }
 
@@ -43,7 +55,7 @@ func TestProject_New_WithGoMock(t *testing.T) {
 	mockPhaseSvc := NewMockPhaseServicer(ctrl)
 	mockFileOp := NewMockFileOperator(ctrl)
 
-	proj := New(projectRoot, mockFS, mockFeatureSvc, mockRulesSvc, mockPhaseSvc, mockFileOp)
+	proj := New(config.Default(projectRoot), mockFS, mockFeatureSvc, mockRulesSvc, mockPhaseSvc, mockFileOp)
 
 	if proj == nil {
 		t.Fatal("New() returned nil")
@@ -198,6 +210,7 @@ func TestProject_Init(t *testing.T) {
 			args: args{clean: true, refresh: false, customRules: false},
 			setupMocks: func(proj *Project, mockFS *portsmocks.MockFileSystem, mockRules *MockRulesServicer, mockPhase *MockPhaseServicer, mockFileOp *MockFileOperator, mockFeature *MockFeatureServicer) {
 				mockFS.EXPECT().Stat(proj.state.D3Dir).Return(testutil.MockFileInfo{FIsDir: true}, nil).Times(1)
+				mockFS.EXPECT().ReadFile(config.Path(proj.state.ProjectRoot)).Return(nil, os.ErrNotExist).Times(1)
 				mockFS.EXPECT().RemoveAll(proj.state.D3Dir).Return(nil).Times(1)
 				// Standard init steps after cleanups
 				mockFS.EXPECT().MkdirAll(proj.state.D3Dir, os.FileMode(0755)).Return(nil).Times(1)
@@ -212,6 +225,27 @@ func TestProject_Init(t *testing.T) {
 			wantErr:       false,
 			wantResultMsg: "Project cleaned and re-initialized successfully. Cursor rules have been updated.",
 		},
+		{
+			name: "clean init keeps config.yaml",
+			args: args{clean: true, refresh: false, customRules: false},
+			setupMocks: func(proj *Project, mockFS *portsmocks.MockFileSystem, mockRules *MockRulesServicer, mockPhase *MockPhaseServicer, mockFileOp *MockFileOperator, mockFeature *MockFeatureServicer) {
+				configPath := config.Path(proj.state.ProjectRoot)
+				mockFS.EXPECT().Stat(proj.state.D3Dir).Return(testutil.MockFileInfo{FIsDir: true}, nil).Times(1)
+				mockFS.EXPECT().ReadFile(configPath).Return([]byte("initial_phase: design\n"), nil).Times(1)
+				mockFS.EXPECT().RemoveAll(proj.state.D3Dir).Return(nil).Times(1)
+				mockFS.EXPECT().MkdirAll(proj.state.D3Dir, os.FileMode(0755)).Return(nil).Times(2)
+				mockFS.EXPECT().WriteFile(configPath, []byte("initial_phase: design\n"), os.FileMode(0644)).Return(nil).Times(1)
+				mockFS.EXPECT().MkdirAll(proj.state.FeaturesDir, os.FileMode(0755)).Return(nil).Times(1)
+				mockFileOp.EXPECT().EnsureMCPJSON(mockFS, proj.state.ProjectRoot).Return(nil).Times(1)
+				mockFileOp.EXPECT().EnsureRootGitignoreEntries(mockFS, proj.state.ProjectRoot).Return(nil).Times(1)
+				mockFileOp.EXPECT().EnsureRootCursorignoreEntries(mockFS, proj.state.ProjectRoot).Return(nil).Times(1)
+				mockFileOp.EXPECT().EnsureProjectFiles(mockFS, proj.state.D3Dir).Return(nil).Times(1)
+				mockRules.EXPECT().RefreshRules("", string(phase.None)).Return(nil).Times(1)
+				mockFeature.EXPECT().ClearActiveFeature().Return(nil).Times(1)
+			},
+			wantErr:       false,
+			wantResultMsg: "Project cleaned and re-initialized successfully. Cursor rules have been updated.",
+		},
 		{
 			name: "refresh on new project",
 			args: args{clean: false, refresh: true, customRules: false},
@@ -227,7 +261,6 @@ func TestProject_Init(t *testing.T) {
 					mockFileOp.EXPECT().EnsureProjectFiles(mockFS, proj.state.D3Dir).Return(nil).Times(1),
 					// Refresh specific calls
 					mockFeature.EXPECT().GetActiveFeature().Return("", nil).Times(1),
-					mockFeature.EXPECT().GetFeaturePhase(gomock.Any(), "").Return(phase.None, nil).Times(1),
 					mockRules.EXPECT().RefreshRules("", string(phase.None)).Return(nil).Times(1),
 
 					// Conditional call due to !originalIsCurrentlyInitialized
@@ -266,6 +299,7 @@ func TestProject_Init(t *testing.T) {
 			args: args{clean: true, refresh: false, customRules: false},
 			setupMocks: func(proj *Project, mockFS *portsmocks.MockFileSystem, mockRules *MockRulesServicer, mockPhase *MockPhaseServicer, mockFileOp *MockFileOperator, mockFeature *MockFeatureServicer) {
 				mockFS.EXPECT().Stat(proj.state.D3Dir).Return(testutil.MockFileInfo{FIsDir: true}, nil).Times(1)
+				mockFS.EXPECT().ReadFile(config.Path(proj.state.ProjectRoot)).Return(nil, os.ErrNotExist).Times(1)
 				mockFS.EXPECT().RemoveAll(proj.state.D3Dir).Return(fmt.Errorf("failed to remove")).Times(1)
 				// mockFeature.EXPECT().ClearActiveFeature().Return(nil).AnyTimes() // This call is inside the if originalIsInitialized for clean
 			},
@@ -408,8 +442,8 @@ func TestProject_CreateFeature(t *testing.T) {
 				featurePath := filepath.Join(proj.state.FeaturesDir, "test-feature")
 				mockFeature.EXPECT().CreateFeature(ctx, "test-feature").Return(&feature.FeatureInfo{Name: "test-feature", Path: featurePath}, nil).Times(1)
 				mockFeature.EXPECT().SetActiveFeature("test-feature").Return(fmt.Errorf("set active failed")).Times(1)
-				// Expect cleanup call
-				mockFeature.EXPECT().DeleteFeature(ctx, "test-feature").Return(false, nil).Times(1) // activeContextCleared might be false, error nil for cleanup
+				// Expect the half-created feature to be purged rather than moved to the trash
+				mockFeature.EXPECT().PurgeFeature(ctx, "test-feature").Return(false, nil).Times(1)
 			},
 			wantErr: true,
 		},
@@ -450,7 +484,7 @@ func TestProject_CreateFeature(t *testing.T) {
 				tt.setupMocks(proj, mockFS, mockFeature, mockRules, mockPhase)
 			}
 
-			_, err := proj.CreateFeature(tt.args.ctx, tt.args.featureName)
+			result, err := proj.CreateFeature(tt.args.ctx, tt.args.featureName)
 
 			if tt.wantErr {
 				if err == nil {
@@ -460,12 +494,46 @@ func TestProject_CreateFeature(t *testing.T) {
 				}
 			} else if err != nil {
 				t.Errorf("CreateFeature() unexpected error = %v", err)
+			} else {
+				wantPath := filepath.Join(proj.state.FeaturesDir, tt.args.featureName)
+				if result.Feature != tt.args.featureName || result.Phase != phase.Define {
+					t.Errorf("CreateFeature() result feature = %q (%s), want %q (define)", result.Feature, result.Phase, tt.args.featureName)
+				}
+				if len(result.FilesTouched) != 1 || result.FilesTouched[0] != wantPath {
+					t.Errorf("CreateFeature() result files = %v, want [%s]", result.FilesTouched, wantPath)
+				}
 			}
 			// Removed verifyProjectStateAndMocks
 		})
 	}
 }
 
+func TestProject_CreateFeature_ConfiguredInitialPhase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cfg := config.Default(t.TempDir())
+	cfg.InitialPhase = phase.Design
+	mockFS := portsmocks.NewMockFileSystem(ctrl)
+	mockFeature := NewMockFeatureServicer(ctrl)
+	mockRules := NewMockRulesServicer(ctrl)
+	mockPhase := NewMockPhaseServicer(ctrl)
+	proj := New(cfg, mockFS, mockFeature, mockRules, mockPhase, NewMockFileOperator(ctrl))
+
+	featurePath := filepath.Join(cfg.FeaturesDir, "new-feature")
+	mockFS.EXPECT().Stat(cfg.D3Dir).Return(testutil.MockFileInfo{FIsDir: true}, nil)
+	mockFeature.EXPECT().CreateFeature(gomock.Any(), "new-feature").Return(&feature.FeatureInfo{Name: "new-feature", Path: featurePath}, nil)
+	mockFeature.EXPECT().SetActiveFeature("new-feature").Return(nil)
+	mockPhase.EXPECT().EnsurePhaseFiles(featurePath).Return(nil)
+	mockRules.EXPECT().RefreshRules("new-feature", string(phase.Design)).Return(nil)
+
+	result, err := proj.CreateFeature(context.Background(), "new-feature")
+	if err != nil {
+		t.Fatalf("CreateFeature() error = %v", err)
+	}
+	if result.Phase != phase.Design || !strings.Contains(result.Message, "design phase") {
+		t.Errorf("CreateFeature() result = %q (%s), want design phase", result.Message, result.Phase)
+	}
+}
+
 func TestProject_ChangePhase(t *testing.T) {
 	type args struct {
 		ctx         context.Context
@@ -547,50 +615,53 @@ func TestProject_ChangePhase(t *testing.T) {
 				mockFS.EXPECT().Stat(proj.state.D3Dir).Return(testutil.MockFileInfo{FIsDir: true}, nil).Times(1)
 				mockFeature.EXPECT().GetActiveFeature().Return("active-feat", nil).Times(1)
 				mockFeature.EXPECT().GetFeaturePhase(ctx, "active-feat").Return(phase.Design, nil).Times(1)
+				mockFeature.EXPECT().GetFeatureDependencies("active-feat").Return(nil, nil).Times(1)
 				mockFeature.EXPECT().SetFeaturePhase(ctx, "active-feat", phase.Deliver).Return(nil).Times(1)
 				mockRules.EXPECT().RefreshRules("active-feat", string(phase.Deliver)).Return(fmt.Errorf("rules refresh failed")).Times(1)
 			},
 			wantErr: true,
 		},
 		{
-			name: "successful phase change, no impact",
+			name: "successful phase change",
 			args: args{ctx: context.Background(), targetPhase: phase.Design},
 			setupMocks: func(proj *Project, mockFS *portsmocks.MockFileSystem, mockFeature *MockFeatureServicer, mockRules *MockRulesServicer, mockPhaseSvc *MockPhaseServicer) {
 				ctx := gomock.Any()
 				featureName := "active-feat"
 				featurePath := filepath.Join(proj.state.FeaturesDir, featureName)
-				targetPhaseDir := filepath.Join(featurePath, string(phase.Design))
 
 				mockFS.EXPECT().Stat(proj.state.D3Dir).Return(testutil.MockFileInfo{FIsDir: true}, nil).Times(1)
 				mockFeature.EXPECT().GetActiveFeature().Return(featureName, nil).Times(1)
 				mockFeature.EXPECT().GetFeaturePhase(ctx, featureName).Return(phase.Define, nil).Times(1)
 				mockFeature.EXPECT().SetFeaturePhase(ctx, featureName, phase.Design).Return(nil).Times(1)
 				mockRules.EXPECT().RefreshRules(featureName, string(phase.Design)).Return(nil).Times(1)
+				mockFS.EXPECT().ReadFile(filepath.Join(featurePath, "define", "problem.md")).Return(nil, os.ErrNotExist).Times(1) // Nothing to snapshot
 				mockPhaseSvc.EXPECT().EnsurePhaseFiles(featurePath).Return(nil).Times(1)
-				mockFS.EXPECT().Stat(targetPhaseDir).Return(nil, os.ErrNotExist).Times(1) // No impact
+				mockFS.EXPECT().ReadFile(filepath.Join(featurePath, "design", "plan.md")).Return(nil, os.ErrNotExist).Times(1) // Nothing marked for review
+				mockFS.EXPECT().ReadFile(filepath.Join(featurePath, "deliver", "progress.yaml")).Return(nil, os.ErrNotExist).Times(1)
 			},
 			wantErr: false,
 			wantMsg: "Moved to design phase. Cursor rules have changed. Stop your current behavior and await further instruction.",
 		},
 		{
-			name: "successful phase change, with impact",
+			name: "successful move to deliver",
 			args: args{ctx: context.Background(), targetPhase: phase.Deliver},
 			setupMocks: func(proj *Project, mockFS *portsmocks.MockFileSystem, mockFeature *MockFeatureServicer, mockRules *MockRulesServicer, mockPhaseSvc *MockPhaseServicer) {
 				ctx := gomock.Any()
 				featureName := "impact-feat"
 				featurePath := filepath.Join(proj.state.FeaturesDir, featureName)
-				targetPhaseDir := filepath.Join(featurePath, string(phase.Deliver))
 
 				mockFS.EXPECT().Stat(proj.state.D3Dir).Return(testutil.MockFileInfo{FIsDir: true}, nil).Times(1)
 				mockFeature.EXPECT().GetActiveFeature().Return(featureName, nil).Times(1)
 				mockFeature.EXPECT().GetFeaturePhase(ctx, featureName).Return(phase.Design, nil).Times(1)
+				mockFeature.EXPECT().GetFeatureDependencies(featureName).Return(nil, nil).Times(1)
 				mockFeature.EXPECT().SetFeaturePhase(ctx, featureName, phase.Deliver).Return(nil).Times(1)
 				mockRules.EXPECT().RefreshRules(featureName, string(phase.Deliver)).Return(nil).Times(1)
 				mockPhaseSvc.EXPECT().EnsurePhaseFiles(featurePath).Return(nil).Times(1)
-				mockFS.EXPECT().Stat(targetPhaseDir).Return(testutil.MockFileInfo{FIsDir: true}, nil).Times(1) // Has impact
+				mockFS.EXPECT().ReadFile(filepath.Join(featurePath, "design", "plan.md")).Return(nil, os.ErrNotExist).Times(2)        // No plan to snapshot or seed tasks from
+				mockFS.EXPECT().ReadFile(filepath.Join(featurePath, "deliver", "progress.yaml")).Return(nil, os.ErrNotExist).Times(1) // Nothing marked for review
 			},
 			wantErr: false,
-			wantMsg: "Moved to deliver phase. Note: Existing files were detected for the target phase. Review required. Cursor rules have changed. Stop your current behavior and await further instruction.",
+			wantMsg: "Moved to deliver phase. Cursor rules have changed. Stop your current behavior and await further instruction.",
 		},
 	}
 
@@ -625,6 +696,68 @@ func TestProject_ChangePhase(t *testing.T) {
 	}
 }
 
+func TestProject_ChangePhase_PreparesProgress(t *testing.T) {
+	const plan = "## Delivery Steps\n- S1 [code] Add the login form (R1)\n- S2 [test] Run the tests\n"
+	tests := []struct {
+		name         string
+		progress     string
+		wantProgress string
+		wantMessage  string
+		wantWarnings []string
+	}{
+		{
+			name:         "empty progress.yaml is generated from the plan",
+			wantProgress: "- id: 1\n  description: Add the login form (R1)\n  type: code\n  status: pending\n  refs: [S1]\n- id: 2\n  description: Run the tests\n  type: test\n  status: pending\n  refs: [S2]\n",
+			wantMessage:  "Generated 2 task(s) in progress.yaml",
+		},
+		{
+			name:         "existing tasks are kept and drift is reported",
+			progress:     "- id: 1\n  description: Build the form\n  type: code\n  status: complete\n  refs: [S1]\n",
+			wantProgress: "- id: 1\n  description: Build the form\n  type: code\n  status: complete\n  refs: [S1]\n",
+			wantWarnings: []string{`progress.yaml differs from plan.md: plan step "S2" has no task in progress.yaml`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			cfg := config.Default("/p")
+			memFS := testutil.NewMemFS()
+			featurePath := filepath.Join(cfg.FeaturesDir, "login")
+			progressFile := filepath.Join(featurePath, "deliver", "progress.yaml")
+			memFS.MkdirAll(cfg.D3Dir, 0755)
+			memFS.AddFile(filepath.Join(featurePath, "design", "plan.md"), plan)
+			memFS.AddFile(progressFile, tt.progress)
+
+			mockFeature := NewMockFeatureServicer(ctrl)
+			mockRules := NewMockRulesServicer(ctrl)
+			mockPhase := NewMockPhaseServicer(ctrl)
+			proj := New(cfg, memFS, mockFeature, mockRules, mockPhase, NewMockFileOperator(ctrl))
+
+			mockFeature.EXPECT().GetActiveFeature().Return("login", nil)
+			mockFeature.EXPECT().GetFeaturePhase(gomock.Any(), "login").Return(phase.Design, nil)
+			mockFeature.EXPECT().GetFeatureDependencies("login").Return(nil, nil)
+			mockFeature.EXPECT().SetFeaturePhase(gomock.Any(), "login", phase.Deliver).Return(nil)
+			mockRules.EXPECT().RefreshRules("login", "deliver").Return(nil)
+			mockPhase.EXPECT().EnsurePhaseFiles(featurePath).Return(nil)
+
+			result, err := proj.ChangePhase(context.Background(), phase.Deliver)
+			if err != nil {
+				t.Fatalf("ChangePhase() error = %v", err)
+			}
+			if !strings.Contains(result.Message, tt.wantMessage) {
+				t.Errorf("ChangePhase() message = %q, want it to contain %q", result.Message, tt.wantMessage)
+			}
+			if !reflect.DeepEqual(result.Warnings, tt.wantWarnings) {
+				t.Errorf("ChangePhase() warnings = %q, want %q", result.Warnings, tt.wantWarnings)
+			}
+			if data, _ := memFS.ReadFile(progressFile); string(data) != tt.wantProgress {
+				t.Errorf("progress.yaml = %q, want %q", data, tt.wantProgress)
+			}
+		})
+	}
+}
+
 func TestProject_EnterFeature(t *testing.T) {
 	type args struct {
 		ctx         context.Context
@@ -753,7 +886,7 @@ func TestProject_ExitFeature(t *testing.T) {
 				mockRules.EXPECT().ClearGeneratedRules().Return(nil).Times(1)                                 // Should still proceed to clear rules
 			},
 			wantErr: false, // Error is logged as warning, main operation proceeds as "no active feature"
-			wantMsg: "No active feature to exit. Cursor rules cleared. Cursor rules have changed. Stop your current behavior and await further instruction.",
+			wantMsg: "No active feature to exit. Cursor rules cleared. Cursor rules have changed. Stop your current behavior and await further instruction.\nWarning: error determining active feature during exit: read active failed",
 		},
 		{
 			name: "ClearActiveFeature fails",
@@ -774,7 +907,7 @@ func TestProject_ExitFeature(t *testing.T) {
 				mockRules.EXPECT().ClearGeneratedRules().Return(fmt.Errorf("rules clear failed")).Times(1)
 			},
 			wantErr: false, // Error is warning
-			wantMsg: "Exited feature 'exited-feature'. No active feature. Cursor rules cleared. Cursor rules have changed. Stop your current behavior and await further instruction.",
+			wantMsg: "Exited feature 'exited-feature'. No active feature. Cursor rules cleared. Cursor rules have changed. Stop your current behavior and await further instruction.\nWarning: failed to clear rules during exit: rules clear failed",
 		},
 		{
 			name: "successful exit",
@@ -817,10 +950,116 @@ func TestProject_ExitFeature(t *testing.T) {
 	}
 }
 
+func TestProject_SyncRules(t *testing.T) {
+	tests := []struct {
+		name         string
+		setupMocks   func(proj *Project, mockFS *portsmocks.MockFileSystem, mockFeature *MockFeatureServicer, mockRules *MockRulesServicer)
+		wantErr      bool
+		wantMsg      string
+		wantFeature  string
+		wantRulesChg bool
+	}{
+		{
+			name: "project not initialized",
+			setupMocks: func(proj *Project, mockFS *portsmocks.MockFileSystem, mockFeature *MockFeatureServicer, mockRules *MockRulesServicer) {
+				mockFS.EXPECT().Stat(proj.state.D3Dir).Return(nil, os.ErrNotExist).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "rules already match the active feature",
+			setupMocks: func(proj *Project, mockFS *portsmocks.MockFileSystem, mockFeature *MockFeatureServicer, mockRules *MockRulesServicer) {
+				mockFS.EXPECT().Stat(proj.state.D3Dir).Return(testutil.MockFileInfo{FIsDir: true}, nil).Times(1)
+				mockFeature.EXPECT().GetActiveFeature().Return("login", nil).Times(1)
+				mockFeature.EXPECT().FeatureExists("login").Return(true).Times(1)
+				mockFeature.EXPECT().GetFeaturePhase(gomock.Any(), "login").Return(phase.Design, nil).Times(1)
+				mockRules.EXPECT().SyncRules("login", "design").Return(nil, nil).Times(1)
+			},
+			wantMsg:     "Cursor rules are up to date.",
+			wantFeature: "login",
+		},
+		{
+			name: "stale rules are rewritten",
+			setupMocks: func(proj *Project, mockFS *portsmocks.MockFileSystem, mockFeature *MockFeatureServicer, mockRules *MockRulesServicer) {
+				mockFS.EXPECT().Stat(proj.state.D3Dir).Return(testutil.MockFileInfo{FIsDir: true}, nil).Times(1)
+				mockFeature.EXPECT().GetActiveFeature().Return("login", nil).Times(1)
+				mockFeature.EXPECT().FeatureExists("login").Return(true).Times(1)
+				mockFeature.EXPECT().GetFeaturePhase(gomock.Any(), "login").Return(phase.Deliver, nil).Times(1)
+				mockRules.EXPECT().SyncRules("login", "deliver").Return([]string{"/p/.cursor/rules/d3/phase.gen.mdc"}, nil).Times(1)
+			},
+			wantMsg:      "Rewrote 1 generated rule file(s) to match the current state. Cursor rules have changed. Stop your current behavior and await further instruction.",
+			wantFeature:  "login",
+			wantRulesChg: true,
+		},
+		{
+			name: "no active feature removes leftover rules",
+			setupMocks: func(proj *Project, mockFS *portsmocks.MockFileSystem, mockFeature *MockFeatureServicer, mockRules *MockRulesServicer) {
+				mockFS.EXPECT().Stat(proj.state.D3Dir).Return(testutil.MockFileInfo{FIsDir: true}, nil).Times(1)
+				mockFeature.EXPECT().GetActiveFeature().Return("", nil).Times(1)
+				mockRules.EXPECT().SyncRules("", "").Return([]string{"/p/.cursor/rules/d3/core.gen.mdc"}, nil).Times(1)
+			},
+			wantMsg:      "Rewrote 1 generated rule file(s) to match the current state. Cursor rules have changed. Stop your current behavior and await further instruction.",
+			wantRulesChg: true,
+		},
+		{
+			name: "deleted active feature is treated as no active feature",
+			setupMocks: func(proj *Project, mockFS *portsmocks.MockFileSystem, mockFeature *MockFeatureServicer, mockRules *MockRulesServicer) {
+				mockFS.EXPECT().Stat(proj.state.D3Dir).Return(testutil.MockFileInfo{FIsDir: true}, nil).Times(1)
+				mockFeature.EXPECT().GetActiveFeature().Return("gone", nil).Times(1)
+				mockFeature.EXPECT().FeatureExists("gone").Return(false).Times(1)
+				mockRules.EXPECT().SyncRules("", "").Return(nil, nil).Times(1)
+			},
+			wantMsg: "Cursor rules are up to date.\nWarning: active feature 'gone' does not exist, so its rules were removed. Run 'd3 doctor --fix' to clear it.",
+		},
+		{
+			name: "invalid phase fails",
+			setupMocks: func(proj *Project, mockFS *portsmocks.MockFileSystem, mockFeature *MockFeatureServicer, mockRules *MockRulesServicer) {
+				mockFS.EXPECT().Stat(proj.state.D3Dir).Return(testutil.MockFileInfo{FIsDir: true}, nil).Times(1)
+				mockFeature.EXPECT().GetActiveFeature().Return("login", nil).Times(1)
+				mockFeature.EXPECT().FeatureExists("login").Return(true).Times(1)
+				mockFeature.EXPECT().GetFeaturePhase(gomock.Any(), "login").Return(phase.None, fmt.Errorf("phase file for feature login is empty")).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "sync fails",
+			setupMocks: func(proj *Project, mockFS *portsmocks.MockFileSystem, mockFeature *MockFeatureServicer, mockRules *MockRulesServicer) {
+				mockFS.EXPECT().Stat(proj.state.D3Dir).Return(testutil.MockFileInfo{FIsDir: true}, nil).Times(1)
+				mockFeature.EXPECT().GetActiveFeature().Return("", nil).Times(1)
+				mockRules.EXPECT().SyncRules("", "").Return(nil, fmt.Errorf("permission denied")).Times(1)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			proj, mockFS, mockFeature, mockRules, _, _ := newTestProjectWithMocks(t, ctrl)
+			tt.setupMocks(proj, mockFS, mockFeature, mockRules)
+
+			result, err := proj.SyncRules(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SyncRules() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if result.FormatMCP() != tt.wantMsg {
+				t.Errorf("SyncRules() result msg = %q, want %q", result.FormatMCP(), tt.wantMsg)
+			}
+			if result.Feature != tt.wantFeature || result.RulesChanged != tt.wantRulesChg {
+				t.Errorf("SyncRules() feature = %q, rules changed = %v, want %q, %v", result.Feature, result.RulesChanged, tt.wantFeature, tt.wantRulesChg)
+			}
+		})
+	}
+}
+
 func TestProject_DeleteFeature(t *testing.T) {
 	type args struct {
 		ctx         context.Context
 		featureName string
+		purge       bool
 	}
 	tests := []struct {
 		name       string
@@ -854,9 +1093,10 @@ func TestProject_DeleteFeature(t *testing.T) {
 				ctx := gomock.Any()
 				mockFS.EXPECT().Stat(proj.state.D3Dir).Return(testutil.MockFileInfo{FIsDir: true}, nil).Times(1)
 				mockFeature.EXPECT().DeleteFeature(ctx, "del-feat1").Return(false, nil).Times(1) // activeContextCleared = false
+				mockFeature.EXPECT().ListFeatures(gomock.Any()).Return(nil, nil).Times(1)
 			},
 			wantErr: false,
-			wantMsg: "Feature 'del-feat1' deleted successfully.",
+			wantMsg: "Feature 'del-feat1' moved to trash. Restore it with 'd3 feature restore del-feat1'.",
 		},
 		{
 			name: "successful delete, was active, rules cleared",
@@ -865,10 +1105,11 @@ func TestProject_DeleteFeature(t *testing.T) {
 				ctx := gomock.Any()
 				mockFS.EXPECT().Stat(proj.state.D3Dir).Return(testutil.MockFileInfo{FIsDir: true}, nil).Times(1)
 				mockFeature.EXPECT().DeleteFeature(ctx, "active-del-feat").Return(true, nil).Times(1) // activeContextCleared = true
+				mockFeature.EXPECT().ListFeatures(gomock.Any()).Return(nil, nil).Times(1)
 				mockRules.EXPECT().ClearGeneratedRules().Return(nil).Times(1)
 			},
 			wantErr: false,
-			wantMsg: "Feature 'active-del-feat' deleted successfully. Active feature context has been cleared. Cursor rules have changed. Stop your current behavior and await further instruction.",
+			wantMsg: "Feature 'active-del-feat' moved to trash. Restore it with 'd3 feature restore active-del-feat'. Active feature context has been cleared. Cursor rules have changed. Stop your current behavior and await further instruction.",
 		},
 		{
 			name: "successful delete, was active, ClearGeneratedRules fails (warning)",
@@ -877,10 +1118,35 @@ func TestProject_DeleteFeature(t *testing.T) {
 				ctx := gomock.Any()
 				mockFS.EXPECT().Stat(proj.state.D3Dir).Return(testutil.MockFileInfo{FIsDir: true}, nil).Times(1)
 				mockFeature.EXPECT().DeleteFeature(ctx, "active-del-rules-fail").Return(true, nil).Times(1) // activeContextCleared = true
+				mockFeature.EXPECT().ListFeatures(gomock.Any()).Return(nil, nil).Times(1)
 				mockRules.EXPECT().ClearGeneratedRules().Return(fmt.Errorf("rules clear failed")).Times(1)
 			},
 			wantErr: false,
-			wantMsg: "Feature 'active-del-rules-fail' deleted successfully. Warning: failed to clear rules after deleting active feature: rules clear failed Active feature context has been cleared.", // Note: No RulesChanged in MCP message if ClearGeneratedRules fails
+			wantMsg: "Feature 'active-del-rules-fail' moved to trash. Restore it with 'd3 feature restore active-del-rules-fail'. Active feature context has been cleared.\nWarning: failed to clear rules after deleting active feature: rules clear failed", // Note: No RulesChanged in MCP message if ClearGeneratedRules fails
+		},
+		{
+			name: "successful purge",
+			args: args{ctx: context.Background(), featureName: "purged-feat", purge: true},
+			setupMocks: func(proj *Project, mockFS *portsmocks.MockFileSystem, mockFeature *MockFeatureServicer, mockRules *MockRulesServicer) {
+				mockFS.EXPECT().Stat(proj.state.D3Dir).Return(testutil.MockFileInfo{FIsDir: true}, nil).Times(1)
+				mockFeature.EXPECT().PurgeFeature(gomock.Any(), "purged-feat").Return(false, nil).Times(1)
+				mockFeature.EXPECT().ListFeatures(gomock.Any()).Return(nil, nil).Times(1)
+			},
+			wantErr: false,
+			wantMsg: "Feature 'purged-feat' permanently deleted.",
+		},
+		{
+			name: "successful delete of a dependency (warning)",
+			args: args{ctx: context.Background(), featureName: "api"},
+			setupMocks: func(proj *Project, mockFS *portsmocks.MockFileSystem, mockFeature *MockFeatureServicer, mockRules *MockRulesServicer) {
+				mockFS.EXPECT().Stat(proj.state.D3Dir).Return(testutil.MockFileInfo{FIsDir: true}, nil).Times(1)
+				mockFeature.EXPECT().DeleteFeature(gomock.Any(), "api").Return(false, nil).Times(1)
+				mockFeature.EXPECT().ListFeatures(gomock.Any()).Return([]feature.FeatureInfo{{Name: "ui"}, {Name: "web"}}, nil).Times(1)
+				mockFeature.EXPECT().GetFeatureDependencies("ui").Return([]string{"api"}, nil).Times(1)
+				mockFeature.EXPECT().GetFeatureDependencies("web").Return(nil, nil).Times(1)
+			},
+			wantErr: false,
+			wantMsg: "Feature 'api' moved to trash. Restore it with 'd3 feature restore api'.\nWarning: 'api' is a dependency of 'ui', which cannot enter the deliver phase until it is restored or the dependency is removed with 'd3 feature depend <name> api --remove'",
 		},
 	}
 
@@ -893,7 +1159,11 @@ func TestProject_DeleteFeature(t *testing.T) {
 				tt.setupMocks(proj, mockFS, mockFeature, mockRules)
 			}
 
-			result, err := proj.DeleteFeature(tt.args.ctx, tt.args.featureName)
+			deleteFeature := proj.DeleteFeature
+			if tt.args.purge {
+				deleteFeature = proj.PurgeFeature
+			}
+			result, err := deleteFeature(tt.args.ctx, tt.args.featureName)
 
 			if tt.wantErr {
 				if err == nil {
@@ -911,3 +1181,691 @@ func TestProject_DeleteFeature(t *testing.T) {
 		})
 	}
 }
+
+func TestProject_RestoreFeature(t *testing.T) {
+	tests := []struct {
+		name       string
+		setupMocks func(proj *Project, mockFS *portsmocks.MockFileSystem, mockFeature *MockFeatureServicer)
+		wantErr    bool
+		wantMsg    string
+	}{
+		{
+			name: "project not initialized",
+			setupMocks: func(proj *Project, mockFS *portsmocks.MockFileSystem, mockFeature *MockFeatureServicer) {
+				mockFS.EXPECT().Stat(proj.state.D3Dir).Return(nil, os.ErrNotExist).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "RestoreFeature service fails",
+			setupMocks: func(proj *Project, mockFS *portsmocks.MockFileSystem, mockFeature *MockFeatureServicer) {
+				mockFS.EXPECT().Stat(proj.state.D3Dir).Return(testutil.MockFileInfo{FIsDir: true}, nil).Times(1)
+				mockFeature.EXPECT().RestoreFeature(gomock.Any(), "old-feat").Return(nil, fmt.Errorf("not in trash")).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "successful restore",
+			setupMocks: func(proj *Project, mockFS *portsmocks.MockFileSystem, mockFeature *MockFeatureServicer) {
+				mockFS.EXPECT().Stat(proj.state.D3Dir).Return(testutil.MockFileInfo{FIsDir: true}, nil).Times(1)
+				mockFeature.EXPECT().RestoreFeature(gomock.Any(), "old-feat").Return(&feature.FeatureInfo{Name: "old-feat"}, nil).Times(1)
+			},
+			wantMsg: "Feature 'old-feat' restored from trash.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			proj, mockFS, mockFeature, _, _, _ := newTestProjectWithMocks(t, ctrl)
+			tt.setupMocks(proj, mockFS, mockFeature)
+
+			result, err := proj.RestoreFeature(context.Background(), "old-feat")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RestoreFeature() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && result.FormatCLI() != tt.wantMsg {
+				t.Errorf("RestoreFeature() result msg = %q, want %q", result.FormatCLI(), tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestProject_ExportFeature(t *testing.T) {
+	tests := []struct {
+		name        string
+		featureName string
+		setupMocks  func(proj *Project, mockFS *portsmocks.MockFileSystem, mockFeature *MockFeatureServicer)
+		wantErr     error
+		wantContain string
+	}{
+		{
+			name: "project not initialized",
+			setupMocks: func(proj *Project, mockFS *portsmocks.MockFileSystem, mockFeature *MockFeatureServicer) {
+				mockFS.EXPECT().Stat(proj.state.D3Dir).Return(nil, os.ErrNotExist).Times(1)
+			},
+			wantErr: ErrNotInitialized,
+		},
+		{
+			name: "no name and no active feature",
+			setupMocks: func(proj *Project, mockFS *portsmocks.MockFileSystem, mockFeature *MockFeatureServicer) {
+				mockFS.EXPECT().Stat(proj.state.D3Dir).Return(testutil.MockFileInfo{FIsDir: true}, nil).Times(1)
+				mockFeature.EXPECT().GetActiveFeature().Return("", nil).Times(1)
+			},
+			wantErr: ErrNoActiveFeature,
+		},
+		{
+			name: "exports active feature",
+			setupMocks: func(proj *Project, mockFS *portsmocks.MockFileSystem, mockFeature *MockFeatureServicer) {
+				featurePath := filepath.Join(proj.state.FeaturesDir, "login")
+				mockFS.EXPECT().Stat(proj.state.D3Dir).Return(testutil.MockFileInfo{FIsDir: true}, nil).Times(1)
+				mockFeature.EXPECT().GetActiveFeature().Return("login", nil).Times(1)
+				mockFeature.EXPECT().FeatureExists("login").Return(true).Times(1)
+				mockFeature.EXPECT().GetFeaturePhase(gomock.Any(), "login").Return(phase.Design, nil).Times(1)
+				mockFeature.EXPECT().GetFeaturePath("login").Return(featurePath).Times(1)
+				mockFS.EXPECT().ReadFile(gomock.Any()).Return(nil, os.ErrNotExist).Times(3)
+			},
+			wantContain: "# Feature: login",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			proj, mockFS, mockFeature, _, _, _ := newTestProjectWithMocks(t, ctrl)
+			tt.setupMocks(proj, mockFS, mockFeature)
+
+			got, err := proj.ExportFeature(context.Background(), tt.featureName, export.FormatMarkdown, export.PresetFull)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ExportFeature() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && !strings.Contains(got, tt.wantContain) {
+				t.Errorf("ExportFeature() = %q, want to contain %q", got, tt.wantContain)
+			}
+		})
+	}
+}
+
+func TestProject_TraceFeature(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	proj, mockFS, mockFeature, _, _, _ := newTestProjectWithMocks(t, ctrl)
+	featurePath := filepath.Join(proj.state.FeaturesDir, "login")
+	files := map[string]string{
+		filepath.Join(featurePath, "define", "problem.md"):     "- R1: User can log in\n- R2: User can log out\n",
+		filepath.Join(featurePath, "design", "plan.md"):        "- S1 [code] Add the login form (R1)\n",
+		filepath.Join(featurePath, "deliver", "progress.yaml"): "- id: 1\n  description: Build the form\n  refs: [S1]\n",
+	}
+
+	mockFS.EXPECT().Stat(proj.state.D3Dir).Return(testutil.MockFileInfo{FIsDir: true}, nil)
+	mockFeature.EXPECT().FeatureExists("login").Return(true)
+	mockFeature.EXPECT().GetFeaturePhase(gomock.Any(), "login").Return(phase.Deliver, nil)
+	mockFeature.EXPECT().GetFeaturePath("login").Return(featurePath)
+	mockFS.EXPECT().ReadFile(gomock.Any()).DoAndReturn(func(name string) ([]byte, error) {
+		return []byte(files[name]), nil
+	}).Times(3)
+	mockFS.EXPECT().Stat(gomock.Any()).Return(testutil.MockFileInfo{}, nil).Times(3)
+
+	report, err := proj.TraceFeature(context.Background(), "login")
+	if err != nil {
+		t.Fatalf("TraceFeature() error = %v", err)
+	}
+	if report.Feature != "login" || len(report.Requirements) != 2 || len(report.Steps) != 1 {
+		t.Errorf("TraceFeature() = %+v", report)
+	}
+	if strings.Join(report.Uncovered, ",") != "R2" {
+		t.Errorf("TraceFeature() uncovered = %v, want [R2]", report.Uncovered)
+	}
+}
+
+func TestProject_CheckFeature(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cfg := config.Default("/p")
+	memFS := testutil.NewMemFS()
+	featurePath := filepath.Join(cfg.FeaturesDir, "login")
+	memFS.MkdirAll(cfg.D3Dir, 0755)
+	memFS.AddFile(filepath.Join(featurePath, "define", "problem.md"), "## Problem Statement\nUsers cannot log in.\n\n## Feature Goals\n\n## Core Requirements\n- R1: Log in\n")
+	memFS.AddFile("/p/.d3/rules/define.schema.yaml", "required: [Problem Statement]\n")
+
+	mockFeature := NewMockFeatureServicer(ctrl)
+	proj := New(cfg, memFS, mockFeature, NewMockRulesServicer(ctrl), NewMockPhaseServicer(ctrl), NewMockFileOperator(ctrl))
+	mockFeature.EXPECT().GetActiveFeature().Return("login", nil)
+	mockFeature.EXPECT().FeatureExists("login").Return(true)
+	mockFeature.EXPECT().GetFeaturePhase(gomock.Any(), "login").Return(phase.Design, nil)
+
+	result, err := proj.CheckFeature(context.Background(), "")
+	if err != nil {
+		t.Fatalf("CheckFeature() error = %v", err)
+	}
+	want := []check.Diagnostic{
+		{File: ".d3/features/login/define/problem.md", Line: 4, Message: `section "Feature Goals" is empty`},
+		{File: ".d3/features/login/design/plan.md", Message: "file does not exist"},
+	}
+	if !reflect.DeepEqual(result.Diagnostics, want) {
+		t.Errorf("CheckFeature() diagnostics = %+v, want %+v", result.Diagnostics, want)
+	}
+	if !reflect.DeepEqual(result.Phases, []phase.Phase{phase.Define, phase.Design}) {
+		t.Errorf("CheckFeature() phases = %v", result.Phases)
+	}
+
+	mockFeature.EXPECT().FeatureExists("missing").Return(false)
+	if _, err := proj.CheckFeature(context.Background(), "missing"); d3err.CodeOf(err) != d3err.FeatureNotFound {
+		t.Errorf("CheckFeature(missing) error = %v, want feature not found", err)
+	}
+}
+
+func TestProject_ChangePhase_CheckGate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cfg := config.Default("/p")
+	cfg.CheckGates = []phase.Phase{phase.Define}
+	memFS := testutil.NewMemFS()
+	featurePath := filepath.Join(cfg.FeaturesDir, "login")
+	memFS.MkdirAll(cfg.D3Dir, 0755)
+	memFS.AddFile(filepath.Join(featurePath, "define", "problem.md"), "## Problem Statement\nUsers cannot log in.\n")
+
+	mockFeature := NewMockFeatureServicer(ctrl)
+	mockRules := NewMockRulesServicer(ctrl)
+	mockPhase := NewMockPhaseServicer(ctrl)
+	proj := New(cfg, memFS, mockFeature, mockRules, mockPhase, NewMockFileOperator(ctrl))
+
+	// Moving forward past define is blocked while problem.md is incomplete
+	mockFeature.EXPECT().GetActiveFeature().Return("login", nil)
+	mockFeature.EXPECT().GetFeaturePhase(gomock.Any(), "login").Return(phase.Define, nil)
+	_, err := proj.ChangePhase(context.Background(), phase.Design)
+	if d3err.CodeOf(err) != d3err.GateFailed || !strings.Contains(err.Error(), `.d3/features/login/define/problem.md: missing required section "Feature Goals"`) {
+		t.Fatalf("ChangePhase() error = %v, want a gate failure listing the diagnostics", err)
+	}
+
+	// Moving backward is never gated
+	mockFeature.EXPECT().GetActiveFeature().Return("login", nil)
+	mockFeature.EXPECT().GetFeaturePhase(gomock.Any(), "login").Return(phase.Design, nil)
+	mockFeature.EXPECT().SetFeaturePhase(gomock.Any(), "login", phase.Define).Return(nil)
+	mockRules.EXPECT().RefreshRules("login", "define").Return(nil)
+	mockPhase.EXPECT().EnsurePhaseFiles(featurePath).Return(nil)
+	if _, err := proj.ChangePhase(context.Background(), phase.Define); err != nil {
+		t.Errorf("ChangePhase() backward error = %v", err)
+	}
+}
+
+func TestProject_ChangePhase_TraceGate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cfg := config.Default("/p")
+	cfg.TraceGates = []phase.Phase{phase.Design}
+	memFS := testutil.NewMemFS()
+	featurePath := filepath.Join(cfg.FeaturesDir, "login")
+	planFile := filepath.Join(featurePath, "design", "plan.md")
+	memFS.MkdirAll(cfg.D3Dir, 0755)
+	memFS.AddFile(filepath.Join(featurePath, "define", "problem.md"), "## Core Requirements\n- R1 Users can log in\n- R2 Users can log out\n")
+	memFS.AddFile(planFile, "## Delivery Steps\n- S1 [code] Add the login form (R1)\n")
+
+	mockFeature := NewMockFeatureServicer(ctrl)
+	proj := New(cfg, memFS, mockFeature, NewMockRulesServicer(ctrl), NewMockPhaseServicer(ctrl), NewMockFileOperator(ctrl))
+	mockFeature.EXPECT().FeatureExists("login").Return(true).AnyTimes()
+	mockFeature.EXPECT().GetFeaturePath("login").Return(featurePath).AnyTimes()
+	mockFeature.EXPECT().GetFeaturePhase(gomock.Any(), "login").Return(phase.Design, nil).AnyTimes()
+
+	// Moving to deliver is blocked while a requirement has no plan step
+	mockFeature.EXPECT().GetActiveFeature().Return("login", nil)
+	_, err := proj.ChangePhase(context.Background(), phase.Deliver)
+	if d3err.CodeOf(err) != d3err.GateFailed || !strings.Contains(err.Error(), "requirement R2 is not covered by any plan step or task") {
+		t.Fatalf("ChangePhase() error = %v, want a gate failure listing the traceability gaps", err)
+	}
+
+	// Covering the requirement opens the gate
+	memFS.AddFile(planFile, "## Delivery Steps\n- S1 [code] Add the login form (R1)\n- S2 [code] Add the logout button (R2)\n")
+	if err := proj.traceGate(context.Background(), "login", phase.Design, phase.Deliver); err != nil {
+		t.Errorf("traceGate() error = %v, want none once every requirement is covered", err)
+	}
+	// Moving backward is never gated
+	memFS.AddFile(planFile, "## Delivery Steps\n- S1 [code] Add the login form (R1)\n")
+	if err := proj.traceGate(context.Background(), "login", phase.Design, phase.Define); err != nil {
+		t.Errorf("traceGate() backward error = %v", err)
+	}
+}
+
+func TestProject_Snapshots(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cfg := config.Default("/p")
+	memFS := testutil.NewMemFS()
+	featurePath := filepath.Join(cfg.FeaturesDir, "login")
+	planFile := filepath.Join(featurePath, "design", "plan.md")
+	memFS.MkdirAll(cfg.D3Dir, 0755)
+	memFS.AddFile(planFile, "## Delivery Steps\nAdd the form\n")
+
+	mockFeature := NewMockFeatureServicer(ctrl)
+	mockRules := NewMockRulesServicer(ctrl)
+	mockPhase := NewMockPhaseServicer(ctrl)
+	proj := New(cfg, memFS, mockFeature, mockRules, mockPhase, NewMockFileOperator(ctrl))
+	mockFeature.EXPECT().FeatureExists("login").Return(true).AnyTimes()
+	mockFeature.EXPECT().GetActiveFeature().Return("login", nil).AnyTimes()
+	mockRules.EXPECT().RefreshRules("login", gomock.Any()).Return(nil).AnyTimes()
+	mockPhase.EXPECT().EnsurePhaseFiles(featurePath).Return(nil).AnyTimes()
+
+	// Leaving design keeps plan.md
+	mockFeature.EXPECT().GetFeaturePhase(gomock.Any(), "login").Return(phase.Design, nil)
+	mockFeature.EXPECT().SetFeaturePhase(gomock.Any(), "login", phase.Define).Return(nil)
+	result, err := proj.ChangePhase(context.Background(), phase.Define)
+	if err != nil {
+		t.Fatalf("ChangePhase() error = %v", err)
+	}
+	snapshots, err := proj.FeatureSnapshots(context.Background(), "")
+	if err != nil || len(snapshots) != 1 {
+		t.Fatalf("FeatureSnapshots() = %+v, %v, want one snapshot", snapshots, err)
+	}
+	snap := snapshots[0]
+	if snap.Reason != "design-to-define" || !reflect.DeepEqual(snap.Files, []string{"design/plan.md"}) {
+		t.Errorf("FeatureSnapshots() = %+v", snap)
+	}
+	if want := "Saved design/plan.md as snapshot " + snap.ID + "."; !strings.Contains(result.Message, want) {
+		t.Errorf("ChangePhase() message = %q, want it to contain %q", result.Message, want)
+	}
+
+	// The plan is rewritten, compared with the snapshot and restored
+	memFS.AddFile(planFile, "## Delivery Steps\nAdd the page\n")
+	diff, err := proj.DiffArtifact(context.Background(), "login", snap.ID[:8], "")
+	if err != nil {
+		t.Fatalf("DiffArtifact() error = %v", err)
+	}
+	wantDiff := "--- snapshots/" + snap.ID + "/design/plan.md\n+++ design/plan.md\n@@ -1,2 +1,2 @@\n ## Delivery Steps\n-Add the form\n+Add the page\n"
+	if diff != wantDiff {
+		t.Errorf("DiffArtifact() =\n%s\nwant\n%s", diff, wantDiff)
+	}
+
+	mockFeature.EXPECT().GetFeaturePhase(gomock.Any(), "login").Return(phase.Define, nil)
+	result, err = proj.RevertArtifact(context.Background(), "login", snap.ID, "")
+	if err != nil {
+		t.Fatalf("RevertArtifact() error = %v", err)
+	}
+	if data, _ := memFS.ReadFile(planFile); string(data) != "## Delivery Steps\nAdd the form\n" {
+		t.Errorf("plan.md after revert = %q", data)
+	}
+	if !strings.Contains(result.Message, "The replaced version was saved as snapshot") {
+		t.Errorf("RevertArtifact() message = %q", result.Message)
+	}
+	snapshots, _ = proj.FeatureSnapshots(context.Background(), "login")
+	if len(snapshots) != 2 || snapshots[1].Reason != snapshot.ReasonRevert {
+		t.Fatalf("FeatureSnapshots() after revert = %+v", snapshots)
+	}
+	if diff, err := proj.DiffArtifact(context.Background(), "login", snap.ID, snapshots[1].ID); err != nil || !strings.Contains(diff, "+Add the page") {
+		t.Errorf("DiffArtifact() between snapshots = %q, %v", diff, err)
+	}
+	if diff, err := proj.DiffArtifact(context.Background(), "login", snap.ID, ""); err != nil || diff != "" {
+		t.Errorf("DiffArtifact() after revert = %q, %v, want no differences", diff, err)
+	}
+	if _, err := proj.RevertArtifact(context.Background(), "login", "1999", ""); d3err.CodeOf(err) != d3err.InvalidArgument {
+		t.Errorf("RevertArtifact() of an unknown snapshot error = %v", err)
+	}
+	if _, err := proj.RevertArtifact(context.Background(), "login", snap.ID, "deliver"); d3err.CodeOf(err) != d3err.InvalidArgument {
+		t.Errorf("RevertArtifact() of an artifact the snapshot does not hold error = %v", err)
+	}
+}
+
+func TestProject_ChangePhase_Review(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cfg := config.Default("/p")
+	memFS := testutil.NewMemFS()
+	featurePath := filepath.Join(cfg.FeaturesDir, "login")
+	planFile := filepath.Join(featurePath, "design", "plan.md")
+	progressFile := filepath.Join(featurePath, "deliver", "progress.yaml")
+	memFS.MkdirAll(cfg.D3Dir, 0755)
+	memFS.AddFile(planFile, "## Delivery Steps\nAdd the form\n")
+	memFS.AddFile(progressFile, "- id: 1\n  description: Add the form\n  status: complete\n")
+
+	mockFeature := NewMockFeatureServicer(ctrl)
+	mockRules := NewMockRulesServicer(ctrl)
+	mockPhase := NewMockPhaseServicer(ctrl)
+	proj := New(cfg, memFS, mockFeature, mockRules, mockPhase, NewMockFileOperator(ctrl))
+	mockFeature.EXPECT().GetActiveFeature().Return("login", nil).AnyTimes()
+	mockRules.EXPECT().RefreshRules("login", gomock.Any()).Return(nil).AnyTimes()
+	mockPhase.EXPECT().EnsurePhaseFiles(featurePath).Return(nil).AnyTimes()
+
+	// Moving back to define marks the plan and the tasks
+	mockFeature.EXPECT().GetFeaturePhase(gomock.Any(), "login").Return(phase.Deliver, nil)
+	mockFeature.EXPECT().SetFeaturePhase(gomock.Any(), "login", phase.Define).Return(nil)
+	result, err := proj.ChangePhase(context.Background(), phase.Define)
+	if err != nil {
+		t.Fatalf("ChangePhase() error = %v", err)
+	}
+	if want := "Marked for review: plan.md, 1 task(s) in progress.yaml."; !strings.Contains(result.Message, want) {
+		t.Errorf("ChangePhase() message = %q, want it to contain %q", result.Message, want)
+	}
+	if plan, _ := memFS.ReadFile(planFile); !strings.HasPrefix(string(plan), "<!-- d3:stale since ") {
+		t.Errorf("plan.md = %q, want a stale marker", plan)
+	}
+	if tasks, _ := memFS.ReadFile(progressFile); !strings.Contains(string(tasks), "status: complete\n  review: needed") {
+		t.Errorf("progress.yaml = %q, want the task flagged and still complete", tasks)
+	}
+
+	// Moving forward again says what is left to revisit
+	mockFeature.EXPECT().GetFeaturePhase(gomock.Any(), "login").Return(phase.Define, nil)
+	mockFeature.EXPECT().SetFeaturePhase(gomock.Any(), "login", phase.Design).Return(nil)
+	result, err = proj.ChangePhase(context.Background(), phase.Design)
+	if err != nil {
+		t.Fatalf("ChangePhase() error = %v", err)
+	}
+	if want := "Needs revisiting: plan.md is marked stale; task 1 in progress.yaml needs review."; !strings.Contains(result.Message, want) {
+		t.Errorf("ChangePhase() message = %q, want it to contain %q", result.Message, want)
+	}
+	if strings.Contains(result.Message, "Marked for review") {
+		t.Errorf("ChangePhase() forward message = %q, want nothing newly marked", result.Message)
+	}
+}
+
+func TestProject_Dependencies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	cfg := config.Default("/p")
+	memFS := testutil.NewMemFS()
+	memFS.MkdirAll(cfg.D3Dir, 0755)
+	memFS.AddFile(filepath.Join(cfg.FeaturesDir, "api", ".phase"), "design")
+	memFS.AddFile(filepath.Join(cfg.FeaturesDir, "auth", ".phase"), "define")
+	memFS.AddFile(filepath.Join(cfg.FeaturesDir, "ui", ".phase"), "design")
+
+	featureSvc := feature.NewService(cfg.ProjectRoot, cfg.FeaturesDir, cfg.D3Dir, memFS)
+	mockRules := NewMockRulesServicer(ctrl)
+	mockPhase := NewMockPhaseServicer(ctrl)
+	proj := New(cfg, memFS, featureSvc, mockRules, mockPhase, NewMockFileOperator(ctrl))
+	mockRules.EXPECT().RefreshRules("ui", "deliver").Return(nil)
+	mockPhase.EXPECT().EnsurePhaseFiles(gomock.Any()).Return(nil)
+
+	result, err := proj.SetDependencies(ctx, "ui", []string{"api", "auth"}, nil)
+	if err != nil {
+		t.Fatalf("SetDependencies() error = %v", err)
+	}
+	if result.Message != "Feature 'ui' now depends on 'api' and 'auth'." {
+		t.Errorf("SetDependencies() message = %q", result.Message)
+	}
+
+	invalid := []struct {
+		feature  string
+		add      []string
+		wantCode d3err.Code
+		wantErr  string
+	}{
+		{feature: "ui", add: []string{"ui"}, wantCode: d3err.InvalidArgument, wantErr: "cannot depend on itself"},
+		{feature: "ui", add: []string{"billing"}, wantCode: d3err.FeatureNotFound},
+		{feature: "auth", add: []string{"ui"}, wantCode: d3err.InvalidArgument, wantErr: "auth -> ui -> auth"},
+	}
+	for _, tt := range invalid {
+		_, err := proj.SetDependencies(ctx, tt.feature, tt.add, nil)
+		if d3err.CodeOf(err) != tt.wantCode || !strings.Contains(fmt.Sprint(err), tt.wantErr) {
+			t.Errorf("SetDependencies(%s, %v) error = %v, want code %s containing %q", tt.feature, tt.add, err, tt.wantCode, tt.wantErr)
+		}
+	}
+
+	// Neither dependency is complete, so ui cannot enter deliver
+	if err := featureSvc.SetActiveFeature("ui"); err != nil {
+		t.Fatal(err)
+	}
+	_, err = proj.ChangePhase(ctx, phase.Deliver)
+	if d3err.CodeOf(err) != d3err.GateFailed || !strings.Contains(err.Error(), "it depends on 'api' (design), 'auth' (define)") {
+		t.Fatalf("ChangePhase() error = %v, want the incomplete dependencies listed", err)
+	}
+
+	// Completing api and dropping auth opens the gate
+	memFS.AddFile(filepath.Join(cfg.FeaturesDir, "api", ".phase"), "deliver")
+	memFS.AddFile(filepath.Join(cfg.FeaturesDir, "api", "deliver", "progress.yaml"), "- id: 1\n  description: Add the endpoint\n  status: complete\n")
+	if result, err = proj.SetDependencies(ctx, "ui", nil, []string{"auth"}); err != nil || result.Message != "Feature 'ui' now depends on 'api'." {
+		t.Fatalf("SetDependencies() = %+v, %v", result, err)
+	}
+	if _, err := proj.ChangePhase(ctx, phase.Deliver); err != nil {
+		t.Fatalf("ChangePhase() error = %v", err)
+	}
+
+	graph, err := proj.FeatureGraph(ctx)
+	if err != nil {
+		t.Fatalf("FeatureGraph() error = %v", err)
+	}
+	want := []depgraph.Node{
+		{Name: "api", Phase: phase.Deliver, Complete: true, DependsOn: []string{}},
+		{Name: "auth", Phase: phase.Define, DependsOn: []string{}},
+		{Name: "ui", Phase: phase.Deliver, DependsOn: []string{"api"}},
+	}
+	if !reflect.DeepEqual(graph.Nodes, want) {
+		t.Errorf("FeatureGraph() nodes = %+v, want %+v", graph.Nodes, want)
+	}
+
+	if result, err = proj.SetDependencies(ctx, "ui", nil, []string{"api"}); err != nil || result.Message != "Feature 'ui' no longer depends on other features." {
+		t.Errorf("SetDependencies() = %+v, %v", result, err)
+	}
+	if exists, _ := memFS.Exists(filepath.Join(cfg.FeaturesDir, "ui", ".depends_on")); exists {
+		t.Error(".depends_on still exists after removing every dependency")
+	}
+}
+
+func TestProject_Dependencies_DeletedDependency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	cfg := config.Default("/p")
+	memFS := testutil.NewMemFS()
+	memFS.MkdirAll(cfg.D3Dir, 0755)
+	memFS.AddFile(filepath.Join(cfg.FeaturesDir, "api", ".phase"), "design")
+	memFS.AddFile(filepath.Join(cfg.FeaturesDir, "ui", ".phase"), "design")
+	memFS.AddFile(filepath.Join(cfg.FeaturesDir, "ui", ".depends_on"), "api\n")
+
+	featureSvc := feature.NewService(cfg.ProjectRoot, cfg.FeaturesDir, cfg.D3Dir, memFS)
+	proj := New(cfg, memFS, featureSvc, NewMockRulesServicer(ctrl), NewMockPhaseServicer(ctrl), NewMockFileOperator(ctrl))
+
+	// Deleting a dependency warns about the features it leaves blocked
+	result, err := proj.DeleteFeature(ctx, "api")
+	if err != nil {
+		t.Fatalf("DeleteFeature() error = %v", err)
+	}
+	if len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], "'api' is a dependency of 'ui'") {
+		t.Errorf("DeleteFeature() warnings = %v, want the dependent feature named", result.Warnings)
+	}
+
+	// A dependency that no longer exists keeps the gate closed
+	if err := featureSvc.SetActiveFeature("ui"); err != nil {
+		t.Fatal(err)
+	}
+	_, err = proj.ChangePhase(ctx, phase.Deliver)
+	if d3err.CodeOf(err) != d3err.GateFailed || !strings.Contains(err.Error(), "it depends on 'api' (missing)") {
+		t.Errorf("ChangePhase() error = %v, want the missing dependency listed", err)
+	}
+}
+
+func TestProject_Hooks(t *testing.T) {
+	hookErr := d3err.Wrap(d3err.GateFailed, errors.New("pre_phase_change hook \"make lint\" failed: exit status 1\nlint: 2 issues"))
+
+	t.Run("failing pre hook aborts the phase change", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		proj, mockFS, mockFeature, _, _, _ := newTestProjectWithMocks(t, ctrl)
+		mockHooks := NewMockHookRunner(ctrl)
+		proj.SetHooks(mockHooks)
+
+		mockFS.EXPECT().Stat(proj.state.D3Dir).Return(testutil.MockFileInfo{FIsDir: true}, nil)
+		mockFeature.EXPECT().GetActiveFeature().Return("login", nil)
+		mockFeature.EXPECT().GetFeaturePhase(gomock.Any(), "login").Return(phase.Define, nil)
+		wantEnv := hooks.Env{Event: hooks.PrePhaseChange, ProjectRoot: proj.state.ProjectRoot, Feature: "login", FromPhase: "define", ToPhase: "design"}
+		mockHooks.EXPECT().Run(gomock.Any(), wantEnv).Return(hookErr)
+		// SetFeaturePhase and RefreshRules must not be called
+
+		_, err := proj.ChangePhase(context.Background(), phase.Design)
+		if !errors.Is(err, d3err.ErrGateFailed) || !strings.Contains(err.Error(), "lint: 2 issues") {
+			t.Errorf("ChangePhase() error = %v, want the gate failure with the hook output", err)
+		}
+	})
+
+	t.Run("failing post hook is a warning", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		proj, mockFS, mockFeature, mockRules, _, _ := newTestProjectWithMocks(t, ctrl)
+		mockHooks := NewMockHookRunner(ctrl)
+		proj.SetHooks(mockHooks)
+
+		mockFS.EXPECT().Stat(proj.state.D3Dir).Return(testutil.MockFileInfo{FIsDir: true}, nil)
+		mockFeature.EXPECT().GetFeaturePhase(gomock.Any(), "login").Return(phase.Design, nil)
+		mockFeature.EXPECT().SetActiveFeature("login").Return(nil)
+		mockRules.EXPECT().RefreshRules("login", "design").Return(nil)
+		gomock.InOrder(
+			mockHooks.EXPECT().Run(gomock.Any(), hooks.Env{Event: hooks.PreEnter, ProjectRoot: proj.state.ProjectRoot, Feature: "login", ToPhase: "design"}).Return(nil),
+			mockHooks.EXPECT().Run(gomock.Any(), hooks.Env{Event: hooks.PostEnter, ProjectRoot: proj.state.ProjectRoot, Feature: "login", ToPhase: "design"}).Return(errors.New("post_enter hook failed")),
+		)
+
+		result, err := proj.EnterFeature(context.Background(), "login")
+		if err != nil {
+			t.Fatalf("EnterFeature() error = %v", err)
+		}
+		if len(result.Warnings) != 1 || result.Warnings[0] != "post_enter hook failed" {
+			t.Errorf("EnterFeature() warnings = %v, want the post hook failure", result.Warnings)
+		}
+	})
+
+	t.Run("invalid target phase runs no gate or hook", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		proj, mockFS, _, _, _, _ := newTestProjectWithMocks(t, ctrl)
+		proj.SetHooks(NewMockHookRunner(ctrl))
+
+		mockFS.EXPECT().Stat(proj.state.D3Dir).Return(testutil.MockFileInfo{FIsDir: true}, nil).Times(1)
+
+		if _, err := proj.ChangePhase(context.Background(), phase.Phase("bogus")); !errors.Is(err, d3err.ErrInvalidPhase) {
+			t.Errorf("ChangePhase() error = %v, want ErrInvalidPhase", err)
+		}
+	})
+
+	t.Run("failing pre hook aborts create, delete and purge", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		proj, mockFS, _, _, _, _ := newTestProjectWithMocks(t, ctrl)
+		mockHooks := NewMockHookRunner(ctrl)
+		proj.SetHooks(mockHooks)
+
+		mockFS.EXPECT().Stat(proj.state.D3Dir).Return(testutil.MockFileInfo{FIsDir: true}, nil).Times(3)
+		mockHooks.EXPECT().Run(gomock.Any(), hooks.Env{Event: hooks.PreCreate, ProjectRoot: proj.state.ProjectRoot, Feature: "login", ToPhase: "define"}).Return(hookErr)
+		mockHooks.EXPECT().Run(gomock.Any(), hooks.Env{Event: hooks.PreDelete, ProjectRoot: proj.state.ProjectRoot, Feature: "login"}).Return(hookErr).Times(2)
+
+		if _, err := proj.CreateFeature(context.Background(), "login"); !errors.Is(err, d3err.ErrGateFailed) {
+			t.Errorf("CreateFeature() error = %v, want ErrGateFailed", err)
+		}
+		if _, err := proj.DeleteFeature(context.Background(), "login"); !errors.Is(err, d3err.ErrGateFailed) {
+			t.Errorf("DeleteFeature() error = %v, want ErrGateFailed", err)
+		}
+		if _, err := proj.PurgeFeature(context.Background(), "login"); !errors.Is(err, d3err.ErrGateFailed) {
+			t.Errorf("PurgeFeature() error = %v, want ErrGateFailed", err)
+		}
+	})
+
+	t.Run("pre hooks see the normalized name", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		proj, mockFS, mockFeature, _, _, _ := newTestProjectWithMocks(t, ctrl)
+		mockHooks := NewMockHookRunner(ctrl)
+		proj.SetHooks(mockHooks)
+
+		mockFS.EXPECT().Stat(proj.state.D3Dir).Return(testutil.MockFileInfo{FIsDir: true}, nil).Times(3)
+		mockFeature.EXPECT().GetFeaturePhase(gomock.Any(), "my-login").Return(phase.Design, nil)
+		mockHooks.EXPECT().Run(gomock.Any(), hooks.Env{Event: hooks.PreCreate, ProjectRoot: proj.state.ProjectRoot, Feature: "my-login", ToPhase: "define"}).Return(hookErr)
+		mockHooks.EXPECT().Run(gomock.Any(), hooks.Env{Event: hooks.PreEnter, ProjectRoot: proj.state.ProjectRoot, Feature: "my-login", ToPhase: "design"}).Return(hookErr)
+		mockHooks.EXPECT().Run(gomock.Any(), hooks.Env{Event: hooks.PreDelete, ProjectRoot: proj.state.ProjectRoot, Feature: "my-login"}).Return(hookErr)
+
+		if _, err := proj.CreateFeature(context.Background(), " My Login"); !errors.Is(err, d3err.ErrGateFailed) {
+			t.Errorf("CreateFeature() error = %v, want ErrGateFailed", err)
+		}
+		if _, err := proj.EnterFeature(context.Background(), "My Login"); !errors.Is(err, d3err.ErrGateFailed) {
+			t.Errorf("EnterFeature() error = %v, want ErrGateFailed", err)
+		}
+		if _, err := proj.DeleteFeature(context.Background(), "MY LOGIN"); !errors.Is(err, d3err.ErrGateFailed) {
+			t.Errorf("DeleteFeature() error = %v, want ErrGateFailed", err)
+		}
+	})
+
+	t.Run("invalid name runs no hook", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		proj, mockFS, _, _, _, _ := newTestProjectWithMocks(t, ctrl)
+		proj.SetHooks(NewMockHookRunner(ctrl))
+
+		mockFS.EXPECT().Stat(proj.state.D3Dir).Return(testutil.MockFileInfo{FIsDir: true}, nil).Times(3)
+
+		if _, err := proj.CreateFeature(context.Background(), "../escape"); !errors.Is(err, d3err.ErrInvalidName) {
+			t.Errorf("CreateFeature() error = %v, want ErrInvalidName", err)
+		}
+		if _, err := proj.EnterFeature(context.Background(), "con"); !errors.Is(err, d3err.ErrInvalidName) {
+			t.Errorf("EnterFeature() error = %v, want ErrInvalidName", err)
+		}
+		if _, err := proj.PurgeFeature(context.Background(), ""); !errors.Is(err, d3err.ErrInvalidName) {
+			t.Errorf("PurgeFeature() error = %v, want ErrInvalidName", err)
+		}
+	})
+}
+
+func TestProject_Lock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	proj, mockFS, _, _, _, _ := newTestProjectWithMocks(t, ctrl)
+	mockLocker := NewMockLocker(ctrl)
+	proj.SetLocker(mockLocker)
+
+	mockFS.EXPECT().Stat(proj.state.D3Dir).Return(testutil.MockFileInfo{FIsDir: true}, nil).Times(2)
+	mockLocker.EXPECT().Lock(gomock.Any()).Return(nil, d3err.New(d3err.Locked, "project is locked by another d3 process")).Times(2)
+	// No service is called while the lock is held elsewhere
+
+	if _, err := proj.CreateFeature(context.Background(), "login"); !errors.Is(err, d3err.ErrLocked) {
+		t.Errorf("CreateFeature() error = %v, want ErrLocked", err)
+	}
+	if _, err := proj.ChangePhase(context.Background(), phase.Design); !errors.Is(err, d3err.ErrLocked) {
+		t.Errorf("ChangePhase() error = %v, want ErrLocked", err)
+	}
+}
+
+func TestProject_SessionSharedRulesWarning(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	proj, mockFS, mockFeature, mockRules, _, _ := newTestProjectWithMocks(t, ctrl)
+	proj.SetSession("alice")
+
+	mockFS.EXPECT().Stat(proj.state.D3Dir).Return(testutil.MockFileInfo{FIsDir: true}, nil).Times(1)
+	mockFeature.EXPECT().GetFeaturePhase(gomock.Any(), "my-feature").Return(phase.Design, nil).Times(1)
+	mockFeature.EXPECT().SetActiveFeature("my-feature").Return(nil).Times(1)
+	mockRules.EXPECT().RefreshRules("my-feature", string(phase.Design)).Return(nil).Times(1)
+
+	result, err := proj.EnterFeature(context.Background(), "my-feature")
+	if err != nil {
+		t.Fatalf("EnterFeature() unexpected error = %v", err)
+	}
+	if len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], "shared by every session") || !strings.Contains(result.Warnings[0], "'alice'") {
+		t.Errorf("EnterFeature() warnings = %v, want the shared rules warning for session alice", result.Warnings)
+	}
+}
+
+func TestResult_WithFeatureAndFiles(t *testing.T) {
+	result := NewResultWithRulesChanged("done").WithFeature("login", phase.Design).WithFiles("a").WithFiles("b", "c")
+
+	if result.Feature != "login" || result.Phase != phase.Design {
+		t.Errorf("WithFeature() = %q (%s), want login (design)", result.Feature, result.Phase)
+	}
+	if got := strings.Join(result.FilesTouched, ","); got != "a,b,c" {
+		t.Errorf("WithFiles() = %s, want a,b,c", got)
+	}
+	if !result.RulesChanged {
+		t.Error("WithFeature() cleared RulesChanged")
+	}
+}
+
+func TestProject_Logged(t *testing.T) {
+	var buf bytes.Buffer
+	p := &Project{}
+	p.SetLogger(slog.New(slog.NewTextHandler(&buf, nil)))
+
+	result := NewResultWithRulesChanged("done").WithFeature("login", phase.Design).WithWarnings("phase files missing")
+	if got := p.logged("phase changed", result); got != result {
+		t.Error("logged() did not return its result")
+	}
+
+	logLine := buf.String()
+	for _, want := range []string{`msg="phase changed"`, "feature=login", "phase=design", "rules_changed=true", `warnings="[phase files missing]"`} {
+		if !strings.Contains(logLine, want) {
+			t.Errorf("logged() wrote %q, want it to contain %s", logLine, want)
+		}
+	}
+}
+
+func TestResult_Warnings(t *testing.T) {
+	result := NewResultWithRulesChanged("Feature 'login' created.").WithWarnings("first").WithWarnings("second")
+
+	wantCLI := "Feature 'login' created. Cursor rules have been updated.\nWarning: first\nWarning: second"
+	if got := result.FormatCLI(); got != wantCLI {
+		t.Errorf("FormatCLI() = %q, want %q", got, wantCLI)
+	}
+	wantMCP := "Feature 'login' created. Cursor rules have changed. Stop your current behavior and await further instruction.\nWarning: first\nWarning: second"
+	if got := result.FormatMCP(); got != wantMCP {
+		t.Errorf("FormatMCP() = %q, want %q", got, wantMCP)
+	}
+	if got := NewResult("done").FormatCLI(); got != "done" {
+		t.Errorf("FormatCLI() without warnings = %q, want %q", got, "done")
+	}
+}
@@ -0,0 +1,97 @@
+package project
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/imcclaskey/d3/internal/core/config"
+	"github.com/imcclaskey/d3/internal/core/feature"
+	"github.com/imcclaskey/d3/internal/core/hooks"
+	"github.com/imcclaskey/d3/internal/core/lock"
+	"github.com/imcclaskey/d3/internal/core/phase"
+	"github.com/imcclaskey/d3/internal/core/ports"
+	"github.com/imcclaskey/d3/internal/core/projectfiles"
+	"github.com/imcclaskey/d3/internal/core/rules"
+)
+
+// Services are the services a Project is wired from, for callers that also use them directly.
+type Services struct {
+	Features  *feature.Service
+	Rules     *rules.Service
+	Generator rules.Generator
+	Phases    *phase.Service
+	Files     *projectfiles.DefaultFileOperator
+}
+
+// WireOptions configures Wire. Zero values select the defaults.
+type WireOptions struct {
+	// Logger receives the logs of the project and every service. Defaults to slog.Default.
+	Logger *slog.Logger
+	// ComponentAttr tags each service's records with a "component" attribute naming the service.
+	ComponentAttr bool
+	// TrashRetention is how long deleted features stay in the trash. Nil keeps the
+	// trash_retention of cfg; zero keeps them until the trash is emptied by hand.
+	TrashRetention *time.Duration
+	// Getenv looks up D3_SESSION when resolving the active feature store. Defaults to os.Getenv.
+	Getenv func(string) string
+}
+
+// Wire builds the services for cfg on fs and the Project that coordinates them. The CLI, the
+// MCP server and the public SDK all construct d3 through it.
+func Wire(cfg config.Config, fs ports.FileSystem, opts WireOptions) (*Project, *Services) {
+	if opts.Logger == nil {
+		opts.Logger = slog.Default()
+	}
+	if opts.Getenv == nil {
+		opts.Getenv = os.Getenv
+	}
+	logger := func(component string) *slog.Logger {
+		if opts.ComponentAttr {
+			return opts.Logger.With("component", component)
+		}
+		return opts.Logger
+	}
+
+	featureSvc := feature.NewService(cfg.ProjectRoot, cfg.FeaturesDir, cfg.D3Dir, fs)
+	featureSvc.SetInitialPhase(cfg.InitialPhase)
+	trashRetention := cfg.TrashRetention
+	if opts.TrashRetention != nil {
+		trashRetention = *opts.TrashRetention
+	}
+	featureSvc.SetTrashRetention(trashRetention)
+	featureSvc.SetActiveFeatureStore(feature.ResolveActiveFeatureStore(cfg.ProjectRoot, cfg.D3Dir, fs, opts.Getenv))
+	featureSvc.SetLogger(logger("feature"))
+
+	generator := rules.NewRuleGenerator(cfg, fs)
+	rulesSvc := rules.NewService(cfg, generator, fs)
+	rulesSvc.SetLogger(logger("rules"))
+
+	phaseSvc := phase.NewService(fs)
+
+	fileOp := projectfiles.NewFileOperator(cfg)
+	fileOp.SetLogger(logger("projectfiles"))
+
+	proj := New(cfg, fs, featureSvc, rulesSvc, phaseSvc, fileOp)
+	proj.SetLogger(logger("project"))
+	proj.SetSession(feature.SessionName(opts.Getenv))
+	if len(cfg.Hooks) > 0 {
+		executor := hooks.NewExecutor(cfg.Hooks)
+		executor.SetLogger(logger("hooks"))
+		proj.SetHooks(executor)
+	}
+	// The lock file is created exclusively through the os package, so only projects on the real
+	// file system are locked
+	if _, ok := fs.(ports.RealFileSystem); ok {
+		proj.SetLocker(lock.New(filepath.Join(cfg.D3Dir, lock.FileName)))
+	}
+
+	return proj, &Services{
+		Features:  featureSvc,
+		Rules:     rulesSvc,
+		Generator: generator,
+		Phases:    phaseSvc,
+		Files:     fileOp,
+	}
+}
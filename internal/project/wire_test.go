@@ -0,0 +1,46 @@
+package project
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/imcclaskey/d3/internal/core/config"
+	"github.com/imcclaskey/d3/internal/testutil"
+)
+
+func TestWire_TrashRetention(t *testing.T) {
+	zero := time.Duration(0)
+	tests := []struct {
+		name       string
+		configured time.Duration
+		retention  *time.Duration
+		wantPruned bool
+	}{
+		{name: "unset uses the configured retention", configured: 30 * 24 * time.Hour, retention: nil, wantPruned: true},
+		{name: "configured zero keeps deleted features", configured: 0, retention: nil, wantPruned: false},
+		{name: "zero override keeps deleted features", configured: 30 * 24 * time.Hour, retention: &zero, wantPruned: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.Default("/p")
+			cfg.TrashRetention = tt.configured
+			memFS := testutil.NewMemFS()
+			memFS.AddFile(filepath.Join(cfg.FeaturesDir, "login", ".phase"), "define")
+			expired := filepath.Join(cfg.D3Dir, ".trash", "old-20200101T000000.000Z")
+			memFS.AddFile(filepath.Join(expired, ".phase"), "define")
+
+			_, services := Wire(cfg, memFS, WireOptions{TrashRetention: tt.retention, Getenv: func(string) string { return "" }})
+			if _, err := services.Features.DeleteFeature(context.Background(), "login"); err != nil {
+				t.Fatalf("DeleteFeature() error = %v", err)
+			}
+
+			exists, _ := memFS.Exists(expired)
+			if exists == tt.wantPruned {
+				t.Errorf("expired trash entry exists = %v, want pruned %v", exists, tt.wantPruned)
+			}
+		})
+	}
+}
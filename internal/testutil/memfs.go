@@ -0,0 +1,243 @@
+package testutil
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// MemFS is an in-memory implementation of ports.FileSystem for tests that exercise
+// real file layouts without touching disk. Create records an empty file, but writes to
+// the *os.File it returns are not kept.
+type MemFS struct {
+	files    map[string][]byte
+	dirs     map[string]bool
+	modTimes map[string]time.Time
+}
+
+// NewMemFS creates an empty in-memory file system containing only the root directory.
+func NewMemFS() *MemFS {
+	return &MemFS{
+		files:    map[string][]byte{},
+		dirs:     map[string]bool{string(filepath.Separator): true},
+		modTimes: map[string]time.Time{},
+	}
+}
+
+// AddFile writes a file and creates its parent directories.
+func (m *MemFS) AddFile(name string, content string) {
+	_ = m.MkdirAll(filepath.Dir(name), 0755)
+	m.files[filepath.Clean(name)] = []byte(content)
+}
+
+// SetModTime sets the modification time reported for a file. Files default to the zero time.
+func (m *MemFS) SetModTime(name string, t time.Time) {
+	m.modTimes[filepath.Clean(name)] = t
+}
+
+// Files returns the paths of every file, sorted.
+func (m *MemFS) Files() []string {
+	paths := make([]string, 0, len(m.files))
+	for path := range m.files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func notExist(op, name string) error {
+	return &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+}
+
+// Stat returns information about a file or directory.
+func (m *MemFS) Stat(name string) (fs.FileInfo, error) {
+	name = filepath.Clean(name)
+	if data, ok := m.files[name]; ok {
+		return MockFileInfo{FName: filepath.Base(name), FSize: int64(len(data)), FMode: 0644, FModTime: m.modTimes[name]}, nil
+	}
+	if m.dirs[name] {
+		return MockFileInfo{FName: filepath.Base(name), FIsDir: true, FMode: fs.ModeDir | 0755}, nil
+	}
+	return nil, notExist("stat", name)
+}
+
+// ReadFile returns a copy of a file's content.
+func (m *MemFS) ReadFile(name string) ([]byte, error) {
+	data, ok := m.files[filepath.Clean(name)]
+	if !ok {
+		return nil, notExist("open", name)
+	}
+	return append([]byte(nil), data...), nil
+}
+
+// WriteFile stores data, requiring the parent directory to exist as os.WriteFile does.
+func (m *MemFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	name = filepath.Clean(name)
+	if !m.dirs[filepath.Dir(name)] {
+		return notExist("open", name)
+	}
+	if m.dirs[name] {
+		return &fs.PathError{Op: "open", Path: name, Err: fmt.Errorf("is a directory")}
+	}
+	m.files[name] = append([]byte(nil), data...)
+	return nil
+}
+
+// Create creates or truncates a file to be empty. The returned file is the writer end
+// of a pipe whose reader is closed, so it can only be closed.
+func (m *MemFS) Create(name string) (*os.File, error) {
+	if err := m.WriteFile(name, nil, 0644); err != nil {
+		return nil, err
+	}
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	r.Close()
+	return w, nil
+}
+
+// MkdirAll creates a directory and any missing parents.
+func (m *MemFS) MkdirAll(path string, perm fs.FileMode) error {
+	path = filepath.Clean(path)
+	for p := path; ; p = filepath.Dir(p) {
+		if _, isFile := m.files[p]; isFile {
+			return &fs.PathError{Op: "mkdir", Path: p, Err: fmt.Errorf("not a directory")}
+		}
+		m.dirs[p] = true
+		if parent := filepath.Dir(p); parent == p {
+			break
+		}
+	}
+	return nil
+}
+
+// ReadDir lists the direct children of a directory, sorted by name.
+func (m *MemFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	name = filepath.Clean(name)
+	if !m.dirs[name] {
+		return nil, notExist("open", name)
+	}
+	entries := []fs.DirEntry{}
+	for path := range m.files {
+		if filepath.Dir(path) == name {
+			entries = append(entries, fs.FileInfoToDirEntry(MockFileInfo{FName: filepath.Base(path), FSize: int64(len(m.files[path])), FModTime: m.modTimes[path]}))
+		}
+	}
+	for path := range m.dirs {
+		if path != name && filepath.Dir(path) == name {
+			entries = append(entries, fs.FileInfoToDirEntry(MockFileInfo{FName: filepath.Base(path), FIsDir: true, FMode: fs.ModeDir}))
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	return entries, nil
+}
+
+// Remove deletes a file or an empty directory.
+func (m *MemFS) Remove(name string) error {
+	name = filepath.Clean(name)
+	if _, ok := m.files[name]; ok {
+		delete(m.files, name)
+		delete(m.modTimes, name)
+		return nil
+	}
+	if !m.dirs[name] {
+		return notExist("remove", name)
+	}
+	if entries, _ := m.ReadDir(name); len(entries) > 0 {
+		return &fs.PathError{Op: "remove", Path: name, Err: fmt.Errorf("directory not empty")}
+	}
+	delete(m.dirs, name)
+	return nil
+}
+
+// RemoveAll deletes a path and everything below it. Missing paths are not an error.
+func (m *MemFS) RemoveAll(path string) error {
+	path = filepath.Clean(path)
+	prefix := path + string(filepath.Separator)
+	for p := range m.files {
+		if p == path || strings.HasPrefix(p, prefix) {
+			delete(m.files, p)
+			delete(m.modTimes, p)
+		}
+	}
+	for p := range m.dirs {
+		if p == path || strings.HasPrefix(p, prefix) {
+			delete(m.dirs, p)
+		}
+	}
+	return nil
+}
+
+// Exists reports whether a file or directory exists.
+func (m *MemFS) Exists(name string) (bool, error) {
+	_, err := m.Stat(name)
+	return err == nil, nil
+}
+
+// Rename moves a file or directory tree.
+func (m *MemFS) Rename(oldpath, newpath string) error {
+	oldpath, newpath = filepath.Clean(oldpath), filepath.Clean(newpath)
+	if _, err := m.Stat(oldpath); err != nil {
+		return &os.LinkError{Op: "rename", Old: oldpath, New: newpath, Err: fs.ErrNotExist}
+	}
+	if !m.dirs[filepath.Dir(newpath)] {
+		return &os.LinkError{Op: "rename", Old: oldpath, New: newpath, Err: fs.ErrNotExist}
+	}
+	oldPrefix := oldpath + string(filepath.Separator)
+	moved := map[string][]byte{}
+	for p, data := range m.files {
+		if p == oldpath || strings.HasPrefix(p, oldPrefix) {
+			delete(m.files, p)
+			moved[newpath+strings.TrimPrefix(p, oldpath)] = data
+			if t, ok := m.modTimes[p]; ok {
+				delete(m.modTimes, p)
+				m.modTimes[newpath+strings.TrimPrefix(p, oldpath)] = t
+			}
+		}
+	}
+	movedDirs := []string{}
+	for p := range m.dirs {
+		if p == oldpath || strings.HasPrefix(p, oldPrefix) {
+			delete(m.dirs, p)
+			movedDirs = append(movedDirs, newpath+strings.TrimPrefix(p, oldpath))
+		}
+	}
+	for p, data := range moved {
+		m.files[p] = data
+	}
+	for _, p := range movedDirs {
+		m.dirs[p] = true
+	}
+	return nil
+}
+
+// Glob returns the files and directories matching pattern, sorted.
+func (m *MemFS) Glob(pattern string) ([]string, error) {
+	matches := []string{}
+	for _, set := range []map[string]bool{m.dirs, m.fileSet()} {
+		for p := range set {
+			ok, err := filepath.Match(pattern, p)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				matches = append(matches, p)
+			}
+		}
+	}
+	sort.Strings(matches)
+	return matches, nil
+}
+
+func (m *MemFS) fileSet() map[string]bool {
+	set := make(map[string]bool, len(m.files))
+	for p := range m.files {
+		set[p] = true
+	}
+	return set
+}
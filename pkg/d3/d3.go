@@ -0,0 +1,309 @@
+// Package d3 is the public Go API for driving d3 programmatically. It offers the operations of
+// the d3 CLI and MCP server, such as creating features, moving them between phases and keeping
+// the generated Cursor rules in sync, without shelling out to the d3 binary.
+//
+// A Project is obtained with Open:
+//
+//	proj, err := d3.Open("/path/to/repo", d3.Options{})
+//	if err != nil {
+//		return err
+//	}
+//	result, err := proj.CreateFeature(ctx, "login")
+//
+// The package is the supported, stable surface of d3; everything under internal/ may change
+// between releases.
+package d3
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/imcclaskey/d3/internal/core/config"
+	"github.com/imcclaskey/d3/internal/core/d3err"
+	"github.com/imcclaskey/d3/internal/core/feature"
+	"github.com/imcclaskey/d3/internal/core/phase"
+	"github.com/imcclaskey/d3/internal/core/ports"
+	"github.com/imcclaskey/d3/internal/project"
+)
+
+// Phase is a stage of a feature's workflow.
+type Phase string
+
+const (
+	// NoPhase is reported when there is no active feature.
+	NoPhase Phase = ""
+	// Define is the phase in which the problem is described.
+	Define Phase = "define"
+	// Design is the phase in which the technical plan is written.
+	Design Phase = "design"
+	// Deliver is the phase in which the plan is implemented.
+	Deliver Phase = "deliver"
+)
+
+// Sentinel errors for matching with errors.Is. Every error returned by a Project that belongs to
+// one of these kinds matches the sentinel, however it is worded; ErrorCode gives the same kinds
+// as strings.
+var (
+	ErrInvalidArgument = error(d3err.ErrInvalidArgument)
+	ErrNotInitialized  = error(d3err.ErrNotInitialized)
+	ErrNoActiveFeature = error(d3err.ErrNoActiveFeature)
+	ErrFeatureExists   = error(d3err.ErrFeatureExists)
+	ErrFeatureNotFound = error(d3err.ErrFeatureNotFound)
+	ErrInvalidName     = error(d3err.ErrInvalidName)
+	ErrInvalidPhase    = error(d3err.ErrInvalidPhase)
+	// ErrGateFailed is returned when a pre hook of .d3/config.yaml refuses an operation.
+	ErrGateFailed = error(d3err.ErrGateFailed)
+	// ErrLocked is returned when another d3 process is changing the project.
+	ErrLocked = error(d3err.ErrLocked)
+)
+
+// ErrorCode returns the stable code of an error returned by a Project, such as
+// "feature_not_found", as reported in the CLI's JSON output. Errors outside d3's taxonomy
+// return "unknown".
+func ErrorCode(err error) string {
+	return string(d3err.CodeOf(err))
+}
+
+// Options configures Open. The zero value works on the real file system with logging disabled.
+type Options struct {
+	// FileSystem is where the project is read and written. Defaults to the operating system's
+	// file system; inject another implementation to test embedding programs without touching disk.
+	FileSystem FileSystem
+	// Logger receives d3's logs, tagged with the service that wrote them. Defaults to discarding
+	// them.
+	Logger *slog.Logger
+	// Getenv looks up D3_SESSION, which selects the active feature store, and D3_TRASH_RETENTION,
+	// which overrides trash_retention of .d3/config.yaml. Defaults to os.Getenv.
+	Getenv func(string) string
+}
+
+// Config describes the resolved locations and settings of a project, after applying
+// .d3/config.yaml. Paths are absolute.
+type Config struct {
+	Root          string
+	D3Dir         string
+	FeaturesDir   string
+	RulesDir      string
+	InitialPhase  Phase
+	MCPServerName string
+}
+
+// Project drives one d3 project. It is safe to use from one goroutine at a time; concurrent
+// processes are coordinated through the files on disk, as with the CLI.
+type Project struct {
+	cfg      config.Config
+	proj     *project.Project
+	services *project.Services
+}
+
+// Open returns the project rooted at root, wiring every d3 service from its .d3/config.yaml.
+// The project does not have to be initialized yet; see Init. With the default file system a
+// relative root is resolved against the working directory. An invalid configuration file is
+// reported as an ErrInvalidArgument error.
+func Open(root string, opts Options) (*Project, error) {
+	fs := ports.FileSystem(ports.RealFileSystem{})
+	if opts.FileSystem != nil {
+		fs = opts.FileSystem
+		root = filepath.Clean(root)
+	} else {
+		abs, err := filepath.Abs(root)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve project root %s: %w", root, err)
+		}
+		root = abs
+	}
+	if opts.Logger == nil {
+		opts.Logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	if opts.Getenv == nil {
+		opts.Getenv = os.Getenv
+	}
+
+	cfg, err := config.Load(fs, root)
+	if err != nil {
+		return nil, err
+	}
+	wireOpts := project.WireOptions{Logger: opts.Logger, ComponentAttr: true, Getenv: opts.Getenv}
+	// D3_TRASH_RETENTION overrides trash_retention of the config; an unparsable value is ignored
+	if value := opts.Getenv(feature.TrashRetentionEnv); value != "" {
+		if retention, err := feature.ParseTrashRetention(value); err == nil {
+			wireOpts.TrashRetention = &retention
+		}
+	}
+
+	proj, services := project.Wire(cfg, fs, wireOpts)
+	return &Project{cfg: cfg, proj: proj, services: services}, nil
+}
+
+// Config returns the project's resolved configuration.
+func (p *Project) Config() Config {
+	return Config{
+		Root:          p.cfg.ProjectRoot,
+		D3Dir:         p.cfg.D3Dir,
+		FeaturesDir:   p.cfg.FeaturesDir,
+		RulesDir:      p.cfg.CursorRulesDir,
+		InitialPhase:  Phase(p.cfg.InitialPhase),
+		MCPServerName: p.cfg.MCPServerName,
+	}
+}
+
+// IsInitialized reports whether the project has a .d3 directory.
+func (p *Project) IsInitialized() bool {
+	return p.proj.IsInitialized()
+}
+
+// InitOptions selects how Init treats an existing installation.
+type InitOptions struct {
+	// Clean removes the existing .d3 directory, except config.yaml, before initializing.
+	Clean bool
+	// Refresh recreates missing files of an existing installation without losing data.
+	Refresh bool
+	// CustomRules creates .d3/rules with the default templates, ready for editing.
+	CustomRules bool
+}
+
+// Init initializes the project, as d3 init does: the .d3 directory, .cursor/mcp.json, the
+// "# d3" sections of the ignore files and the generated rules. Clean and Refresh are mutually
+// exclusive. The .cursor directory must exist.
+func (p *Project) Init(ctx context.Context, opts InitOptions) (*Result, error) {
+	if opts.Clean && opts.Refresh {
+		return nil, d3err.New(d3err.InvalidArgument, "clean and refresh are mutually exclusive")
+	}
+	return newResult(p.proj.Init(opts.Clean, opts.Refresh, opts.CustomRules))
+}
+
+// CreateFeature creates a feature in the configured initial phase and makes it active. The name
+// is normalized as the CLI does, so "My Feature" creates "my-feature".
+func (p *Project) CreateFeature(ctx context.Context, name string) (*Result, error) {
+	name, err := feature.NormalizeName(name)
+	if err != nil {
+		return nil, err
+	}
+	return newResult(p.proj.CreateFeature(ctx, name))
+}
+
+// EnterFeature makes an existing feature active, resuming its phase.
+func (p *Project) EnterFeature(ctx context.Context, name string) (*Result, error) {
+	name, err := feature.NormalizeName(name)
+	if err != nil {
+		return nil, err
+	}
+	return newResult(p.proj.EnterFeature(ctx, name))
+}
+
+// ExitFeature clears the active feature.
+func (p *Project) ExitFeature(ctx context.Context) (*Result, error) {
+	return newResult(p.proj.ExitFeature(ctx))
+}
+
+// DeleteFeature moves a feature to the trash, clearing it first if it is active.
+func (p *Project) DeleteFeature(ctx context.Context, name string) (*Result, error) {
+	name, err := feature.NormalizeName(name)
+	if err != nil {
+		return nil, err
+	}
+	return newResult(p.proj.DeleteFeature(ctx, name))
+}
+
+// RestoreFeature brings a deleted feature back from the trash.
+func (p *Project) RestoreFeature(ctx context.Context, name string) (*Result, error) {
+	name, err := feature.NormalizeName(name)
+	if err != nil {
+		return nil, err
+	}
+	return newResult(p.proj.RestoreFeature(ctx, name))
+}
+
+// ChangePhase moves the active feature to another phase and regenerates the rules.
+func (p *Project) ChangePhase(ctx context.Context, target Phase) (*Result, error) {
+	return newResult(p.proj.ChangePhase(ctx, phase.Phase(target)))
+}
+
+// SyncRules reconciles the generated rules with the active feature and phase on disk, as
+// d3 rules sync does. Result.RulesChanged reports whether any rule file was rewritten.
+func (p *Project) SyncRules(ctx context.Context) (*Result, error) {
+	return newResult(p.proj.SyncRules(ctx))
+}
+
+// Feature describes one feature of the project.
+type Feature struct {
+	Name  string
+	Path  string
+	Phase Phase
+	// Active reports whether the feature is the active feature of the current session.
+	Active bool
+}
+
+// Features lists the project's features with their phases.
+func (p *Project) Features(ctx context.Context) ([]Feature, error) {
+	if err := p.proj.RequiresInitialized(); err != nil {
+		return nil, err
+	}
+	infos, err := p.services.Features.ListFeatures(ctx)
+	if err != nil {
+		return nil, err
+	}
+	active, err := p.services.Features.GetActiveFeature()
+	if err != nil {
+		return nil, err
+	}
+
+	features := make([]Feature, 0, len(infos))
+	for _, info := range infos {
+		current, err := p.services.Features.GetFeaturePhase(ctx, info.Name)
+		if err != nil {
+			return nil, err
+		}
+		features = append(features, Feature{Name: info.Name, Path: info.Path, Phase: Phase(current), Active: info.Name == active})
+	}
+	return features, nil
+}
+
+// FeaturePhase returns the phase of a feature.
+func (p *Project) FeaturePhase(ctx context.Context, name string) (Phase, error) {
+	name, err := feature.NormalizeName(name)
+	if err != nil {
+		return NoPhase, err
+	}
+	current, err := p.services.Features.GetFeaturePhase(ctx, name)
+	return Phase(current), err
+}
+
+// Status is the state d3 status reports.
+type Status struct {
+	Initialized bool
+	// ActiveFeature is empty when no feature is active; Phase and Branch are then empty too.
+	ActiveFeature string
+	Phase         Phase
+	// Branch is the git branch recorded for the active feature, if any.
+	Branch string
+}
+
+// Status returns whether the project is initialized and which feature is active.
+func (p *Project) Status(ctx context.Context) (*Status, error) {
+	status := &Status{Initialized: p.proj.IsInitialized()}
+	if !status.Initialized {
+		return status, nil
+	}
+
+	active, err := p.services.Features.GetActiveFeature()
+	if err != nil || active == "" {
+		return status, err
+	}
+	current, err := p.services.Features.GetFeaturePhase(ctx, active)
+	if err != nil {
+		return nil, err
+	}
+	branch, err := p.services.Features.GetFeatureBranch(active)
+	if err != nil {
+		return nil, err
+	}
+	status.ActiveFeature = active
+	status.Phase = Phase(current)
+	status.Branch = branch
+	return status, nil
+}
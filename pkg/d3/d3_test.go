@@ -0,0 +1,137 @@
+package d3
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/imcclaskey/d3/internal/testutil"
+)
+
+// openMem opens a project at /p on an in-memory file system with a .cursor directory, ready to
+// be initialized.
+func openMem(t *testing.T, files map[string]string) (*Project, *testutil.MemFS) {
+	t.Helper()
+	memFS := testutil.NewMemFS()
+	memFS.MkdirAll("/p/.cursor", 0755)
+	for name, content := range files {
+		memFS.AddFile(name, content)
+	}
+	proj, err := Open("/p", Options{FileSystem: memFS, Getenv: func(string) string { return "" }})
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	return proj, memFS
+}
+
+func TestProject_Workflow(t *testing.T) {
+	ctx := context.Background()
+	proj, memFS := openMem(t, nil)
+
+	if status, err := proj.Status(ctx); err != nil || status.Initialized {
+		t.Fatalf("Status() before Init = %+v, %v, want not initialized", status, err)
+	}
+	if _, err := proj.CreateFeature(ctx, "login"); !errors.Is(err, ErrNotInitialized) {
+		t.Fatalf("CreateFeature() before Init error = %v, want ErrNotInitialized", err)
+	}
+
+	if _, err := proj.Init(ctx, InitOptions{}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	result, err := proj.CreateFeature(ctx, "login")
+	if err != nil {
+		t.Fatalf("CreateFeature() error = %v", err)
+	}
+	if result.Feature != "login" || result.Phase != Define || !result.RulesChanged {
+		t.Errorf("CreateFeature() result = %+v", result)
+	}
+	if exists, _ := memFS.Exists("/p/.d3/features/login/define/problem.md"); !exists {
+		t.Error("CreateFeature() did not create the define document")
+	}
+
+	if _, err := proj.ChangePhase(ctx, Design); err != nil {
+		t.Fatalf("ChangePhase() error = %v", err)
+	}
+	status, err := proj.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	if want := (&Status{Initialized: true, ActiveFeature: "login", Phase: Design}); !reflect.DeepEqual(status, want) {
+		t.Errorf("Status() = %+v, want %+v", status, want)
+	}
+
+	features, err := proj.Features(ctx)
+	if err != nil {
+		t.Fatalf("Features() error = %v", err)
+	}
+	want := []Feature{{Name: "login", Path: "/p/.d3/features/login", Phase: Design, Active: true}}
+	if !reflect.DeepEqual(features, want) {
+		t.Errorf("Features() = %+v, want %+v", features, want)
+	}
+
+	if result, err := proj.SyncRules(ctx); err != nil || result.RulesChanged {
+		t.Errorf("SyncRules() = %+v, %v, want rules up to date", result, err)
+	}
+
+	if _, err := proj.EnterFeature(ctx, "missing"); ErrorCode(err) != "feature_not_found" {
+		t.Errorf("EnterFeature() error code = %q, want feature_not_found", ErrorCode(err))
+	}
+}
+
+func TestProject_NormalizesNames(t *testing.T) {
+	ctx := context.Background()
+	proj, memFS := openMem(t, nil)
+	if _, err := proj.Init(ctx, InitOptions{}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	result, err := proj.CreateFeature(ctx, "  My Feature ")
+	if err != nil {
+		t.Fatalf("CreateFeature() error = %v", err)
+	}
+	if result.Feature != "my-feature" {
+		t.Errorf("CreateFeature() feature = %q, want my-feature", result.Feature)
+	}
+	if exists, _ := memFS.Exists("/p/.d3/features/my-feature"); !exists {
+		t.Error("CreateFeature() did not create the normalized feature directory")
+	}
+	if current, err := proj.FeaturePhase(ctx, "My Feature"); err != nil || current != Define {
+		t.Errorf("FeaturePhase() = %q, %v, want define", current, err)
+	}
+	if _, err := proj.EnterFeature(ctx, "MY FEATURE"); err != nil {
+		t.Errorf("EnterFeature() error = %v", err)
+	}
+	if _, err := proj.DeleteFeature(ctx, "My Feature"); err != nil {
+		t.Errorf("DeleteFeature() error = %v", err)
+	}
+	if _, err := proj.RestoreFeature(ctx, "My Feature"); err != nil {
+		t.Errorf("RestoreFeature() error = %v", err)
+	}
+	if _, err := proj.CreateFeature(ctx, "bad/name"); !errors.Is(err, ErrInvalidName) {
+		t.Errorf("CreateFeature() with a path separator error = %v, want ErrInvalidName", err)
+	}
+}
+
+func TestOpen_Config(t *testing.T) {
+	proj, _ := openMem(t, map[string]string{
+		"/p/.d3/config.yaml": "features_dir: docs/features\ninitial_phase: design\n",
+	})
+	cfg := proj.Config()
+	if cfg.Root != "/p" || cfg.FeaturesDir != "/p/docs/features" || cfg.InitialPhase != Design {
+		t.Errorf("Config() = %+v", cfg)
+	}
+
+	memFS := testutil.NewMemFS()
+	memFS.AddFile("/p/.d3/config.yaml", "features_dir: /abs\n")
+	if _, err := Open("/p", Options{FileSystem: memFS}); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Open() with invalid config error = %v, want ErrInvalidArgument", err)
+	}
+}
+
+func TestProject_InitOptions(t *testing.T) {
+	proj, _ := openMem(t, nil)
+	if _, err := proj.Init(context.Background(), InitOptions{Clean: true, Refresh: true}); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Init() with clean and refresh error = %v, want ErrInvalidArgument", err)
+	}
+}
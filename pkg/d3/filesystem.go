@@ -0,0 +1,40 @@
+package d3
+
+import (
+	"io/fs"
+	"os"
+
+	"github.com/imcclaskey/d3/internal/core/ports"
+)
+
+// FileSystem is the file access d3 performs. Paths are absolute and use the host's separator.
+// Implementations must report missing files with errors matching fs.ErrNotExist, and WriteFile,
+// like os.WriteFile, does not create parent directories.
+type FileSystem interface {
+	Stat(name string) (fs.FileInfo, error)
+	ReadFile(name string) ([]byte, error)
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+	MkdirAll(path string, perm fs.FileMode) error
+	ReadDir(name string) ([]fs.DirEntry, error)
+	// Create creates or truncates the named file, as os.Create does. d3 only closes the
+	// returned file, to leave an empty phase file behind.
+	Create(name string) (*os.File, error)
+	Remove(name string) error
+	// RemoveAll removes path and everything below it. A missing path is not an error.
+	RemoveAll(path string) error
+	Exists(name string) (bool, error)
+	Rename(oldpath, newpath string) error
+	// Glob returns the paths matching a filepath.Match pattern, as filepath.Glob does.
+	Glob(pattern string) ([]string, error)
+}
+
+// OSFileSystem returns the operating system's file system, which Open uses by default.
+func OSFileSystem() FileSystem {
+	return ports.RealFileSystem{}
+}
+
+// The public interface must stay interchangeable with the one the services use.
+var (
+	_ ports.FileSystem = FileSystem(nil)
+	_ FileSystem       = ports.FileSystem(nil)
+)
@@ -0,0 +1,35 @@
+package d3
+
+import "github.com/imcclaskey/d3/internal/project"
+
+// Result describes the outcome of an operation that changes the project.
+type Result struct {
+	// Message is a short human-readable summary, as printed by the CLI.
+	Message string
+	// RulesChanged reports whether the generated Cursor rules were rewritten.
+	RulesChanged bool
+	// Feature is the feature the operation acted on, if any.
+	Feature string
+	// Phase is that feature's phase after the operation.
+	Phase Phase
+	// FilesTouched lists the files and directories created, modified, moved or removed, not
+	// counting generated rule files.
+	FilesTouched []string
+	// Warnings lists problems that did not stop the operation.
+	Warnings []string
+}
+
+// newResult converts a project result, passing errors through.
+func newResult(r *project.Result, err error) (*Result, error) {
+	if err != nil {
+		return nil, err
+	}
+	return &Result{
+		Message:      r.Message,
+		RulesChanged: r.RulesChanged,
+		Feature:      r.Feature,
+		Phase:        Phase(r.Phase),
+		FilesTouched: append([]string{}, r.FilesTouched...),
+		Warnings:     append([]string{}, r.Warnings...),
+	}, nil
+}